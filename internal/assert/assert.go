@@ -0,0 +1,259 @@
+// Package assert 解析并执行 --assert 响应断言，例如 status==200、$.errCode==0、body contains 门店
+package assert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+)
+
+// maxActualLength 报告中实际值的最大显示长度
+const maxActualLength = 120
+
+// operators 支持的比较运算符，双字符运算符在前以便优先匹配
+var operators = []string{"==", "!=", ">=", "<=", ">", "<"}
+
+// Assertion 单条响应断言
+type Assertion struct {
+	expr     string
+	status   bool           // 断言状态码
+	path     *jsonpath.Path // 断言JSON字段
+	contains bool           // 断言响应体包含文本
+	negate   bool           // contains 取反
+	op       string
+	want     string
+}
+
+// Result 单条断言的执行结果
+type Result struct {
+	Expr   string `json:"expr"`
+	Passed bool   `json:"passed"`
+	Actual string `json:"actual,omitempty"`
+}
+
+// Parse 解析断言表达式：
+//
+//	status==200、status<400       比较HTTP状态码
+//	$.errCode==0、$.data.name!=""  比较JSONPath取到的值，期望值按JSON字面量解析，解析失败时视为字符串
+//	body contains X、body !contains X  检查响应体是否包含文本
+func Parse(expr string) (*Assertion, error) {
+	expr = strings.TrimSpace(expr)
+	a := &Assertion{expr: expr}
+
+	if rest, ok := strings.CutPrefix(expr, "body "); ok {
+		rest = strings.TrimSpace(rest)
+		if text, ok := strings.CutPrefix(rest, "!contains "); ok {
+			a.contains, a.negate, a.want = true, true, unquote(text)
+			return a, nil
+		}
+		if text, ok := strings.CutPrefix(rest, "contains "); ok {
+			a.contains, a.want = true, unquote(text)
+			return a, nil
+		}
+		return nil, i18n.Errorf("无法解析断言 %s：body 之后应为 contains 或 !contains", expr)
+	}
+
+	left, op, right, ok := splitOperator(expr)
+	if !ok {
+		return nil, i18n.Errorf("无法解析断言 %s：缺少比较运算符（==、!=、>、>=、<、<=）", expr)
+	}
+	a.op, a.want = op, right
+
+	switch {
+	case left == "status":
+		if _, err := strconv.Atoi(right); err != nil {
+			return nil, i18n.Errorf("无法解析断言 %s：状态码应为整数", expr)
+		}
+		a.status = true
+	case strings.HasPrefix(left, "$"):
+		path, err := jsonpath.Parse(left)
+		if err != nil {
+			return nil, i18n.Errorf("无法解析断言 %s: %w", expr, err)
+		}
+		a.path = path
+	default:
+		return nil, i18n.Errorf("无法解析断言 %s：左侧应为 status 或以 $ 开头的JSONPath", expr)
+	}
+	return a, nil
+}
+
+// ParseAll 依次解析多条断言
+func ParseAll(exprs []string) ([]*Assertion, error) {
+	list := make([]*Assertion, 0, len(exprs))
+	for _, expr := range exprs {
+		a, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, a)
+	}
+	return list, nil
+}
+
+// splitOperator 按第一个出现的比较运算符拆分表达式
+func splitOperator(expr string) (left, op, right string, ok bool) {
+	for i := 0; i < len(expr); i++ {
+		for _, candidate := range operators {
+			if strings.HasPrefix(expr[i:], candidate) {
+				return strings.TrimSpace(expr[:i]), candidate, strings.TrimSpace(expr[i+len(candidate):]), true
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// unquote 去掉文本两侧成对的引号
+func unquote(text string) string {
+	text = strings.TrimSpace(text)
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		return text[1 : len(text)-1]
+	}
+	return text
+}
+
+// String 返回断言表达式
+func (a *Assertion) String() string {
+	return a.expr
+}
+
+// Check 针对状态码和响应体执行断言
+func (a *Assertion) Check(status int, body []byte) Result {
+	result := Result{Expr: a.expr}
+	switch {
+	case a.contains:
+		found := strings.Contains(string(body), a.want)
+		result.Passed = found != a.negate
+		if !result.Passed {
+			result.Actual = truncate(string(body))
+		}
+	case a.status:
+		want, _ := strconv.Atoi(a.want)
+		result.Passed, _ = compareNumbers(float64(status), float64(want), a.op)
+		result.Actual = strconv.Itoa(status)
+	default:
+		var data interface{}
+		if err := json.Unmarshal(body, &data); err != nil {
+			result.Actual = i18n.T("响应体不是有效的JSON")
+			return result
+		}
+		actual, err := a.path.Lookup(data)
+		if errors.Is(err, jsonpath.ErrNotFound) {
+			result.Actual = i18n.T("字段不存在")
+			return result
+		}
+		result.Passed = compareValues(actual, a.want, a.op)
+		content, _ := json.Marshal(actual)
+		result.Actual = truncate(string(content))
+	}
+	return result
+}
+
+// compareValues 比较JSON值与期望值，期望值优先按JSON字面量解析
+func compareValues(actual interface{}, wantText, op string) bool {
+	var want interface{}
+	if err := json.Unmarshal([]byte(wantText), &want); err != nil {
+		want = unquote(wantText)
+	}
+
+	actualNum, actualIsNum := actual.(float64)
+	wantNum, wantIsNum := want.(float64)
+	if actualIsNum && wantIsNum {
+		passed, _ := compareNumbers(actualNum, wantNum, op)
+		return passed
+	}
+
+	switch op {
+	case "==":
+		return reflect.DeepEqual(actual, want)
+	case "!=":
+		return !reflect.DeepEqual(actual, want)
+	default:
+		// 大小比较只对数字有意义
+		return false
+	}
+}
+
+func compareNumbers(actual, want float64, op string) (bool, error) {
+	switch op {
+	case "==":
+		return actual == want, nil
+	case "!=":
+		return actual != want, nil
+	case ">":
+		return actual > want, nil
+	case ">=":
+		return actual >= want, nil
+	case "<":
+		return actual < want, nil
+	case "<=":
+		return actual <= want, nil
+	}
+	return false, fmt.Errorf("unknown operator %s", op)
+}
+
+func truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) > maxActualLength {
+		return string(runes[:maxActualLength]) + "..."
+	}
+	return text
+}
+
+// Failure 存在未通过的断言，Error 输出所有断言的执行报告
+type Failure struct {
+	Results []Result
+}
+
+func (f *Failure) Error() string {
+	failed := 0
+	for _, r := range f.Results {
+		if !r.Passed {
+			failed++
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(i18n.Sprintf("响应断言失败（%d/%d 未通过）:", failed, len(f.Results)))
+	for _, r := range f.Results {
+		if r.Passed {
+			fmt.Fprintf(&b, "\n  ✅ %s", r.Expr)
+			continue
+		}
+		fmt.Fprintf(&b, "\n  ❌ %s", r.Expr)
+		if r.Actual != "" {
+			b.WriteString(i18n.Sprintf("（实际: %s）", r.Actual))
+		}
+	}
+	return b.String()
+}
+
+// CheckAll 执行全部断言，有未通过的断言时返回 *Failure
+func CheckAll(list []*Assertion, status int, body []byte) error {
+	results := make([]Result, 0, len(list))
+	passed := true
+	for _, a := range list {
+		r := a.Check(status, body)
+		passed = passed && r.Passed
+		results = append(results, r)
+	}
+	if passed {
+		return nil
+	}
+	return &Failure{Results: results}
+}
+
+// Hook 返回在 execute 阶段之后执行断言的流水线钩子，断言失败时以 exitcode.Assertion 中止
+func Hook(list []*Assertion) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		return exitcode.Wrap(exitcode.Assertion, CheckAll(list, state.StatusCode, state.Body))
+	}
+}
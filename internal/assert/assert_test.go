@@ -0,0 +1,75 @@
+package assert
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const body = `{"errCode":0,"message":"ok","data":{"items":[{"name":"门店搜索"}],"total":3}}`
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		expr   string
+		status int
+		want   bool
+	}{
+		{expr: "status==200", status: 200, want: true},
+		{expr: "status==200", status: 401, want: false},
+		{expr: "status<400", status: 302, want: true},
+		{expr: "$.errCode==0", status: 200, want: true},
+		{expr: "$.errCode != 0", status: 200, want: false},
+		{expr: `$.message=="ok"`, status: 200, want: true},
+		{expr: "$.message==ok", status: 200, want: true},
+		{expr: "$.data.total>=3", status: 200, want: true},
+		{expr: "$.data.items[0].name==门店搜索", status: 200, want: true},
+		{expr: "$.data.missing==1", status: 200, want: false},
+		{expr: "$.message>1", status: 200, want: false},
+		{expr: "body contains 门店", status: 200, want: true},
+		{expr: `body contains "not here"`, status: 200, want: false},
+		{expr: "body !contains error", status: 200, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			a, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got := a.Check(tt.status, []byte(body)); got.Passed != tt.want {
+				t.Errorf("Check() = %+v, want passed %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"status", "status==abc", "errCode==0", "body has x", "$.a[==1"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", expr)
+		}
+	}
+}
+
+func TestCheckAll_Report(t *testing.T) {
+	list, err := ParseAll([]string{"status==200", "$.errCode==1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := CheckAll(list[:1], 200, []byte(body)); err != nil {
+		t.Errorf("CheckAll() error = %v, want nil", err)
+	}
+
+	err = CheckAll(list, 200, []byte(body))
+	var failure *Failure
+	if !errors.As(err, &failure) {
+		t.Fatalf("CheckAll() error = %v, want *Failure", err)
+	}
+	report := err.Error()
+	for _, want := range []string{"1/2", "✅ status==200", "❌ $.errCode==1", "0"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("report missing %q:\n%s", want, report)
+		}
+	}
+}
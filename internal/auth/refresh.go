@@ -0,0 +1,165 @@
+// Package auth 在接口返回401/419时执行刷新请求，按JSONPath提取新的token或cookie写入原请求，
+// 由处理器在写入后重试一次原请求
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/batch"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+)
+
+// DefaultTarget 默认将新token写入 Authorization 请求头
+const DefaultTarget = "header:Authorization=Bearer {{.token}}"
+
+// 凭据写入的位置
+const (
+	KindHeader = "header"
+	KindCookie = "cookie"
+)
+
+// Fetcher 执行刷新请求并返回JSON响应体，由 processor.Processor 实现
+type Fetcher interface {
+	Fetch(ctx context.Context, input string) ([]byte, error)
+}
+
+// Target 新凭据写入的位置
+type Target struct {
+	Kind     string // header 或 cookie
+	Name     string // 请求头或cookie名称
+	Template string // 写入的值，{{.token}} 替换为新token；为空时直接写入token
+}
+
+// ParseTarget 解析 header:NAME[=模板] 或 cookie:NAME[=模板] 形式的写入位置
+func ParseTarget(spec string) (Target, error) {
+	kind, rest, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if !ok || (kind != KindHeader && kind != KindCookie) {
+		return Target{}, i18n.Errorf("无效的凭据写入位置 %q，应为 header:名称 或 cookie:名称", spec)
+	}
+
+	name, template, _ := strings.Cut(rest, "=")
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Target{}, i18n.Errorf("无效的凭据写入位置 %q，应为 header:名称 或 cookie:名称", spec)
+	}
+	return Target{Kind: kind, Name: name, Template: strings.TrimSpace(template)}, nil
+}
+
+// Apply 将token写入请求的请求头或cookie
+func (t Target) Apply(req *config.RequestInfo, token string) {
+	value := token
+	if t.Template != "" {
+		value, _ = batch.Substitute(t.Template, map[string]string{"token": token})
+	}
+
+	if t.Kind == KindHeader {
+		setHeader(req, t.Name, value)
+		return
+	}
+
+	if req.Cookies != nil {
+		req.Cookies[t.Name] = value
+	}
+	// 浏览器复制的cURL通过 Cookie 请求头携带cookie，替换其中的同名项
+	cookieHeader := ""
+	for key, v := range req.Headers {
+		if strings.EqualFold(key, "Cookie") {
+			cookieHeader = v
+		}
+	}
+	setHeader(req, "Cookie", replaceCookie(cookieHeader, t.Name, value))
+}
+
+// setHeader 设置请求头，删除大小写不同的同名请求头
+func setHeader(req *config.RequestInfo, name, value string) {
+	if req.Headers == nil {
+		req.Headers = make(map[string]string)
+	}
+	for key := range req.Headers {
+		if strings.EqualFold(key, name) {
+			delete(req.Headers, key)
+		}
+	}
+	req.Headers[name] = value
+}
+
+// replaceCookie 替换或追加cookie字符串中的name项
+func replaceCookie(header, name, value string) string {
+	var pairs []string
+	replaced := false
+	for _, pair := range strings.Split(header, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		if key, _, _ := strings.Cut(pair, "="); strings.TrimSpace(key) == name {
+			pair = name + "=" + value
+			replaced = true
+		}
+		pairs = append(pairs, pair)
+	}
+	if !replaced {
+		pairs = append(pairs, name+"="+value)
+	}
+	return strings.Join(pairs, "; ")
+}
+
+// Refresher 执行刷新请求并将提取到的凭据写入原请求
+type Refresher struct {
+	curl   string
+	token  *jsonpath.Path
+	target Target
+}
+
+// New 创建刷新器，curl 为刷新请求，tokenPath 为新token在刷新响应中的JSONPath，
+// target 为写入位置（见 ParseTarget），为空时使用 DefaultTarget
+func New(curl, tokenPath, target string) (*Refresher, error) {
+	if strings.TrimSpace(tokenPath) == "" {
+		return nil, i18n.Errorf("使用刷新请求时必须指定新token的JSONPath")
+	}
+	path, err := jsonpath.Parse(tokenPath)
+	if err != nil {
+		return nil, err
+	}
+	if target == "" {
+		target = DefaultTarget
+	}
+	t, err := ParseTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	return &Refresher{curl: curl, token: path, target: t}, nil
+}
+
+// Refresh 执行刷新请求，提取新token并写入req
+func (r *Refresher) Refresh(ctx context.Context, fetcher Fetcher, req *config.RequestInfo) error {
+	body, err := fetcher.Fetch(ctx, r.curl)
+	if err != nil {
+		return i18n.Errorf("刷新请求失败: %w", err)
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return i18n.Errorf("响应体不是有效的JSON: %w", err)
+	}
+	value, err := r.token.Lookup(data)
+	if errors.Is(err, jsonpath.ErrNotFound) {
+		return i18n.Errorf("刷新响应中没有找到 %s", r.token)
+	}
+	if err != nil {
+		return err
+	}
+	token, ok := value.(string)
+	if !ok || token == "" {
+		return i18n.Errorf("刷新响应中 %s 不是非空字符串", r.token)
+	}
+
+	r.target.Apply(req, token)
+	return nil
+}
@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+// fakeFetcher 返回预设的刷新响应
+type fakeFetcher struct {
+	body string
+	err  error
+}
+
+func (f fakeFetcher) Fetch(ctx context.Context, input string) ([]byte, error) {
+	return []byte(f.body), f.err
+}
+
+func TestParseTarget(t *testing.T) {
+	tests := map[string]Target{
+		DefaultTarget:                {Kind: KindHeader, Name: "Authorization", Template: "Bearer {{.token}}"},
+		"header:x-jwt-token":         {Kind: KindHeader, Name: "x-jwt-token"},
+		" Cookie: sid = {{.token}} ": {Kind: KindCookie, Name: "sid", Template: "{{.token}}"},
+	}
+	for spec, want := range tests {
+		got, err := ParseTarget(spec)
+		if err != nil || got != want {
+			t.Errorf("ParseTarget(%q) = %+v, %v, want %+v", spec, got, err, want)
+		}
+	}
+
+	for _, spec := range []string{"", "x-jwt-token", "query:token", "header:", "cookie:=x"} {
+		if _, err := ParseTarget(spec); err == nil {
+			t.Errorf("ParseTarget(%q) error = nil, want error", spec)
+		}
+	}
+}
+
+func TestTarget_Apply(t *testing.T) {
+	req := &config.RequestInfo{
+		Headers: map[string]string{"authorization": "Bearer old", "cookie": "lang=zh; sid=old"},
+		Cookies: map[string]string{"sid": "old"},
+	}
+
+	Target{Kind: KindHeader, Name: "Authorization", Template: "Bearer {{.token}}"}.Apply(req, "new")
+	if len(req.Headers) != 2 || req.Headers["Authorization"] != "Bearer new" {
+		t.Errorf("Headers = %v, want replaced Authorization", req.Headers)
+	}
+
+	Target{Kind: KindCookie, Name: "sid"}.Apply(req, "s2")
+	if req.Headers["Cookie"] != "lang=zh; sid=s2" || req.Cookies["sid"] != "s2" {
+		t.Errorf("Headers = %v, Cookies = %v, want replaced sid", req.Headers, req.Cookies)
+	}
+
+	Target{Kind: KindCookie, Name: "token"}.Apply(req, "t1")
+	if req.Headers["Cookie"] != "lang=zh; sid=s2; token=t1" {
+		t.Errorf("Cookie = %s, want appended token", req.Headers["Cookie"])
+	}
+}
+
+func TestRefresher_Refresh(t *testing.T) {
+	r, err := New("curl https://api.example.com/refresh", "$.data.token", "header:x-jwt-token")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	req := &config.RequestInfo{Headers: map[string]string{}}
+	if err := r.Refresh(context.Background(), fakeFetcher{body: `{"data":{"token":"abc"}}`}, req); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if req.Headers["x-jwt-token"] != "abc" {
+		t.Errorf("Headers = %v, want x-jwt-token=abc", req.Headers)
+	}
+
+	failures := map[string]fakeFetcher{
+		"请求失败":   {err: errors.New("connection refused")},
+		"不是JSON": {body: "<html></html>"},
+		"路径不存在":  {body: `{"data":{}}`},
+		"不是字符串":  {body: `{"data":{"token":1}}`},
+		"空字符串":   {body: `{"data":{"token":""}}`},
+	}
+	for name, fetcher := range failures {
+		t.Run(name, func(t *testing.T) {
+			if err := r.Refresh(context.Background(), fetcher, req); err == nil {
+				t.Error("Refresh() error = nil, want error")
+			}
+		})
+	}
+
+	if _, err := New("curl x", "", ""); err == nil {
+		t.Error("New() without token path error = nil, want error")
+	}
+}
@@ -0,0 +1,386 @@
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// blockSeparator 多行cURL块之间的分隔行
+const blockSeparator = "---"
+
+// summaryFile 输出目录中的汇总报告文件名
+const summaryFile = "summary.json"
+
+// Entry 批量文件中的单个cURL请求
+type Entry struct {
+	Index   int               // 从1开始的序号
+	Line    int               // 在文件中的起始行号
+	Curl    string            // cURL命令
+	Vars    map[string]string // 数据驱动模式下本次请求使用的变量
+	Options *Options          // 请求前的内联选项，没有时为nil
+}
+
+// OutputName 返回请求在输出目录中的结果文件名，默认为 <序号>.json
+func (e *Entry) OutputName() string {
+	if e.Options != nil && e.Options.Output != "" {
+		return e.Options.Output
+	}
+	return fmt.Sprintf("%03d.json", e.Index)
+}
+
+// Fingerprint 返回请求的指纹（cURL命令与内联选项的SHA-256），--resume 据此判断请求在两次执行之间是否变化
+func (e *Entry) Fingerprint() string {
+	h := sha256.New()
+	h.Write([]byte(e.Curl))
+	if e.Options != nil {
+		options, _ := json.Marshal(e.Options)
+		h.Write([]byte("\n"))
+		h.Write(options)
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Options 请求前以JSON对象声明的内联选项，覆盖命令行中的同名参数，使不同接口可以在一次批量执行中处理：
+//
+//	{"timeout": 60, "title-key": ["caseName"], "output": "cases.json"}
+//	curl https://example.com/api/cases
+type Options struct {
+	Timeout      int      `json:"timeout,omitempty"` // HTTP请求超时时间（秒）
+	TitleKeys    []string `json:"title-key,omitempty"`
+	ChildrenKeys []string `json:"children-keys,omitempty"`
+	Output       string   `json:"output,omitempty"` // 输出目录中的结果文件名，没有扩展名时补全 .json
+}
+
+// Overrides 判断选项是否覆盖了处理器的参数，只指定 output 时可以共用默认处理器
+func (o *Options) Overrides() bool {
+	return o != nil && (o.Timeout > 0 || len(o.TitleKeys) > 0 || len(o.ChildrenKeys) > 0)
+}
+
+// parseOptions 解析内联选项，不允许未知的键
+func parseOptions(raw string) (*Options, error) {
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	var opts Options
+	if err := decoder.Decode(&opts); err != nil {
+		return nil, i18n.Errorf("内联选项无效: %w", err)
+	}
+	if opts.Timeout < 0 {
+		return nil, i18n.Errorf("内联选项 timeout 不能为负数")
+	}
+	if opts.Output != "" && filepath.Ext(opts.Output) == "" {
+		opts.Output += ".json"
+	}
+	return &opts, nil
+}
+
+// checkOutputs 检查请求的结果文件名都是输出目录中的文件名，没有重复，也不与汇总报告重名
+func checkOutputs(entries []Entry) error {
+	seen := map[string]int{summaryFile: 0}
+	for i := range entries {
+		name := entries[i].OutputName()
+		if strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+			return i18n.Errorf("第 %d 个请求的输出文件名 %q 只能是文件名，不能包含目录", entries[i].Index, name)
+		}
+		if index, ok := seen[name]; ok {
+			if index == 0 {
+				return i18n.Errorf("第 %d 个请求的输出文件名 %s 与汇总报告重名", entries[i].Index, name)
+			}
+			return i18n.Errorf("第 %d 个与第 %d 个请求的输出文件名都是 %s", index, entries[i].Index, name)
+		}
+		seen[name] = entries[i].Index
+	}
+	return nil
+}
+
+// Result 单个请求的执行结果
+type Result struct {
+	Index       int               `json:"index"`
+	Line        int               `json:"line"`
+	Fingerprint string            `json:"fingerprint"`
+	Vars        map[string]string `json:"vars,omitempty"`
+	Success     bool              `json:"success"`
+	Resumed     bool              `json:"resumed,omitempty"` // 使用 --resume 时沿用了上次执行的结果
+	Output      string            `json:"output,omitempty"`
+	Nodes       int               `json:"nodes"`
+	Error       string            `json:"error,omitempty"`
+	Duration    string            `json:"duration"`
+}
+
+// Summary 批量执行汇总报告，每个请求结束后更新，执行中断时 Results 只包含已结束的请求
+type Summary struct {
+	Total     int       `json:"total"`
+	Succeeded int       `json:"succeeded"`
+	Failed    int       `json:"failed"`
+	Resumed   int       `json:"resumed,omitempty"`
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration"`
+	Results   []Result  `json:"results"`
+}
+
+// ParseEntries 将批量文件内容拆分为cURL请求
+// 文件中存在单独一行的 --- 时按块拆分（支持多行cURL），否则每个非空行为一个请求；
+// 以 # 开头的行为注释，行尾的 \ 表示续行；请求前以 { 开头的JSON对象（可跨行）为该请求的内联选项
+func ParseEntries(content string) ([]Entry, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	lines := strings.Split(content, "\n")
+
+	blockMode := false
+	for _, line := range lines {
+		if strings.TrimSpace(line) == blockSeparator {
+			blockMode = true
+			break
+		}
+	}
+
+	var entries []Entry
+	var current []string
+	startLine := 0
+
+	// 正在读取的内联选项行与已读取、等待下一个请求使用的内联选项
+	var optionLines []string
+	optionsLine := 0
+	var pending *Options
+
+	flush := func() error {
+		if len(optionLines) > 0 {
+			return i18n.Errorf("第 %d 行的内联选项不是完整的JSON对象", optionsLine)
+		}
+		curl := strings.TrimSpace(strings.Join(current, "\n"))
+		current = nil
+		if curl == "" {
+			if pending != nil {
+				return i18n.Errorf("第 %d 行的内联选项之后没有cURL命令", optionsLine)
+			}
+			return nil
+		}
+		entries = append(entries, Entry{Index: len(entries) + 1, Line: startLine, Curl: curl, Options: pending})
+		pending = nil
+		return nil
+	}
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if blockMode && trimmed == blockSeparator {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if len(current) == 0 && len(optionLines) == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "#")) {
+			continue
+		}
+
+		if len(current) == 0 && (len(optionLines) > 0 || strings.HasPrefix(trimmed, "{")) {
+			if len(optionLines) == 0 {
+				if pending != nil {
+					return nil, i18n.Errorf("第 %d 行: 同一个请求只能有一组内联选项", i+1)
+				}
+				optionsLine = i + 1
+			}
+			optionLines = append(optionLines, line)
+			if raw := strings.Join(optionLines, "\n"); json.Valid([]byte(raw)) {
+				opts, err := parseOptions(raw)
+				if err != nil {
+					return nil, i18n.Errorf("第 %d 行: %w", optionsLine, err)
+				}
+				pending, optionLines = opts, nil
+			}
+			continue
+		}
+
+		if len(current) == 0 {
+			startLine = i + 1
+		}
+		current = append(current, line)
+
+		if !blockMode && !strings.HasSuffix(trimmed, `\`) {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	if err := checkOutputs(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Runner 批量执行器
+type Runner struct {
+	processor    *processor.Processor
+	newProcessor func(*Options) *processor.Processor
+	outDir       string
+	logger       *slog.Logger
+	failEmpty    bool
+	completed    map[int]Result // --resume 时上次执行成功的请求，按序号索引
+}
+
+// NewRunner 创建批量执行器，结果写入outDir，log 为nil时不输出日志
+func NewRunner(p *processor.Processor, outDir string, log *slog.Logger) *Runner {
+	if log == nil {
+		log = logger.Discard()
+	}
+	return &Runner{
+		processor: p,
+		outDir:    outDir,
+		logger:    log,
+	}
+}
+
+// SetFailEmpty 设置是否将抽取结果为空树的请求视为失败
+func (r *Runner) SetFailEmpty(failEmpty bool) {
+	r.failEmpty = failEmpty
+}
+
+// SetProcessorFunc 设置为覆盖了处理器参数的内联选项创建处理器的函数，未设置时这些请求使用默认处理器
+func (r *Runner) SetProcessorFunc(fn func(*Options) *processor.Processor) {
+	r.newProcessor = fn
+}
+
+// SetResume 设置上次执行的汇总报告：序号与指纹相同、上次成功且结果文件仍然存在的请求不再执行，沿用上次的结果
+func (r *Runner) SetResume(previous *Summary) {
+	r.completed = make(map[int]Result)
+	for _, result := range previous.Results {
+		if result.Success {
+			r.completed[result.Index] = result
+		}
+	}
+}
+
+// LoadSummary 读取输出目录中上次执行的汇总报告，不存在时返回 nil, nil
+func LoadSummary(outDir string) (*Summary, error) {
+	content, err := os.ReadFile(filepath.Join(outDir, summaryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取汇总报告失败: %w", err)
+	}
+	var summary Summary
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return nil, i18n.Errorf("解析汇总报告 %s 失败: %w", filepath.Join(outDir, summaryFile), err)
+	}
+	return &summary, nil
+}
+
+// Run 依次执行所有请求，单个失败不会中断后续请求；ctx 取消后剩余请求均以取消错误结束。
+// 汇总报告在每个请求结束后更新，执行中断后可以用 SetResume 从失败处继续
+func (r *Runner) Run(ctx context.Context, entries []Entry) (*Summary, error) {
+	if err := os.MkdirAll(r.outDir, 0755); err != nil {
+		return nil, i18n.Errorf("创建输出目录失败: %w", err)
+	}
+
+	summary := &Summary{
+		Total:     len(entries),
+		StartedAt: time.Now(),
+		Results:   []Result{},
+	}
+	if err := r.writeSummary(summary); err != nil {
+		return summary, err
+	}
+
+	for _, entry := range entries {
+		result, resumed := r.resumed(entry)
+		if resumed {
+			summary.Resumed++
+		} else {
+			result = r.runEntry(ctx, entry)
+		}
+		if result.Success {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+		summary.Results = append(summary.Results, result)
+		if err := r.writeSummary(summary); err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// resumed 返回上次执行中同一请求的成功结果，请求有变化或结果文件已不存在时重新执行
+func (r *Runner) resumed(entry Entry) (Result, bool) {
+	previous, ok := r.completed[entry.Index]
+	if !ok || previous.Fingerprint != entry.Fingerprint() || previous.Output != filepath.Join(r.outDir, entry.OutputName()) {
+		return Result{}, false
+	}
+	if _, err := os.Stat(previous.Output); err != nil {
+		return Result{}, false
+	}
+	r.logger.Debug(i18n.T("沿用上次执行的结果"), "index", entry.Index, "output", previous.Output)
+	previous.Resumed = true
+	return previous, true
+}
+
+// runEntry 执行单个请求并写入结果文件
+func (r *Runner) runEntry(ctx context.Context, entry Entry) Result {
+	start := time.Now()
+	result := Result{Index: entry.Index, Line: entry.Line, Fingerprint: entry.Fingerprint(), Vars: entry.Vars}
+
+	r.logger.Debug(i18n.T("执行cURL命令"), "index", entry.Index, "line", entry.Line)
+
+	p := r.processor
+	if entry.Options.Overrides() && r.newProcessor != nil {
+		p = r.newProcessor(entry.Options)
+	}
+	output, err := p.Process(ctx, entry.Curl, nil)
+	if err == nil {
+		path := filepath.Join(r.outDir, entry.OutputName())
+		if err = os.WriteFile(path, output, 0644); err == nil {
+			result.Output = path
+			if nodes, parseErr := extractor.ParseNodes(output); parseErr == nil {
+				result.Nodes = extractor.CountNodes(nodes)
+			}
+			if r.failEmpty && result.Nodes == 0 {
+				err = errs.Mark(i18n.Errorf("抽取结果为空树"), errs.ErrEmptyTree)
+			}
+		}
+	}
+
+	result.Success = err == nil
+	if err != nil {
+		result.Error = err.Error()
+	}
+	result.Duration = time.Since(start).Round(time.Millisecond).String()
+	return result
+}
+
+// writeSummary 将汇总报告写入输出目录
+func (r *Runner) writeSummary(summary *Summary) error {
+	summary.Duration = time.Since(summary.StartedAt).Round(time.Millisecond).String()
+	content, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return i18n.Errorf("汇总报告序列化失败: %w", err)
+	}
+	if err := os.WriteFile(r.SummaryPath(), content, 0644); err != nil {
+		return i18n.Errorf("写入汇总报告失败: %w", err)
+	}
+	return nil
+}
+
+// SummaryPath 汇总报告文件路径
+func (r *Runner) SummaryPath() string {
+	return filepath.Join(r.outDir, summaryFile)
+}
@@ -0,0 +1,293 @@
+package batch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/pkg/parser"
+)
+
+func TestParseEntries(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantCurls []string
+		wantLines []int
+	}{
+		{
+			name:      "每行一个请求",
+			content:   "curl http://a.com\n\n# 注释\ncurl http://b.com\n",
+			wantCurls: []string{"curl http://a.com", "curl http://b.com"},
+			wantLines: []int{1, 4},
+		},
+		{
+			name:      "反斜杠续行",
+			content:   "curl http://a.com \\\n  -H 'a: b'\ncurl http://b.com",
+			wantCurls: []string{"curl http://a.com \\\n  -H 'a: b'", "curl http://b.com"},
+			wantLines: []int{1, 3},
+		},
+		{
+			name:      "按---分块",
+			content:   "curl http://a.com\n  -H 'a: b'\n---\n\ncurl http://b.com\n---\n",
+			wantCurls: []string{"curl http://a.com\n  -H 'a: b'", "curl http://b.com"},
+			wantLines: []int{1, 5},
+		},
+		{
+			name:      "CRLF换行",
+			content:   "curl http://a.com\r\ncurl http://b.com\r\n",
+			wantCurls: []string{"curl http://a.com", "curl http://b.com"},
+			wantLines: []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := ParseEntries(tt.content)
+			if err != nil {
+				t.Fatalf("ParseEntries() error = %v", err)
+			}
+			if len(entries) != len(tt.wantCurls) {
+				t.Fatalf("ParseEntries() 返回 %d 个请求, want %d", len(entries), len(tt.wantCurls))
+			}
+			for i, entry := range entries {
+				if entry.Curl != tt.wantCurls[i] {
+					t.Errorf("entries[%d].Curl = %q, want %q", i, entry.Curl, tt.wantCurls[i])
+				}
+				if entry.Line != tt.wantLines[i] {
+					t.Errorf("entries[%d].Line = %d, want %d", i, entry.Line, tt.wantLines[i])
+				}
+				if entry.Index != i+1 {
+					t.Errorf("entries[%d].Index = %d, want %d", i, entry.Index, i+1)
+				}
+			}
+		})
+	}
+}
+
+func TestParseEntries_Options(t *testing.T) {
+	content := `{"timeout": 60, "title-key": ["caseName"], "output": "cases"}
+curl http://a.com
+curl http://b.com
+{
+  "children-keys": ["items"]
+}
+# 注释
+curl http://c.com \
+  -H 'a: b'
+`
+	entries, err := ParseEntries(content)
+	if err != nil {
+		t.Fatalf("ParseEntries() error = %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("ParseEntries() 返回 %d 个请求, want 3", len(entries))
+	}
+	if opts := entries[0].Options; opts == nil || opts.Timeout != 60 || opts.TitleKeys[0] != "caseName" || !opts.Overrides() {
+		t.Errorf("entries[0].Options = %+v", opts)
+	}
+	if name := entries[0].OutputName(); name != "cases.json" {
+		t.Errorf("entries[0].OutputName() = %s, want cases.json", name)
+	}
+	if entries[1].Options != nil || entries[1].OutputName() != "002.json" {
+		t.Errorf("entries[1] = %+v, want no options", entries[1])
+	}
+	if opts := entries[2].Options; opts == nil || opts.ChildrenKeys[0] != "items" || entries[2].Line != 8 {
+		t.Errorf("entries[2] = %+v, options %+v", entries[2], opts)
+	}
+
+	invalid := map[string]string{
+		"未知的键":    "{\"retries\": 3}\ncurl http://a.com",
+		"没有请求":    "curl http://a.com\n---\n{\"timeout\": 5}\n---\ncurl http://b.com",
+		"JSON不完整": "{\"timeout\": 5\n---\ncurl http://a.com",
+		"两组选项":    "{\"timeout\": 5}\n{\"output\": \"a\"}\ncurl http://a.com",
+		"输出文件重名":  "{\"output\": \"a\"}\ncurl http://a.com\n{\"output\": \"a.json\"}\ncurl http://b.com",
+		"输出包含目录":  "{\"output\": \"../a\"}\ncurl http://a.com",
+		"与汇总报告重名": "{\"output\": \"summary\"}\ncurl http://a.com",
+	}
+	for name, content := range invalid {
+		if _, err := ParseEntries(content); err == nil {
+			t.Errorf("%s: ParseEntries() 应返回错误", name)
+		}
+	}
+}
+
+func TestExpandEntries(t *testing.T) {
+	rows, err := ParseCSV(strings.NewReader("\ufeffproject_id,case_id\n2020093407,11052476\n2020093408,11052477\n"))
+	if err != nil {
+		t.Fatalf("ParseCSV() error = %v", err)
+	}
+
+	templates := []Entry{{Index: 1, Line: 1, Curl: `curl https://a.com -H 'projectid: {{.project_id}}' -H 'x-token: {{env:TOKEN}}' --data-raw '{"TestCaseId":{{ .case_id }}}'`}}
+	entries, err := ExpandEntries(templates, rows)
+	if err != nil {
+		t.Fatalf("ExpandEntries() error = %v", err)
+	}
+
+	want := []string{
+		`curl https://a.com -H 'projectid: 2020093407' -H 'x-token: {{env:TOKEN}}' --data-raw '{"TestCaseId":11052476}'`,
+		`curl https://a.com -H 'projectid: 2020093408' -H 'x-token: {{env:TOKEN}}' --data-raw '{"TestCaseId":11052477}'`,
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("ExpandEntries() 返回 %d 个请求, want %d", len(entries), len(want))
+	}
+	for i, entry := range entries {
+		if entry.Curl != want[i] {
+			t.Errorf("entries[%d].Curl = %s, want %s", i, entry.Curl, want[i])
+		}
+		if entry.Index != i+1 {
+			t.Errorf("entries[%d].Index = %d, want %d", i, entry.Index, i+1)
+		}
+	}
+
+	// 输出文件名中的变量按每行展开，展开后重名时返回错误
+	templates[0].Options = &Options{Output: "case_{{.case_id}}.json"}
+	if entries, err = ExpandEntries(templates, rows); err != nil || entries[1].OutputName() != "case_11052477.json" {
+		t.Errorf("ExpandEntries() = %+v, %v", entries, err)
+	}
+	templates[0].Options = &Options{Output: "case.json"}
+	if _, err := ExpandEntries(templates, rows); err == nil {
+		t.Errorf("ExpandEntries() 输出文件名重复时应返回错误")
+	}
+
+	if _, err := Render("{{.missing}}", rows[0]); err == nil {
+		t.Errorf("Render() 缺少变量时应返回错误")
+	}
+}
+
+func TestSubstituteCurl(t *testing.T) {
+	// 变量值试图闭合引号、追加选项或读取本地文件
+	values := []string{
+		`x' -H 'Injected: 1' -d @/etc/passwd '`,
+		`x" -H "Injected: 1`,
+		`x -H Injected:1 --data-binary @/etc/passwd`,
+		"line1\nline2\r\n$(id)`id`\\",
+		`a"}, "extra": {"b`,
+	}
+	template := `curl https://a.com/{{.v}} -H 'single: {{.v}}' -H "double: {{.v}}" -H $'ansi: {{.v}}' --data-binary {"name":"{{.v}}","raw":{{.v}}}`
+
+	for _, value := range values {
+		curl, missing := SubstituteCurl(template, map[string]string{"v": value})
+		if len(missing) > 0 {
+			t.Fatalf("SubstituteCurl() missing = %v", missing)
+		}
+		info, err := parser.New(parser.WithoutFileAccess()).Parse(curl)
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", curl, err)
+		}
+		// 请求头中的换行由解析器合并，三种引号得到的值应相同
+		if len(info.Headers) != 3 || !strings.Contains(value, "\n") && info.Headers["single"] != value {
+			t.Errorf("value %q: headers = %v", value, info.Headers)
+		}
+		if info.Headers["double"] != info.Headers["single"] || info.Headers["ansi"] != info.Headers["single"] {
+			t.Errorf("value %q: headers = %v", value, info.Headers)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(info.Body), &body); err != nil || len(body) != 2 || body["name"] != value || body["raw"] != value {
+			t.Errorf("value %q: body = %s, %v", value, info.Body, err)
+		}
+		if !strings.HasPrefix(info.URL, "https://a.com/") {
+			t.Errorf("value %q: url = %s", value, info.URL)
+		}
+	}
+
+	// 合法的JSON值原样写入未加引号的JSON
+	curl, _ := SubstituteCurl(`curl https://a.com --data-binary {"id":{{.id}}} -H 'x: {{.missing}}'`, map[string]string{"id": "11052476"})
+	if want := `curl https://a.com --data-binary {"id":11052476} -H 'x: {{.missing}}'`; curl != want {
+		t.Errorf("SubstituteCurl() = %s, want %s", curl, want)
+	}
+}
+
+func TestRunner_Resume(t *testing.T) {
+	var calls sync.Map
+	failing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count, _ := calls.LoadOrStore(r.URL.Path, new(int32))
+		atomic.AddInt32(count.(*int32), 1)
+		if r.URL.Path == "/b" && failing {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"根\"},\"children\":[]}"}}`)
+	}))
+	defer server.Close()
+	called := func(path string) int32 {
+		count, ok := calls.Load(path)
+		if !ok {
+			return 0
+		}
+		return atomic.LoadInt32(count.(*int32))
+	}
+
+	var entries []Entry
+	for i, path := range []string{"/a", "/b", "/c"} {
+		entries = append(entries, Entry{Index: i + 1, Line: i + 1, Curl: server.URL + path})
+	}
+	outDir := t.TempDir()
+	newRunner := func() *Runner {
+		p := processor.New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+		// 与cURL解析无关，直接把 Curl 作为请求URL
+		p.Hooks().Before(pipeline.StageParse, func(ctx context.Context, state *pipeline.State) error {
+			state.Request, state.Input = &config.RequestInfo{Method: "GET", URL: state.Input}, ""
+			return nil
+		})
+		return NewRunner(p, outDir, nil)
+	}
+
+	summary, err := newRunner().Run(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Succeeded != 2 || summary.Failed != 1 {
+		t.Fatalf("第一次执行 成功 %d 失败 %d, want 2 和 1", summary.Succeeded, summary.Failed)
+	}
+
+	// 第二次执行只重新执行失败的请求与结果文件已被删除的请求
+	failing = false
+	if err := os.Remove(filepath.Join(outDir, "003.json")); err != nil {
+		t.Fatal(err)
+	}
+	previous, err := LoadSummary(outDir)
+	if err != nil || previous == nil {
+		t.Fatalf("LoadSummary() = %v, %v", previous, err)
+	}
+	runner := newRunner()
+	runner.SetResume(previous)
+	if summary, err = runner.Run(context.Background(), entries); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if summary.Succeeded != 3 || summary.Failed != 0 || summary.Resumed != 1 {
+		t.Errorf("继续执行 成功 %d 失败 %d 沿用 %d, want 3、0、1", summary.Succeeded, summary.Failed, summary.Resumed)
+	}
+	if called("/a") != 1 || called("/b") != 2 || called("/c") != 2 {
+		t.Errorf("请求次数 a=%d b=%d c=%d, want 1、2、2", called("/a"), called("/b"), called("/c"))
+	}
+	if !summary.Results[0].Resumed || summary.Results[1].Resumed {
+		t.Errorf("Results = %+v, 只有第一个请求应沿用上次的结果", summary.Results)
+	}
+
+	// 请求变化后不再沿用
+	entries[0].Curl += "?case=1"
+	runner = newRunner()
+	runner.SetResume(summary)
+	if summary, err = runner.Run(context.Background(), entries); err != nil || summary.Resumed != 2 || called("/a") != 2 {
+		t.Errorf("修改请求后 沿用 %d, a 请求次数 %d, want 2 和 2", summary.Resumed, called("/a"))
+	}
+
+	if previous, err := LoadSummary(t.TempDir()); previous != nil || err != nil {
+		t.Errorf("LoadSummary() 没有汇总报告时 = %v, %v, want nil, nil", previous, err)
+	}
+}
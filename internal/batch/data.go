@@ -0,0 +1,140 @@
+package batch
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// variableRe 匹配cURL模板中的 {{.column}} 变量
+var variableRe = regexp.MustCompile(`\{\{\s*\.([A-Za-z0-9_\-]+)\s*\}\}`)
+
+// nameRe 合法的模板变量名
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// LoadCSV 读取CSV变量文件，第一行为列名，每行返回一组变量
+func LoadCSV(path string) ([]map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseCSV(file)
+}
+
+// ParseCSV 解析CSV变量数据，第一行为列名
+func ParseCSV(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, i18n.Errorf("CSV文件为空")
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取CSV表头失败: %w", err)
+	}
+	for i, column := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(column, "\ufeff"))
+	}
+
+	var rows []map[string]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, i18n.Errorf("读取CSV数据失败: %w", err)
+		}
+
+		row := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				row[column] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, i18n.Errorf("CSV文件没有数据行")
+	}
+	return rows, nil
+}
+
+// ExpandEntries 将每个cURL模板按每行变量展开为独立请求
+func ExpandEntries(templates []Entry, rows []map[string]string) ([]Entry, error) {
+	var entries []Entry
+	for _, tmpl := range templates {
+		for rowIndex, vars := range rows {
+			curl, err := RenderCurl(tmpl.Curl, vars)
+			if err != nil {
+				return nil, i18n.Errorf("第 %d 行变量: %w", rowIndex+1, err)
+			}
+			opts := tmpl.Options
+			if opts != nil && opts.Output != "" {
+				// 输出文件名中同样可以使用变量，使每行数据写入不同的文件
+				rendered := *opts
+				if rendered.Output, err = Render(opts.Output, vars); err != nil {
+					return nil, i18n.Errorf("第 %d 行变量: %w", rowIndex+1, err)
+				}
+				opts = &rendered
+			}
+			entries = append(entries, Entry{
+				Index:   len(entries) + 1,
+				Line:    tmpl.Line,
+				Curl:    curl,
+				Vars:    vars,
+				Options: opts,
+			})
+		}
+	}
+	if err := checkOutputs(entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Render 将模板中的 {{.column}} 替换为变量值，缺少的列返回错误
+func Render(template string, vars map[string]string) (string, error) {
+	return render(template, vars, Substitute)
+}
+
+// RenderCurl 与 Render 相同，模板为cURL命令，变量值按 SubstituteCurl 引用
+func RenderCurl(template string, vars map[string]string) (string, error) {
+	return render(template, vars, SubstituteCurl)
+}
+
+func render(template string, vars map[string]string, substitute func(string, map[string]string) (string, []string)) (string, error) {
+	result, missing := substitute(template, vars)
+	if len(missing) > 0 {
+		return "", i18n.Errorf("CSV中缺少变量: %s", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// Substitute 将模板中的 {{.name}} 替换为变量值，缺少的变量保持原样并按出现顺序返回其名称
+func Substitute(template string, vars map[string]string) (string, []string) {
+	var missing []string
+	result := variableRe.ReplaceAllStringFunc(template, func(match string) string {
+		name := variableRe.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+	return result, missing
+}
+
+// ValidName 判断name能否作为模板变量名使用
+func ValidName(name string) bool {
+	return nameRe.MatchString(name)
+}
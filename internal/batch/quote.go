@@ -0,0 +1,200 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// quoteContext 变量在cURL命令中所处的引用方式，与 parser 切分单词的规则一致
+type quoteContext int
+
+const (
+	unquoted   quoteContext = iota // 引号之外
+	single                         // '...'
+	double                         // "..."
+	ansi                           // $'...'
+	jsonValue                      // 以 { 或 [ 开头的未加引号JSON中、字符串之外
+	jsonString                     // 未加引号JSON中的字符串
+)
+
+// SubstituteCurl 与 Substitute 相同，但模板为cURL命令：变量值按它在命令中的位置引用或转义，
+// 使命令切分单词后变量值始终完整地属于原来的单词，值中的引号、空白与 -H 等内容不会改变命令的结构
+func SubstituteCurl(template string, vars map[string]string) (string, []string) {
+	var missing []string
+	var b strings.Builder
+	s := &curlScanner{wordStart: true}
+	last := 0
+	for _, loc := range variableRe.FindAllStringSubmatchIndex(template, -1) {
+		s.scan(template, last, loc[0])
+		last = loc[1]
+
+		name := template[loc[2]:loc[3]]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			b.WriteString(template[s.copied:loc[1]])
+		} else {
+			b.WriteString(template[s.copied:loc[0]])
+			b.WriteString(quoteFor(s.context(), value))
+		}
+		s.copied = loc[1]
+		s.wordStart = false
+	}
+	s.scan(template, last, len(template))
+	b.WriteString(template[s.copied:])
+	return b.String(), missing
+}
+
+// curlScanner 逐字节跟踪模板在各位置的引用方式，规则见 parser 的 splitWords
+type curlScanner struct {
+	state     quoteContext
+	wordStart bool // 下一个字节是否为单词的开头
+	depth     int  // 未加引号JSON中括号的嵌套层数
+	copied    int  // 已写入结果的模板位置
+}
+
+// context 返回当前位置的引用方式；未加引号JSON的括号已全部闭合时与引号之外相同
+func (s *curlScanner) context() quoteContext {
+	if s.state == jsonValue && s.depth <= 0 {
+		return unquoted
+	}
+	return s.state
+}
+
+// scan 跟踪 template[from:to] 之后的状态
+func (s *curlScanner) scan(template string, from, to int) {
+	for i := from; i < to; i++ {
+		c := template[i]
+		switch s.state {
+		case unquoted:
+			switch {
+			case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+				s.wordStart = true
+				continue
+			case s.wordStart && (c == '{' || c == '['):
+				s.state, s.depth = jsonValue, 1
+			case c == '\'':
+				s.state = single
+			case c == '"':
+				s.state = double
+			case c == '$' && i+1 < to && template[i+1] == '\'':
+				s.state = ansi
+				i++
+			case c == '\\':
+				// 续行不影响是否处于单词开头
+				if i+1 < to && template[i+1] == '\n' {
+					i++
+					continue
+				}
+				i++
+			}
+		case single:
+			if c == '\'' {
+				s.state = unquoted
+			}
+		case double, ansi:
+			switch {
+			case c == '\\':
+				i++
+			case c == '"' && s.state == double, c == '\'' && s.state == ansi:
+				s.state = unquoted
+			}
+		case jsonValue:
+			switch c {
+			case '"':
+				s.state = jsonString
+			case '{', '[':
+				s.depth++
+			case '}', ']':
+				s.depth--
+			case ' ', '\t', '\n', '\r':
+				if s.depth <= 0 {
+					s.state, s.wordStart = unquoted, true
+					continue
+				}
+			}
+		case jsonString:
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				s.state = jsonValue
+			}
+		}
+		s.wordStart = false
+	}
+}
+
+// quoteFor 按引用方式转义变量值
+func quoteFor(context quoteContext, value string) string {
+	switch context {
+	case single:
+		if hasControl(value) {
+			return "'" + ansiQuote(value) + "'"
+		}
+		return strings.ReplaceAll(value, "'", `'\''`)
+	case double:
+		if hasControl(value) {
+			return `"` + ansiQuote(value) + `"`
+		}
+		return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", `\$`, "`", "\\`").Replace(value)
+	case ansi:
+		quoted := ansiQuote(value)
+		return quoted[2 : len(quoted)-1]
+	case jsonString:
+		quoted := jsonQuote(value)
+		return quoted[1 : len(quoted)-1]
+	case jsonValue:
+		// 合法的JSON值（数字、对象等）原样写入，其他值作为JSON字符串
+		if json.Valid([]byte(value)) && !hasControl(value) {
+			return value
+		}
+		return jsonQuote(value)
+	}
+	// 引号之外只含安全字符的值原样写入，其他值加引号
+	if value != "" && strings.Trim(value, safeChars) == "" {
+		return value
+	}
+	if hasControl(value) {
+		return ansiQuote(value)
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// safeChars 引号之外不需要引用的字符
+const safeChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-"
+
+// hasControl 判断值中是否有换行等控制字符，这些字符在单引号与双引号中会被切分单词前的换行规整改写
+func hasControl(value string) bool {
+	return strings.IndexFunc(value, func(r rune) bool { return r < 0x20 || r == 0x7f }) >= 0
+}
+
+// ansiQuote 使用 $'...' 引用值，反斜杠、单引号与控制字符转义
+func ansiQuote(value string) string {
+	var b strings.Builder
+	b.WriteString("$'")
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' || c == '\'':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
+
+// jsonQuote 返回值的JSON字符串字面量，不转义 <、>、&
+func jsonQuote(value string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,150 @@
+// Package bodyedit 解析并执行 --body-set 与 --body-delete，在发送前修改JSON请求体中的字段，
+// 例如 --body-set 'project_id=1024'、--body-set '$.page.size=100'、--body-delete 'filters[0]'
+package bodyedit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+)
+
+// Edit 对请求体的一处修改
+type Edit struct {
+	expr   string
+	path   *jsonpath.Path
+	value  interface{}
+	delete bool
+}
+
+// ParseSet 解析 路径=值 形式的修改：路径为JSONPath，省略开头的 $ 时相对于根对象；
+// 值按JSON字面量解析（如 100、true、null、{"a":1}），解析失败时视为字符串，需要字符串 "100" 时加上引号
+func ParseSet(expr string) (*Edit, error) {
+	rawPath, rawValue, ok := strings.Cut(expr, "=")
+	if !ok {
+		return nil, i18n.Errorf("无效的 --body-set %q，格式应为 路径=值", expr)
+	}
+	path, err := parsePath(rawPath)
+	if err != nil {
+		return nil, i18n.Errorf("无效的 --body-set %q: %w", expr, err)
+	}
+	return &Edit{expr: expr, path: path, value: parseValue(rawValue)}, nil
+}
+
+// ParseDelete 解析要删除的对象键或数组元素的路径
+func ParseDelete(expr string) (*Edit, error) {
+	path, err := parsePath(expr)
+	if err != nil {
+		return nil, i18n.Errorf("无效的 --body-delete %q: %w", expr, err)
+	}
+	if path.String() == "$" {
+		return nil, i18n.Errorf("无效的 --body-delete %q: 不能删除整个请求体", expr)
+	}
+	return &Edit{expr: expr, path: path, delete: true}, nil
+}
+
+// ParseAll 解析全部修改，先执行所有 --body-set，再执行 --body-delete，各自按指定的顺序
+func ParseAll(sets, deletes []string) ([]*Edit, error) {
+	edits := make([]*Edit, 0, len(sets)+len(deletes))
+	for _, expr := range sets {
+		edit, err := ParseSet(expr)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	for _, expr := range deletes {
+		edit, err := ParseDelete(expr)
+		if err != nil {
+			return nil, err
+		}
+		edits = append(edits, edit)
+	}
+	return edits, nil
+}
+
+// parsePath 解析路径，page.size 与 [0].id 分别视为 $.page.size 与 $[0].id
+func parsePath(expr string) (*jsonpath.Path, error) {
+	expr = strings.TrimSpace(expr)
+	switch {
+	case expr == "":
+		return nil, i18n.Errorf("路径为空")
+	case strings.HasPrefix(expr, "$"):
+	case strings.HasPrefix(expr, "["):
+		expr = "$" + expr
+	default:
+		expr = "$." + expr
+	}
+	return jsonpath.Parse(expr)
+}
+
+// parseValue 按JSON字面量解析值，大整数保持原样，解析失败时视为字符串
+func parseValue(text string) interface{} {
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil || decoder.More() {
+		return text
+	}
+	return value
+}
+
+// String 返回修改的原始表达式
+func (e *Edit) String() string {
+	return e.expr
+}
+
+// Apply 依次执行修改并返回新的请求体。请求体必须是JSON，数字保持原样，
+// 对象的键按名称排序输出，HTML字符不转义
+func Apply(body string, edits []*Edit) (string, error) {
+	if strings.TrimSpace(body) == "" {
+		return "", i18n.Errorf("请求没有请求体，无法修改")
+	}
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil || decoder.More() {
+		return "", i18n.Errorf("请求体不是有效的JSON，无法修改")
+	}
+
+	for _, edit := range edits {
+		var err error
+		if edit.delete {
+			data, err = edit.path.Delete(data)
+		} else {
+			data, err = edit.path.Set(data, edit.value)
+		}
+		if errors.Is(err, jsonpath.ErrNotFound) {
+			return "", i18n.Errorf("%s: 请求体中不存在路径 %s", edit.expr, edit.path)
+		}
+		if err != nil {
+			return "", i18n.Errorf("%s: %w", edit.expr, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(data); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// Hook 返回在 parse 阶段之后修改请求体的流水线钩子，请求体不是JSON或路径不存在时以 exitcode.Usage 中止
+func Hook(edits []*Edit) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		body, err := Apply(state.Request.Body, edits)
+		if err != nil {
+			return exitcode.Wrap(exitcode.Usage, err)
+		}
+		state.Request.Body = body
+		return nil
+	}
+}
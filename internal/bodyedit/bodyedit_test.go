@@ -0,0 +1,80 @@
+package bodyedit
+
+import "testing"
+
+const body = `{"project_id":1,"page":{"size":20,"cursor":"abc"},"filters":[{"id":12345678901234567890},{"id":2}],"debug":true}`
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		sets    []string
+		deletes []string
+		want    string
+	}{
+		{
+			name: "number",
+			sets: []string{"project_id=1024", "$.page.size=100"},
+			want: `{"debug":true,"filters":[{"id":12345678901234567890},{"id":2}],"page":{"cursor":"abc","size":100},"project_id":1024}`,
+		},
+		{
+			name: "string and object",
+			sets: []string{"page.cursor=", `name="100"`, "tag=a<b", `extra={"x":[1]}`},
+			want: `{"debug":true,"extra":{"x":[1]},"filters":[{"id":12345678901234567890},{"id":2}],"name":"100","page":{"cursor":"","size":20},"project_id":1,"tag":"a<b"}`,
+		},
+		{
+			name:    "delete",
+			sets:    []string{"filters[-1].id=3"},
+			deletes: []string{"debug", "filters[0]", "page.cursor"},
+			want:    `{"filters":[{"id":3}],"page":{"size":20},"project_id":1}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits, err := ParseAll(tt.sets, tt.deletes)
+			if err != nil {
+				t.Fatalf("ParseAll() error = %v", err)
+			}
+			got, err := Apply(body, edits)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Apply() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	edits, err := ParseAll([]string{"page.size=1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range []string{"", "page=1", `{"page":1}`, `{"page":[]}`} {
+		if _, err := Apply(b, edits); err == nil {
+			t.Errorf("Apply(%q) error = nil, want error", b)
+		}
+	}
+
+	deletes, err := ParseAll(nil, []string{"missing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(body, deletes); err == nil {
+		t.Error("Apply() deleting a missing key error = nil, want error")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"project_id", "=1", "a[x]=1"} {
+		if _, err := ParseSet(expr); err == nil {
+			t.Errorf("ParseSet(%q) error = nil, want error", expr)
+		}
+	}
+	for _, expr := range []string{"", "$", "a[0"} {
+		if _, err := ParseDelete(expr); err == nil {
+			t.Errorf("ParseDelete(%q) error = nil, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+// Package capture 读取抓包工具导出的会话文件（Charles .chlsj、Fiddler .saz、mitmproxy流文件），
+// 从中按URL挑选一条请求转换为请求信息，覆盖浏览器之外（如移动端App）抓到的流量。
+// 会话中记录的响应可以通过 Replay 直接回放，不再发送请求
+package capture
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 支持的会话文件格式
+const (
+	FormatCharles   = "charles"
+	FormatFiddler   = "fiddler"
+	FormatMitmproxy = "mitmproxy"
+)
+
+// Entry 会话中的一条请求记录
+type Entry struct {
+	Request  *config.RequestInfo
+	Response *Response // 没有记录响应（如请求未完成）或响应无法解压时为nil
+
+	responseErr error // 响应无法解压的原因
+}
+
+// Response 记录的响应，Body 已按 Content-Encoding 解压
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Load 读取会话文件，格式按扩展名确定：.chlsj、.saz，mitmproxy 的 .flow/.mitm 或 mitmdump -w 写出的无扩展名文件
+func Load(path string) ([]Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取抓包文件失败: %w", err)
+	}
+
+	var entries []Entry
+	switch format := Detect(path, data); format {
+	case FormatCharles:
+		entries, err = parseCharles(data)
+	case FormatFiddler:
+		entries, err = parseFiddler(data)
+	case FormatMitmproxy:
+		entries, err = parseMitmproxy(data)
+	default:
+		return nil, i18n.Errorf("无法识别抓包文件 %s 的格式，支持 Charles（.chlsj）、Fiddler（.saz）和 mitmproxy（.flow）", filepath.Base(path))
+	}
+	if err != nil {
+		return nil, i18n.Errorf("解析抓包文件 %s 失败: %w", filepath.Base(path), err)
+	}
+	if len(entries) == 0 {
+		return nil, i18n.Errorf("抓包文件 %s 中没有HTTP请求", filepath.Base(path))
+	}
+	return entries, nil
+}
+
+// Detect 按扩展名识别会话文件格式，扩展名未知时按内容判断，无法识别时返回空字符串
+func Detect(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".chlsj":
+		return FormatCharles
+	case ".saz":
+		return FormatFiddler
+	case ".flow", ".flows", ".mitm":
+		return FormatMitmproxy
+	}
+	trimmed := bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("[")):
+		return FormatCharles
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return FormatFiddler
+	case len(data) > 0 && data[0] >= '0' && data[0] <= '9':
+		// tnetstring 以十进制长度开头
+		return FormatMitmproxy
+	}
+	return ""
+}
+
+// Pick 返回URL包含filter的最后一条记录（同一接口多次请求时取最新的一次）以及匹配的记录数，filter 为空时匹配全部
+func Pick(entries []Entry, filter string) (*Entry, int, error) {
+	var matched []*Entry
+	for i := range entries {
+		if strings.Contains(entries[i].Request.URL, filter) {
+			matched = append(matched, &entries[i])
+		}
+	}
+	if len(matched) == 0 {
+		return nil, 0, i18n.Errorf("抓包文件的 %d 条请求中没有URL包含 %q 的请求", len(entries), filter)
+	}
+	return matched[len(matched)-1], len(matched), nil
+}
+
+// Replay 返回按记录的响应应答的传输层，不发送任何网络请求
+func (e *Entry) Replay() (http.RoundTripper, error) {
+	if e.responseErr != nil {
+		return nil, i18n.Errorf("无法回放 %s 的响应: %w", e.Request.URL, e.responseErr)
+	}
+	if e.Response == nil {
+		return nil, i18n.Errorf("抓包文件中没有记录 %s 的响应", e.Request.URL)
+	}
+	return replay{e.Response}, nil
+}
+
+type replay struct {
+	resp *Response
+}
+
+func (r replay) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.resp.StatusCode, http.StatusText(r.resp.StatusCode)),
+		StatusCode:    r.resp.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        r.resp.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(r.resp.Body)),
+		ContentLength: int64(len(r.resp.Body)),
+		Request:       req,
+	}, nil
+}
+
+// skippedHeaders 重新请求时不复制的请求头：由HTTP客户端自动生成，或声明的压缩格式Go无法自动解压
+var skippedHeaders = map[string]bool{
+	"host":              true,
+	"connection":        true,
+	"content-length":    true,
+	"accept-encoding":   true,
+	"transfer-encoding": true,
+	"proxy-connection":  true,
+}
+
+// SkipHeader 判断重新发送捕获的请求时是否跳过该请求头，HTTP/2伪头（如 :authority）同样跳过
+func SkipHeader(name string) bool {
+	return strings.HasPrefix(name, ":") || skippedHeaders[strings.ToLower(name)]
+}
+
+// newRequest 按捕获的请求行与请求头构建请求信息，重复的请求头合并（Cookie以 "; " 连接）
+func newRequest(method, url string, headers [][2]string, body []byte) *config.RequestInfo {
+	info := &config.RequestInfo{
+		URL:     url,
+		Method:  strings.ToUpper(method),
+		Headers: make(map[string]string),
+		Cookies: make(map[string]string),
+		Body:    string(body),
+	}
+	for _, h := range headers {
+		name, value := h[0], h[1]
+		if SkipHeader(name) {
+			continue
+		}
+		if previous, ok := info.Headers[name]; ok {
+			separator := ", "
+			if strings.EqualFold(name, "Cookie") {
+				separator = "; "
+			}
+			value = previous + separator + value
+		}
+		info.Headers[name] = value
+	}
+	return info
+}
+
+// setResponse 记录响应，decoded 为false时按 Content-Encoding 解压；解压失败时只在回放时报告
+func (e *Entry) setResponse(status int, headers [][2]string, body []byte, decoded bool) {
+	header := headerOf(headers)
+	if decoded {
+		header.Del("Content-Encoding")
+	} else {
+		var err error
+		if body, err = decodeBody(header, body); err != nil {
+			e.responseErr = err
+			return
+		}
+	}
+	e.Response = &Response{StatusCode: status, Header: header, Body: body}
+}
+
+// headerOf 将请求头列表转换为 http.Header
+func headerOf(headers [][2]string) http.Header {
+	header := make(http.Header)
+	for _, h := range headers {
+		if !strings.HasPrefix(h[0], ":") {
+			header.Add(h[0], h[1])
+		}
+	}
+	return header
+}
+
+// decodeBody 按 Content-Encoding 解压响应体，并从header中移除 Content-Encoding
+func decodeBody(header http.Header, body []byte) ([]byte, error) {
+	encoding := strings.ToLower(strings.TrimSpace(header.Get("Content-Encoding")))
+	var reader io.Reader
+	switch encoding {
+	case "", "identity":
+		return body, nil
+	case "gzip", "x-gzip":
+		gz, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, i18n.Errorf("解压响应体失败: %w", err)
+		}
+		reader = gz
+	case "deflate":
+		// deflate 通常为zlib格式，也有服务直接返回原始deflate数据
+		if zr, err := zlib.NewReader(bytes.NewReader(body)); err == nil {
+			reader = zr
+		} else {
+			reader = flate.NewReader(bytes.NewReader(body))
+		}
+	default:
+		return nil, i18n.Errorf("不支持的响应压缩格式 %s", encoding)
+	}
+	decoded, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, i18n.Errorf("解压响应体失败: %w", err)
+	}
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	return decoded, nil
+}
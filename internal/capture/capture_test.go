@@ -0,0 +1,228 @@
+package capture
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const responseBody = `{"data":{"name":"门店列表"}}`
+
+func gzipped(t *testing.T, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(s))
+	w.Close()
+	return buf.Bytes()
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// checkEntry 检查导入的请求与回放的响应
+func checkEntry(t *testing.T, entry *Entry, wantURL, wantMethod, wantBody string) {
+	t.Helper()
+	req := entry.Request
+	if req.URL != wantURL || req.Method != wantMethod || req.Body != wantBody {
+		t.Errorf("request = %s %s %q, want %s %s %q", req.Method, req.URL, req.Body, wantMethod, wantURL, wantBody)
+	}
+	if req.Headers["Authorization"] != "Bearer token" {
+		t.Errorf("Authorization = %q", req.Headers["Authorization"])
+	}
+	for name := range req.Headers {
+		if SkipHeader(name) {
+			t.Errorf("header %s should be skipped", name)
+		}
+	}
+
+	transport, err := entry.Replay()
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+	httpReq, _ := http.NewRequest(req.Method, req.URL, nil)
+	resp, err := transport.RoundTrip(httpReq)
+	if err != nil {
+		t.Fatalf("RoundTrip() error = %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != responseBody {
+		t.Errorf("replayed %d %q, want 200 %q", resp.StatusCode, body, responseBody)
+	}
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("Content-Encoding should be removed after decoding")
+	}
+}
+
+func TestLoad_Charles(t *testing.T) {
+	session := fmt.Sprintf(`[
+  {"method":"CONNECT","scheme":"https","host":"api.example.com","port":443,"path":""},
+  {
+    "method":"POST","scheme":"https","host":"api.example.com","port":443,"path":"/case/list","query":"page=1",
+    "request":{"header":{"headers":[
+      {"name":"Host","value":"api.example.com"},
+      {"name":"Authorization","value":"Bearer token"},
+      {"name":"Accept-Encoding","value":"gzip"}
+    ]},"body":{"text":"{\"id\":1}"}},
+    "response":{"status":200,"header":{"headers":[
+      {"name":"Content-Type","value":"application/json"},
+      {"name":"Content-Encoding","value":"gzip"}
+    ]},"body":{"encoded":%q}}
+  }
+]`, base64.StdEncoding.EncodeToString(gzipped(t, responseBody)))
+
+	entries, err := Load(writeFile(t, "session.chlsj", []byte(session)))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("len(entries) = %d, want 1 (CONNECT skipped)", len(entries))
+	}
+	checkEntry(t, &entries[0], "https://api.example.com/case/list?page=1", "POST", `{"id":1}`)
+}
+
+func TestLoad_Fiddler(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	add := func(name, content string) {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	add("raw/01_c.txt", "GET http://api.example.com:8080/case/detail?id=1 HTTP/1.1\r\n"+
+		"Host: api.example.com:8080\r\nAuthorization: Bearer token\r\nCookie: a=1\r\nCookie: b=2\r\n\r\n")
+	add("raw/01_s.txt", "HTTP/1.1 200 OK\r\nContent-Type: application/json\r\n"+
+		fmt.Sprintf("Content-Length: %d\r\n\r\n", len(responseBody))+responseBody)
+	add("raw/02_c.txt", "GET /static/app.js HTTP/1.1\r\nHost: cdn.example.com\r\nAuthorization: Bearer token\r\n\r\n")
+	zw.Close()
+
+	entries, err := Load(writeFile(t, "session.saz", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	checkEntry(t, &entries[0], "http://api.example.com:8080/case/detail?id=1", "GET", "")
+	if got := entries[0].Request.Headers["Cookie"]; got != "a=1; b=2" {
+		t.Errorf("Cookie = %q, want merged cookies", got)
+	}
+	if entries[1].Request.URL != "http://cdn.example.com/static/app.js" {
+		t.Errorf("URL = %q", entries[1].Request.URL)
+	}
+	if _, err := entries[1].Replay(); err == nil {
+		t.Error("Replay() without recorded response should fail")
+	}
+}
+
+// tnet 按tnetstring编码测试数据，[]byte 编码为字节串
+func tnet(v interface{}) string {
+	wrap := func(payload string, kind byte) string {
+		return fmt.Sprintf("%d:%s%c", len(payload), payload, kind)
+	}
+	switch v := v.(type) {
+	case []byte:
+		return wrap(string(v), ',')
+	case string:
+		return wrap(v, ';')
+	case int:
+		return wrap(fmt.Sprint(v), '#')
+	case []interface{}:
+		var b strings.Builder
+		for _, item := range v {
+			b.WriteString(tnet(item))
+		}
+		return wrap(b.String(), ']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var b strings.Builder
+		for _, k := range keys {
+			b.WriteString(tnet(k) + tnet(v[k]))
+		}
+		return wrap(b.String(), '}')
+	}
+	return "0:~"
+}
+
+func header(name, value string) []interface{} {
+	return []interface{}{[]byte(name), []byte(value)}
+}
+
+func TestLoad_Mitmproxy(t *testing.T) {
+	flow := func(path string) string {
+		return tnet(map[string]interface{}{
+			"type": "http",
+			"request": map[string]interface{}{
+				"method": []byte("PUT"), "scheme": []byte("https"), "host": "api.example.com", "port": 443,
+				"path":    []byte(path),
+				"headers": []interface{}{header("Authorization", "Bearer token"), header(":authority", "api.example.com")},
+				"content": []byte(`{"id":2}`),
+			},
+			"response": map[string]interface{}{
+				"status_code": 200,
+				"headers":     []interface{}{header("Content-Encoding", "gzip")},
+				"content":     gzipped(t, responseBody),
+			},
+		})
+	}
+	data := flow("/case/1") + tnet(map[string]interface{}{"type": "tcp"}) + flow("/case/2")
+
+	entries, err := Load(writeFile(t, "dump", []byte(data)))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	checkEntry(t, &entries[1], "https://api.example.com/case/2", "PUT", `{"id":2}`)
+
+	if _, err := Load(writeFile(t, "broken.flow", []byte("12:abc"))); err == nil {
+		t.Error("Load() should fail on truncated tnetstring")
+	}
+}
+
+func TestLoad_UnknownFormat(t *testing.T) {
+	_, err := Load(writeFile(t, "notes.txt", []byte("hello")))
+	if err == nil || !strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("Load() error = %v, want unrecognized format", err)
+	}
+}
+
+func TestPick(t *testing.T) {
+	entries := []Entry{
+		{Request: newRequest("GET", "https://api.example.com/case/list?page=1", nil, nil)},
+		{Request: newRequest("GET", "https://api.example.com/user", nil, nil)},
+		{Request: newRequest("GET", "https://api.example.com/case/list?page=2", nil, nil)},
+	}
+	entry, matched, err := Pick(entries, "/case/list")
+	if err != nil {
+		t.Fatalf("Pick() error = %v", err)
+	}
+	if matched != 2 || entry != &entries[2] {
+		t.Errorf("Pick() = %s (%d matched), want the last match", entry.Request.URL, matched)
+	}
+	if entry, matched, _ = Pick(entries, ""); matched != 3 || entry != &entries[2] {
+		t.Errorf("Pick(\"\") matched %d", matched)
+	}
+	if _, _, err = Pick(entries, "/order"); err == nil {
+		t.Error("Pick() should fail when nothing matches")
+	}
+}
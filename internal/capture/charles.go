@@ -0,0 +1,102 @@
+package capture
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+)
+
+// charlesSession Charles "Export Session → JSON Session File (.chlsj)" 中的一条会话
+type charlesSession struct {
+	Method   string         `json:"method"`
+	Scheme   string         `json:"scheme"`
+	Host     string         `json:"host"`
+	Port     int            `json:"port"`
+	Path     string         `json:"path"`
+	Query    string         `json:"query"`
+	Request  charlesMessage `json:"request"`
+	Response *struct {
+		Status int `json:"status"`
+		charlesMessage
+	} `json:"response"`
+}
+
+type charlesMessage struct {
+	Header struct {
+		Headers []struct {
+			Name  string `json:"name"`
+			Value string `json:"value"`
+		} `json:"headers"`
+	} `json:"header"`
+	Body *charlesBody `json:"body"`
+}
+
+// charlesBody 文本内容在 text 中；二进制内容以base64保存在 encoded 中，
+// 较早的版本以 encoding: base64 标记 text。decoded 表示Charles已经去掉了传输压缩
+type charlesBody struct {
+	Text     string `json:"text"`
+	Encoding string `json:"encoding"`
+	Encoded  string `json:"encoded"`
+	Decoded  bool   `json:"decoded"`
+}
+
+func (b *charlesBody) bytes() ([]byte, error) {
+	switch {
+	case b == nil:
+		return nil, nil
+	case b.Encoded != "":
+		return base64.StdEncoding.DecodeString(b.Encoded)
+	case b.Encoding == "base64":
+		return base64.StdEncoding.DecodeString(b.Text)
+	}
+	return []byte(b.Text), nil
+}
+
+func (m *charlesMessage) headers() [][2]string {
+	headers := make([][2]string, 0, len(m.Header.Headers))
+	for _, h := range m.Header.Headers {
+		headers = append(headers, [2]string{h.Name, h.Value})
+	}
+	return headers
+}
+
+// url 由会话的协议、主机、端口、路径与查询参数拼接，省略默认端口
+func (s *charlesSession) url() string {
+	url := s.Scheme + "://" + s.Host
+	if s.Port != 0 && !(s.Scheme == "http" && s.Port == 80) && !(s.Scheme == "https" && s.Port == 443) {
+		url += ":" + strconv.Itoa(s.Port)
+	}
+	url += s.Path
+	if s.Query != "" {
+		url += "?" + s.Query
+	}
+	return url
+}
+
+func parseCharles(data []byte) ([]Entry, error) {
+	var sessions []charlesSession
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, session := range sessions {
+		if session.Method == "" || session.Method == "CONNECT" {
+			continue
+		}
+		body, err := session.Request.Body.bytes()
+		if err != nil {
+			return nil, err
+		}
+		entry := Entry{Request: newRequest(session.Method, session.url(), session.Request.headers(), body)}
+		if resp := session.Response; resp != nil && resp.Status != 0 {
+			content, err := resp.Body.bytes()
+			if err != nil {
+				return nil, err
+			}
+			entry.setResponse(resp.Status, resp.headers(), content, resp.Body != nil && resp.Body.Decoded)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
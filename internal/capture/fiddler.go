@@ -0,0 +1,103 @@
+package capture
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// parseFiddler 解析Fiddler的 .saz 会话压缩包：raw/<序号>_c.txt 为原始请求，raw/<序号>_s.txt 为原始响应
+func parseFiddler(data []byte) ([]Entry, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*zip.File)
+	var ids []string
+	for _, f := range archive.File {
+		name := strings.TrimPrefix(strings.ReplaceAll(f.Name, "\\", "/"), "raw/")
+		files[name] = f
+		if id, ok := strings.CutSuffix(name, "_c.txt"); ok && !strings.Contains(id, "/") {
+			ids = append(ids, id)
+		}
+	}
+	// 按会话序号排序，序号在文件名中可能带前导零
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+
+	var entries []Entry
+	for _, id := range ids {
+		raw, err := readZipFile(files[id+"_c.txt"])
+		if err != nil {
+			return nil, err
+		}
+		req, err := http.ReadRequest(bufio.NewReader(bytes.NewReader(raw)))
+		if err != nil {
+			return nil, err
+		}
+		if req.Method == http.MethodConnect {
+			continue
+		}
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := Entry{Request: newRequest(req.Method, fiddlerURL(req), headerPairs(req.Header), body)}
+		if f := files[id+"_s.txt"]; f != nil {
+			if raw, err := readZipFile(f); err == nil && len(raw) > 0 {
+				// 响应不完整（如请求被中止）时不记录响应
+				if resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(raw)), req); err == nil {
+					if content, err := io.ReadAll(resp.Body); err == nil {
+						entry.setResponse(resp.StatusCode, headerPairs(resp.Header), content, false)
+					}
+				}
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// fiddlerURL Fiddler记录的请求行通常为完整URL，只有路径时按Host请求头补全
+func fiddlerURL(req *http.Request) string {
+	if req.URL.IsAbs() {
+		return req.URL.String()
+	}
+	return "http://" + req.Host + req.URL.RequestURI()
+}
+
+// headerPairs 将 http.Header 转换为请求头列表，按名称排序保证结果稳定
+func headerPairs(header http.Header) [][2]string {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pairs [][2]string
+	for _, name := range names {
+		for _, value := range header[name] {
+			pairs = append(pairs, [2]string{name, value})
+		}
+	}
+	return pairs
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
@@ -0,0 +1,153 @@
+package capture
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// parseMitmproxy 解析mitmproxy保存的流文件（界面中的 File → Save 或 mitmdump -w），
+// 文件由连续的tnetstring编码的流组成，只读取其中的HTTP流
+func parseMitmproxy(data []byte) ([]Entry, error) {
+	var entries []Entry
+	for len(bytes.TrimSpace(data)) > 0 {
+		value, rest, err := parseTNetString(data)
+		if err != nil {
+			return nil, err
+		}
+		data = rest
+
+		flow, ok := value.(map[string]interface{})
+		if !ok || text(flow["type"]) != "http" {
+			continue
+		}
+		req, ok := flow["request"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		method := text(req["method"])
+		if method == "" || method == "CONNECT" {
+			continue
+		}
+
+		entry := Entry{Request: newRequest(method, mitmproxyURL(req), mitmproxyHeaders(req["headers"]), raw(req["content"]))}
+		if resp, ok := flow["response"].(map[string]interface{}); ok {
+			status, _ := resp["status_code"].(int64)
+			entry.setResponse(int(status), mitmproxyHeaders(resp["headers"]), raw(resp["content"]), false)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// mitmproxyURL 由协议、主机、端口与路径拼接，省略默认端口
+func mitmproxyURL(req map[string]interface{}) string {
+	scheme, host := text(req["scheme"]), text(req["host"])
+	port, _ := req["port"].(int64)
+	url := scheme + "://" + host
+	if port != 0 && !(scheme == "http" && port == 80) && !(scheme == "https" && port == 443) {
+		url += ":" + strconv.FormatInt(port, 10)
+	}
+	return url + text(req["path"])
+}
+
+// mitmproxyHeaders 请求头保存为 [[名称, 值], ...]
+func mitmproxyHeaders(value interface{}) [][2]string {
+	list, _ := value.([]interface{})
+	headers := make([][2]string, 0, len(list))
+	for _, item := range list {
+		if pair, ok := item.([]interface{}); ok && len(pair) == 2 {
+			headers = append(headers, [2]string{text(pair[0]), text(pair[1])})
+		}
+	}
+	return headers
+}
+
+// text 返回字节串或字符串的文本，不同版本的mitmproxy对同一字段可能使用不同类型
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	}
+	return ""
+}
+
+func raw(value interface{}) []byte {
+	switch v := value.(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return nil
+}
+
+// errTNetString 数据不是有效的tnetstring
+func errTNetString() error {
+	return i18n.Errorf("不是有效的mitmproxy流文件（tnetstring格式错误）")
+}
+
+// parseTNetString 解析一个tnetstring值（<长度>:<内容><类型>），返回值与剩余数据。
+// 类型：, 字节串  ; 字符串  # 整数  ^ 浮点数  ! 布尔  ~ 空值  ] 列表  } 字典
+func parseTNetString(data []byte) (interface{}, []byte, error) {
+	data = bytes.TrimLeft(data, " \t\r\n")
+	colon := bytes.IndexByte(data, ':')
+	if colon <= 0 || colon > 12 {
+		return nil, nil, errTNetString()
+	}
+	length, err := strconv.Atoi(string(data[:colon]))
+	if err != nil || length < 0 || colon+1+length >= len(data) {
+		return nil, nil, errTNetString()
+	}
+	payload, kind, rest := data[colon+1:colon+1+length], data[colon+1+length], data[colon+2+length:]
+
+	switch kind {
+	case ',':
+		return payload, rest, nil
+	case ';':
+		return string(payload), rest, nil
+	case '#':
+		n, err := strconv.ParseInt(string(payload), 10, 64)
+		if err != nil {
+			return nil, nil, errTNetString()
+		}
+		return n, rest, nil
+	case '^':
+		f, err := strconv.ParseFloat(string(payload), 64)
+		if err != nil {
+			return nil, nil, errTNetString()
+		}
+		return f, rest, nil
+	case '!':
+		return string(payload) == "true", rest, nil
+	case '~':
+		return nil, rest, nil
+	case ']':
+		list := []interface{}{}
+		for len(payload) > 0 {
+			var item interface{}
+			if item, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			list = append(list, item)
+		}
+		return list, rest, nil
+	case '}':
+		dict := make(map[string]interface{})
+		for len(payload) > 0 {
+			var key, value interface{}
+			if key, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			if value, payload, err = parseTNetString(payload); err != nil {
+				return nil, nil, err
+			}
+			dict[text(key)] = value
+		}
+		return dict, rest, nil
+	}
+	return nil, nil, errTNetString()
+}
@@ -0,0 +1,186 @@
+// Package chain 执行YAML文件描述的链式请求：前面的步骤通过JSONPath从响应中提取变量，
+// 以 {{.变量名}} 渲染进后续步骤的cURL命令，最后一步的响应用于抽取树状结构
+package chain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wellkilo/Curl2json/internal/batch"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+)
+
+// File 链式请求文件
+//
+//	vars:
+//	  token: xxx
+//	steps:
+//	  - name: task
+//	    curl: |
+//	      curl "https://api.example.com/tasks?latest=1" -H "x-jwt-token: {{.token}}"
+//	    extract:
+//	      taskId: $.data.id
+//	  - name: mind
+//	    curl: |
+//	      curl "https://api.example.com/tasks/{{.taskId}}/mind" -H "x-jwt-token: {{.token}}"
+type File struct {
+	Vars  map[string]string `yaml:"vars"`  // 初始变量
+	Steps []Step            `yaml:"steps"` // 按顺序执行的步骤
+}
+
+// Step 链式请求中的单个步骤
+type Step struct {
+	Name    string            `yaml:"name"`
+	Curl    string            `yaml:"curl"`    // cURL命令模板
+	Extract map[string]string `yaml:"extract"` // 变量名 -> JSONPath，最后一步不能提取变量
+
+	paths map[string]*jsonpath.Path
+}
+
+// Fetcher 执行中间步骤的请求并返回JSON响应体，由 processor.Processor 实现
+type Fetcher interface {
+	Fetch(ctx context.Context, input string) ([]byte, error)
+}
+
+// Load 读取并解析链式请求文件
+func Load(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取链式请求文件失败: %w", err)
+	}
+	return Parse(content)
+}
+
+// Parse 解析链式请求文件内容，未知字段、空步骤和无效的JSONPath都会返回错误
+func Parse(content []byte) (*File, error) {
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil {
+		return nil, i18n.Errorf("解析链式请求文件失败: %w", err)
+	}
+	if len(f.Steps) == 0 {
+		return nil, i18n.Errorf("链式请求文件中没有步骤")
+	}
+
+	for name := range f.Vars {
+		if !batch.ValidName(name) {
+			return nil, i18n.Errorf("无效的变量名 %q，只能包含字母、数字、下划线和连字符", name)
+		}
+	}
+	for i := range f.Steps {
+		step := &f.Steps[i]
+		if step.Name == "" {
+			step.Name = strconv.Itoa(i + 1)
+		}
+		step.Curl = strings.TrimSpace(step.Curl)
+		if step.Curl == "" {
+			return nil, i18n.Errorf("第 %d 步（%s）缺少curl", i+1, step.Name)
+		}
+		if i == len(f.Steps)-1 && len(step.Extract) > 0 {
+			return nil, i18n.Errorf("最后一步（%s）的响应用于抽取树状结构，不能提取变量", step.Name)
+		}
+
+		step.paths = make(map[string]*jsonpath.Path, len(step.Extract))
+		for name, expr := range step.Extract {
+			if !batch.ValidName(name) {
+				return nil, i18n.Errorf("无效的变量名 %q，只能包含字母、数字、下划线和连字符", name)
+			}
+			path, err := jsonpath.Parse(expr)
+			if err != nil {
+				return nil, i18n.Errorf("第 %d 步（%s）的变量 %s: %w", i+1, step.Name, name, err)
+			}
+			step.paths[name] = path
+		}
+	}
+	return &f, nil
+}
+
+// Resolve 依次执行除最后一步以外的步骤并提取变量，返回渲染后的最后一步cURL命令与全部变量
+func (f *File) Resolve(ctx context.Context, fetcher Fetcher, log *slog.Logger) (string, map[string]string, error) {
+	vars := make(map[string]string, len(f.Vars))
+	for name, value := range f.Vars {
+		vars[name] = value
+	}
+
+	for i, step := range f.Steps {
+		curl, err := render(step.Curl, vars)
+		if err != nil {
+			return "", nil, i18n.Errorf("第 %d 步（%s）: %w", i+1, step.Name, err)
+		}
+		if i == len(f.Steps)-1 {
+			return curl, vars, nil
+		}
+
+		log.Info(i18n.T("执行链式请求步骤"), "step", i+1, "name", step.Name)
+		body, err := fetcher.Fetch(ctx, curl)
+		if err != nil {
+			return "", nil, i18n.Errorf("第 %d 步（%s）失败: %w", i+1, step.Name, err)
+		}
+		if err := step.extract(body, vars); err != nil {
+			return "", nil, i18n.Errorf("第 %d 步（%s）: %w", i+1, step.Name, err)
+		}
+	}
+	return "", nil, i18n.Errorf("链式请求文件中没有步骤")
+}
+
+// extract 按JSONPath从响应中提取变量写入vars
+func (s *Step) extract(body []byte, vars map[string]string) error {
+	if len(s.paths) == 0 {
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return i18n.Errorf("响应体不是有效的JSON: %w", err)
+	}
+
+	names := make([]string, 0, len(s.paths))
+	for name := range s.paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := s.paths[name].Lookup(data)
+		if errors.Is(err, jsonpath.ErrNotFound) {
+			return i18n.Errorf("变量 %s 的路径 %s 在响应中不存在", name, s.Extract[name])
+		}
+		if err != nil {
+			return err
+		}
+		vars[name] = stringify(value)
+	}
+	return nil
+}
+
+// render 渲染cURL命令模板，变量值来自响应，按 batch.SubstituteCurl 引用；缺少变量时返回错误
+func render(template string, vars map[string]string) (string, error) {
+	result, missing := batch.SubstituteCurl(template, vars)
+	if len(missing) > 0 {
+		return "", i18n.Errorf("缺少变量: %s（需在 vars 中定义或由之前步骤的 extract 提取）", strings.Join(missing, ", "))
+	}
+	return result, nil
+}
+
+// stringify 将提取到的JSON值转换为模板中使用的文本：字符串原样使用，其他值使用JSON表示
+func stringify(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(content)
+}
@@ -0,0 +1,105 @@
+package chain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/logger"
+)
+
+// fakeFetcher 按cURL命令返回预设的响应，并记录请求顺序
+type fakeFetcher struct {
+	responses map[string]string
+	calls     []string
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, input string) ([]byte, error) {
+	f.calls = append(f.calls, input)
+	body, ok := f.responses[input]
+	if !ok {
+		return nil, errors.New("unexpected request")
+	}
+	return []byte(body), nil
+}
+
+const chainYAML = `
+vars:
+  token: abc
+steps:
+  - name: task
+    curl: |
+      curl https://api.example.com/tasks -H "x-jwt-token: {{.token}}"
+    extract:
+      taskId: $.data.items[0].id
+      owner: $.data.items[0].owner
+  - name: detail
+    curl: 'curl https://api.example.com/tasks/{{.taskId}} -H "x-jwt-token: {{.token}}"'
+    extract:
+      mindId: $.data.mindId
+  - name: mind
+    curl: curl "https://api.example.com/minds/{{ .mindId }}?owner={{.owner}}"
+`
+
+func TestResolve(t *testing.T) {
+	f, err := Parse([]byte(chainYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	fetcher := &fakeFetcher{responses: map[string]string{
+		`curl https://api.example.com/tasks -H "x-jwt-token: abc"`:    `{"data":{"items":[{"id":42,"owner":"张三"}]}}`,
+		`curl https://api.example.com/tasks/42 -H "x-jwt-token: abc"`: `{"data":{"mindId":"m-7"}}`,
+	}}
+	curl, vars, err := f.Resolve(context.Background(), fetcher, logger.Discard())
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+
+	if want := `curl "https://api.example.com/minds/m-7?owner=张三"`; curl != want {
+		t.Errorf("Resolve() curl = %s, want %s", curl, want)
+	}
+	if vars["taskId"] != "42" || len(fetcher.calls) != 2 {
+		t.Errorf("vars = %v, calls = %v", vars, fetcher.calls)
+	}
+}
+
+func TestResolve_Errors(t *testing.T) {
+	f, err := Parse([]byte(chainYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetcher := &fakeFetcher{responses: map[string]string{
+		`curl https://api.example.com/tasks -H "x-jwt-token: abc"`: `{"data":{"items":[]}}`,
+	}}
+	_, _, err = f.Resolve(context.Background(), fetcher, logger.Discard())
+	if err == nil || !strings.Contains(err.Error(), "$.data.items[0]") {
+		t.Errorf("Resolve() error = %v, want missing path", err)
+	}
+
+	f.Vars = nil
+	_, _, err = f.Resolve(context.Background(), fetcher, logger.Discard())
+	if err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("Resolve() error = %v, want missing variable", err)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"没有步骤":     `vars: {a: b}`,
+		"缺少curl":   `steps: [{name: a}]`,
+		"未知字段":     `steps: [{curl: curl x, extrct: {a: $.a}}]`,
+		"无效路径":     `steps: [{curl: curl x, extract: {a: data.a}}, {curl: curl y}]`,
+		"无效变量名":    `steps: [{curl: curl x, extract: {"a b": $.a}}, {curl: curl y}]`,
+		"最后一步提取变量": `steps: [{curl: curl x, extract: {a: $.a}}]`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(content)); err == nil {
+				t.Errorf("Parse() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/assert"
+	"github.com/wellkilo/Curl2json/internal/batch"
+	"github.com/wellkilo/Curl2json/internal/bodyedit"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/http"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/mock"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/postprocess"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/internal/report"
+)
+
+// runBatch 执行批量文件或数据驱动模板中的所有cURL请求，--out 作为输出目录
+// input 为单个cURL命令来源（--curl-file等）读取到的模板，仅在未使用 --batch 时生效；
+// manifest 非nil时在执行结束后写入成功请求的输出与汇总报告的产物清单，部分请求失败时也会写入
+func (o *fetchOptions) runBatch(ctx context.Context, cfg *config.Config, input string, assertions []*assert.Assertion, script *postprocess.Script, manifest *report.ManifestRecorder) (*runSummary, error) {
+	entries, source, err := o.loadBatchEntries(input)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+
+	outDir := o.out
+	if outDir == "" {
+		outDir = fmt.Sprintf("batch_%s", time.Now().Format("20060102_150405"))
+	}
+
+	cfg.Logger.Info(i18n.T("开始批量执行"), "source", source, "count", len(entries), "out_dir", outDir)
+
+	// 所有请求（包括使用单独处理器的请求）共用一个传输，复用到同一主机的连接与TLS会话
+	if cfg.Transport == nil {
+		transport := http.NewTransport()
+		defer transport.CloseIdleConnections()
+		cfg.Transport = transport
+	}
+
+	newProcessor := func(cfg *config.Config) *processor.Processor {
+		p := processor.New(cfg)
+		if len(o.bodyEdits) > 0 {
+			p.Hooks().After(pipeline.StageParse, bodyedit.Hook(o.bodyEdits))
+		}
+		if manifest != nil {
+			manifest.Register(p.Hooks())
+		}
+		if o.saveFixture != "" {
+			p.Hooks().After(pipeline.StageExecute, mock.RecordHook(o.saveFixture))
+		}
+		if len(assertions) > 0 {
+			p.Hooks().After(pipeline.StageExecute, assert.Hook(assertions))
+		}
+		registerPlugins(p.Hooks(), o.loadedPlugins)
+		if script != nil {
+			p.Hooks().After(pipeline.StageExtract, script.Hook())
+		}
+		if o.fromDepth > 1 || o.toDepth > 0 {
+			p.Hooks().After(pipeline.StageExtract, depthHook(o.fromDepth, o.toDepth))
+		}
+		if o.leavesOnly {
+			p.Hooks().After(pipeline.StageExtract, leavesHook(o.leafPaths))
+		}
+		return p
+	}
+	runner := batch.NewRunner(newProcessor(cfg), outDir, cfg.Logger)
+	runner.SetFailEmpty(o.failEmpty)
+	if o.resume {
+		previous, err := batch.LoadSummary(outDir)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		if previous != nil {
+			runner.SetResume(previous)
+		} else {
+			cfg.Logger.Info(i18n.T("输出目录中没有上次的汇总报告，执行全部请求"), "out_dir", outDir)
+		}
+	}
+	// 内联选项覆盖了超时或候选键名的请求使用单独的处理器
+	runner.SetProcessorFunc(func(opts *batch.Options) *processor.Processor {
+		entryCfg := *cfg
+		if opts.Timeout > 0 {
+			entryCfg.Timeout = time.Duration(opts.Timeout) * time.Second
+		}
+		if len(opts.TitleKeys) > 0 {
+			entryCfg.TitleKeys = opts.TitleKeys
+		}
+		if len(opts.ChildrenKeys) > 0 {
+			entryCfg.ChildrenKeys = opts.ChildrenKeys
+		}
+		return newProcessor(&entryCfg)
+	})
+	summary, err := runner.Run(ctx, entries)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.OutputWrite, err)
+	}
+
+	nodes := 0
+	for _, result := range summary.Results {
+		nodes += result.Nodes
+		if manifest != nil && result.Success {
+			manifest.AddFile(report.ArtifactOutput, result.Output)
+		}
+		if !o.humanOutput() {
+			continue
+		}
+		switch {
+		case result.Resumed:
+			fmt.Printf(i18n.T("  ⏭️ [%d] 第 %d 行 -> %s (上次已完成)\n"), result.Index, result.Line, result.Output)
+		case result.Success:
+			fmt.Printf(i18n.T("  ✅ [%d] 第 %d 行 -> %s (%s)\n"), result.Index, result.Line, result.Output, result.Duration)
+		default:
+			fmt.Printf(i18n.T("  ❌ [%d] 第 %d 行: %s\n"), result.Index, result.Line, result.Error)
+		}
+	}
+	if o.humanOutput() {
+		fmt.Printf(i18n.T("批量执行完成: 成功 %d，失败 %d，耗时 %s，汇总报告: %s\n"),
+			summary.Succeeded, summary.Failed, summary.Duration, runner.SummaryPath())
+		if summary.Resumed > 0 {
+			fmt.Printf(i18n.T("其中 %d 个请求沿用了上次的结果\n"), summary.Resumed)
+		}
+	}
+
+	if manifest != nil {
+		manifest.AddFile(report.ArtifactBatchSummary, runner.SummaryPath())
+		if err := manifest.WriteFile(o.manifest); err != nil {
+			return nil, exitcode.Wrap(exitcode.OutputWrite, err)
+		}
+		cfg.Logger.Info(i18n.T("已写入产物清单"), "path", o.manifest)
+	}
+
+	run := &runSummary{Output: outDir, Nodes: nodes, Succeeded: summary.Succeeded, Failed: summary.Failed}
+	if summary.Failed > 0 {
+		return run, i18n.Errorf("批量执行中有 %d 个请求失败", summary.Failed)
+	}
+	return run, nil
+}
+
+// loadBatchEntries 构建批量请求列表，使用 --batch-data 时按CSV每行展开模板
+func (o *fetchOptions) loadBatchEntries(input string) ([]batch.Entry, string, error) {
+	var templates []batch.Entry
+	source := o.batchFile
+
+	if o.batchFile != "" {
+		content, err := readFromFile(o.batchFile)
+		if err != nil {
+			return nil, "", i18n.Errorf("读取批量文件失败: %w", err)
+		}
+		templates, err = batch.ParseEntries(content)
+		if err != nil {
+			return nil, "", i18n.Errorf("解析批量文件 %s 失败: %w", o.batchFile, err)
+		}
+		if len(templates) == 0 {
+			return nil, "", i18n.Errorf("批量文件中没有cURL命令: %s", o.batchFile)
+		}
+	} else {
+		if input == "" {
+			return nil, "", i18n.Errorf("--batch-data 需要配合cURL命令模板使用（--curl-file、--from-curl等）")
+		}
+		templates = []batch.Entry{{Index: 1, Line: 1, Curl: input}}
+		source = i18n.T("cURL模板")
+	}
+
+	if o.batchData == "" {
+		return templates, source, nil
+	}
+
+	rows, err := batch.LoadCSV(o.batchData)
+	if err != nil {
+		return nil, "", i18n.Errorf("读取CSV变量文件失败: %w", err)
+	}
+	entries, err := batch.ExpandEntries(templates, rows)
+	if err != nil {
+		return nil, "", err
+	}
+	return entries, i18n.Sprintf("%s × %s(%d行)", source, o.batchData, len(rows)), nil
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// goldenOptions check 命令的golden树参数
+type goldenOptions struct {
+	path   string
+	update bool
+}
+
+// newCheckCmd 抓取并抽取树，与提交在仓库中的golden树比较，用作CI中的接口契约检查
+func newCheckCmd() *cobra.Command {
+	opts := &fetchOptions{}
+	cmd := &cobra.Command{
+		Use:   "check --golden expected.json [-- curl ...]",
+		Short: "将抽取结果与golden树比较，结构变化时以非零退出码失败",
+		Long: `执行请求并抽取树，与预先提交的golden树比较，用于在CI中检查接口返回的用例结构是否发生变化。
+
+比较前两棵树的节点名称都会去掉首尾空白、合并连续空白；比较按节点路径进行，与兄弟节点的顺序无关。
+有差异时在stderr打印新增（+）和删除（-）的节点路径，并以退出码11失败。
+默认不写入输出文件，指定 --out 时同时写入本次的抽取结果。`,
+		Example: `  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt
+  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt --update   # 接受当前结果
+  ./caseurl2md check --golden testdata/cases.golden.json -- curl "https://api.example.com/cases" -H "x-jwt-token: xxx"`,
+		RunE: opts.runRoot,
+	}
+	addFetchFlags(cmd, opts)
+	cmd.Flags().StringVar(&opts.golden.path, "golden", "", "期望的树状JSON文件")
+	cmd.Flags().BoolVar(&opts.golden.update, "update", false, "用本次的抽取结果覆盖golden文件，而不是比较")
+	cmd.MarkFlagRequired("golden")
+	return cmd
+}
+
+// check 将本次的输出与golden树比较，--update 时改为写入golden文件
+func (g *goldenOptions) check(result []byte, log *slog.Logger) error {
+	if g.update {
+		if err := os.MkdirAll(filepath.Dir(g.path), 0o755); err != nil {
+			return exitcode.Errorf(exitcode.OutputWrite, i18n.T("写入golden文件失败: %w"), err)
+		}
+		if err := os.WriteFile(g.path, result, 0o644); err != nil {
+			return exitcode.Errorf(exitcode.OutputWrite, i18n.T("写入golden文件失败: %w"), err)
+		}
+		log.Info(i18n.T("已更新golden文件"), "path", g.path)
+		return nil
+	}
+
+	content, err := os.ReadFile(g.path)
+	if err != nil {
+		return exitcode.Errorf(exitcode.Usage, i18n.T("读取golden文件失败（首次使用可加 --update 生成）: %w"), err)
+	}
+	expected, err := extractor.ParseNodes(content)
+	if err != nil {
+		return exitcode.Errorf(exitcode.Usage, i18n.T("golden文件 %s 不是有效的树状JSON: %w"), g.path, err)
+	}
+	actual, err := extractor.ParseNodes(result)
+	if err != nil {
+		return err
+	}
+
+	changes := treediff.Compare(treediff.Normalize(expected), treediff.Normalize(actual))
+	if len(changes) == 0 {
+		log.Info(i18n.T("抽取结果与golden树一致"), "path", g.path, "nodes", len(treediff.Paths(actual)))
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, i18n.T("抽取结果与golden树 %s 不一致（%s）：\n"), g.path, treediff.Summary(changes))
+	fmt.Fprint(os.Stderr, treediff.FormatColor(changes, color.For(os.Stderr)))
+	return exitcode.Wrap(exitcode.Drift, i18n.Errorf("抽取结果与golden树 %s 不一致: %s", g.path, treediff.Summary(changes)))
+}
@@ -0,0 +1,153 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/doctor"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/http"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/parser"
+)
+
+// doctorOptions doctor子命令的参数
+type doctorOptions struct {
+	url      string
+	method   string
+	headers  []string
+	data     string
+	fromCurl string
+	curlFile string
+	timeout  int
+	json     bool
+}
+
+// newDoctorCmd 逐阶段诊断网络环境，定位请求失败的具体原因
+func newDoctorCmd() *cobra.Command {
+	opts := &doctorOptions{}
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "诊断DNS、代理、TLS与认证请求头等环境问题",
+		Long: `依次检查URL解析、代理检测、DNS解析、TCP连接、TLS握手、认证请求头和HTTP请求，
+并指出首个失败的阶段。大多数“HTTP请求执行失败”都源于网络环境问题，可先用本命令排查。`,
+		Example: `  ./caseurl2md doctor --url https://api.example.com/cases --header "x-jwt-token: xxx"
+  ./caseurl2md doctor --curl-file curl.txt`,
+		Args: cobra.NoArgs,
+		RunE: opts.run,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.url, "url", "", "请求URL（不使用cURL时必需）")
+	flags.StringVar(&opts.method, "method", "GET", "请求方法")
+	flags.StringSliceVar(&opts.headers, "header", []string{}, "请求头，格式为'Key: Value'，可多次使用")
+	flags.StringVar(&opts.data, "data", "", "请求体数据")
+	flags.StringVar(&opts.fromCurl, "from-curl", "", "直接从命令行接收cURL命令")
+	flags.StringVar(&opts.curlFile, "curl-file", "", "从文件读取cURL命令")
+	flags.IntVar(&opts.timeout, "timeout", 10, "每个检查阶段的超时时间（秒）")
+	flags.BoolVar(&opts.json, "json", false, "以JSON格式输出诊断报告")
+	return cmd
+}
+
+func (o *doctorOptions) run(cmd *cobra.Command, args []string) error {
+	info, err := o.request()
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	// cURL命令中的 -k、--cacert 与 --cert 同样用于TLS握手检查
+	tlsConfig, err := http.TLSConfig(info)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
+	report := doctor.Run(cmd.Context(), info, doctor.Options{Timeout: time.Duration(o.timeout) * time.Second, TLSConfig: tlsConfig})
+	if o.json {
+		content, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(content))
+	} else {
+		printDoctorReport(report)
+		// 报告中已给出失败阶段，不再重复输出错误
+		cmd.SilenceErrors = true
+	}
+
+	failed := report.Failed()
+	if failed == nil {
+		return nil
+	}
+	code := exitcode.Network
+	switch failed.Stage {
+	case doctor.StageURL, doctor.StageAuth:
+		code = exitcode.Usage
+	case doctor.StageHTTP:
+		if failed.StatusCode != 0 {
+			code = exitcode.HTTPStatus
+		}
+	}
+	return exitcode.Wrap(code, i18n.Errorf("诊断失败于「%s」阶段: %s", failed.Stage.Name(), failed.Detail))
+}
+
+// request 根据 --curl-file/--from-curl 或 --url 等参数构建待诊断的请求
+func (o *doctorOptions) request() (*config.RequestInfo, error) {
+	input, baseDir := o.fromCurl, ""
+	if o.curlFile != "" {
+		content, err := readFromFile(o.curlFile)
+		if err != nil {
+			return nil, exitcode.Errorf(exitcode.Usage, i18n.T("读取cURL文件失败: %w"), err)
+		}
+		input, baseDir = content, filepath.Dir(o.curlFile)
+	}
+	if input != "" {
+		info, err := parser.New(parser.WithBaseDir(baseDir)).Parse(input)
+		if err != nil {
+			return nil, exitcode.Errorf(exitcode.Parse, i18n.T("cURL解析失败: %w"), err)
+		}
+		return info, nil
+	}
+
+	if o.url == "" {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("请通过 --url、--curl-file 或 --from-curl 指定要诊断的请求"))
+	}
+	return &config.RequestInfo{
+		URL:     o.url,
+		Method:  o.method,
+		Headers: parseHeaders(o.headers),
+		Body:    o.data,
+	}, nil
+}
+
+// printDoctorReport 逐行输出各阶段的检查结果
+func printDoctorReport(report *doctor.Report) {
+	palette := color.For(os.Stdout)
+	fmt.Printf(i18n.T("诊断 %s\n"), report.URL)
+	for _, result := range report.Results {
+		var mark string
+		switch result.Status {
+		case doctor.OK:
+			mark = palette.Green("✅")
+		case doctor.Warn:
+			mark = palette.Yellow("⚠️")
+		case doctor.Fail:
+			mark = palette.Red("❌")
+		default:
+			mark = "➖"
+		}
+		fmt.Printf("  %s %s: %s\n", mark, result.Stage.Name(), result.Detail)
+	}
+
+	if failed := report.Failed(); failed != nil {
+		fmt.Println(palette.Red(i18n.Sprintf("诊断失败于「%s」阶段", failed.Stage.Name())))
+	} else {
+		fmt.Println(palette.Green(i18n.T("所有检查通过")))
+	}
+}
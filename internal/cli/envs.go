@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/internal/report"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// environment --envs 中的一个环境，请求URL的协议与主机替换为 base 的协议与主机
+type environment struct {
+	name string
+	base *url.URL
+}
+
+// parseEnvs 解析 --envs 的 名称=地址 列表，保持指定的顺序，第一个环境作为比较基准
+func parseEnvs(values []string) ([]environment, error) {
+	envs := make([]environment, 0, len(values))
+	seen := make(map[string]bool)
+	for _, value := range values {
+		name, raw, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, i18n.Errorf("无效的环境 %q，格式应为 名称=地址，如 staging=https://stg.example.com", value)
+		}
+		if seen[name] {
+			return nil, i18n.Errorf("环境 %s 重复", name)
+		}
+		seen[name] = true
+
+		base, err := url.Parse(strings.TrimSpace(raw))
+		if err != nil || (base.Scheme != "http" && base.Scheme != "https") || base.Host == "" {
+			return nil, i18n.Errorf("环境 %s 的地址 %q 无效，应为 http(s)://主机[:端口]", name, raw)
+		}
+		if strings.Trim(base.Path, "/") != "" || base.RawQuery != "" {
+			return nil, i18n.Errorf("环境 %s 的地址 %q 只能包含协议与主机，请求的路径和参数保持不变", name, raw)
+		}
+		envs = append(envs, environment{name: name, base: base})
+	}
+	if len(envs) < 2 {
+		return nil, i18n.Errorf("--envs 至少需要两个环境")
+	}
+	return envs, nil
+}
+
+// request 返回指向该环境的请求副本，显式设置的Host请求头一并去掉
+func (e *environment) request(req *config.RequestInfo) (*config.RequestInfo, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil || u.Host == "" {
+		return nil, i18n.Errorf("无法替换请求URL %q 中的主机", req.URL)
+	}
+	u.Scheme, u.Host = e.base.Scheme, e.base.Host
+	clone := req.Clone()
+	clone.URL = u.String()
+	for name := range clone.Headers {
+		if strings.EqualFold(name, "Host") {
+			delete(clone.Headers, name)
+		}
+	}
+	return clone, nil
+}
+
+// envsReport --envs 写入 --out 的合并报告
+type envsReport struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"` // 原请求的URL，敏感参数已脱敏
+	Baseline     string      `json:"baseline"`
+	Environments []envResult `json:"environments"`
+	Diffs        []envDiff   `json:"diffs"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// envResult 单个环境的运行结果
+type envResult struct {
+	Name       string          `json:"name"`
+	URL        string          `json:"url"`
+	Status     string          `json:"status"`
+	Nodes      int             `json:"nodes"`
+	DurationMs int64           `json:"duration_ms"`
+	Error      string          `json:"error,omitempty"`
+	Tree       json.RawMessage `json:"tree,omitempty"`
+
+	roots []*extractor.SimplifiedNode
+}
+
+// envDiff 基准环境与另一环境之间的树结构差异
+type envDiff struct {
+	From    string            `json:"from"`
+	To      string            `json:"to"`
+	Stats   treediff.Stats    `json:"stats"`
+	Changes []treediff.Change `json:"changes"`
+}
+
+// runEnvs 将同一请求并发发送到各个环境，写入包含各环境的树与跨环境差异的合并报告
+func (o *fetchOptions) runEnvs(ctx context.Context, p *processor.Processor, input string, requestInfo *config.RequestInfo, log *slog.Logger) (*runSummary, error) {
+	req := requestInfo
+	if input != "" {
+		parsed, err := p.ParseCurlOnly(input)
+		if err != nil {
+			return nil, exitcode.Errorf(exitcode.Parse, i18n.T("cURL解析失败: %w"), err)
+		}
+		req = parsed
+	}
+
+	rep := &envsReport{
+		Method:       req.Method,
+		URL:          report.RedactURL(req.URL),
+		Baseline:     o.environments[0].name,
+		Environments: make([]envResult, len(o.environments)),
+		Diffs:        []envDiff{},
+		CreatedAt:    time.Now(),
+	}
+	var wg sync.WaitGroup
+	for i := range o.environments {
+		env := &o.environments[i]
+		envReq, err := env.request(req)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		result := &rep.Environments[i]
+		result.Name, result.URL = env.name, report.RedactURL(envReq.URL)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			output, err := p.Process(ctx, "", envReq)
+			result.DurationMs = time.Since(start).Milliseconds()
+			if err == nil {
+				result.roots, err = extractor.ParseNodes(output)
+			}
+			if err != nil {
+				result.Status, result.Error = statusError, err.Error()
+				log.Error(i18n.T("环境执行失败"), "env", result.Name, "error", err)
+				return
+			}
+			result.Status, result.Tree, result.Nodes = statusSuccess, output, extractor.CountNodes(result.roots)
+			log.Info(i18n.T("环境执行完成"), "env", result.Name, "nodes", result.Nodes, "duration_ms", result.DurationMs)
+		}()
+	}
+	wg.Wait()
+
+	// 与基准环境比较，基准环境失败时无法比较
+	failed := 0
+	baseline := &rep.Environments[0]
+	for i := range rep.Environments {
+		result := &rep.Environments[i]
+		if result.Status != statusSuccess {
+			failed++
+			continue
+		}
+		if i == 0 || baseline.Status != statusSuccess {
+			continue
+		}
+		changes := treediff.Compare(baseline.roots, result.roots)
+		if changes == nil {
+			changes = []treediff.Change{}
+		}
+		rep.Diffs = append(rep.Diffs, envDiff{From: baseline.Name, To: result.Name, Stats: treediff.Count(changes), Changes: changes})
+	}
+
+	content, err := json.MarshalIndent(rep, "", "  ")
+	if err == nil {
+		content, err = o.keyStyle.Convert(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := writeOutput(ctx, o.out, content, formatJSON); err != nil {
+		return nil, exitcode.Errorf(exitcode.OutputWrite, i18n.T("写入输出文件失败: %w"), err)
+	}
+	log.Info(i18n.T("成功将结果写入文件"), "path", o.out)
+
+	if o.humanOutput() {
+		o.printEnvs(rep)
+	}
+
+	nodes := 0
+	for _, result := range rep.Environments {
+		nodes += result.Nodes
+	}
+	summary := &runSummary{Output: o.out, Nodes: nodes, Succeeded: len(rep.Environments) - failed, Failed: failed}
+	if failed > 0 {
+		return summary, i18n.Errorf("%d 个环境执行失败", failed)
+	}
+	return summary, nil
+}
+
+// printEnvs 向stdout输出各环境的结果与差异摘要
+func (o *fetchOptions) printEnvs(rep *envsReport) {
+	for _, result := range rep.Environments {
+		if result.Status == statusSuccess {
+			fmt.Printf(i18n.T("  ✅ %s: %d 个节点 (%dms)\n"), result.Name, result.Nodes, result.DurationMs)
+		} else {
+			fmt.Printf(i18n.T("  ❌ %s: %s\n"), result.Name, result.Error)
+		}
+	}
+	for _, diff := range rep.Diffs {
+		if len(diff.Changes) == 0 {
+			fmt.Printf(i18n.T("%s -> %s: 树结构一致\n"), diff.From, diff.To)
+			continue
+		}
+		fmt.Printf("%s -> %s: %s\n", diff.From, diff.To, treediff.Summary(diff.Changes))
+		fmt.Print(treediff.Format(diff.Changes))
+	}
+}
@@ -0,0 +1,18 @@
+package cli
+
+import "github.com/spf13/cobra"
+
+// newFetchCmd 执行请求并抽取树结构，与根命令行为一致
+// 支持 `fetch -- curl ...` 将 -- 之后的内容原样作为cURL命令
+func newFetchCmd() *cobra.Command {
+	opts := &fetchOptions{}
+	cmd := &cobra.Command{
+		Use:   "fetch [-- curl ...]",
+		Short: "执行cURL请求并输出树状JSON",
+		Example: `  ./caseurl2md fetch -- curl "https://api.example.com/cases" -H "x-jwt-token: xxx" --data-raw '{"id":1}'
+  ./caseurl2md fetch --curl-file curl.txt --out result.json`,
+		RunE: opts.runRoot,
+	}
+	addFetchFlags(cmd, opts)
+	return cmd
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// flagRule 一条参数组合规则：invalid 返回true时以 message 作为用法错误失败
+type flagRule struct {
+	invalid func(o *fetchOptions) bool
+	message string
+}
+
+// flagRules 参数之间的互斥与依赖关系，按顺序检查，报告第一条违反的规则；
+// 参数取值本身的校验（范围、格式）仍在 runFetch 中进行
+var flagRules = []flagRule{
+	{
+		invalid: func(o *fetchOptions) bool { return o.watchInterval > 0 && o.interactive },
+		message: "--watch 与 --interactive 不能同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.batchMode() && (o.watchInterval > 0 || o.interactive) },
+		message: "--batch/--batch-data 不能与 --watch 或 --interactive 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.summaryJSON && (o.watchInterval > 0 || o.interactive) },
+		message: "--summary-json 不能与 --watch 或 --interactive 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.chainFile != "" && (o.batchMode() || o.watchInterval > 0) },
+		message: "--chain 不能与 --batch/--batch-data 或 --watch 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.reportPath != "" && (o.batchMode() || o.watchInterval > 0) },
+		message: "--report 不能与 --batch/--batch-data 或 --watch 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.publish.target != "" && (o.batchMode() || o.watchInterval > 0) },
+		message: "--publish 不能与 --batch/--batch-data 或 --watch 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.historyPath != "" && (o.batchMode() || o.watchInterval > 0) },
+		message: "--history 不能与 --batch/--batch-data 或 --watch 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.debugBundle != "" && (o.batchMode() || o.watchInterval > 0) },
+		message: "--debug-bundle 不能与 --batch/--batch-data 或 --watch 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.saveHeaders != "" && (o.batchMode() || len(o.envs) > 0) },
+		message: "--save-headers 不能与 --batch/--batch-data 或 --envs 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.resume && (!o.batchMode() || o.out == "") },
+		message: "--resume 需要配合 --batch/--batch-data 使用，并用 --out 指定上次的输出目录",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return (o.saveSchema != "" || o.baselineSchema != "") && (o.batchMode() || len(o.envs) > 0)
+		},
+		message: "--save-schema 和 --baseline-schema 不能与 --batch/--batch-data 或 --envs 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return o.manifest != "" && (o.watchInterval > 0 || len(o.envs) > 0 || o.interactive)
+		},
+		message: "--manifest 不能与 --watch、--envs 或 --interactive 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.leafPaths && !o.leavesOnly },
+		message: "--leaf-paths 需要配合 --leaves-only 使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.leavesOnly && o.nodeOrder },
+		message: "--with-order 不能与 --leaves-only 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return !o.scalarTitles && (o.scalarFormat != "" || len(o.scalarBool) > 0) },
+		message: "--scalar-title-format 和 --scalar-title-bool 需要配合 --scalar-titles 使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.saveHeaders == "" && o.redactHeaders },
+		message: "--redact-headers 需要配合 --save-headers 使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return (o.saveRaw != "" || o.replayRaw != "") && (o.batchMode() || len(o.envs) > 0)
+		},
+		message: "--save-raw 和 --replay-raw 不能与 --batch/--batch-data 或 --envs 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return o.preview > 0 && (o.out != "" || o.batchMode() || o.watchInterval > 0 || len(o.envs) > 0 || o.interactive ||
+				o.summaryJSON || o.publish.target != "" || o.golden.path != "")
+		},
+		message: "--preview 不写入输出文件，不能与 --out、--batch/--batch-data、--watch、--envs、--interactive、--summary-json、--publish 或 check 命令同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.replayRaw != "" && (o.chainFile != "" || o.capture.offline) },
+		message: "--replay-raw 不能与 --chain 或 --import-offline 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.format != formatJSON && o.batchMode() },
+		message: "--format 不能与 --batch/--batch-data 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return len(o.envs) > 0 && (o.batchMode() || o.watchInterval > 0 || o.interactive || o.golden.path != "")
+		},
+		message: "--envs 不能与 --batch/--batch-data、--watch、--interactive 或 check 命令同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return len(o.envs) > 0 && (o.reportPath != "" || o.debugBundle != "" || o.historyPath != "" ||
+				o.publish.target != "" || o.format != formatJSON)
+		},
+		message: "--envs 输出合并报告，不能与 --report、--debug-bundle、--history、--publish 或 --format 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return o.sync != "" && (o.batchMode() || o.watchInterval > 0 || len(o.envs) > 0 || o.preview > 0 || o.format != formatJSON)
+		},
+		message: "--sync 不能与 --batch/--batch-data、--watch、--envs、--preview 或 --format 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool { return o.capture.file != "" && o.batchMode() },
+		message: "--import 不能与 --batch-data 同时使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return o.capture.file == "" && (o.capture.filter != "" || o.capture.offline)
+		},
+		message: "--import-filter 和 --import-offline 需要配合 --import 使用",
+	},
+	{
+		invalid: func(o *fetchOptions) bool {
+			return o.golden.path != "" && (o.batchMode() || o.watchInterval > 0 || o.interactive)
+		},
+		message: "--golden 不能与 --batch/--batch-data、--watch 或 --interactive 同时使用",
+	},
+}
+
+// batchMode 是否为批量模式（--batch 或 --batch-data）
+func (o *fetchOptions) batchMode() bool {
+	return o.batchFile != "" || o.batchData != ""
+}
+
+// checkFlagRules 按 flagRules 检查参数组合，返回第一条违反的规则对应的错误
+func (o *fetchOptions) checkFlagRules() error {
+	for _, rule := range flagRules {
+		if rule.invalid(o) {
+			return errors.New(i18n.T(rule.message))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+// formatJSON 默认输出格式，即流水线 render 阶段输出的树状JSON
+const formatJSON = render.JSON
+
+// checkFormat 校验 --format 参数，可选的格式见 render.Names（包括 --plugin 加载的 renderer 插件）
+func checkFormat(format string) error {
+	if _, ok := render.Lookup(format); !ok {
+		return i18n.Errorf("不支持的输出格式 %q，可选 %s", format, strings.Join(render.Names(), "、"))
+	}
+	return nil
+}
+
+// formatExt 返回格式默认输出文件的扩展名
+func formatExt(format string) string {
+	f, _ := render.Lookup(format)
+	return f.Ext
+}
+
+// formatContentType 返回上传到对象存储时格式的Content-Type
+func formatContentType(format string) string {
+	f, _ := render.Lookup(format)
+	return f.ContentType
+}
+
+// renderOutput 将树状JSON渲染为指定格式，json 格式原样返回
+func renderOutput(ctx context.Context, result []byte, format string) ([]byte, error) {
+	if format == formatJSON {
+		return result, nil
+	}
+	var tree extractor.Tree
+	if err := tree.UnmarshalJSON(result); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := render.Render(format, &tree, &buf, render.Options{Context: ctx}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
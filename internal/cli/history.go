@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/history"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// historyOptions history 子命令的参数
+type historyOptions struct {
+	db          string
+	fingerprint string
+	limit       int
+	meta        bool
+}
+
+// newHistoryCmd 查看 --history 记录的运行历史
+func newHistoryCmd() *cobra.Command {
+	o := &historyOptions{}
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "查看 --history 记录的运行历史",
+		Long: `列出、查看和比较通过 --history 保存到SQLite数据库中的运行记录。
+相同请求（方法、脱敏后的URL与请求体相同）的记录具有相同的指纹，可以跟踪接口的树结构随时间的变化。`,
+		Example: `  ./caseurl2md history list
+  ./caseurl2md history list --fingerprint 3f2a9c0d1e4b5a67
+  ./caseurl2md history show 12 > result.json
+  ./caseurl2md history diff 12        # 与同一请求的上一次记录比较
+  ./caseurl2md history diff 8 12`,
+	}
+	cmd.PersistentFlags().StringVar(&o.db, "db", history.DefaultPath(), "历史数据库路径")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "按时间倒序列出运行记录",
+		Args:  cobra.NoArgs,
+		RunE:  o.runList,
+	}
+	list.Flags().StringVar(&o.fingerprint, "fingerprint", "", "只列出该请求指纹的记录")
+	list.Flags().IntVar(&o.limit, "limit", 20, "最多列出的记录数")
+
+	show := &cobra.Command{
+		Use:   "show <id>",
+		Short: "输出运行记录中的树状JSON",
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.runShow,
+	}
+	show.Flags().BoolVar(&o.meta, "meta", false, "输出包含请求与统计信息的完整记录")
+
+	diff := &cobra.Command{
+		Use:   "diff <id> [id]",
+		Short: "比较两次运行的树结构，只指定一个ID时与同一请求的上一次记录比较",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE:  o.runDiff,
+	}
+
+	cmd.AddCommand(list, show, diff)
+	return cmd
+}
+
+// open 打开历史数据库，参数已经通过校验，之后的错误不再打印用法
+func (o *historyOptions) open(cmd *cobra.Command) (*history.Store, error) {
+	cmd.SilenceUsage = true
+	return history.Open(expandHome(o.db))
+}
+
+func (o *historyOptions) runList(cmd *cobra.Command, args []string) error {
+	store, err := o.open(cmd)
+	if err != nil {
+		return err
+	}
+	runs, err := store.List(cmd.Context(), o.fingerprint, o.limit)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		fmt.Println(i18n.T("没有运行记录"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("ID\t时间\t状态\t节点\t指纹\t请求"))
+	for _, run := range runs {
+		status := run.Status
+		if run.StatusCode != 0 {
+			status = fmt.Sprintf("%s(%d)", status, run.StatusCode)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s %s\n", run.ID, run.CreatedAt.Local().Format(time.DateTime),
+			status, run.Nodes, run.Fingerprint, run.Method, run.URL)
+	}
+	return w.Flush()
+}
+
+func (o *historyOptions) runShow(cmd *cobra.Command, args []string) error {
+	id, err := parseRunID(args[0])
+	if err != nil {
+		return err
+	}
+	store, err := o.open(cmd)
+	if err != nil {
+		return err
+	}
+	run, err := store.Get(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	if o.meta {
+		content, err := json.MarshalIndent(run, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(content))
+		return nil
+	}
+	if run.Tree == nil {
+		return i18n.Errorf("记录 #%d 没有树（运行失败: %s）", run.ID, run.Error)
+	}
+	fmt.Println(string(run.Tree))
+	return nil
+}
+
+func (o *historyOptions) runDiff(cmd *cobra.Command, args []string) error {
+	ids := make([]int64, len(args))
+	for i, arg := range args {
+		id, err := parseRunID(arg)
+		if err != nil {
+			return err
+		}
+		ids[i] = id
+	}
+	store, err := o.open(cmd)
+	if err != nil {
+		return err
+	}
+
+	newer, err := store.Get(cmd.Context(), ids[len(ids)-1])
+	if err != nil {
+		return err
+	}
+	var older *history.Run
+	if len(ids) == 2 {
+		if older, err = store.Get(cmd.Context(), ids[0]); err != nil {
+			return err
+		}
+	} else {
+		if older, err = store.Previous(cmd.Context(), newer); err != nil {
+			return err
+		}
+		if older == nil {
+			return i18n.Errorf("记录 #%d 之前没有同一请求（指纹 %s）的记录", newer.ID, newer.Fingerprint)
+		}
+	}
+
+	oldRoots, err := runNodes(older)
+	if err != nil {
+		return err
+	}
+	newRoots, err := runNodes(newer)
+	if err != nil {
+		return err
+	}
+	changes := treediff.Compare(oldRoots, newRoots)
+	if len(changes) == 0 {
+		fmt.Printf(i18n.T("#%d -> #%d: 树结构无变化\n"), older.ID, newer.ID)
+		return nil
+	}
+	fmt.Printf("#%d (%s) -> #%d (%s): %s\n", older.ID, older.CreatedAt.Local().Format(time.DateTime),
+		newer.ID, newer.CreatedAt.Local().Format(time.DateTime), treediff.Summary(changes))
+	fmt.Print(treediff.FormatColor(changes, color.For(os.Stdout)))
+	return nil
+}
+
+// runNodes 解析记录中的树
+func runNodes(run *history.Run) ([]*extractor.SimplifiedNode, error) {
+	if run.Tree == nil {
+		return nil, i18n.Errorf("记录 #%d 没有树（运行失败: %s）", run.ID, run.Error)
+	}
+	return extractor.ParseNodes(run.Tree)
+}
+
+func parseRunID(arg string) (int64, error) {
+	id, err := strconv.ParseInt(strings.TrimPrefix(arg, "#"), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, exitcode.Wrap(exitcode.Usage, i18n.Errorf("无效的记录ID %q", arg))
+	}
+	return id, nil
+}
+
+// expandHome 展开路径开头的 ~/，用于 --history=~/... 这类shell不会展开的写法
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
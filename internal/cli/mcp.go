@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/mcp"
+	"github.com/wellkilo/Curl2json/internal/version"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// serviceLimits 服务模式下的响应体安全上限默认值，防止异常或恶意构造的响应拖垮常驻进程
+var serviceLimits = limitOptions{
+	maxResponseSize: 64 << 20,
+	maxJSONDepth:    1000,
+	maxStringLength: 16 << 20,
+}
+
+// serviceOptions serve与mcp子命令共用的默认转换参数
+type serviceOptions struct {
+	titleKeys    []string
+	childrenKeys []string
+	timeout      int
+	verbose      bool
+	limits       limitOptions
+	log          logOptions
+}
+
+// addServiceFlags 注册serve与mcp子命令共用的flags
+func addServiceFlags(cmd *cobra.Command, o *serviceOptions) {
+	flags := cmd.Flags()
+	flags.StringSliceVar(&o.titleKeys, "title-key", extractor.DefaultTitleKeys(), "默认的节点内容字段候选键名")
+	flags.StringSliceVar(&o.childrenKeys, "children-keys", extractor.DefaultChildrenKeys(), "默认的子节点数组候选键名")
+	flags.IntVar(&o.timeout, "timeout", 30, "默认的HTTP请求超时时间（秒）")
+	addLimitFlags(cmd, &o.limits, serviceLimits)
+}
+
+// config 根据参数构建默认转换配置
+func (o *serviceOptions) config() (*config.Config, error) {
+	log, err := o.log.newLogger(o.verbose)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &config.Config{
+		Timeout:      time.Duration(o.timeout) * time.Second,
+		TitleKeys:    o.titleKeys,
+		ChildrenKeys: o.childrenKeys,
+		Verbose:      o.verbose,
+		Logger:       log,
+	}
+	o.limits.apply(cfg)
+	return cfg, nil
+}
+
+// newMCPCmd 以Model Context Protocol（stdio）方式提供转换工具
+func newMCPCmd() *cobra.Command {
+	opts := &serviceOptions{}
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "启动MCP工具服务（stdio），供LLM Agent和IDE助手调用",
+		Long: `通过标准输入/输出提供Model Context Protocol服务，暴露两个工具：
+
+  fetch_and_extract_tree   执行cURL请求并抽取业务用例树
+  extract_tree_from_json   从已有JSON响应中抽取业务用例树
+
+在MCP客户端中配置命令 "caseurl2md mcp" 即可使用。`,
+		Example: `  # Claude Desktop / IDE 配置示例
+  {"mcpServers": {"caseurl2md": {"command": "caseurl2md", "args": ["mcp"]}}}`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := opts.config()
+			if err != nil {
+				return err
+			}
+			return mcp.New(cfg, version.Version).Serve(cmd.Context(), os.Stdin, os.Stdout)
+		},
+	}
+	addServiceFlags(cmd, opts)
+	addLogFlags(cmd, &opts.log)
+	return cmd
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/mock"
+)
+
+// newMockCmd 启动按请求指纹应答录制响应的模拟服务
+func newMockCmd() *cobra.Command {
+	var fixturesDir, listenAddr string
+	var verbose bool
+	var logOpts logOptions
+	cmd := &cobra.Command{
+		Use:   "mock",
+		Short: "启动模拟服务，按请求指纹应答录制的响应",
+		Long: `启动模拟服务，应答夹具目录中录制的响应，无需访问真实的内部接口即可调试和演示抽取参数。
+
+夹具目录中可以放置：
+  *.json                     --save-fixture 录制的夹具文件，响应体可以直接编辑
+  .chlsj、.saz、.flow 等     抓包工具导出的会话文件，其中记录了响应的请求都会加载
+
+请求按指纹匹配：方法、路径、查询参数与请求体相同即视为同一请求，不比较协议、主机与请求头；
+查询参数不分顺序，token等可能携带凭据的参数只比较名称，JSON请求体忽略键的顺序与空白。
+没有匹配的夹具时返回404，响应体中包含该请求的指纹。`,
+		Example: `  ./caseurl2md --curl-file curl.txt --out result.json --save-fixture fixtures/   # 录制
+  ./caseurl2md mock --fixtures fixtures/ --listen :9090
+  ./caseurl2md --url 'http://localhost:9090/api/cases?id=1' --title-key caseName --out result.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMock(cmd, fixturesDir, listenAddr, verbose, &logOpts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&fixturesDir, "fixtures", "", "夹具目录（必填）")
+	flags.StringVar(&listenAddr, "listen", ":9090", "监听地址")
+	flags.BoolVarP(&verbose, "verbose", "v", false, "显示详细日志")
+	addLogFlags(cmd, &logOpts)
+	return cmd
+}
+
+func runMock(cmd *cobra.Command, fixturesDir, listenAddr string, verbose bool, logOpts *logOptions) error {
+	if fixturesDir == "" {
+		return exitcode.Wrap(exitcode.Usage, i18n.Errorf("必须使用 --fixtures 指定夹具目录"))
+	}
+	log, err := logOpts.newLogger(verbose)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
+	fixtures, err := mock.Load(fixturesDir)
+	if err != nil {
+		return exitcode.Wrap(exitcode.Usage, err)
+	}
+
+	srv := mock.New(fixtures, log)
+	for _, fp := range srv.Fingerprints() {
+		f := srv.Fixture(fp)
+		log.Debug(i18n.T("已加载夹具"), "fingerprint", fp, "method", f.Request.Method, "url", f.Request.URL)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info(i18n.T("模拟服务已启动"), "listen", listenAddr, "fixtures", len(fixtures))
+	if err := srv.ListenAndServe(ctx, listenAddr); err != nil {
+		return err
+	}
+	log.Info(i18n.T("模拟服务已退出"))
+	return nil
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/notify"
+	"github.com/wellkilo/Curl2json/internal/objstore"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// runEvent 根据单次或批量运行的摘要生成通知
+func (o *fetchOptions) runEvent(summary *runSummary) *notify.Event {
+	event := &notify.Event{Mode: notify.ModeRun}
+	if o.batchFile != "" || o.batchData != "" {
+		event.Mode = notify.ModeBatch
+	}
+	if summary == nil {
+		return event
+	}
+	event.Output, event.Nodes, event.Diff = summary.Output, summary.Nodes, summary.Diff
+	event.Succeeded, event.Failed = summary.Succeeded, summary.Failed
+	if summary.Publish != nil {
+		event.URL = summary.Publish.URL
+	}
+	return event
+}
+
+// notify 补全状态与耗时后发送通知，发送失败只记录警告，不影响运行结果
+func (o *fetchOptions) notify(ctx context.Context, event *notify.Event, err error, start time.Time, log *slog.Logger) {
+	event.Status = statusSuccess
+	if err != nil {
+		event.Status = statusError
+		event.Error = err.Error()
+	}
+	event.DurationMs = time.Since(start).Milliseconds()
+	if event.Output != "" {
+		if abs, absErr := filepath.Abs(event.Output); absErr == nil {
+			event.Output = abs
+		}
+	}
+
+	if sendErr := o.notifier.Send(ctx, event); sendErr != nil {
+		log.Warn(i18n.T("发送完成通知失败"), "error", sendErr)
+		return
+	}
+	log.Debug(i18n.T("已发送完成通知"), "status", event.Status)
+}
+
+// readPreviousNodes 读取即将被覆盖的输出文件，文件不存在、不是树状JSON或位于对象存储时返回nil
+func readPreviousNodes(path string) []*extractor.SimplifiedNode {
+	if objstore.IsRemote(path) {
+		return nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	nodes, err := extractor.ParseNodes(content)
+	if err != nil {
+		return nil
+	}
+	return nodes
+}
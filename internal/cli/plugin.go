@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/plugin"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+// pluginOptions plugin 子命令的参数
+type pluginOptions struct {
+	dir    string
+	digest string
+	force  bool
+}
+
+// newPluginCmd 管理外部抽取插件
+func newPluginCmd() *cobra.Command {
+	o := &pluginOptions{}
+	cmd := &cobra.Command{
+		Use:   "plugin",
+		Short: "管理外部抽取插件",
+		Long: `安装、列出和删除外部抽取插件。插件是包含 plugin.yaml 的目录，可以来自Git仓库、OCI制品或本地目录：
+  strategy   代替内置抽取器，从响应体中抽取树
+  converter  在内置抽取之后调整树
+安装时记录插件目录内容的摘要，每次通过 --plugin 使用前重新校验，文件被修改的插件拒绝加载。`,
+		Example: `  ./caseurl2md plugin install https://github.com/example/curl2json-feishu.git#v1.2.0
+  ./caseurl2md plugin install oci://ghcr.io/example/curl2json-feishu:1.2.0 --sha256 9f86d08...
+  ./caseurl2md plugin install ./my-plugin
+  ./caseurl2md plugin list
+  ./caseurl2md --curl-file curl.txt --plugin feishu-mind
+  ./caseurl2md plugin remove feishu-mind`,
+	}
+	cmd.PersistentFlags().StringVar(&o.dir, "dir", plugin.DefaultDir(), "插件安装目录")
+
+	list := &cobra.Command{
+		Use:   "list",
+		Short: "列出已安装的插件并校验完整性",
+		Args:  cobra.NoArgs,
+		RunE:  o.runList,
+	}
+
+	install := &cobra.Command{
+		Use:   "install <source>",
+		Short: "从Git仓库（URL[#ref]）、OCI制品（oci://仓库:标签）或本地目录安装插件",
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.runInstall,
+	}
+	install.Flags().StringVar(&o.digest, "sha256", "", "期望的插件内容摘要，不一致时拒绝安装")
+	install.Flags().BoolVar(&o.force, "force", false, "覆盖已安装的同名插件")
+
+	remove := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "删除已安装的插件",
+		Args:  cobra.ExactArgs(1),
+		RunE:  o.runRemove,
+	}
+
+	cmd.AddCommand(list, install, remove)
+	return cmd
+}
+
+// registry 打开插件安装目录，参数已经通过校验，之后的错误不再打印用法
+func (o *pluginOptions) registry(cmd *cobra.Command) *plugin.Registry {
+	cmd.SilenceUsage = true
+	return plugin.Open(expandHome(o.dir))
+}
+
+func (o *pluginOptions) runList(cmd *cobra.Command, args []string) error {
+	registry := o.registry(cmd)
+	installed, err := registry.List()
+	if err != nil {
+		return err
+	}
+	if len(installed) == 0 {
+		fmt.Println(i18n.T("没有安装插件"))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, i18n.T("名称\t版本\t类型\t状态\t安装时间\t来源"))
+	for i := range installed {
+		p := &installed[i]
+		source := p.Source
+		if p.Ref != "" && p.Ref != source {
+			source = fmt.Sprintf("%s (%s)", source, p.Ref)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", p.Name, p.Version, p.Kind, registry.Status(p),
+			p.InstalledAt.Local().Format(time.DateTime), source)
+	}
+	return w.Flush()
+}
+
+func (o *pluginOptions) runInstall(cmd *cobra.Command, args []string) error {
+	installed, err := o.registry(cmd).Install(args[0], plugin.InstallOptions{Digest: o.digest, Force: o.force})
+	if err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("已安装插件 %s %s（%s）\n摘要: %s\n"), installed.Name, installed.Version, installed.Kind, installed.Digest)
+	return nil
+}
+
+func (o *pluginOptions) runRemove(cmd *cobra.Command, args []string) error {
+	if err := o.registry(cmd).Remove(args[0]); err != nil {
+		return err
+	}
+	fmt.Printf(i18n.T("已删除插件 %s\n"), args[0])
+	return nil
+}
+
+// loadPlugins 加载 --plugin 指定的插件，最多只能使用一个 strategy 插件
+func loadPlugins(names []string) ([]*plugin.Plugin, error) {
+	registry := plugin.Open(plugin.DefaultDir())
+	plugins := make([]*plugin.Plugin, 0, len(names))
+	var strategy string
+	for _, name := range names {
+		p, err := registry.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		switch p.Kind {
+		case plugin.KindStrategy:
+			if strategy != "" {
+				return nil, i18n.Errorf("只能使用一个 strategy 插件（%s 与 %s）", strategy, p.Name)
+			}
+			strategy = p.Name
+		case plugin.KindRenderer:
+			// renderer 插件以插件名称注册为 --format 的可选格式
+			if _, ok := render.Lookup(p.Name); ok {
+				return nil, i18n.Errorf("renderer 插件 %s 与已有的输出格式同名", p.Name)
+			}
+			render.Register(p.Format())
+		}
+		plugins = append(plugins, p)
+	}
+	return plugins, nil
+}
+
+// registerPlugins 按 --plugin 的顺序注册插件钩子，需要在 --post-process 脚本之前注册
+func registerPlugins(hooks *pipeline.Hooks, plugins []*plugin.Plugin) {
+	for _, p := range plugins {
+		p.Register(hooks)
+	}
+}
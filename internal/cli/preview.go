@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// previewName 预览中单行显示的节点名称，换行替换为空格
+var previewName = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// printPreview 按层输出 --preview 的预览：每层前n个节点名称与该层节点数，最后输出节点统计
+func printPreview(w io.Writer, result []byte, n int) error {
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		return err
+	}
+
+	var total, leaves int
+	level := 0
+	for ; len(nodes) > 0; level++ {
+		var names, next []*extractor.SimplifiedNode
+		count := 0
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			count++
+			if len(names) < n {
+				names = append(names, node)
+			}
+			if len(node.Children) == 0 {
+				leaves++
+			}
+			next = append(next, node.Children...)
+		}
+		if count == 0 {
+			break
+		}
+		total += count
+
+		shown := make([]string, len(names))
+		for i, node := range names {
+			shown[i] = previewName.Replace(node.Name)
+		}
+		line := strings.Join(shown, i18n.T("、"))
+		if rest := count - len(names); rest > 0 {
+			line += fmt.Sprintf(i18n.T(" …（另有 %d 个）"), rest)
+		}
+		fmt.Fprintf(w, i18n.T("第%d层（%d 个节点）: %s\n"), level+1, count, line)
+		nodes = next
+	}
+	fmt.Fprintf(w, i18n.T("共 %d 个节点，%d 个叶子节点，最大 %d 层\n"), total, leaves, level)
+	return nil
+}
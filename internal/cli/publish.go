@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/publish"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// 发布目标
+const (
+	publishConfluence = "confluence"
+	publishJira       = "jira"
+	publishTestRail   = "testrail"
+	publishFeishu     = "feishu"
+)
+
+// publishOptions --publish 相关参数，凭据从环境变量读取，避免出现在命令行历史中
+type publishOptions struct {
+	target string
+	title  string
+	format string
+	dryRun bool
+
+	confluenceURL string
+	space         string
+	parentPage    string
+
+	jiraURL       string
+	jiraProject   string
+	jiraIssueType string
+	jiraParent    string
+
+	testrailURL     string
+	testrailProject int
+	testrailSuite   int
+	testrailSection int
+
+	feishuURL    string
+	feishuFolder string
+}
+
+// addPublishFlags 注册发布相关flags
+func addPublishFlags(cmd *cobra.Command, o *publishOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.target, "publish", "", "写入结果后发布到外部系统：confluence、jira、testrail、feishu")
+	flags.StringVar(&o.target, "upload", "", "同 --publish，如 --upload testrail")
+	flags.StringVar(&o.title, "publish-title", "", "发布的页面标题（默认使用根节点名称）")
+	flags.BoolVar(&o.dryRun, "publish-dry-run", false, "只列出将要发布的页面或问题，不调用外部系统的写入接口")
+	flags.StringVar(&o.format, "publish-format", publish.FormatStorage, "Confluence页面格式：storage（嵌套列表）或 markdown（Markdown宏）")
+	flags.StringVar(&o.confluenceURL, "confluence-url", os.Getenv("CONFLUENCE_URL"), "Confluence地址，如 https://example.atlassian.net/wiki（默认读取 CONFLUENCE_URL）")
+	flags.StringVar(&o.space, "space", "", "发布到的Confluence空间key")
+	flags.StringVar(&o.parentPage, "parent-page", "", "Confluence父页面ID或标题")
+	flags.StringVar(&o.jiraURL, "jira-url", os.Getenv("JIRA_URL"), "Jira地址，如 https://example.atlassian.net（默认读取 JIRA_URL）")
+	flags.StringVar(&o.jiraProject, "jira-project", "", "创建问题的Jira项目key")
+	flags.StringVar(&o.jiraIssueType, "jira-issue-type", "", "Jira问题类型（默认 Task，指定 --jira-parent 时为 Sub-task）")
+	flags.StringVar(&o.jiraParent, "jira-parent", "", "父问题key，指定时每个叶子节点创建为其子任务")
+	flags.StringVar(&o.testrailURL, "testrail-url", os.Getenv("TESTRAIL_URL"), "TestRail地址，如 https://example.testrail.io（默认读取 TESTRAIL_URL）")
+	flags.IntVar(&o.testrailProject, "testrail-project", 0, "上传到的TestRail项目ID")
+	flags.IntVar(&o.testrailSuite, "testrail-suite", 0, "TestRail用例库ID（多用例库项目必须指定）")
+	flags.StringVar(&o.feishuURL, "feishu-url", os.Getenv("FEISHU_URL"), "飞书开放平台地址，Lark为 https://open.larksuite.com（默认读取 FEISHU_URL，未设置时为 https://open.feishu.cn）")
+	flags.StringVar(&o.feishuFolder, "feishu-folder", "", "创建飞书文档的文件夹token（默认为应用的云空间根目录）")
+	flags.IntVar(&o.testrailSection, "testrail-section", 0, "TestRail父分组ID，为0时根节点创建为顶层分组")
+}
+
+// newPublisher 根据 --publish 创建发布目标，未指定时返回nil
+func (o *publishOptions) newPublisher() (publish.Publisher, error) {
+	switch o.target {
+	case "":
+		return nil, nil
+	case publishConfluence:
+		if o.confluenceURL == "" || o.space == "" {
+			return nil, i18n.Errorf("--publish confluence 需要 --confluence-url（或 CONFLUENCE_URL）和 --space")
+		}
+		token := os.Getenv("CONFLUENCE_TOKEN")
+		if token == "" {
+			return nil, i18n.Errorf("请通过 CONFLUENCE_TOKEN 环境变量提供Confluence访问令牌（Cloud还需设置 CONFLUENCE_USER 为账号邮箱）")
+		}
+		return &publish.Confluence{
+			BaseURL: o.confluenceURL,
+			Space:   o.space,
+			Parent:  o.parentPage,
+			User:    os.Getenv("CONFLUENCE_USER"),
+			Token:   token,
+			Format:  o.format,
+			DryRun:  o.dryRun,
+		}, nil
+	case publishJira:
+		if o.jiraProject == "" {
+			return nil, i18n.Errorf("--publish jira 需要 --jira-project")
+		}
+		token := os.Getenv("JIRA_TOKEN")
+		if !o.dryRun && (o.jiraURL == "" || token == "") {
+			return nil, i18n.Errorf("请通过 --jira-url（或 JIRA_URL）和 JIRA_TOKEN 环境变量提供Jira地址与访问令牌（Cloud还需设置 JIRA_USER 为账号邮箱）")
+		}
+		return &publish.Jira{
+			BaseURL:   o.jiraURL,
+			Project:   o.jiraProject,
+			IssueType: o.jiraIssueType,
+			Parent:    o.jiraParent,
+			User:      os.Getenv("JIRA_USER"),
+			Token:     token,
+			DryRun:    o.dryRun,
+		}, nil
+	case publishTestRail:
+		if o.testrailProject <= 0 {
+			return nil, i18n.Errorf("--upload testrail 需要 --testrail-project")
+		}
+		user, key := os.Getenv("TESTRAIL_USER"), os.Getenv("TESTRAIL_API_KEY")
+		if !o.dryRun && (o.testrailURL == "" || user == "" || key == "") {
+			return nil, i18n.Errorf("请通过 --testrail-url（或 TESTRAIL_URL）以及 TESTRAIL_USER、TESTRAIL_API_KEY 环境变量提供TestRail地址、账号与API key")
+		}
+		return &publish.TestRail{
+			BaseURL:   o.testrailURL,
+			ProjectID: o.testrailProject,
+			SuiteID:   o.testrailSuite,
+			SectionID: o.testrailSection,
+			User:      user,
+			Token:     key,
+			DryRun:    o.dryRun,
+		}, nil
+	case publishFeishu:
+		token, appID, appSecret := os.Getenv("FEISHU_TENANT_TOKEN"), os.Getenv("FEISHU_APP_ID"), os.Getenv("FEISHU_APP_SECRET")
+		if !o.dryRun && token == "" && (appID == "" || appSecret == "") {
+			return nil, i18n.Errorf("请通过 FEISHU_TENANT_TOKEN 或 FEISHU_APP_ID、FEISHU_APP_SECRET 环境变量提供飞书凭证")
+		}
+		return &publish.Feishu{
+			BaseURL:     o.feishuURL,
+			Folder:      o.feishuFolder,
+			TenantToken: token,
+			AppID:       appID,
+			AppSecret:   appSecret,
+			DryRun:      o.dryRun,
+		}, nil
+	default:
+		return nil, i18n.Errorf("不支持的发布目标: %s（可选 confluence、jira、testrail、feishu）", o.target)
+	}
+}
+
+// run 发布抽取结果，Confluence页面与飞书文档的标题默认使用单根树的根节点名称
+func (o *publishOptions) run(ctx context.Context, publisher publish.Publisher, result []byte, log *slog.Logger) (*publish.Result, error) {
+	tree := &extractor.Tree{}
+	if err := json.Unmarshal(result, tree); err != nil {
+		return nil, err
+	}
+	title := o.title
+	if title == "" {
+		title = publish.Title(tree)
+	}
+	if title == "" && (o.target == publishConfluence || o.target == publishFeishu) {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("结果不是单根树，请通过 --publish-title 指定发布标题"))
+	}
+
+	published, err := publisher.Publish(ctx, title, tree)
+	if err != nil {
+		return nil, exitcode.Errorf(exitcode.OutputWrite, i18n.T("发布到 %s 失败: %w"), o.target, err)
+	}
+	for _, item := range published.Items {
+		log.Info(i18n.T("发布条目"), "action", published.Action, "kind", item.Kind, "id", item.ID, "title", item.Title)
+	}
+	log.Info(i18n.T("发布完成"), "target", published.Target, "action", published.Action, "url", published.URL, "items", len(published.Items))
+	return published, nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/capture"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+)
+
+// rawContentTypes --replay-raw 按文件扩展名还原的响应Content-Type，其他扩展名按JSON处理
+var rawContentTypes = map[string]string{
+	".ndjson": "application/x-ndjson",
+	".jsonl":  "application/x-ndjson",
+	".xml":    "application/xml",
+	".yaml":   "application/yaml",
+	".yml":    "application/yaml",
+	".html":   "text/html",
+	".htm":    "text/html",
+}
+
+// saveRawHook 返回在 execute 阶段之后将未经转换的响应体写入path的钩子，后续阶段失败时文件也已写入
+func saveRawHook(path string) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		if err := os.WriteFile(path, state.Body, 0o644); err != nil {
+			return i18n.Errorf("写入原始响应失败: %w", err)
+		}
+		return nil
+	}
+}
+
+// replayRaw 返回以 --save-raw 保存的响应体应答的传输层，请求不会发送到服务器
+func replayRaw(path string) (http.RoundTripper, error) {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取原始响应失败: %w", err)
+	}
+	contentType, ok := rawContentTypes[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		contentType = "application/json"
+	}
+	entry := &capture.Entry{Response: &capture.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": {contentType}},
+		Body:       body,
+	}}
+	return entry.Replay()
+}
@@ -1,37 +1,186 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
-	"caseurl2md/internal/config"
-	"caseurl2md/internal/processor"
+	"github.com/spf13/pflag"
+	"github.com/wellkilo/Curl2json/internal/assert"
+	"github.com/wellkilo/Curl2json/internal/auth"
+	"github.com/wellkilo/Curl2json/internal/bodyedit"
+	"github.com/wellkilo/Curl2json/internal/capture"
+	"github.com/wellkilo/Curl2json/internal/chain"
+	"github.com/wellkilo/Curl2json/internal/clipboard"
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/drift"
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/group"
+	"github.com/wellkilo/Curl2json/internal/history"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/keystyle"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/mock"
+	"github.com/wellkilo/Curl2json/internal/notify"
+	"github.com/wellkilo/Curl2json/internal/objstore"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/plugin"
+	"github.com/wellkilo/Curl2json/internal/postprocess"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/internal/profile"
+	"github.com/wellkilo/Curl2json/internal/protojson"
+	"github.com/wellkilo/Curl2json/internal/redact"
+	"github.com/wellkilo/Curl2json/internal/report"
+	"github.com/wellkilo/Curl2json/internal/rewrite"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/internal/version"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
 )
 
-var (
-	curlFile      string
-	fromCurl      string
-	rawCurl       string
-	url           string
-	method        string
-	headers       []string
-	data          string
-	cookies       string
-	out           string
-	titleKeys     []string
-	childrenKeys  []string
-	timeout       int
-	verbose       bool
-)
+// fetchOptions 根命令与fetch子命令的参数，随命令一起创建，多次构建和执行命令之间互不影响
+type fetchOptions struct {
+	curlFile         string
+	fromCurl         string
+	rawCurl          string
+	fromClipboard    bool
+	passthroughCurl  string
+	url              string
+	method           string
+	headers          []string
+	data             string
+	cookies          string
+	out              string
+	format           string
+	titleKeys        []string
+	levelTitleKeys   []string
+	childrenKeys     []string
+	mergeChildren    bool
+	scalarTitles     bool
+	scalarFormat     string
+	scalarBool       []string
+	maxTitleLength   int
+	maxDepth         int
+	nodeOrder        bool
+	tagFields        []string
+	textProfile      string
+	onConflict       string
+	leavesOnly       bool
+	leafPaths        bool
+	fromDepth        int
+	toDepth          int
+	timeout          int
+	noRedirects      bool
+	verbose          bool
+	interactive      bool
+	preview          int
+	watchInterval    time.Duration
+	watchDiff        bool
+	sync             string
+	syncBase         []*extractor.SimplifiedNode // --sync 文件中上一次的完整结果
+	batchFile        string
+	batchData        string
+	resume           bool
+	chainFile        string
+	envs             []string
+	environments     []environment
+	summaryJSON      bool
+	notifyWebhook    string
+	notifier         *notify.Webhook
+	historyPath      string
+	historyRecorder  *history.Recorder
+	noProgress       bool
+	failEmpty        bool
+	validateOutput   bool
+	keyStyleName     string
+	keyStyle         keystyle.Style // 由 --key-style 解析得到
+	asserts          []string
+	bodySets         []string
+	bodyDeletes      []string
+	bodyEdits        []*bodyedit.Edit
+	rewrites         []string
+	decompressFields []string
+	protoJSON        bool
+	groupBy          []string
+	postProcess      string
+	plugins          []string
+	loadedPlugins    []*plugin.Plugin
+	waitFor          string
+	pollInterval     time.Duration
+	pollTimeout      time.Duration
+	retryUnsafe      bool
+	idempotencyKey   string
+	reportPath       string
+	debugBundle      string
+	debugDir         string
+	saveHeaders      string
+	redactHeaders    bool
+	redactRules      string
+	saveRaw          string
+	replayRaw        string
+	saveFixture      string
+	saveSchema       string
+	baselineSchema   string
+	manifest         string
+	authRefresh      authRefreshOptions
+	capture          captureOptions
+	publish          publishOptions
+	golden           goldenOptions
+	limits           limitOptions
+	log              logOptions
+}
+
+// authRefreshOptions 认证失效时的刷新请求参数
+type authRefreshOptions struct {
+	curl  string
+	token string
+	into  string
+}
+
+// captureOptions 从抓包会话文件导入请求的参数
+type captureOptions struct {
+	file    string
+	filter  string
+	offline bool
+}
+
+// logOptions 日志相关参数
+type logOptions struct {
+	level  string
+	format string
+	quiet  bool
+}
+
+// limitOptions 响应体安全上限参数，0 表示不限制
+type limitOptions struct {
+	maxResponseSize int64
+	maxJSONDepth    int
+	maxStringLength int
+}
+
+// newRootCmd 构建完整的命令树，每次调用都会创建新的命令与参数，可以安全地重复执行
+func newRootCmd() *cobra.Command {
+	var (
+		lang    string
+		noColor bool
+	)
+	opts := &fetchOptions{}
+	prof := &profile.Profiler{}
 
-// rootCmd represents the base command when called without any subcommands
-var rootCmd = &cobra.Command{
-	Use:   "caseurl2md",
-	Short: "cURL请求到树状JSON转换工具",
-	Long: `将cURL命令转换为精简的树状JSON结构工具。
+	rootCmd := &cobra.Command{
+		Use:   "caseurl2md",
+		Short: "cURL请求到树状JSON转换工具",
+		Long: `将cURL命令转换为精简的树状JSON结构工具。
 
 该工具能够：
 1. 解析cURL命令
@@ -43,175 +192,887 @@ var rootCmd = &cobra.Command{
 - 从stdin读取cURL命令
 - 从文件读取cURL命令
 - 通过命令行参数直接指定请求信息`,
-	Example: `  # 直接使用cURL命令
+		Example: `  # 直接使用cURL命令
   ./caseurl2md --from-curl 'curl "http://example.com/api" -H "Authorization: Bearer token"'
 
   # 从文件读取cURL
   ./caseurl2md --curl-file curl.txt --out result.json
 
+  # 批量执行文件中的多个cURL
+  ./caseurl2md --batch curls.txt --out results/
+
+  # 从剪贴板读取（浏览器中 Copy as cURL 后直接运行）
+  ./caseurl2md --from-clipboard
+
+  # 使用 -- 原样透传cURL参数，避免引号与flag冲突
+  ./caseurl2md fetch --out result.json -- curl "http://example.com/api" -H "Authorization: Bearer token"
+
   # 手动指定参数
   ./caseurl2md --url "http://api.example.com/data" --header "Content-Type: application/json" --method POST`,
-	RunE: runRoot,
+		RunE: opts.runRoot,
+	}
+
+	addFetchFlags(rootCmd, opts)
+	rootCmd.PersistentFlags().StringVar(&lang, "lang", "", "界面语言：zh 或 en（默认根据 LANG 等环境变量检测）")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "关闭终端颜色输出（也可设置 NO_COLOR 环境变量）")
+	// 供维护者诊断性能问题，不在帮助中显示
+	rootCmd.PersistentFlags().StringVar(&prof.CPUPath, "pprof-cpu", "", "将本次运行的CPU profile写入文件")
+	rootCmd.PersistentFlags().StringVar(&prof.MemPath, "pprof-mem", "", "运行结束时将堆内存profile写入文件")
+	rootCmd.PersistentFlags().MarkHidden("pprof-cpu")
+	rootCmd.PersistentFlags().MarkHidden("pprof-mem")
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		if noColor {
+			color.Disable()
+		}
+	}
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.Wrap(exitcode.Usage, err)
+	})
+
+	// 重要：禁用 Cobra 的默认解析行为，防止它错误解析 cURL 命令中的参数
+	rootCmd.DisableFlagParsing = false
+
+	rootCmd.Version = version.Version
+	rootCmd.SetVersionTemplate(version.Get().String() + "\n")
+
+	rootCmd.AddCommand(
+		newFetchCmd(),
+		newCheckCmd(),
+		newServeCmd(),
+		newMCPCmd(),
+		newMockCmd(),
+		newDoctorCmd(),
+		newViewCmd(),
+		newHistoryCmd(),
+		newPluginCmd(),
+		newVersionCmd(),
+	)
+	profileCommands(rootCmd, prof)
+	return rootCmd
+}
+
+// profileCommands 包装命令树中各命令的 RunE，在命令执行期间按 --pprof-cpu/--pprof-mem 采集profile；
+// 命令失败时同样写入profile
+func profileCommands(cmd *cobra.Command, prof *profile.Profiler) {
+	if run := cmd.RunE; run != nil {
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := prof.Start(); err != nil {
+				return exitcode.Wrap(exitcode.Usage, err)
+			}
+			err := run(cmd, args)
+			if stopErr := prof.Stop(); stopErr != nil {
+				if err == nil {
+					return exitcode.Wrap(exitcode.OutputWrite, stopErr)
+				}
+				fmt.Fprintln(os.Stderr, stopErr)
+			}
+			return err
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		profileCommands(sub, prof)
+	}
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() error {
+	// 帮助文本在flag解析前就会用到，因此先从参数和环境变量中确定语言
+	if err := i18n.SetLang(i18n.Detect(os.Args[1:], os.Getenv)); err != nil {
+		return err
+	}
+	rootCmd := newRootCmd()
+	localize(rootCmd)
 	return rootCmd.Execute()
 }
 
-func init() {
+// localize 将命令及其子命令的说明和flag帮助翻译为当前语言
+func localize(cmd *cobra.Command) {
+	cmd.Short = i18n.T(cmd.Short)
+	cmd.Long = i18n.T(cmd.Long)
+	cmd.Example = i18n.T(cmd.Example)
+	translateFlag := func(f *pflag.Flag) {
+		f.Usage = i18n.T(f.Usage)
+	}
+	cmd.LocalFlags().VisitAll(translateFlag)
+	cmd.PersistentFlags().VisitAll(translateFlag)
+	for _, sub := range cmd.Commands() {
+		localize(sub)
+	}
+}
+
+// addFetchFlags 注册请求与抽取相关的flags，根命令与fetch子命令共用
+func addFetchFlags(cmd *cobra.Command, o *fetchOptions) {
+	flags := cmd.Flags()
+
 	// 输入相关flags
-	rootCmd.Flags().StringVar(&fromCurl, "from-curl", "", "直接从命令行接收cURL命令")
-	rootCmd.Flags().StringVar(&rawCurl, "raw-curl", "", "接收完整的cURL命令字符串（支��多行格式）")
-	rootCmd.Flags().StringVar(&curlFile, "curl-file", "", "从文件读取cURL命令")
-	rootCmd.Flags().StringVar(&url, "url", "", "请求URL（不使用cURL时必需）")
-	rootCmd.Flags().StringVar(&method, "method", "GET", "请求方法")
-	rootCmd.Flags().StringSliceVar(&headers, "header", []string{}, "请求头，格式为'Key: Value'，可多次使用")
-	rootCmd.Flags().StringVar(&data, "data", "", "请求体数据")
-	rootCmd.Flags().StringVar(&cookies, "cookies", "", "cookies字符串，格式为'key1=value1; key2=value2'")
+	flags.StringVar(&o.fromCurl, "from-curl", "", "直接从命令行接收cURL命令")
+	flags.StringVar(&o.rawCurl, "raw-curl", "", "接收完整的cURL命令字符串（支持多行格式）")
+	flags.StringVar(&o.curlFile, "curl-file", "", "从文件读取cURL命令")
+	flags.BoolVar(&o.fromClipboard, "from-clipboard", false, "从系统剪贴板读取cURL命令（配合浏览器Copy as cURL使用）")
+	flags.StringVar(&o.batchFile, "batch", "", "批量文件，每个非空行（或以---分隔的块）为一个cURL命令")
+	flags.StringVar(&o.batchData, "batch-data", "", "CSV变量文件，每行数据渲染一次cURL模板中的{{.列名}}并执行")
+	flags.BoolVar(&o.resume, "resume", false, "批量执行从上次中断或失败处继续：--out 目录中上次已成功的请求不再执行")
+	flags.StringVar(&o.capture.file, "import", "", "从抓包会话文件导入请求：Charles（.chlsj）、Fiddler（.saz）或 mitmproxy（.flow）")
+	flags.StringVar(&o.capture.filter, "import-filter", "", "按URL子串挑选会话中的请求，多条匹配时使用最后一条")
+	flags.BoolVar(&o.capture.offline, "import-offline", false, "直接使用会话中记录的响应，不重新发送请求")
+	flags.StringVar(&o.chainFile, "chain", "", "链式请求YAML文件，前面步骤提取的变量渲染进后续请求，最后一步的响应用于抽取")
+	flags.StringVar(&o.url, "url", "", "请求URL（不使用cURL时必需）")
+	flags.StringSliceVar(&o.envs, "envs", nil, "将同一请求并发发送到多个环境并输出合并报告，如 staging=https://stg.example.com,prod=https://prod.example.com（替换请求URL的协议与主机，第一个环境为比较基准）")
+	flags.StringVar(&o.method, "method", "GET", "请求方法")
+	flags.StringSliceVar(&o.headers, "header", []string{}, "请求头，格式为'Key: Value'，可多次使用")
+	flags.StringVar(&o.data, "data", "", "请求体数据")
+	flags.StringVar(&o.cookies, "cookies", "", "cookies字符串，格式为'key1=value1; key2=value2'")
 
 	// 输出相关flags
-	rootCmd.Flags().StringVar(&out, "out", "", "输出文件路径（默认为output_{timestamp}.json）")
+	flags.StringVar(&o.out, "out", "", "输出文件路径（默认为output_{timestamp}.json），也可以是 s3://bucket/key 或 gs://bucket/key；批量模式下为输出目录")
+
+	flags.StringVar(&o.format, "format", formatJSON, "输出格式：json（树状JSON）、markdown（Markdown嵌套列表）、testcasemind（还原为TestCaseMind脑图，可修改后重新上传）或 --plugin 加载的 renderer 插件名称")
 
 	// 抽取规则相关flags
-	rootCmd.Flags().StringSliceVar(&titleKeys, "title-key", []string{"case_title", "title", "name", "label"}, "节点内容字段候选键名，按优先级排序")
-	rootCmd.Flags().StringSliceVar(&childrenKeys, "children-keys", []string{"children", "nodes", "sub_cases", "items", "data"}, "子节点数组候选键名，按优先级排序")
+	flags.StringSliceVar(&o.titleKeys, "title-key", extractor.DefaultTitleKeys(), "节点内容字段候选键名，按优先级排序")
+	flags.StringArrayVar(&o.levelTitleKeys, "title-key-level", nil, "指定层级优先使用的节点内容字段候选键名，格式为 层数=键名[,键名]，根节点为第1层，如 '1=module_name' '2=case_title'，可多次使用")
+	flags.StringSliceVar(&o.childrenKeys, "children-keys", extractor.DefaultChildrenKeys(), "子节点数组候选键名，按优先级排序")
+	flags.BoolVar(&o.scalarTitles, "scalar-titles", false, "标题字段为数字或布尔值（如编号、版本号、开关）时也作为节点标题，默认只使用字符串")
+	flags.StringVar(&o.scalarFormat, "scalar-title-format", "", "数字与布尔值标题的模板，{{.key}} 替换为字段名，{{.value}} 替换为值，如 '{{.key}} {{.value}}'；默认直接使用值")
+	flags.StringSliceVar(&o.scalarBool, "scalar-title-bool", nil, "布尔值标题的文字，格式为 真,假，如 '是,否'；默认为 true,false")
+	flags.IntVar(&o.maxTitleLength, "max-title-length", 0, "节点名称的最大字符数，超过时截断并以 … 结尾（0 表示不限制）")
+	flags.IntVar(&o.maxDepth, "max-depth", 0, "树抽取的最大递归深度，更深的子节点被丢弃并在警告与运行报告中记录（0 表示使用默认值 100）")
+	flags.StringVar(&o.textProfile, "text-profile", extractor.ProfileAuto, "业务文本规则：auto（按响应中占多数的语言自动选择）、zh、en、ja、ko")
+	flags.StringVar(&o.onConflict, "on-conflict", string(extractor.ConflictKeepAll), "合并多个JSON文档的根节点时同名节点的处理方式：keep-all（全部保留）、keep-first、keep-last、suffix-index（加序号）、merge-children（逐层合并子节点）")
+	flags.BoolVar(&o.nodeOrder, "with-order", false, "为每个节点输出 order 字段，记录它在兄弟节点中的原始序号（从0开始），便于重新排序后恢复原来的顺序")
+	flags.StringSliceVar(&o.tagFields, "tag-from", nil, "将节点源对象中的这些字段转换为 tags 数组，每个标签为 字段名:值，如 'priority,owner'")
+	flags.IntVar(&o.fromDepth, "from-depth", 1, "只输出从第N层开始的节点（根节点为第1层），第N层的节点成为新的根节点")
+	flags.IntVar(&o.toDepth, "to-depth", 0, "只输出到第N层为止的节点，更深的子节点被丢弃（0 表示不限制）")
+	flags.BoolVar(&o.leavesOnly, "leaves-only", false, "只输出叶子节点（去重后按原顺序排列为多根列表），不保留分组层级")
+	flags.BoolVar(&o.leafPaths, "leaf-paths", false, "配合 --leaves-only 使用，叶子节点名称带上从根节点开始的路径，如 '门店管理 / 搜索 / 按名称搜索'")
+	flags.BoolVar(&o.mergeChildren, "children-merge", false, "合并节点上所有匹配 --children-keys 的子节点数组（按候选键名的顺序），默认只使用第一个")
 
 	// 其他flags
-	rootCmd.Flags().IntVar(&timeout, "timeout", 30, "HTTP请求超时时间（秒）")
-	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "显示详细日志")
+	flags.IntVar(&o.timeout, "timeout", 30, "HTTP请求超时时间（秒）")
+	flags.BoolVar(&o.noRedirects, "no-redirects", false, "不跟随重定向，3xx响应按非2xx状态码处理；默认跟随，次数由cURL命令中的 -L 与 --max-redirs 决定")
+	flags.BoolVarP(&o.verbose, "verbose", "v", false, "显示详细日志")
+	addLogFlags(cmd, &o.log)
+	flags.BoolVarP(&o.interactive, "interactive", "i", false, "写入结果后打开交互式树浏览器")
+	flags.IntVar(&o.preview, "preview", 0, "只在终端预览抽取结果：每层显示前N个节点名称与节点数，不写入输出文件")
+	flags.DurationVar(&o.watchInterval, "watch", 0, "按指定间隔（如30s）重复执行请求并重写输出")
+	flags.BoolVar(&o.watchDiff, "watch-diff", false, "监听模式下每轮打印与上一轮的树结构差异")
+	flags.StringVar(&o.sync, "sync", "", "增量同步：与该文件中上一次的完整结果比较，--out（必填）只写入新增、修改的子树与删除的节点，全部检查通过后以本次的完整结果更新该文件")
+	flags.BoolVar(&o.summaryJSON, "summary-json", false, "结束时向stdout输出一行JSON运行摘要（状态、输出路径、节点数、耗时）")
+	flags.StringVar(&o.historyPath, "history", "", "将本次运行的请求指纹、树与统计信息保存到SQLite数据库，如 ~/.curl2json/history.db（需要sqlite3命令）")
+	flags.StringVar(&o.notifyWebhook, "notify-webhook", "", "运行结束（监听模式为每轮结束）时向该地址POST一条JSON摘要，兼容Slack等incoming webhook")
+	flags.BoolVar(&o.noProgress, "no-progress", false, "不在stderr显示下载进度")
+	flags.BoolVar(&o.failEmpty, "fail-empty", false, "抽取结果为空树时以非零退出码失败（结果文件仍会写入）")
+	flags.StringArrayVar(&o.asserts, "assert", nil, "请求完成后检查响应，如 'status==200'、'$.errCode==0'、'body contains 门店'，可多次使用，任一失败即中止")
+	flags.StringArrayVar(&o.bodySets, "body-set", nil, "发送前修改JSON请求体中的字段，格式为 路径=值，如 'project_id=1024'、'$.page.size=100'，值按JSON解析，可多次使用")
+	flags.StringArrayVar(&o.bodyDeletes, "body-delete", nil, "发送前删除JSON请求体中的字段或数组元素，如 'debug'、'filters[0]'，可多次使用")
+	flags.StringArrayVar(&o.plugins, "plugin", nil, "使用已安装的插件（见 plugin 命令）：strategy 插件代替内置抽取，converter 插件在抽取后调整树，可多次使用")
+	flags.StringArrayVar(&o.decompressFields, "decompress-field", nil, "抽取前还原先经 gzip、zlib 或 zip 压缩再base64编码的字段，如 '$.data.tree'，内容为JSON时替换为解析后的值；路径可用 [*] 与 $..key，在 --response-rewrite 之前执行，可多次使用")
+	flags.BoolVar(&o.protoJSON, "proto-json", false, "抽取前按protobuf JSON约定规整响应：展开 {\"value\": X} 与oneof包装，删除 @type 和 *_UNSPECIFIED 枚举字段，64位整数字符串转换为数字（标题字段除外），在 --decompress-field 之后、--response-rewrite 之前执行")
+	flags.StringArrayVar(&o.rewrites, "response-rewrite", nil, "抽取前改写响应JSON：'delete:路径'、'rename:路径=新键名' 或 'move:路径=目标路径'，路径可用 [*] 与 $..key，按顺序执行，可多次使用")
+	flags.StringArrayVar(&o.groupBy, "group-by", nil, "抽取前按字段值将数组元素分组为中间节点，如 '$.data.cases[*].module'；同一数组的多条规则逐层嵌套分组，在 --response-rewrite 之后执行，可多次使用")
+	flags.StringVar(&o.postProcess, "post-process", "", "抽取后、写入前对树执行的脚本：.js（node，导出以树为参数的函数）、.jq（jq过滤器）或 .cel（以 tree 为变量的CEL表达式）")
+	flags.BoolVar(&o.validateOutput, "validate-output", false, "写入前按内置结构校验输出（节点仅含name字符串和children数组）")
+	flags.StringVar(&o.keyStyleName, "key-style", "", "输出JSON的键名风格：snake（如 duration_ms，默认）、camel（durationMs）或 kebab（duration-ms），用于结果、--summary-json、--report、--envs 与 --sync 的输出")
+	flags.StringVar(&o.waitFor, "wait-for", "", "重复请求直到响应满足条件后再抽取，语法同 --assert，如 '$.data.status==\"done\"'")
+	flags.DurationVar(&o.pollInterval, "poll-interval", 5*time.Second, "--wait-for 的重试间隔")
+	flags.DurationVar(&o.pollTimeout, "poll-timeout", 5*time.Minute, "--wait-for 的最长等待时间，超时以退出码10失败")
+	flags.BoolVar(&o.retryUnsafe, "retry-unsafe", false, "允许 --wait-for 与 --watch 重复发送POST、PATCH等非幂等请求")
+	flags.StringVar(&o.idempotencyKey, "idempotency-key", "", "每次执行前在指定名称的请求头（通常为 Idempotency-Key）中写入新生成的幂等键，轮询时沿用同一个键，并允许重复发送非幂等请求")
+	flags.StringVar(&o.reportPath, "report", "", "将本次运行的请求（已脱敏）、响应状态与耗时、校验与抽取情况写入JSON报告文件")
+	flags.StringVar(&o.debugBundle, "debug-bundle", "", "将运行报告、原始响应、debug日志与运行环境打包写入zip文件（凭据已脱敏），便于反馈问题")
+	flags.StringVar(&o.debugDir, "debug-dir", "", "抽取失败时保存原始响应 debug_response_*.json 的目录（默认仅在 --verbose 时保存到系统临时目录）")
+	flags.StringVar(&o.saveHeaders, "save-headers", "", "将响应头与Set-Cookie下发的cookie写入JSON文件，便于在后续cURL中使用新的会话cookie")
+	flags.BoolVar(&o.redactHeaders, "redact-headers", false, "--save-headers 写入时隐藏cookie的值与可能携带凭据的响应头")
+	flags.StringVar(&o.redactRules, "redact-rules", "", "脱敏规则文件（YAML或JSON），在内置规则之外指定视为凭据的请求头名称、请求体JSONPath与正则表达式，作用于详细日志、运行报告、调试包与 --redact-headers")
+	flags.StringVar(&o.saveRaw, "save-raw", "", "将未经转换的原始响应体写入文件，之后可以用 --replay-raw 换用其他参数离线重新抽取")
+	flags.StringVar(&o.saveFixture, "save-fixture", "", "将请求与响应录制为夹具文件写入该目录，供 mock 命令的模拟服务应答")
+	flags.StringVar(&o.saveSchema, "save-schema", "", "将响应每一层对象出现的键写入JSON文件，作为之后 --baseline-schema 的基线")
+	flags.StringVar(&o.baselineSchema, "baseline-schema", "", "与 --save-schema 保存的基线比较响应结构，某一层新增或缺少键时输出警告（不影响抽取）")
+	flags.StringVar(&o.manifest, "manifest", "", "将产物清单（各输出文件的SHA-256、请求URL的哈希与工具版本）写入JSON文件，便于审计生成的文档")
+	flags.StringVar(&o.replayRaw, "replay-raw", "", "不发送请求，使用 --save-raw 保存的响应体重新抽取（按扩展名识别 .xml、.yaml、.ndjson、.html，其他按JSON处理）")
+	flags.StringVar(&o.authRefresh.curl, "auth-refresh-curl", "", "请求返回401/419时执行的刷新cURL命令，提取新凭据写入原请求后重试一次")
+	flags.StringVar(&o.authRefresh.token, "auth-refresh-token", "", "新token在刷新响应中的JSONPath，如 '$.data.token'")
+	flags.StringVar(&o.authRefresh.into, "auth-refresh-into", auth.DefaultTarget, "新token的写入位置，如 'header:x-jwt-token' 或 'cookie:sid'，可用 {{.token}} 指定值模板")
+	addPublishFlags(cmd, &o.publish)
+	addLimitFlags(cmd, &o.limits, limitOptions{})
+}
 
-	// 重要：禁用 Cobra 的默认解析行为，防止它错误解析 cURL 命令中的参数
-	rootCmd.DisableFlagParsing = false
+// addLogFlags 注册日志相关flags，日志统一写入stderr
+func addLogFlags(cmd *cobra.Command, o *logOptions) {
+	flags := cmd.Flags()
+	flags.StringVar(&o.level, "log-level", "", "日志级别：debug、info、warn、error（默认info，--verbose时为debug）")
+	flags.StringVar(&o.format, "log-format", logger.FormatText, "日志格式：text 或 json")
+	flags.BoolVarP(&o.quiet, "quiet", "q", false, "静默模式，仅输出错误")
+}
+
+// addLimitFlags 注册响应体安全上限flags，defaults 为各上限的默认值
+func addLimitFlags(cmd *cobra.Command, o *limitOptions, defaults limitOptions) {
+	flags := cmd.Flags()
+	flags.Int64Var(&o.maxResponseSize, "max-response-size", defaults.maxResponseSize, "响应体最大字节数，超过时校验失败（0 表示不限制）")
+	flags.IntVar(&o.maxJSONDepth, "max-json-depth", defaults.maxJSONDepth, "响应JSON最大嵌套深度（0 表示不限制）")
+	flags.IntVar(&o.maxStringLength, "max-string-length", defaults.maxStringLength, "响应JSON中单个字符串的最大字节数（0 表示不限制）")
+}
+
+// apply 设置了刷新请求时为配置注册认证刷新回调
+func (o *authRefreshOptions) apply(cfg *config.Config) error {
+	if o.curl == "" {
+		if o.token != "" {
+			return i18n.Errorf("--auth-refresh-token 需要与 --auth-refresh-curl 一起使用")
+		}
+		return nil
+	}
+	refresher, err := auth.New(o.curl, o.token, o.into)
+	if err != nil {
+		return err
+	}
+
+	// 刷新请求不经过断言、轮询等钩子，也不会再次触发刷新
+	fetcher := processor.New(&config.Config{
+		Timeout:         cfg.Timeout,
+		Logger:          cfg.Logger,
+		MaxResponseSize: cfg.MaxResponseSize,
+		MaxJSONDepth:    cfg.MaxJSONDepth,
+		MaxStringLength: cfg.MaxStringLength,
+	})
+	cfg.AuthRefresh = func(ctx context.Context, req *config.RequestInfo) error {
+		return refresher.Refresh(ctx, fetcher, req)
+	}
+	return nil
+}
+
+// apply 将安全上限写入配置
+func (o *limitOptions) apply(cfg *config.Config) {
+	cfg.MaxResponseSize = o.maxResponseSize
+	cfg.MaxJSONDepth = o.maxJSONDepth
+	cfg.MaxStringLength = o.maxStringLength
+}
+
+// progressWriter 返回下载进度的输出位置，仅在stderr为终端且未静默时显示
+func (o *fetchOptions) progressWriter() io.Writer {
+	if o.log.quiet || o.noProgress || !isatty.IsTerminal(os.Stderr.Fd()) {
+		return nil
+	}
+	return os.Stderr
+}
+
+// newLogger 根据 --log-level/--log-format/--verbose/--quiet 创建写入stderr的日志器
+func (o *logOptions) newLogger(verbose bool) (*slog.Logger, error) {
+	level := o.level
+	if level == "" && verbose {
+		level = "debug"
+	}
+	if o.quiet {
+		level = "error"
+	}
+	return logger.New(os.Stderr, level, o.format)
 }
 
-func runRoot(cmd *cobra.Command, args []string) error {
+func (o *fetchOptions) runRoot(cmd *cobra.Command, args []string) error {
+	if o.log.quiet || o.summaryJSON {
+		cmd.SilenceUsage = true
+	}
+
+	start := time.Now()
+	summary, err := o.runFetch(cmd, args)
+	if o.summaryJSON {
+		printRunSummary(summary, err, start, o.keyStyle)
+	}
+	// 监听模式在每轮结束时发送通知
+	if o.notifier != nil && o.watchInterval <= 0 {
+		if log, logErr := o.log.newLogger(o.verbose); logErr == nil {
+			o.notify(cmd.Context(), o.runEvent(summary), err, start, log)
+		}
+	}
+	return err
+}
+
+// runFetch 执行一次完整的转换流程，单次与批量模式返回运行摘要
+func (o *fetchOptions) runFetch(cmd *cobra.Command, args []string) (*runSummary, error) {
+	// `--` 之后的参数原样作为cURL命令，不经过Cobra的flag解析
+	if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+		o.passthroughCurl = joinCurlArgs(args[dash:])
+		args = args[:dash]
+	}
+
 	// 特殊处理：如果使用 --from-curl 参数，但存在额外参数，将它们合并到 fromCurl 中
-	if fromCurl != "" && len(args) > 0 {
+	if o.fromCurl != "" && len(args) > 0 {
 		// 将额外的参数追加到 fromCurl 命令中
-		fromCurl = fromCurl + " " + strings.Join(args, " ")
+		o.fromCurl = o.fromCurl + " " + strings.Join(args, " ")
 	}
 
 	// 验证输入���数
-	if err := validateInput(); err != nil {
-		return err
+	if err := o.validateInput(); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	if err := o.checkFlagRules(); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	batchMode := o.batchMode()
+	keyStyle, err := keystyle.Parse(o.keyStyleName)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	o.keyStyle = keyStyle
+	if _, ok := extractor.LookupTextProfile(o.textProfile); !ok {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--text-profile 只能是 auto 或 %s", strings.Join(extractor.TextProfiles(), "、")))
+	}
+	if !slices.Contains(extractor.ConflictStrategies(), o.onConflict) {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--on-conflict 只能是 %s", strings.Join(extractor.ConflictStrategies(), "、")))
+	}
+	if o.maxTitleLength < 0 {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--max-title-length 不能为负数"))
+	}
+	if o.maxDepth < 0 {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--max-depth 不能为负数"))
+	}
+	if o.fromDepth < 1 || o.toDepth < 0 {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--from-depth 必须大于0，--to-depth 不能为负数"))
+	}
+	if o.toDepth > 0 && o.toDepth < o.fromDepth {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--to-depth (%d) 不能小于 --from-depth (%d)", o.toDepth, o.fromDepth))
+	}
+	if len(o.scalarBool) > 0 && len(o.scalarBool) != 2 {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("无效的 --scalar-title-bool %q，格式应为 真,假", strings.Join(o.scalarBool, ",")))
+	}
+	if o.preview < 0 {
+		return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--preview 不能为负数"))
+	}
+	if len(o.envs) > 0 {
+		environments, err := parseEnvs(o.envs)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		o.environments = environments
+	}
+	if o.sync != "" {
+		if err := checkSyncOut(o.sync, o.out); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		base, err := loadSyncBase(o.sync)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		o.syncBase = base
+	}
+	if objstore.IsRemote(o.out) {
+		if batchMode {
+			return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("批量模式下 --out 为输出目录，不支持对象存储地址"))
+		}
+		if err := objstore.Check(o.out); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+
+	log, err := o.log.newLogger(o.verbose)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	if o.notifyWebhook != "" {
+		if o.notifier, err = notify.New(o.notifyWebhook); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+	if o.historyPath != "" {
+		store, err := history.Open(expandHome(o.historyPath))
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		o.historyRecorder = store.Recorder()
+	}
+	var redactRules *redact.Rules
+	if o.redactRules != "" {
+		if redactRules, err = redact.Load(o.redactRules); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+	redact.Use(redactRules)
+	// 调试包记录完整的debug日志，不受 --log-level/--quiet 影响
+	var bundle *report.Bundle
+	if o.debugBundle != "" {
+		bundle = report.NewBundle()
+		log = bundle.Logger(log)
+	}
+	if o.debugDir != "" {
+		if err := os.MkdirAll(o.debugDir, 0o755); err != nil {
+			return nil, exitcode.Errorf(exitcode.Usage, i18n.T("创建调试目录失败: %w"), err)
+		}
+	}
+
+	levelTitleKeys, err := parseLevelTitleKeys(o.levelTitleKeys)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
 	}
 
 	// 构建配置
 	cfg := &config.Config{
-		Timeout:      time.Duration(timeout) * time.Second,
-		TitleKeys:    titleKeys,
-		ChildrenKeys: childrenKeys,
-		Verbose:      verbose,
+		Timeout:           time.Duration(o.timeout) * time.Second,
+		NoRedirects:       o.noRedirects,
+		TitleKeys:         o.titleKeys,
+		LevelTitleKeys:    levelTitleKeys,
+		ChildrenKeys:      o.childrenKeys,
+		MergeChildren:     o.mergeChildren,
+		ScalarTitles:      o.scalarTitles,
+		ScalarTitleFormat: o.scalarFormat,
+		MaxTitleLength:    o.maxTitleLength,
+		MaxDepth:          o.maxDepth,
+		NodeOrder:         o.nodeOrder,
+		TagFields:         o.tagFields,
+		TextProfile:       o.textProfile,
+		OnConflict:        o.onConflict,
+		Verbose:           o.verbose,
+		Logger:            log,
+		Progress:          o.progressWriter(),
+		ValidateOutput:    o.validateOutput,
+		KeyStyle:          string(o.keyStyle),
+		DebugDir:          o.debugDir,
+	}
+	if len(o.scalarBool) == 2 {
+		cfg.ScalarTitleTrue, cfg.ScalarTitleFalse = o.scalarBool[0], o.scalarBool[1]
+	}
+	o.limits.apply(cfg)
+
+	assertions, err := assert.ParseAll(o.asserts)
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	if o.bodyEdits, err = bodyedit.ParseAll(o.bodySets, o.bodyDeletes); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	publisher, err := o.publish.newPublisher()
+	if err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	if o.loadedPlugins, err = loadPlugins(o.plugins); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	// renderer 插件加载后才能确定可选的输出格式
+	if err := checkFormat(o.format); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
+	}
+	var script *postprocess.Script
+	if o.postProcess != "" {
+		if script, err = postprocess.Load(o.postProcess); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+	if o.waitFor != "" {
+		if o.pollInterval <= 0 || o.pollTimeout <= 0 {
+			return nil, exitcode.Wrap(exitcode.Usage, i18n.Errorf("--poll-interval 和 --poll-timeout 必须大于0"))
+		}
+		condition, err := assert.Parse(o.waitFor)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		cfg.WaitFor = func(statusCode int, body []byte) bool {
+			return condition.Check(statusCode, body).Passed
+		}
+		cfg.PollInterval, cfg.PollTimeout = o.pollInterval, o.pollTimeout
+	}
+	cfg.RetryUnsafe, cfg.IdempotencyKey = o.retryUnsafe, o.idempotencyKey
+	if len(o.decompressFields) > 0 || o.protoJSON || len(o.rewrites) > 0 || len(o.groupBy) > 0 {
+		var decompressRules []*rewrite.Rule
+		for _, path := range o.decompressFields {
+			rule, err := rewrite.Decompress(path)
+			if err != nil {
+				return nil, exitcode.Wrap(exitcode.Usage, err)
+			}
+			decompressRules = append(decompressRules, rule)
+		}
+		rules, err := rewrite.ParseAll(o.rewrites)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		var normalizer *protojson.Normalizer
+		if o.protoJSON {
+			keep := append([]string{}, cfg.TitleKeys...)
+			for _, keys := range cfg.LevelTitleKeys {
+				keep = append(keep, keys...)
+			}
+			normalizer = protojson.New(keep...)
+		}
+		var grouper *group.Grouper
+		if len(o.groupBy) > 0 {
+			if grouper, err = group.New(o.groupBy, firstOr(cfg.TitleKeys, extractor.DefaultTitleKeys()), firstOr(cfg.ChildrenKeys, extractor.DefaultChildrenKeys())); err != nil {
+				return nil, exitcode.Wrap(exitcode.Usage, err)
+			}
+		}
+		cfg.RewriteResponse = func(body []byte) ([]byte, error) {
+			body, err := applyRewrites(body, decompressRules, log)
+			if err != nil {
+				return nil, err
+			}
+			if normalizer != nil {
+				if body, err = normalizer.Normalize(body); err != nil {
+					return nil, err
+				}
+			}
+			if body, err = applyRewrites(body, rules, log); err != nil || grouper == nil {
+				return body, err
+			}
+			body, unmatched, err := grouper.Apply(body)
+			for _, rule := range unmatched {
+				log.Warn(i18n.T("分组规则没有分组任何元素"), "rule", rule.String())
+			}
+			return body, err
+		}
+	}
+	if err := o.authRefresh.apply(cfg); err != nil {
+		return nil, exitcode.Wrap(exitcode.Usage, err)
 	}
 
 	// 获取输入源
 	var input string
-	var err error
+	var chainFile *chain.File
+	var captured *capture.Entry
 
 	switch {
-	case rawCurl != "":
-		input = rawCurl
-		if verbose {
-			fmt.Println("使用 --raw-curl 参数接收完整cURL命令")
-			fmt.Printf("完整cURL命令: %s\n", input)
-		}
-	case fromCurl != "":
-		input = fromCurl
-		if verbose {
-			fmt.Println("从命令行参数读取cURL命令")
-			fmt.Printf("完整cURL命令: %s\n", input)
-		}
-	case curlFile != "":
-		input, err = readFromFile(curlFile)
+	case o.rawCurl != "":
+		input = o.rawCurl
+		log.Debug(i18n.T("使用 --raw-curl 参数接收完整cURL命令"), "curl", redact.Text(input))
+	case o.passthroughCurl != "":
+		input = o.passthroughCurl
+		log.Debug(i18n.T("使用 -- 之后的参数作为cURL命令"), "curl", redact.Text(input))
+	case o.fromCurl != "":
+		input = o.fromCurl
+		log.Debug(i18n.T("从命令行参数读取cURL命令"), "curl", redact.Text(input))
+	case o.curlFile != "":
+		input, err = readFromFile(o.curlFile)
 		if err != nil {
-			return fmt.Errorf("读取cURL文件失败: %w", err)
+			return nil, exitcode.Errorf(exitcode.Usage, i18n.T("读取cURL文件失败: %w"), err)
 		}
-		if verbose {
-			fmt.Printf("从文件读取cURL命令: %s\n", curlFile)
+		log.Debug(i18n.T("从文件读取cURL命令"), "file", o.curlFile)
+		cfg.CurlDir = filepath.Dir(o.curlFile)
+	case o.batchFile != "":
+		// 批量文件在 runBatch 中读取
+		cfg.CurlDir = filepath.Dir(o.batchFile)
+	case o.chainFile != "":
+		// 最后一步的cURL命令在前面的步骤执行完成后才能确定
+		chainFile, err = chain.Load(o.chainFile)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
 		}
-	case url != "":
+		log.Debug(i18n.T("从文件读取链式请求"), "file", o.chainFile, "steps", len(chainFile.Steps))
+	case o.capture.file != "":
+		captured, err = o.capture.pick(log)
+		if err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	case o.fromClipboard:
+		input, err = clipboard.Read()
+		if err != nil {
+			return nil, i18n.Errorf("读取剪贴板失败: %w", err)
+		}
+		log.Debug(i18n.T("从剪贴板读取cURL命令"))
+	case o.url != "":
 		// 直接使用参数模式，不需要cURL
 		input = ""
-		if verbose {
-			fmt.Printf("使用参数模式: %s %s\n", method, url)
-		}
+		log.Debug(i18n.T("使用参数模式"), "method", o.method, "url", o.url)
 	default:
 		// 从stdin读取
 		input, err = readFromStdin()
 		if err != nil {
-			return fmt.Errorf("从stdin读取失败: %w", err)
-		}
-		if verbose {
-			fmt.Println("从stdin读取cURL命令")
+			return nil, i18n.Errorf("从stdin读取失败: %w", err)
 		}
+		log.Debug(i18n.T("从stdin读取cURL命令"))
+	}
+
+	var manifest *report.ManifestRecorder
+	if o.manifest != "" {
+		manifest = report.NewManifest()
+	}
+	if batchMode {
+		return o.runBatch(cmd.Context(), cfg, input, assertions, script, manifest)
 	}
 
-	// 设置默认输出文件
-	if out == "" {
+	// 设置默认输出文件，check 命令只在指定 --out 时写入，预览时不写入
+	if o.out == "" && o.golden.path == "" && o.preview == 0 {
 		timestamp := time.Now().Format("20060102_150405")
-		out = fmt.Sprintf("output_%s.json", timestamp)
+		o.out = fmt.Sprintf("output_%s%s", timestamp, formatExt(o.format))
+	}
+
+	if captured != nil && o.capture.offline {
+		if cfg.Transport, err = captured.Replay(); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+	}
+	if o.replayRaw != "" {
+		if cfg.Transport, err = replayRaw(o.replayRaw); err != nil {
+			return nil, exitcode.Wrap(exitcode.Usage, err)
+		}
+		log.Debug(i18n.T("使用保存的原始响应，不发送请求"), "file", o.replayRaw)
 	}
 
 	// 创建处理器并执行
 	processor := processor.New(cfg)
+	// 插件、后处理脚本、层级截取、--leaves-only 与结构跟踪先于报告钩子注册，报告中的抽取统计与警告为处理后的结果
+	registerPlugins(processor.Hooks(), o.loadedPlugins)
+	if script != nil {
+		processor.Hooks().After(pipeline.StageExtract, script.Hook())
+	}
+	if o.fromDepth > 1 || o.toDepth > 0 {
+		processor.Hooks().After(pipeline.StageExtract, depthHook(o.fromDepth, o.toDepth))
+	}
+	if o.leavesOnly {
+		processor.Hooks().After(pipeline.StageExtract, leavesHook(o.leafPaths))
+	}
+	if o.saveSchema != "" || o.baselineSchema != "" {
+		var baseline *drift.Schema
+		if o.baselineSchema != "" {
+			if baseline, err = drift.Load(o.baselineSchema); err != nil {
+				return nil, exitcode.Wrap(exitcode.Usage, err)
+			}
+		}
+		drift.NewTracker(baseline, o.saveSchema, log).Register(processor.Hooks())
+	}
+	// 请求体修改先于报告钩子注册，报告与调试包中记录的是实际发送的请求
+	if len(o.bodyEdits) > 0 {
+		processor.Hooks().After(pipeline.StageParse, bodyedit.Hook(o.bodyEdits))
+	}
+	// 报告、响应头与原始响应钩子先于断言注册，断言失败时响应信息也已记录
+	var recorder *report.Recorder
+	if o.reportPath != "" || bundle != nil || o.historyRecorder != nil {
+		recorder = report.New()
+		recorder.Register(processor.Hooks())
+	}
+	if o.historyRecorder != nil {
+		o.historyRecorder.Register(processor.Hooks())
+	}
+	if bundle != nil {
+		bundle.Register(processor.Hooks())
+	}
+	if o.saveHeaders != "" {
+		processor.Hooks().After(pipeline.StageExecute, report.HeadersHook(o.saveHeaders, o.redactHeaders))
+	}
+	if o.saveRaw != "" {
+		processor.Hooks().After(pipeline.StageExecute, saveRawHook(o.saveRaw))
+	}
+	if o.saveFixture != "" {
+		processor.Hooks().After(pipeline.StageExecute, mock.RecordHook(o.saveFixture))
+	}
+	if manifest != nil {
+		manifest.Register(processor.Hooks())
+	}
+	if len(assertions) > 0 {
+		processor.Hooks().After(pipeline.StageExecute, assert.Hook(assertions))
+	}
+	requestInfo := &config.RequestInfo{
+		URL:     o.url,
+		Method:  o.method,
+		Headers: parseHeaders(o.headers),
+		Cookies: parseCookies(o.cookies),
+		Body:    o.data,
+	}
+	if captured != nil {
+		requestInfo = captured.Request
+	}
 
-	result, err := processor.Process(input, &config.RequestInfo{
-		URL:     url,
-		Method:  method,
-		Headers: parseHeaders(headers),
-		Cookies: parseCookies(cookies),
-		Body:    data,
-	})
+	if chainFile != nil {
+		input, _, err = chainFile.Resolve(cmd.Context(), processor, log)
+		if err != nil {
+			return nil, o.finishDiagnostics(recorder, bundle, nil, err, log)
+		}
+	}
 
-	if err != nil {
-		return err
+	if len(o.environments) > 0 {
+		return o.runEnvs(cmd.Context(), processor, input, requestInfo, log)
 	}
 
-	// 写入输出文件
-	if err := writeOutput(out, result); err != nil {
-		return err
+	if o.watchInterval > 0 {
+		if err := processor.CheckRepeatable(input, requestInfo, "--watch"); err != nil {
+			return nil, err
+		}
+		return nil, o.runWatch(cmd.Context(), processor, input, requestInfo, log)
+	}
+
+	summary, result, err := o.runOnce(cmd.Context(), processor, input, requestInfo, log)
+	if err == nil && publisher != nil {
+		summary.Publish, err = o.publish.run(cmd.Context(), publisher, result, log)
+	}
+	if err := o.finishDiagnostics(recorder, bundle, summary, err, log); err != nil {
+		return summary, err
+	}
+	if manifest != nil {
+		if err := o.writeManifest(cmd.Context(), manifest, result, log); err != nil {
+			return summary, exitcode.Wrap(exitcode.OutputWrite, err)
+		}
 	}
 
-	fmt.Printf("成功将结果写入文件: %s\n", out)
+	if o.interactive {
+		return nil, browse(result)
+	}
+	if o.preview > 0 {
+		if err := printPreview(os.Stdout, result, o.preview); err != nil {
+			return summary, err
+		}
+	}
+	return summary, nil
+}
+
+// finishDiagnostics 写入 --report 报告、--debug-bundle 调试包与 --history 记录，返回本次运行最终的错误；
+// 运行已经失败时写入失败只记录警告，不覆盖原来的错误
+func (o *fetchOptions) finishDiagnostics(recorder *report.Recorder, bundle *report.Bundle, summary *runSummary, runErr error, log *slog.Logger) error {
+	if recorder == nil {
+		return runErr
+	}
+	if summary != nil {
+		recorder.AddOutput(summary.Output)
+	}
+
+	err := o.writeDiagnostics(recorder.Finish(runErr), bundle, log)
+	if err == nil {
+		return runErr
+	}
+	if runErr == nil {
+		return exitcode.Wrap(exitcode.OutputWrite, err)
+	}
+	log.Warn(err.Error())
+	return runErr
+}
+
+// writeDiagnostics 按参数写入报告文件、调试包与运行历史
+func (o *fetchOptions) writeDiagnostics(rep *report.Report, bundle *report.Bundle, log *slog.Logger) error {
+	if o.reportPath != "" {
+		if err := rep.WriteFile(o.reportPath, o.keyStyle); err != nil {
+			return err
+		}
+		log.Info(i18n.T("已写入运行报告"), "path", o.reportPath)
+	}
+	if bundle != nil {
+		if err := bundle.WriteFile(o.debugBundle, rep); err != nil {
+			return err
+		}
+		log.Info(i18n.T("已写入调试包"), "path", o.debugBundle)
+	}
+	if o.historyRecorder != nil {
+		id, err := o.historyRecorder.Save(context.Background(), rep)
+		if err != nil {
+			return err
+		}
+		if id > 0 {
+			log.Info(i18n.T("已记录运行历史"), "id", id, "path", o.historyPath)
+		}
+	}
+	return nil
+}
+
+// writeManifest 记录本次运行写入的各个产物并写入 --manifest 清单，只在运行成功后调用
+func (o *fetchOptions) writeManifest(ctx context.Context, manifest *report.ManifestRecorder, result []byte, log *slog.Logger) error {
+	if objstore.IsRemote(o.out) {
+		// 已上传的结果无法从本地读取，按上传的内容计算哈希
+		content, err := renderOutput(ctx, result, o.format)
+		if err != nil {
+			return err
+		}
+		manifest.AddContent(report.ArtifactOutput, o.out, content)
+	} else {
+		manifest.AddFile(report.ArtifactOutput, o.out)
+	}
+	manifest.AddFile(report.ArtifactReport, o.reportPath)
+	manifest.AddFile(report.ArtifactDebugBundle, o.debugBundle)
+	manifest.AddFile(report.ArtifactRawResponse, o.saveRaw)
+	manifest.AddFile(report.ArtifactHeaders, o.saveHeaders)
+	if err := manifest.WriteFile(o.manifest); err != nil {
+		return err
+	}
+	log.Info(i18n.T("已写入产物清单"), "path", o.manifest)
 	return nil
 }
 
-func validateInput() error {
+// runOnce 执行一次转换并写入输出文件，写入成功后返回的摘要非nil
+func (o *fetchOptions) runOnce(ctx context.Context, p *processor.Processor, input string, requestInfo *config.RequestInfo, log *slog.Logger) (*runSummary, []byte, error) {
+	result, err := p.Process(ctx, input, requestInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 通知中包含与覆盖前输出文件的差异
+	var previous []*extractor.SimplifiedNode
+	if o.notifier != nil && o.format == formatJSON && o.sync == "" {
+		previous = readPreviousNodes(o.out)
+	}
+
+	// --sync 时输出文件写入增量，写入成功且全部检查通过后再更新同步基准
+	output := result
+	if o.sync != "" {
+		if output, err = o.syncDelta(result, log); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// 写入输出文件
+	if o.out != "" {
+		if err := writeOutput(ctx, o.out, output, o.format); err != nil {
+			return nil, nil, exitcode.Errorf(exitcode.OutputWrite, i18n.T("写入输出文件失败: %w"), err)
+		}
+		log.Info(i18n.T("成功将结果写入文件"), "path", o.out)
+	}
+	summary := &runSummary{Output: o.out, Nodes: countResultNodes(result)}
+	if previous != nil {
+		if current, err := extractor.ParseNodes(result); err == nil {
+			stats := treediff.Count(treediff.Compare(previous, current))
+			summary.Diff = &stats
+		}
+	}
+	if o.golden.path != "" {
+		if err := o.golden.check(result, log); err != nil {
+			return summary, result, err
+		}
+	}
+	if o.failEmpty && summary.Nodes == 0 {
+		return summary, result, exitcode.Wrap(exitcode.EmptyTree, errs.Mark(i18n.Errorf("抽取结果为空树: %s", o.out), errs.ErrEmptyTree))
+	}
+	// 全部检查通过后才更新同步基准，失败的运行下次仍与原来的基准比较
+	if o.sync != "" {
+		if err := writeSyncBase(o.sync, result); err != nil {
+			return summary, result, exitcode.Errorf(exitcode.OutputWrite, i18n.T("更新同步基准文件失败: %w"), err)
+		}
+		log.Debug(i18n.T("已更新同步基准文件"), "path", o.sync)
+	}
+	return summary, result, nil
+}
+
+func (o *fetchOptions) validateInput() error {
 	// 检查是否有输入
 	inputCount := 0
-	if rawCurl != "" {
+	if o.rawCurl != "" {
+		inputCount++
+	}
+	if o.fromCurl != "" {
+		inputCount++
+	}
+	if o.passthroughCurl != "" {
+		inputCount++
+	}
+	if o.curlFile != "" {
+		inputCount++
+	}
+	if o.fromClipboard {
+		inputCount++
+	}
+	if o.batchFile != "" {
 		inputCount++
 	}
-	if fromCurl != "" {
+	if o.chainFile != "" {
 		inputCount++
 	}
-	if curlFile != "" {
+	if o.capture.file != "" {
 		inputCount++
 	}
-	if url != "" {
+	if o.url != "" {
 		inputCount++
 	}
 
 	if inputCount == 0 {
-		return fmt.Errorf("必须指定一种输入方式：--raw-curl, --from-curl, --curl-file, --url, 或者从stdin提供cURL命令")
+		return i18n.Errorf("必须指定一种输入方式：--raw-curl, --from-curl, --curl-file, --from-clipboard, --batch, --chain, --import, --url, -- curl ..., 或者从stdin提供cURL命令")
 	}
 
 	if inputCount > 1 {
-		return fmt.Errorf("只能指定一种输入方式")
+		return i18n.Errorf("只能指定一种输入方式")
 	}
 
 	return nil
 }
 
-func readFromFile(filename string) (string, error) {
-	content, err := os.ReadFile(filename)
-	if err != nil {
-		return "", err
-	}
-	return strings.TrimSpace(string(content)), nil
-}
-
 func readFromStdin() (string, error) {
 	var content []byte
 	buf := make([]byte, 1024)
@@ -224,7 +1085,76 @@ func readFromStdin() (string, error) {
 			break
 		}
 	}
-	return strings.TrimSpace(string(content)), nil
+	text, err := decodeText(content)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// joinCurlArgs 将shell已拆分的参数重新拼接为cURL命令字符串，必要时补充引号
+func joinCurlArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch {
+		case arg == "":
+			quoted = append(quoted, "''")
+		case !strings.ContainsAny(arg, " \t\n'\"\\"):
+			quoted = append(quoted, arg)
+		case !strings.Contains(arg, "'"):
+			quoted = append(quoted, "'"+arg+"'")
+		default:
+			escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg)
+			quoted = append(quoted, `"`+escaped+`"`)
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// parseLevelTitleKeys 解析 --title-key-level 的 层数=键名[,键名] 列表，同一层多次指定时后者覆盖前者
+func parseLevelTitleKeys(values []string) (map[int][]string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	levels := make(map[int][]string, len(values))
+	for _, value := range values {
+		rawLevel, rawKeys, ok := strings.Cut(value, "=")
+		level, err := strconv.Atoi(strings.TrimSpace(rawLevel))
+		if !ok || err != nil || level < 1 {
+			return nil, i18n.Errorf("无效的 --title-key-level %q，格式应为 层数=键名[,键名]，层数从1开始", value)
+		}
+		var keys []string
+		for _, key := range strings.Split(rawKeys, ",") {
+			if key = strings.TrimSpace(key); key != "" {
+				keys = append(keys, key)
+			}
+		}
+		if len(keys) == 0 {
+			return nil, i18n.Errorf("--title-key-level %q 没有指定键名", value)
+		}
+		levels[level] = keys
+	}
+	return levels, nil
+}
+
+// applyRewrites 执行响应重写规则，没有规则时原样返回；没有匹配到任何字段的规则输出警告
+func applyRewrites(body []byte, rules []*rewrite.Rule, log *slog.Logger) ([]byte, error) {
+	if len(rules) == 0 {
+		return body, nil
+	}
+	result, unmatched, err := rewrite.Apply(body, rules)
+	for _, rule := range unmatched {
+		log.Warn(i18n.T("响应重写规则没有匹配到任何字段"), "rule", rule.String())
+	}
+	return result, err
+}
+
+// firstOr 返回keys的第一个元素，keys为空时返回fallback的第一个元素
+func firstOr(keys, fallback []string) string {
+	if len(keys) > 0 {
+		return keys[0]
+	}
+	return fallback[0]
 }
 
 func parseHeaders(headerSlice []string) map[string]string {
@@ -265,6 +1195,31 @@ func parseCookies(cookieStr string) map[string]string {
 	return cookies
 }
 
-func writeOutput(filename string, content []byte) error {
+// writeOutput 按 --format 渲染树状JSON并写入输出文件，s3:// 与 gs:// 地址上传到对象存储
+func writeOutput(ctx context.Context, filename string, result []byte, format string) error {
+	content, err := renderOutput(ctx, result, format)
+	if err != nil {
+		return err
+	}
+	if objstore.IsRemote(filename) {
+		return objstore.Upload(ctx, filename, content, formatContentType(format))
+	}
 	return os.WriteFile(filename, content, 0644)
-}
\ No newline at end of file
+}
+
+// pick 读取抓包会话文件并按 --import-filter 挑选一条请求
+func (c *captureOptions) pick(log *slog.Logger) (*capture.Entry, error) {
+	entries, err := capture.Load(c.file)
+	if err != nil {
+		return nil, err
+	}
+	entry, matched, err := capture.Pick(entries, c.filter)
+	if err != nil {
+		return nil, err
+	}
+	if matched > 1 {
+		log.Warn(i18n.T("抓包文件中有多条请求匹配，使用最后一条"), "matched", matched, "url", entry.Request.URL)
+	}
+	log.Debug(i18n.T("从抓包文件导入请求"), "file", c.file, "method", entry.Request.Method, "url", entry.Request.URL)
+	return entry, nil
+}
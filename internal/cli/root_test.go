@@ -0,0 +1,751 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/mock"
+	"github.com/wellkilo/Curl2json/internal/report"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+const testCaseMindResponse = `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[]}]}"}}`
+
+// execute 每次构建新的命令树并执行
+func execute(args ...string) error {
+	cmd := newRootCmd()
+	cmd.SetArgs(args)
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	return cmd.Execute()
+}
+
+// TestExecute_Isolated 多次执行之间不共享参数：第二次执行未带认证请求头，应当失败
+func TestExecute_Isolated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-jwt-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	first := filepath.Join(dir, "first.json")
+	if err := execute("--url", server.URL, "--header", "x-jwt-token: token", "--out", first, "-q", "--no-progress"); err != nil {
+		t.Fatalf("first Execute() error = %v", err)
+	}
+	if _, err := os.Stat(first); err != nil {
+		t.Errorf("first output not written: %v", err)
+	}
+
+	second := filepath.Join(dir, "second.json")
+	err := execute("--url", server.URL, "--out", second, "-q", "--no-progress")
+	if got := exitcode.From(err); got != exitcode.HTTPStatus {
+		t.Errorf("second Execute() exit code = %d (%v), want %d", got, err, exitcode.HTTPStatus)
+	}
+}
+
+func TestExecute_MultipleInputs(t *testing.T) {
+	err := execute("--url", "http://127.0.0.1", "--from-curl", "curl http://127.0.0.1", "-q")
+	if got := exitcode.From(err); got != exitcode.Usage {
+		t.Errorf("Execute() exit code = %d (%v), want %d", got, err, exitcode.Usage)
+	}
+}
+
+func TestExecute_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	golden := filepath.Join(t.TempDir(), "testdata", "cases.golden.json")
+	check := func(extra ...string) error {
+		return execute(append([]string{"check", "--golden", golden, "--url", server.URL, "-q", "--no-progress"}, extra...)...)
+	}
+
+	if err := check(); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("golden文件不存在时 exit code = %d (%v), want %d", exitcode.From(err), err, exitcode.Usage)
+	}
+	if err := check("--update"); err != nil {
+		t.Fatalf("check --update error = %v", err)
+	}
+	if err := check(); err != nil {
+		t.Errorf("check error = %v", err)
+	}
+
+	// 只有空白差异时视为一致
+	os.WriteFile(golden, []byte(`{"name":" 客户详情-门店列表 ","children":[{"name":"门店搜索\n"}]}`), 0o644)
+	if err := check(); err != nil {
+		t.Errorf("check with whitespace-only changes error = %v", err)
+	}
+
+	os.WriteFile(golden, []byte(`{"name":"客户详情-门店列表","children":[{"name":"门店搜索"},{"name":"门店详情"}]}`), 0o644)
+	if err := check(); exitcode.From(err) != exitcode.Drift {
+		t.Errorf("check with drift exit code = %d (%v), want %d", exitcode.From(err), err, exitcode.Drift)
+	}
+
+	if outputs, _ := filepath.Glob("output_*.json"); len(outputs) > 0 {
+		t.Errorf("未指定 --out 时不应写入输出文件: %v", outputs)
+	}
+}
+
+func TestExecute_Format(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tests := map[string]string{
+		"markdown":     "- 客户详情-门店列表\n  - 门店搜索\n",
+		"testcasemind": `"TestCaseMind": "{\"data\":{\"text\":\"客户详情-门店列表\"`,
+	}
+	for format, want := range tests {
+		out := filepath.Join(dir, format)
+		if err := execute("--url", server.URL, "--format", format, "--out", out, "-q", "--no-progress"); err != nil {
+			t.Fatalf("--format %s error = %v", format, err)
+		}
+		content, _ := os.ReadFile(out)
+		if !strings.Contains(string(content), want) {
+			t.Errorf("--format %s output = %s, want %s", format, content, want)
+		}
+	}
+
+	if err := execute("--url", server.URL, "--format", "yaml", "-q"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("unknown format exit code = %d (%v), want %d", exitcode.From(err), err, exitcode.Usage)
+	}
+}
+
+func TestExecute_Envs(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+	)
+	newServer := func(response string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			paths = append(paths, r.URL.RequestURI())
+			mu.Unlock()
+			fmt.Fprint(w, response)
+		}))
+	}
+	staging := newServer(strings.Replace(testCaseMindResponse, "门店搜索", "门店筛选", 1))
+	defer staging.Close()
+	prod := newServer(testCaseMindResponse)
+	defer prod.Close()
+
+	out := filepath.Join(t.TempDir(), "envs.json")
+	err := execute("--url", "https://api.example.com/case/detail?id=1", "--envs", "prod="+prod.URL+",staging="+staging.URL,
+		"--out", out, "-q", "--no-progress")
+	if err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	for _, path := range paths {
+		if path != "/case/detail?id=1" {
+			t.Errorf("request path = %s, want the original path and query", path)
+		}
+	}
+
+	var rep envsReport
+	content, _ := os.ReadFile(out)
+	if err := json.Unmarshal(content, &rep); err != nil {
+		t.Fatalf("report %s: %v", content, err)
+	}
+	if len(rep.Environments) != 2 || rep.Environments[0].Name != "prod" || rep.Environments[1].Status != statusSuccess {
+		t.Errorf("environments = %+v", rep.Environments)
+	}
+	if len(rep.Diffs) != 1 || rep.Diffs[0].Stats.Added != 1 || rep.Diffs[0].Stats.Removed != 1 {
+		t.Errorf("diffs = %+v, want one added and one removed node", rep.Diffs)
+	}
+
+	for _, envs := range []string{"prod=" + prod.URL, "prod=" + prod.URL + "/api,staging=" + staging.URL} {
+		if err := execute("--url", "https://api.example.com/", "--envs", envs, "-q"); exitcode.From(err) != exitcode.Usage {
+			t.Errorf("--envs %s exit code = %d (%v), want %d", envs, exitcode.From(err), err, exitcode.Usage)
+		}
+	}
+}
+
+func TestExecute_ImportOffline(t *testing.T) {
+	dir := t.TempDir()
+	body, _ := json.Marshal(testCaseMindResponse)
+	// 端口1没有服务监听，回放模式下不应发出请求
+	session := `[{"method":"GET","scheme":"http","host":"127.0.0.1","port":1,"path":"/case/list",
+  "request":{"header":{"headers":[]}},
+  "response":{"status":200,"header":{"headers":[{"name":"Content-Type","value":"application/json"}]},"body":{"text":` + string(body) + `}}}]`
+	sessionFile := filepath.Join(dir, "session.chlsj")
+	os.WriteFile(sessionFile, []byte(session), 0o644)
+
+	out := filepath.Join(dir, "out.json")
+	if err := execute("--import", sessionFile, "--import-filter", "/case/", "--import-offline", "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	content, err := os.ReadFile(out)
+	if err != nil || !strings.Contains(string(content), "门店搜索") {
+		t.Errorf("output = %s (%v), want extracted tree", content, err)
+	}
+
+	if err := execute("--import", sessionFile, "--import-filter", "/order/", "--out", out, "-q"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("no matching request exit code = %d (%v), want %d", exitcode.From(err), err, exitcode.Usage)
+	}
+}
+
+func TestExecute_SaveHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "fresh-session", Path: "/"})
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	headers := filepath.Join(dir, "headers.json")
+	if err := execute("--url", server.URL, "--out", filepath.Join(dir, "out.json"), "--save-headers", headers, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	content, err := os.ReadFile(headers)
+	if err != nil || !strings.Contains(string(content), `"cookie_header": "sid=fresh-session"`) {
+		t.Errorf("headers = %s (%v), want the session cookie", content, err)
+	}
+
+	if err := execute("--url", server.URL, "--redact-headers", "-q"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--redact-headers without --save-headers exit code = %d (%v), want %d", exitcode.From(err), err, exitcode.Usage)
+	}
+}
+
+func TestExecute_SaveRawReplay(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	url := server.URL
+
+	dir := t.TempDir()
+	raw := filepath.Join(dir, "raw.json")
+	if err := execute("--url", url, "--out", filepath.Join(dir, "out.json"), "--save-raw", raw, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	if content, _ := os.ReadFile(raw); string(content) != testCaseMindResponse {
+		t.Errorf("raw response = %s, want the untouched body", content)
+	}
+
+	// 服务关闭后使用保存的响应体换用其他参数重新抽取
+	server.Close()
+	out := filepath.Join(dir, "replay.md")
+	if err := execute("--url", url, "--replay-raw", raw, "--format", "markdown", "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("replay error = %v", err)
+	}
+	if content, _ := os.ReadFile(out); !strings.Contains(string(content), "- 客户详情-门店列表") {
+		t.Errorf("replay output = %s", content)
+	}
+}
+
+func TestExecute_DynamicPlaceholders(t *testing.T) {
+	var gotHeader, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotHeader, gotBody = r.Header.Get("X-Request-Id"), string(body)
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	err := execute("--url", server.URL, "--method", "POST", "--header", "X-Request-Id: {{uuid}}",
+		"--data", `{"nonce":"{{random:8}}","ts":{{now:unixms}}}`, "--out", filepath.Join(t.TempDir(), "out.json"), "-q", "--no-progress")
+	if err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	if len(gotHeader) != 36 || strings.Contains(gotHeader, "{{") {
+		t.Errorf("X-Request-Id = %q, want a UUID", gotHeader)
+	}
+	var body struct {
+		Nonce string `json:"nonce"`
+		TS    int64  `json:"ts"`
+	}
+	if err := json.Unmarshal([]byte(gotBody), &body); err != nil || len(body.Nonce) != 8 || body.TS == 0 {
+		t.Errorf("request body = %s (%v), want resolved placeholders", gotBody, err)
+	}
+}
+
+func TestExecute_BodyEdit(t *testing.T) {
+	var gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	out := filepath.Join(t.TempDir(), "out.json")
+	err := execute("--url", server.URL, "--method", "POST", "--data", `{"project_id":1,"page":{"size":20},"debug":true}`,
+		"--body-set", "project_id=1024", "--body-set", "$.page.size=100", "--body-delete", "debug", "--out", out, "-q", "--no-progress")
+	if err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	if want := `{"page":{"size":100},"project_id":1024}`; gotBody != want {
+		t.Errorf("request body = %s, want %s", gotBody, want)
+	}
+
+	err = execute("--url", server.URL, "--method", "POST", "--data", `{"page":{}}`, "--body-delete", "debug", "--out", out, "-q", "--no-progress")
+	if exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--body-delete of a missing key error = %v, want a usage error", err)
+	}
+	if err := execute("--url", server.URL, "--body-set", "project_id", "--out", out); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--body-set without a value error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_ResponseRewrite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Replace(testCaseMindResponse, `"data":`, `"payload":`, 1))
+	}))
+	defer server.Close()
+
+	out := filepath.Join(t.TempDir(), "out.json")
+	if err := execute("--url", server.URL, "--out", out, "-q", "--no-progress"); exitcode.From(err) != exitcode.Validation {
+		t.Fatalf("execute without rewrite error = %v, want a validation error", err)
+	}
+	if err := execute("--url", server.URL, "--response-rewrite", "move:$.payload=$.data", "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	result, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(result), "门店搜索") {
+		t.Errorf("output = %s, want the rewritten tree", result)
+	}
+
+	if err := execute("--url", server.URL, "--response-rewrite", "drop:$.payload", "--out", out); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--response-rewrite with an unknown operation error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_LeavesOnly(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	out := filepath.Join(t.TempDir(), "out.json")
+	if err := execute("--url", server.URL, "--leaves-only", "--leaf-paths", "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	result, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "客户详情-门店列表 / 门店搜索" {
+		t.Errorf("output = %s, want the single leaf with its path", result)
+	}
+
+	if err := execute("--url", server.URL, "--leaf-paths", "--out", out); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--leaf-paths without --leaves-only error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_FromDepth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	out := filepath.Join(t.TempDir(), "out.json")
+	if err := execute("--url", server.URL, "--from-depth", "2", "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	result, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(nodes) != 1 || nodes[0].Name != "门店搜索" {
+		t.Errorf("output = %s, want the second level as roots", result)
+	}
+
+	if err := execute("--url", server.URL, "--from-depth", "3", "--to-depth", "2", "--out", out); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--to-depth below --from-depth error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_Manifest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.json")
+	raw := filepath.Join(dir, "raw.json")
+	manifestPath := filepath.Join(dir, "manifest.json")
+	if err := execute("--url", server.URL, "--out", out, "--save-raw", raw, "--manifest", manifestPath, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	content, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest report.Manifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if len(manifest.Sources) != 1 || len(manifest.Artifacts) != 2 {
+		t.Fatalf("manifest = %s, want one source and two artifacts", content)
+	}
+	result, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256(result)
+	if artifact := manifest.Artifacts[0]; artifact.Kind != report.ArtifactOutput || artifact.Path != out || artifact.SHA256 != hex.EncodeToString(sum[:]) {
+		t.Errorf("output artifact = %+v, want sha256 of %s", artifact, out)
+	}
+	if manifest.Artifacts[1].Kind != report.ArtifactRawResponse {
+		t.Errorf("second artifact = %+v, want the raw response", manifest.Artifacts[1])
+	}
+
+	if err := execute("--url", server.URL, "--manifest", manifestPath, "--watch", "1s"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--manifest with --watch error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_SaveFixtureAndMock(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	fixtures := filepath.Join(dir, "fixtures")
+	recorded := filepath.Join(dir, "recorded.json")
+	if err := execute("--url", server.URL+"/cases?id=1&token=abc", "--save-fixture", fixtures, "--out", recorded, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	loaded, err := mock.Load(fixtures)
+	if err != nil {
+		t.Fatalf("mock.Load() error = %v", err)
+	}
+	mockServer := httptest.NewServer(mock.New(loaded, logger.Discard()))
+	defer mockServer.Close()
+
+	mocked := filepath.Join(dir, "mocked.json")
+	if err := execute("--url", mockServer.URL+"/cases?token=rotated&id=1", "--out", mocked, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute against mock error = %v", err)
+	}
+	want, _ := os.ReadFile(recorded)
+	got, _ := os.ReadFile(mocked)
+	if string(got) != string(want) {
+		t.Errorf("output from mock = %s, want %s", got, want)
+	}
+
+	if err := execute("mock", "--listen", ":0"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("mock without --fixtures error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_ResumeRequiresBatch(t *testing.T) {
+	if err := execute("--url", "http://example.com", "--resume"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--resume without --batch error = %v, want a usage error", err)
+	}
+	batchFile := filepath.Join(t.TempDir(), "batch.txt")
+	if err := os.WriteFile(batchFile, []byte("curl 'http://example.com'\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("--batch", batchFile, "--resume"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--resume without --out error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_BaselineSchema(t *testing.T) {
+	response := testCaseMindResponse
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.json")
+	baseline := filepath.Join(dir, "schema.json")
+	if err := execute("--url", server.URL, "--out", out, "--save-schema", baseline, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+
+	// 上游新增了 traceId，抽取仍然成功，报告中记录结构变化的警告
+	response = strings.Replace(testCaseMindResponse, `{"errCode":0,`, `{"errCode":0,"traceId":"t-1",`, 1)
+	reportPath := filepath.Join(dir, "report.json")
+	if err := execute("--url", server.URL, "--out", out, "--baseline-schema", baseline, "--report", reportPath, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute with --baseline-schema error = %v", err)
+	}
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep report.Report
+	if err := json.Unmarshal(content, &rep); err != nil {
+		t.Fatal(err)
+	}
+	if rep.Extraction == nil || len(rep.Extraction.Warnings) != 1 || !strings.Contains(rep.Extraction.Warnings[0], "traceId") {
+		t.Errorf("report warnings = %+v, want a drift warning about traceId", rep.Extraction)
+	}
+
+	if err := execute("--url", server.URL, "--baseline-schema", filepath.Join(dir, "missing.json")); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("missing baseline error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_MaxDepth(t *testing.T) {
+	response := `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[{\"data\":{\"text\":\"输入存在的门店名称\"},\"children\":[]}]}]}"}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "report.json")
+	if err := execute("--url", server.URL, "--out", filepath.Join(dir, "out.json"), "--max-depth", "1", "--report", reportPath, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep report.Report
+	if err := json.Unmarshal(content, &rep); err != nil {
+		t.Fatal(err)
+	}
+	want := &extractor.DepthLimit{MaxDepth: 1, Hits: 1, DeepestPath: []string{"客户详情-门店列表", "门店搜索"}}
+	if rep.Extraction == nil || !reflect.DeepEqual(rep.Extraction.DepthLimit, want) || len(rep.Extraction.Warnings) != 1 {
+		t.Errorf("report extraction = %+v, want depth limit %+v and one warning", rep.Extraction, want)
+	}
+
+	if err := execute("--url", server.URL, "--max-depth", "-1"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("negative --max-depth error = %v, want a usage error", err)
+	}
+}
+
+func TestExecute_Preview(t *testing.T) {
+	if err := execute("--url", "http://example.com", "--preview", "1", "--out", filepath.Join(t.TempDir(), "out.json")); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("--preview with --out error = %v, want a usage error", err)
+	}
+
+	result := []byte(`{"name":"门店","children":[{"name":"搜索","children":[]},{"name":"详情\n页","children":[{"name":"编辑","children":[]}]}]}`)
+	var out strings.Builder
+	if err := printPreview(&out, result, 1); err != nil {
+		t.Fatalf("printPreview() error = %v", err)
+	}
+	want := "第1层（1 个节点）: 门店\n第2层（2 个节点）: 搜索 …（另有 1 个）\n第3层（1 个节点）: 编辑\n共 4 个节点，2 个叶子节点，最大 3 层\n"
+	if out.String() != want {
+		t.Errorf("printPreview() = %q, want %q", out.String(), want)
+	}
+}
+
+func TestReadFromFile_WindowsEncodings(t *testing.T) {
+	const want = "curl 'https://api.example.com/cases' \\\n  -H 'x-jwt-token: 令牌'"
+	crlf := strings.ReplaceAll(want, "\n", "\r\n") + "\r\n"
+
+	utf16le := []byte{0xFF, 0xFE}
+	utf16be := []byte{0xFE, 0xFF}
+	var noBOM []byte
+	for _, unit := range utf16.Encode([]rune(crlf)) {
+		utf16le = append(utf16le, byte(unit), byte(unit>>8))
+		utf16be = append(utf16be, byte(unit>>8), byte(unit))
+		noBOM = append(noBOM, byte(unit), byte(unit>>8))
+	}
+
+	files := map[string][]byte{
+		"utf8.txt":       []byte(want),
+		"crlf.txt":       []byte(crlf),
+		"bom.txt":        append([]byte{0xEF, 0xBB, 0xBF}, crlf...),
+		"utf16le.txt":    utf16le,
+		"utf16be.txt":    utf16be,
+		"utf16nobom.txt": noBOM,
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name)
+			if err := os.WriteFile(path, content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readFromFile(path)
+			if err != nil || got != want {
+				t.Errorf("readFromFile() = %q, %v, want %q", got, err, want)
+			}
+		})
+	}
+
+	path := filepath.Join(dir, "gbk.txt")
+	os.WriteFile(path, []byte{0xC3, 0xC5, 0xB5, 0xEA}, 0o644)
+	if _, err := readFromFile(path); err == nil {
+		t.Error("readFromFile() error = nil, want encoding error")
+	}
+}
+
+func TestExecute_Sync(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	base, out := filepath.Join(dir, "base.json"), filepath.Join(dir, "delta.json")
+	readDelta := func() treediff.Delta {
+		t.Helper()
+		var delta treediff.Delta
+		content, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(content, &delta); err != nil {
+			t.Fatalf("output %s is not a delta: %v", content, err)
+		}
+		return delta
+	}
+
+	// 首次同步：基准文件不存在，整棵树为新增，随后写入基准文件
+	if err := execute("--url", server.URL, "--sync", base, "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	if delta := readDelta(); len(delta.Added) != 1 || len(delta.Added[0].Parent) != 0 || len(delta.Removed) != 0 {
+		t.Errorf("first sync = %+v, want the whole tree added", delta)
+	}
+	content, err := os.ReadFile(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if nodes, err := extractor.ParseNodes(content); err != nil || len(nodes) != 1 {
+		t.Fatalf("sync base = %s, want the full tree", content)
+	}
+
+	// 基准文件中多一个根节点、少一个子节点
+	if err := os.WriteFile(base, []byte(`[{"name":"客户详情-门店列表","children":[]},{"name":"旧模块","children":[]}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("--url", server.URL, "--sync", base, "--out", out, "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	delta := readDelta()
+	if len(delta.Added) != 1 || strings.Join(delta.Added[0].Parent, "/") != "客户详情-门店列表" || delta.Added[0].Node.Name != "门店搜索" {
+		t.Errorf("added = %+v, want 门店搜索 under the root", delta.Added)
+	}
+	if len(delta.Removed) != 1 || strings.Join(delta.Removed[0].Path, "/") != "旧模块" {
+		t.Errorf("removed = %+v, want 旧模块", delta.Removed)
+	}
+
+	// 检查失败时不更新基准文件，下次仍与原来的基准比较
+	before, err := os.ReadFile(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join(dir, "golden.json")
+	if err := os.WriteFile(golden, []byte(`[{"name":"其他模块","children":[]}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := execute("check", "--golden", golden, "--url", server.URL, "--sync", base, "--out", out, "-q", "--no-progress"); err == nil {
+		t.Fatal("check with a mismatched golden file should fail")
+	}
+	if after, err := os.ReadFile(base); err != nil || string(after) != string(before) {
+		t.Errorf("sync base = %s, want it unchanged after a failed check", after)
+	}
+
+	for _, args := range [][]string{
+		{"--url", server.URL, "--sync", base, "--format", "markdown", "--out", out},
+		{"check", "--golden", golden, "--url", server.URL, "--sync", base},
+		{"--url", server.URL, "--sync", base, "--out", base},
+	} {
+		if err := execute(args...); exitcode.From(err) != exitcode.Usage {
+			t.Errorf("--sync with %v error = %v, want a usage error", args, err)
+		}
+	}
+}
+
+func TestExecute_KeyStyle(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	out, reportPath := filepath.Join(dir, "out.json"), filepath.Join(dir, "report.json")
+	if err := execute("--url", server.URL, "--out", out, "--report", reportPath, "--key-style", "camel", "--with-order", "--validate-output", "-q", "--no-progress"); err != nil {
+		t.Fatalf("execute error = %v", err)
+	}
+	content, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rep map[string]interface{}
+	if err := json.Unmarshal(content, &rep); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rep["durationMs"]; !ok {
+		t.Errorf("report keys = %s, want camelCase durationMs", content)
+	}
+	if strings.Contains(string(content), "duration_ms") {
+		t.Errorf("report still contains snake_case keys: %s", content)
+	}
+	if result, err := os.ReadFile(out); err != nil || !strings.Contains(string(result), `"order": 0`) {
+		t.Errorf("output = %s, %v, want single-word tree keys unchanged", result, err)
+	}
+
+	if err := execute("--url", server.URL, "--key-style", "pascal"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("invalid --key-style error = %v, want a usage error", err)
+	}
+}
+
+func TestFetchOptions_CheckFlagRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    fetchOptions
+		wantErr string
+	}{
+		{name: "无冲突", opts: fetchOptions{out: "out.json", leavesOnly: true, leafPaths: true}},
+		{name: "监听与交互", opts: fetchOptions{watchInterval: time.Second, interactive: true}, wantErr: "--watch 与 --interactive"},
+		{name: "批量与报告", opts: fetchOptions{batchFile: "batch.txt", reportPath: "report.json"}, wantErr: "--report 不能与"},
+		{name: "缺少依赖的参数", opts: fetchOptions{leafPaths: true}, wantErr: "--leaf-paths 需要配合"},
+		{name: "断点续跑需要批量", opts: fetchOptions{resume: true, out: "dir"}, wantErr: "--resume 需要配合"},
+		{name: "多环境与格式", opts: fetchOptions{envs: []string{"a=1"}, format: render.Markdown}, wantErr: "--envs 输出合并报告"},
+		{name: "预览与输出文件", opts: fetchOptions{preview: 3, out: "out.json"}, wantErr: "--preview 不写入输出文件"},
+		{name: "按顺序报告第一条", opts: fetchOptions{watchInterval: time.Second, interactive: true, summaryJSON: true}, wantErr: "--watch 与 --interactive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.opts.format == "" {
+				tt.opts.format = formatJSON
+			}
+			err := tt.opts.checkFlagRules()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkFlagRules() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkFlagRules() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/server"
+)
+
+// newServeCmd 以HTTP服务方式提供转换能力
+func newServeCmd() *cobra.Command {
+	var listenAddr, ingestDir string
+	opts := &serviceOptions{}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "启动HTTP服务，提供 POST /convert 转换接口",
+		Long: `启动HTTP服务，供Web前端或其他服务复用转换能力，无需调用命令行。
+
+接口：
+  POST /convert  请求体 {"curl": "curl ...", "options": {"title_keys": [...], "children_keys": [...], "timeout": 30}}
+                 请求头 Content-Type 须为 application/json，只接受本机请求；成功返回树状JSON，失败返回 {"error": "..."}
+  POST /render   请求体 {"tree": <树状JSON>, "format": "json|markdown|testcasemind"}，返回渲染后的文件内容
+  POST /ingest   接收浏览器扩展从开发者工具捕获的请求（HAR条目），只接受本机请求，返回树状JSON
+  GET  /healthz  健康检查
+
+浏览器访问 / 可以打开Web界面：粘贴cURL、调整抽取选项、交互式预览树并下载JSON或Markdown。`,
+		Example: `  ./caseurl2md serve                    # 浏览器打开 http://localhost:8080/
+  curl -X POST localhost:8080/convert -H 'Content-Type: application/json' -d '{"curl":"curl https://api.example.com/cases -H \"x-jwt-token: xxx\""}'`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runServe(cmd, opts, listenAddr, ingestDir)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&listenAddr, "listen", "127.0.0.1:8080", "监听地址，默认只监听本机")
+	flags.StringVar(&ingestDir, "ingest-dir", "", "将 /ingest 的结果另存到该目录（文件名为output_{timestamp}.json）")
+	addServiceFlags(cmd, opts)
+	flags.BoolVarP(&opts.verbose, "verbose", "v", false, "显示详细日志")
+	addLogFlags(cmd, &opts.log)
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, opts *serviceOptions, listenAddr, ingestDir string) error {
+	cfg, err := opts.config()
+	if err != nil {
+		return err
+	}
+	log := cfg.Logger
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info(i18n.T("HTTP服务已启动，浏览器访问 / 使用Web界面，POST /convert 进行转换"), "listen", listenAddr)
+	if err := server.New(cfg, server.WithIngestDir(ingestDir)).ListenAndServe(ctx, listenAddr); err != nil {
+		return err
+	}
+	log.Info(i18n.T("HTTP服务已退出"))
+	return nil
+}
@@ -0,0 +1,29 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+)
+
+// 以下钩子在 extract 阶段之后改变树的形状，需在插件与后处理脚本之后注册，它们仍然收到完整的树
+
+// depthHook 返回只保留第from到第to层节点的钩子（--from-depth、--to-depth）
+func depthHook(from, to int) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		if state.Tree != nil {
+			state.Tree.SliceDepth(from, to)
+		}
+		return nil
+	}
+}
+
+// leavesHook 返回将树替换为去重后的叶子节点列表的钩子（--leaves-only），在 depthHook 之后注册
+func leavesHook(withPath bool) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		if state.Tree != nil {
+			state.Tree.FlattenLeaves(withPath)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/keystyle"
+	"github.com/wellkilo/Curl2json/internal/publish"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// 运行状态
+const (
+	statusSuccess = "success"
+	statusError   = "error"
+)
+
+// runSummary --summary-json 输出的单行运行摘要
+type runSummary struct {
+	Status     string          `json:"status"`
+	Output     string          `json:"output,omitempty"`
+	Nodes      int             `json:"nodes"`
+	Succeeded  int             `json:"succeeded,omitempty"` // 批量模式成功的请求数
+	Failed     int             `json:"failed,omitempty"`    // 批量模式失败的请求数
+	Diff       *treediff.Stats `json:"diff,omitempty"`      // 与覆盖前输出文件的差异，仅在发送通知时计算
+	Publish    *publish.Result `json:"publish,omitempty"`
+	DurationMs int64           `json:"duration_ms"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// humanOutput 是否向stdout输出面向人的提示信息，--quiet 或 --summary-json 时stdout只保留机器可读内容
+func (o *fetchOptions) humanOutput() bool {
+	return !o.log.quiet && !o.summaryJSON
+}
+
+// printRunSummary 按键名风格向stdout输出一行JSON摘要，err 非nil时状态为error
+func printRunSummary(summary *runSummary, err error, start time.Time, style keystyle.Style) {
+	if summary == nil {
+		summary = &runSummary{}
+	}
+	summary.Status = statusSuccess
+	if err != nil {
+		summary.Status = statusError
+		summary.Error = err.Error()
+	}
+	summary.DurationMs = time.Since(start).Milliseconds()
+
+	content, marshalErr := json.Marshal(summary)
+	if marshalErr == nil {
+		content, marshalErr = style.Convert(content)
+	}
+	if marshalErr != nil {
+		return
+	}
+	fmt.Println(string(content))
+}
+
+// countResultNodes 统计抽取结果中的节点数，结果无法解析时返回0
+func countResultNodes(result []byte) int {
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		return 0
+	}
+	return extractor.CountNodes(nodes)
+}
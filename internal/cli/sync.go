@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/objstore"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// loadSyncBase 读取 --sync 指定的上一次的完整结果，文件不存在时视为空树（首次同步时全部节点为新增）
+func loadSyncBase(path string) ([]*extractor.SimplifiedNode, error) {
+	if objstore.IsRemote(path) {
+		return nil, i18n.Errorf("--sync 只支持本地文件")
+	}
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取同步基准文件失败: %w", err)
+	}
+	nodes, err := extractor.ParseNodes(content)
+	if err != nil {
+		return nil, i18n.Errorf("同步基准文件 %s 不是树状JSON: %w", path, err)
+	}
+	return nodes, nil
+}
+
+// checkSyncOut 检查 --sync 与 --out 的组合：增量只写入 --out，同步基准不能与之为同一个文件
+func checkSyncOut(sync, out string) error {
+	if out == "" {
+		return i18n.Errorf("--sync 需要配合 --out 使用")
+	}
+	if objstore.IsRemote(out) {
+		return nil
+	}
+	syncPath, err := filepath.Abs(sync)
+	if err != nil {
+		return err
+	}
+	outPath, err := filepath.Abs(out)
+	if err != nil {
+		return err
+	}
+	if syncPath == outPath {
+		return i18n.Errorf("--sync 与 --out 不能是同一个文件")
+	}
+	return nil
+}
+
+// writeSyncBase 以本次的完整结果替换同步基准文件：先写入同目录下的临时文件再重命名，中途失败时原文件不变
+func writeSyncBase(path string, result []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(result); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// syncDelta 返回本次结果相对于同步基准的增量JSON，键名按 --key-style 转换
+func (o *fetchOptions) syncDelta(result []byte, log *slog.Logger) ([]byte, error) {
+	current, err := extractor.ParseNodes(result)
+	if err != nil {
+		return nil, err
+	}
+	delta := treediff.Sync(o.syncBase, current)
+	log.Info(i18n.T("增量同步"), "base", o.sync, "summary", delta.String())
+	content, err := json.MarshalIndent(delta, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return o.keyStyle.Convert(content)
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 字节序标记
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// readFromFile 读取文本文件并去除首尾空白，兼容Windows编辑器保存的文件：
+// UTF-8 BOM、UTF-16（记事本的"Unicode"编码）与CRLF换行
+func readFromFile(filename string) (string, error) {
+	// Windows下超过MAX_PATH的路径只有转换为绝对路径后才会自动加上 \\?\ 前缀
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return "", err
+	}
+	text, err := decodeText(content)
+	if err != nil {
+		return "", i18n.Errorf("%s: %w", filename, err)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// decodeText 按BOM或内容识别UTF-8/UTF-16编码并转换为UTF-8，CRLF与单独的CR统一为LF
+func decodeText(content []byte) (string, error) {
+	var text string
+	switch {
+	case bytes.HasPrefix(content, bomUTF8):
+		text = string(content[len(bomUTF8):])
+	case bytes.HasPrefix(content, bomUTF16LE):
+		text = decodeUTF16(content[len(bomUTF16LE):], binary.LittleEndian)
+	case bytes.HasPrefix(content, bomUTF16BE):
+		text = decodeUTF16(content[len(bomUTF16BE):], binary.BigEndian)
+	default:
+		if order, ok := guessUTF16(content); ok {
+			text = decodeUTF16(content, order)
+		} else {
+			text = string(content)
+		}
+	}
+
+	if !utf8.ValidString(text) {
+		return "", i18n.Errorf("文件不是UTF-8或UTF-16编码的文本，请以UTF-8编码重新保存")
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n"), nil
+}
+
+// decodeUTF16 按字节序解码UTF-16，末尾多出的单个字节被忽略
+func decodeUTF16(content []byte, order binary.ByteOrder) string {
+	units := make([]uint16, len(content)/2)
+	for i := range units {
+		units[i] = order.Uint16(content[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
+
+// guessUTF16 识别没有BOM的UTF-16文本：cURL命令以ASCII字符为主，
+// UTF-16编码后奇数（小端）或偶数（大端）位置上的字节大多为0，另一侧几乎没有0
+func guessUTF16(content []byte) (binary.ByteOrder, bool) {
+	if len(content) < 4 || len(content)%2 != 0 {
+		return nil, false
+	}
+	var evenZeros, oddZeros int
+	for i := 0; i < len(content); i += 2 {
+		if content[i] == 0 {
+			evenZeros++
+		}
+		if content[i+1] == 0 {
+			oddZeros++
+		}
+	}
+	half := len(content) / 2
+	switch {
+	case oddZeros*2 > half && evenZeros*4 < oddZeros:
+		return binary.LittleEndian, true
+	case evenZeros*2 > half && oddZeros*4 < evenZeros:
+		return binary.BigEndian, true
+	default:
+		return nil, false
+	}
+}
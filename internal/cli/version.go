@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/version"
+)
+
+// newVersionCmd 输出构建信息，便于在问题反馈中附上准确版本
+func newVersionCmd() *cobra.Command {
+	var checkUpdate, asJSON bool
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "显示版本与构建信息",
+		Example: `  ./caseurl2md version
+  ./caseurl2md version --check-update`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runVersion(cmd, checkUpdate, asJSON)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&checkUpdate, "check-update", false, "查询最新发布版本并提示是否需要升级")
+	flags.BoolVar(&asJSON, "json", false, "以JSON格式输出构建信息")
+	return cmd
+}
+
+func runVersion(cmd *cobra.Command, checkUpdate, asJSON bool) error {
+	info := version.Get()
+	if asJSON {
+		content, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(content))
+	} else {
+		fmt.Println(info.String())
+	}
+
+	if !checkUpdate {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	latest, err := version.Latest(ctx, http.DefaultClient)
+	if err != nil {
+		return err
+	}
+	if version.Compare(info.Version, latest) < 0 {
+		fmt.Printf(i18n.T("发现新版本 %s（当前 %s），请前往 https://github.com/wellkilo/Curl2json/releases 下载\n"), latest, info.Version)
+	} else {
+		fmt.Printf(i18n.T("当前已是最新版本（最新发布: %s）\n"), latest)
+	}
+	return nil
+}
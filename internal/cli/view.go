@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/tui"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// newViewCmd 在终端中交互式浏览已生成的树状JSON
+func newViewCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "view <result.json>",
+		Short: "在终端中交互式浏览树状JSON",
+		Long: `打开终端界面浏览树状JSON结果，支持展开/折叠、搜索和复制节点路径。
+
+按键：
+  ↑/↓ 或 j/k   移动光标
+  →/l ←/h      展开/折叠（折叠状态下←跳到父节点）
+  enter/空格   切换展开状态
+  e / c        全部展开 / 全部折叠
+  /            搜索，n/N 跳转下一个/上一个匹配
+  y            复制当前节点路径到剪贴板
+  q            退出`,
+		Example: `  ./caseurl2md view result.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return i18n.Errorf("读取结果文件失败: %w", err)
+			}
+			return browse(content)
+		},
+	}
+}
+
+// browse 解析树状JSON并打开交互式浏览器
+func browse(content []byte) error {
+	roots, err := extractor.ParseNodes(content)
+	if err != nil {
+		return err
+	}
+	return tui.Run(roots)
+}
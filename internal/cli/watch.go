@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/notify"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// runWatch 按固定间隔重复执行请求并重写输出文件，直到收到中断信号
+func (o *fetchOptions) runWatch(ctx context.Context, p *processor.Processor, input string, requestInfo *config.RequestInfo, log *slog.Logger) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info(i18n.T("进入监听模式，按 Ctrl+C 退出"), "interval", o.watchInterval)
+
+	ticker := time.NewTicker(o.watchInterval)
+	defer ticker.Stop()
+
+	var previous []*extractor.SimplifiedNode
+	for cycle := 1; ; cycle++ {
+		previous = o.watchCycle(ctx, p, input, requestInfo, cycle, previous, log)
+
+		select {
+		case <-ctx.Done():
+			log.Info(i18n.T("监听模式已退出"))
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// watchCycle 执行一轮抓取，失败时仅记录错误并保留上一轮结果；差异输出到stdout
+func (o *fetchOptions) watchCycle(ctx context.Context, p *processor.Processor, input string, requestInfo *config.RequestInfo, cycle int, previous []*extractor.SimplifiedNode, log *slog.Logger) []*extractor.SimplifiedNode {
+	start := time.Now()
+	timestamp := start.Format("15:04:05")
+
+	result, err := p.Process(ctx, input, requestInfo)
+	if ctx.Err() != nil {
+		// 收到中断信号时进行中的请求被取消，不视为本轮失败
+		return previous
+	}
+
+	// 每轮结束时发送通知，失败的轮次同样通知
+	event := &notify.Event{Mode: notify.ModeWatch, Cycle: cycle}
+	if o.notifier != nil {
+		defer func() { o.notify(ctx, event, err, start, log) }()
+	}
+
+	if err != nil {
+		log.Error(i18n.T("本轮执行失败"), "time", timestamp, "cycle", cycle, "error", err)
+		return previous
+	}
+
+	if err = writeOutput(ctx, o.out, result, o.format); err != nil {
+		log.Error(i18n.T("本轮写入失败"), "time", timestamp, "cycle", cycle, "error", err)
+		return previous
+	}
+
+	current, err := extractor.ParseNodes(result)
+	if err != nil {
+		log.Error(i18n.T("本轮结果解析失败"), "time", timestamp, "cycle", cycle, "error", err)
+		return previous
+	}
+	event.Output, event.Nodes = o.out, extractor.CountNodes(current)
+
+	var changes []treediff.Change
+	if previous != nil {
+		changes = treediff.Compare(previous, current)
+		stats := treediff.Count(changes)
+		event.Diff = &stats
+	}
+
+	if previous == nil || !o.watchDiff {
+		log.Info(i18n.T("本轮完成，结果已写入"), "time", timestamp, "cycle", cycle, "path", o.out)
+		return current
+	}
+
+	if len(changes) == 0 {
+		log.Info(i18n.T("本轮完成，树结构无变化"), "time", timestamp, "cycle", cycle)
+		return current
+	}
+
+	fmt.Printf(i18n.T("[%s] 第 %d 轮完成，%s:\n"), timestamp, cycle, treediff.Summary(changes))
+	fmt.Print(treediff.FormatColor(changes, color.For(os.Stdout)))
+	return current
+}
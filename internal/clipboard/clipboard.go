@@ -0,0 +1,82 @@
+package clipboard
+
+import (
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// readCommands 各平台读取剪贴板的命令，按优先级排序
+var readCommands = map[string][][]string{
+	"darwin":  {{"pbpaste"}},
+	"windows": {{"powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"}},
+	"linux": {
+		{"wl-paste", "--no-newline"},
+		{"xclip", "-selection", "clipboard", "-o"},
+		{"xsel", "--clipboard", "--output"},
+	},
+}
+
+// writeCommands 各平台写入剪贴板的命令，按优先级排序
+var writeCommands = map[string][][]string{
+	"darwin":  {{"pbcopy"}},
+	"windows": {{"clip"}},
+	"linux": {
+		{"wl-copy"},
+		{"xclip", "-selection", "clipboard", "-i"},
+		{"xsel", "--clipboard", "--input"},
+	},
+}
+
+// Read 从系统剪贴板读取文本
+func Read() (string, error) {
+	var output []byte
+	err := runFirstAvailable(readCommands, func(cmd *exec.Cmd) error {
+		var runErr error
+		output, runErr = cmd.Output()
+		return runErr
+	})
+	if err != nil {
+		return "", err
+	}
+
+	content := strings.TrimSpace(string(output))
+	if content == "" {
+		return "", i18n.Errorf("剪贴板为空")
+	}
+	return content, nil
+}
+
+// Write 将文本写入系统剪贴板
+func Write(text string) error {
+	return runFirstAvailable(writeCommands, func(cmd *exec.Cmd) error {
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	})
+}
+
+// runFirstAvailable 依次尝试当前平台可用的剪贴板命令，直到有一个执行成功
+func runFirstAvailable(commands map[string][][]string, run func(cmd *exec.Cmd) error) error {
+	candidates, ok := commands[runtime.GOOS]
+	if !ok {
+		return i18n.Errorf("当前系统不支持剪贴板: %s", runtime.GOOS)
+	}
+
+	var lastErr error
+	for _, args := range candidates {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			lastErr = err
+			continue
+		}
+
+		if err := run(exec.Command(args[0], args[1:]...)); err != nil {
+			lastErr = i18n.Errorf("%s 执行失败: %w", args[0], err)
+			continue
+		}
+		return nil
+	}
+
+	return i18n.Errorf("未找到可用的剪贴板工具（linux需要wl-clipboard、xclip或xsel）: %w", lastErr)
+}
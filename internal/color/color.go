@@ -0,0 +1,75 @@
+package color
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-isatty"
+)
+
+// ANSI颜色代码
+const (
+	reset   = "\x1b[0m"
+	red     = "\x1b[31m"
+	green   = "\x1b[32m"
+	yellow  = "\x1b[33m"
+	blue    = "\x1b[34m"
+	magenta = "\x1b[35m"
+	cyan    = "\x1b[36m"
+)
+
+// depthPalette 树的各层级循环使用的颜色
+var depthPalette = []string{cyan, green, yellow, magenta, blue}
+
+var disabled bool
+
+// Disable 全局关闭颜色输出（--no-color）
+func Disable() {
+	disabled = true
+}
+
+// Supported 判断写入w的内容是否应当着色：未关闭颜色、未设置 NO_COLOR、TERM 不是 dumb 且w为终端
+func Supported(w io.Writer) bool {
+	if disabled || os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	return isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd())
+}
+
+// Palette 按需着色的调色板，Enabled 为 false 时原样返回文本
+type Palette struct {
+	Enabled bool
+}
+
+// For 根据w是否支持颜色创建调色板
+func For(w io.Writer) Palette {
+	return Palette{Enabled: Supported(w)}
+}
+
+func (p Palette) wrap(code, s string) string {
+	if !p.Enabled || s == "" {
+		return s
+	}
+	return code + s + reset
+}
+
+// Red 红色文本
+func (p Palette) Red(s string) string { return p.wrap(red, s) }
+
+// Green 绿色文本
+func (p Palette) Green(s string) string { return p.wrap(green, s) }
+
+// Yellow 黄色文本
+func (p Palette) Yellow(s string) string { return p.wrap(yellow, s) }
+
+// Depth 按树的层级着色，层级超过调色板长度时循环使用
+func (p Palette) Depth(depth int, s string) string {
+	if depth < 0 {
+		depth = 0
+	}
+	return p.wrap(depthPalette[depth%len(depthPalette)], s)
+}
@@ -0,0 +1,43 @@
+package color
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPaletteDisabled(t *testing.T) {
+	p := Palette{}
+	if got := p.Red("x"); got != "x" {
+		t.Errorf("未开启颜色时应原样返回，实际: %q", got)
+	}
+	if got := p.Depth(3, "x"); got != "x" {
+		t.Errorf("未开启颜色时应原样返回，实际: %q", got)
+	}
+}
+
+func TestPaletteEnabled(t *testing.T) {
+	p := Palette{Enabled: true}
+	if got := p.Green("+ a"); got != green+"+ a"+reset {
+		t.Errorf("Green() = %q", got)
+	}
+	if p.Depth(0, "a") != p.Depth(len(depthPalette), "a") {
+		t.Error("层级颜色应循环使用")
+	}
+	if p.Depth(0, "a") == p.Depth(1, "a") {
+		t.Error("相邻层级颜色应不同")
+	}
+	if got := p.Red(""); got != "" {
+		t.Errorf("空字符串不应着色，实际: %q", got)
+	}
+}
+
+func TestSupported(t *testing.T) {
+	if Supported(&bytes.Buffer{}) {
+		t.Error("非终端输出不应着色")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if For(&bytes.Buffer{}).Enabled {
+		t.Error("设置 NO_COLOR 时不应着色")
+	}
+}
@@ -1,13 +1,67 @@
 package config
 
-import "time"
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
 
 // Config 工具配置
 type Config struct {
-	Timeout      time.Duration
-	TitleKeys    []string
-	ChildrenKeys []string
-	Verbose      bool
+	Timeout        time.Duration
+	TitleKeys      []string
+	LevelTitleKeys map[int][]string // 按层数（根节点为第1层）优先使用的内容字段候选键名
+	ChildrenKeys   []string
+	MergeChildren  bool // 合并节点上所有匹配 ChildrenKeys 的子节点数组
+	Verbose        bool
+	Logger         *slog.Logger      // 为nil时根据Verbose创建写入stderr的默认日志器
+	Progress       io.Writer         // 非nil时在其上显示响应下载进度
+	MaxDepth       int               // 树抽取的最大递归深度，0 表示使用默认值
+	MaxTitleLength int               // 节点名称的最大字符数，超过时截断，0 表示不限制
+	NodeOrder      bool              // 为每个节点输出它在兄弟节点中的原始序号 order
+	TagFields      []string          // 转换为节点 tags 标签的源字段，为空时不输出标签
+	TextProfile    string            // 业务文本规则（见 extractor.TextProfiles），为空或 auto 时按响应中占多数的语言自动选择
+	OnConflict     string            // 合并多个JSON文档的根节点时同名节点的处理方式（见 extractor.ConflictStrategies），为空时全部保留
+	Transport      http.RoundTripper // 为nil时使用 http.DefaultTransport
+	ValidateOutput bool              // 输出前按 extractor.DefaultOutputSchema 校验最终结果
+	KeyStyle       string            // 输出JSON的键名风格（见 keystyle.Styles），为空时保持 snake_case
+	DebugDir       string            // 抽取失败时保存原始响应的目录；为空时仅在Verbose下保存到系统临时目录
+	CurlDir        string            // cURL命令所在文件的目录，-d @file 等引用的相对路径在当前目录下不存在时相对于它查找
+	NoRedirects    bool              // 不跟随重定向，3xx响应原样交给后续阶段
+	NoFileAccess   bool              // 不允许cURL命令引用本地文件（-d @file、-F name=@file、--cacert 等），用于来自网络或代理的cURL命令
+	NoLocalSecrets bool              // 不解析 env、keychain 等读取本机环境变量与凭据的占位符，用于来自网络或代理的请求
+
+	// 标量标题：ScalarTitles 为true时，标题字段为数字或布尔值的节点也有标题，格式见 extractor.ScalarTitleFormat
+	ScalarTitles      bool
+	ScalarTitleFormat string // 标题模板，为空时直接使用值
+	ScalarTitleTrue   string // 布尔值true的文字，为空时为 true
+	ScalarTitleFalse  string // 布尔值false的文字，为空时为 false
+
+	// 轮询等待：WaitFor 非nil时按 PollInterval 重复执行请求，直到其返回true或超过 PollTimeout，
+	// 间隔和超时为0时分别使用5秒和5分钟
+	WaitFor      func(statusCode int, body []byte) bool
+	PollInterval time.Duration
+	PollTimeout  time.Duration
+
+	// 重复发送非幂等请求（POST、PATCH等）可能在服务端重复创建任务：轮询这类请求前需设置 RetryUnsafe，
+	// 或设置 IdempotencyKey 在每次执行前为该名称的请求头写入新生成的幂等键，同一次执行内的轮询与重试沿用同一个键
+	RetryUnsafe    bool
+	IdempotencyKey string
+
+	// RewriteResponse 非nil时，在响应解码为JSON之后、错误响应检查与抽取之前调用它改写响应，
+	// 返回错误时按响应校验失败处理
+	RewriteResponse func(body []byte) ([]byte, error)
+
+	// AuthRefresh 非nil时，请求返回401/419后调用它为请求写入新凭据，成功后重试一次原请求；
+	// 传入的是原请求的副本
+	AuthRefresh func(ctx context.Context, req *RequestInfo) error
+
+	// 响应体安全上限，0 表示不限制
+	MaxResponseSize int64 // 响应体最大字节数
+	MaxJSONDepth    int   // JSON最大嵌套深度
+	MaxStringLength int   // 单个JSON字符串最大字节数
 }
 
 // RequestInfo HTTP请求信息
@@ -17,4 +71,61 @@ type RequestInfo struct {
 	Headers map[string]string
 	Cookies map[string]string
 	Body    string
-}
\ No newline at end of file
+	Form    []FormField // -F/--form 的表单字段，非空时以 multipart/form-data 发送，忽略 Body
+
+	// Compressed 对应 --compressed：没有 Accept-Encoding 请求头时请求gzip、deflate或br压缩的响应。
+	// 无论是否设置，执行器都会按响应的 Content-Encoding 解压响应体
+	Compressed bool
+
+	// -u/--user 的用户名与密码，User 非空且没有 Authorization 请求头时由执行器生成认证请求头；
+	// AuthType 为空或 basic 时为Basic认证，digest 为Digest认证（暂不支持）
+	User     string
+	Password string
+	AuthType string
+
+	// -L/--location、--location-trusted 与 --max-redirs。执行器默认跟随重定向（见 Config.NoRedirects），
+	// MaxRedirects 为nil时 Location 为true最多50次（与cURL一致），否则最多10次，-1 表示不限制；
+	// 跨主机的重定向不携带凭据请求头，LocationTrusted 为true时照常携带
+	Location        bool
+	LocationTrusted bool
+	MaxRedirects    *int
+
+	// -x/--proxy 与 --socks5：Proxy 为补全了协议与端口的代理地址（http、https 或 socks5），ProxyUser 对应 -U/--proxy-user
+	// 的 user:password，优先于代理地址中的用户信息；NoProxy 对应 --noproxy，为逗号分隔的直连主机、域名或网段，* 表示全部直连。
+	// Proxy 为空时按 HTTP_PROXY、HTTPS_PROXY 与 NO_PROXY 环境变量选择代理，NoProxy 中的主机同样直连
+	Proxy     string
+	ProxyUser string
+	NoProxy   string
+
+	// -k/--insecure、--cacert、-E/--cert 与 --key：Insecure 为true时不校验服务端证书；CACert 为PEM格式的CA证书文件，
+	// 以其中的证书代替系统根证书；Cert 与 Key 为PEM格式的客户端证书与私钥文件（mTLS），Key 为空时私钥与证书在同一个文件中
+	Insecure bool
+	CACert   string
+	Cert     string
+	Key      string
+}
+
+// FormField multipart/form-data 表单中的一个字段
+type FormField struct {
+	Name        string
+	Value       string // 文本字段的值
+	File        string // 非空时上传该文件（-F name=@path）
+	ValueFile   string // 非空时读取该文件的内容作为文本字段的值（-F name=<path）
+	Filename    string // 上传时使用的文件名，为空时取 File 的文件名
+	ContentType string // 字段的Content-Type，为空时文件按扩展名推断，文本字段不设置
+}
+
+// Clone 复制请求信息，修改副本的请求头与Cookie不影响原请求
+func (r *RequestInfo) Clone() *RequestInfo {
+	clone := *r
+	clone.Headers = make(map[string]string, len(r.Headers))
+	for key, value := range r.Headers {
+		clone.Headers[key] = value
+	}
+	clone.Cookies = make(map[string]string, len(r.Cookies))
+	for key, value := range r.Cookies {
+		clone.Cookies[key] = value
+	}
+	clone.Form = append([]FormField(nil), r.Form...)
+	return &clone
+}
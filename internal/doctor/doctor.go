@@ -0,0 +1,360 @@
+package doctor
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/placeholder"
+)
+
+// Stage 诊断阶段
+type Stage string
+
+// 诊断阶段，按执行顺序排列
+const (
+	StageURL   Stage = "url"
+	StageProxy Stage = "proxy"
+	StageDNS   Stage = "dns"
+	StageTCP   Stage = "tcp"
+	StageTLS   Stage = "tls"
+	StageAuth  Stage = "auth"
+	StageHTTP  Stage = "http"
+)
+
+// Name 阶段的显示名称
+func (s Stage) Name() string {
+	switch s {
+	case StageURL:
+		return i18n.T("URL解析")
+	case StageProxy:
+		return i18n.T("代理检测")
+	case StageDNS:
+		return i18n.T("DNS解析")
+	case StageTCP:
+		return i18n.T("TCP连接")
+	case StageTLS:
+		return i18n.T("TLS握手")
+	case StageAuth:
+		return i18n.T("认证请求头")
+	case StageHTTP:
+		return i18n.T("HTTP请求")
+	default:
+		return string(s)
+	}
+}
+
+// Status 检查结果状态
+type Status string
+
+const (
+	// OK 检查通过
+	OK Status = "ok"
+	// Warn 存在潜在问题但不影响后续检查
+	Warn Status = "warn"
+	// Fail 检查失败，后续阶段不再执行
+	Fail Status = "fail"
+	// Skip 当前请求不需要该阶段（如HTTP请求没有TLS握手）
+	Skip Status = "skip"
+)
+
+// Result 单个阶段的检查结果
+type Result struct {
+	Stage      Stage  `json:"stage"`
+	Status     Status `json:"status"`
+	Detail     string `json:"detail"`
+	StatusCode int    `json:"status_code,omitempty"` // 仅HTTP阶段
+}
+
+// Report 诊断报告
+type Report struct {
+	URL     string   `json:"url"`
+	Results []Result `json:"results"`
+}
+
+// Failed 返回第一个失败的阶段，全部通过时返回nil
+func (r *Report) Failed() *Result {
+	for i := range r.Results {
+		if r.Results[i].Status == Fail {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// Options 诊断选项
+type Options struct {
+	Timeout   time.Duration                         // 每个网络阶段的超时时间
+	Proxy     func(*http.Request) (*url.URL, error) // 为nil时使用 http.ProxyFromEnvironment
+	TLSConfig *tls.Config                           // 为nil时使用系统根证书
+}
+
+// Run 依次执行各阶段检查，遇到失败的阶段即停止
+func Run(ctx context.Context, info *config.RequestInfo, opts Options) *Report {
+	if opts.Timeout <= 0 {
+		opts.Timeout = 10 * time.Second
+	}
+	if opts.Proxy == nil {
+		opts.Proxy = http.ProxyFromEnvironment
+	}
+
+	d := &diagnosis{ctx: ctx, info: info, opts: opts, report: &Report{URL: info.URL}}
+	steps := []func() Result{d.checkURL, d.checkProxy, d.checkDNS, d.checkTCP, d.checkTLS, d.checkAuth, d.checkHTTP}
+	for _, step := range steps {
+		result := step()
+		d.report.Results = append(d.report.Results, result)
+		if result.Status == Fail {
+			break
+		}
+	}
+	if d.conn != nil {
+		d.conn.Close()
+	}
+	return d.report
+}
+
+// diagnosis 单次诊断过程中各阶段共享的状态
+type diagnosis struct {
+	ctx    context.Context
+	info   *config.RequestInfo
+	opts   Options
+	report *Report
+
+	target  *url.URL
+	proxy   *url.URL
+	addr    string // 实际建立TCP连接的地址（使用代理时为代理地址）
+	conn    net.Conn
+	headers map[string]string
+}
+
+func (d *diagnosis) checkURL() Result {
+	target, err := url.Parse(d.info.URL)
+	if err != nil {
+		return Result{Stage: StageURL, Status: Fail, Detail: err.Error()}
+	}
+	if target.Scheme != "http" && target.Scheme != "https" {
+		return Result{Stage: StageURL, Status: Fail, Detail: i18n.Sprintf("不支持的协议: %q（需要 http 或 https）", target.Scheme)}
+	}
+	if target.Hostname() == "" {
+		return Result{Stage: StageURL, Status: Fail, Detail: i18n.T("URL中缺少主机名")}
+	}
+	d.target = target
+	return Result{Stage: StageURL, Status: OK, Detail: target.Scheme + "://" + hostPort(target)}
+}
+
+func (d *diagnosis) checkProxy() Result {
+	proxy, err := d.opts.Proxy(&http.Request{URL: d.target})
+	if err != nil {
+		return Result{Stage: StageProxy, Status: Fail, Detail: i18n.Sprintf("代理配置无效: %v", err)}
+	}
+	if proxy == nil {
+		d.addr = hostPort(d.target)
+		return Result{Stage: StageProxy, Status: OK, Detail: i18n.T("直连（未配置代理或命中 NO_PROXY）")}
+	}
+	d.proxy = proxy
+	d.addr = hostPort(proxy)
+	return Result{Stage: StageProxy, Status: OK, Detail: i18n.Sprintf("经由代理 %s", proxy.Redacted())}
+}
+
+func (d *diagnosis) checkDNS() Result {
+	host, _, _ := net.SplitHostPort(d.addr)
+	if net.ParseIP(host) != nil {
+		return Result{Stage: StageDNS, Status: Skip, Detail: i18n.Sprintf("%s 为IP地址，无需解析", host)}
+	}
+
+	ctx, cancel := context.WithTimeout(d.ctx, d.opts.Timeout)
+	defer cancel()
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	if err != nil {
+		return Result{Stage: StageDNS, Status: Fail, Detail: i18n.Sprintf("无法解析 %s: %v", host, err)}
+	}
+	return Result{Stage: StageDNS, Status: OK, Detail: host + " -> " + strings.Join(addrs, ", ")}
+}
+
+func (d *diagnosis) checkTCP() Result {
+	dialer := &net.Dialer{Timeout: d.opts.Timeout}
+	start := time.Now()
+	conn, err := dialer.DialContext(d.ctx, "tcp", d.addr)
+	if err != nil {
+		return Result{Stage: StageTCP, Status: Fail, Detail: i18n.Sprintf("无法连接 %s: %v", d.addr, err)}
+	}
+	d.conn = conn
+	return Result{Stage: StageTCP, Status: OK, Detail: i18n.Sprintf("已连接 %s（%s）", d.addr, time.Since(start).Round(time.Millisecond))}
+}
+
+func (d *diagnosis) checkTLS() Result {
+	if d.target.Scheme != "https" {
+		return Result{Stage: StageTLS, Status: Skip, Detail: i18n.T("非HTTPS请求")}
+	}
+
+	d.conn.SetDeadline(time.Now().Add(d.opts.Timeout))
+	if d.proxy != nil {
+		if err := connectTunnel(d.conn, hostPort(d.target)); err != nil {
+			return Result{Stage: StageTLS, Status: Fail, Detail: i18n.Sprintf("代理隧道建立失败: %v", err)}
+		}
+	}
+
+	cfg := &tls.Config{}
+	if d.opts.TLSConfig != nil {
+		cfg = d.opts.TLSConfig.Clone()
+	}
+	cfg.ServerName = d.target.Hostname()
+
+	tlsConn := tls.Client(d.conn, cfg)
+	if err := tlsConn.HandshakeContext(d.ctx); err != nil {
+		return Result{Stage: StageTLS, Status: Fail, Detail: err.Error()}
+	}
+	d.conn = tlsConn
+
+	state := tlsConn.ConnectionState()
+	detail := tls.VersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		cert := state.PeerCertificates[0]
+		detail += i18n.Sprintf("，证书 %s 有效期至 %s", cert.Subject.CommonName, cert.NotAfter.Format("2006-01-02"))
+		if time.Until(cert.NotAfter) < 14*24*time.Hour {
+			return Result{Stage: StageTLS, Status: Warn, Detail: detail + i18n.T("（即将过期）")}
+		}
+	}
+	return Result{Stage: StageTLS, Status: OK, Detail: detail}
+}
+
+func (d *diagnosis) checkAuth() Result {
+	headers, err := placeholder.ExpandMap(d.info.Headers)
+	if err != nil {
+		return Result{Stage: StageAuth, Status: Fail, Detail: i18n.Sprintf("解析请求头占位符失败: %v", err)}
+	}
+	d.headers = headers
+
+	var found []string
+	for key, value := range headers {
+		if !isAuthHeader(key) {
+			continue
+		}
+		found = append(found, key)
+		if exp, ok := jwtExpiry(value); ok && time.Now().After(exp) {
+			return Result{Stage: StageAuth, Status: Fail, Detail: i18n.Sprintf("%s 中的JWT已于 %s 过期", key, exp.Format("2006-01-02 15:04:05"))}
+		}
+	}
+	if len(found) == 0 {
+		return Result{Stage: StageAuth, Status: Warn, Detail: i18n.T("未发现认证相关请求头（Authorization、Cookie、*token* 等），服务器可能拒绝请求")}
+	}
+	sort.Strings(found)
+	return Result{Stage: StageAuth, Status: OK, Detail: strings.Join(found, ", ")}
+}
+
+func (d *diagnosis) checkHTTP() Result {
+	ctx, cancel := context.WithTimeout(d.ctx, d.opts.Timeout)
+	defer cancel()
+
+	var body io.Reader
+	if d.info.Body != "" {
+		body = strings.NewReader(d.info.Body)
+	}
+	method := d.info.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, d.info.URL, body)
+	if err != nil {
+		return Result{Stage: StageHTTP, Status: Fail, Detail: err.Error()}
+	}
+	for key, value := range d.headers {
+		req.Header.Set(key, value)
+	}
+	if d.info.Body != "" && req.Header.Get("Content-Type") == "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Transport: &http.Transport{Proxy: d.opts.Proxy, TLSClientConfig: d.opts.TLSConfig}}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return Result{Stage: StageHTTP, Status: Fail, Detail: err.Error()}
+	}
+	resp.Body.Close()
+
+	detail := i18n.Sprintf("HTTP %d，Content-Type: %s（%s）", resp.StatusCode, resp.Header.Get("Content-Type"), time.Since(start).Round(time.Millisecond))
+	result := Result{Stage: StageHTTP, Status: OK, Detail: detail, StatusCode: resp.StatusCode}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		result.Status = Fail
+		result.Detail = detail + i18n.T("：认证失败，请检查token或cookie是否过期")
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		result.Status = Fail
+	}
+	return result
+}
+
+// connectTunnel 通过HTTP代理的CONNECT方法建立到目标地址的隧道
+func connectTunnel(conn net.Conn, addr string) error {
+	req := &http.Request{Method: http.MethodConnect, URL: &url.URL{Opaque: addr}, Host: addr, Header: http.Header{}}
+	if err := req.Write(conn); err != nil {
+		return err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return i18n.Errorf("代理返回 %s", resp.Status)
+	}
+	return nil
+}
+
+// isAuthHeader 判断请求头名称是否与认证相关
+func isAuthHeader(key string) bool {
+	lower := strings.ToLower(key)
+	for _, keyword := range []string{"auth", "token", "cookie", "session", "api-key", "apikey"} {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// jwtExpiry 从形如JWT的值中读取exp声明，值可带 Bearer 前缀
+func jwtExpiry(value string) (time.Time, bool) {
+	token := strings.TrimSpace(value)
+	if len(token) > 7 && strings.EqualFold(token[:7], "bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp float64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(claims.Exp), 0), true
+}
+
+// hostPort 返回URL的 host:port，未指定端口时按协议补全
+func hostPort(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
@@ -0,0 +1,144 @@
+package doctor
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+func noProxy(*http.Request) (*url.URL, error) { return nil, nil }
+
+// trustServer 返回信任测试服务器证书的TLS配置
+func trustServer(server *httptest.Server) *tls.Config {
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	return &tls.Config{RootCAs: pool}
+}
+
+func statuses(report *Report) map[Stage]Status {
+	got := make(map[Stage]Status)
+	for _, result := range report.Results {
+		got[result.Stage] = result.Status
+	}
+	return got
+}
+
+func TestRun_AllStagesPass(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-jwt-token") == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"data":{}}`)
+	}))
+	defer server.Close()
+
+	info := &config.RequestInfo{URL: server.URL, Method: "GET", Headers: map[string]string{"x-jwt-token": "abc"}}
+	report := Run(context.Background(), info, Options{Proxy: noProxy, TLSConfig: trustServer(server)})
+
+	if failed := report.Failed(); failed != nil {
+		t.Fatalf("Failed() = %+v, want nil", failed)
+	}
+	want := map[Stage]Status{StageURL: OK, StageProxy: OK, StageDNS: Skip, StageTCP: OK, StageTLS: OK, StageAuth: OK, StageHTTP: OK}
+	got := statuses(report)
+	for stage, status := range want {
+		if got[stage] != status {
+			t.Errorf("stage %s = %q, want %q", stage, got[stage], status)
+		}
+	}
+}
+
+func TestRun_FailingStage(t *testing.T) {
+	tlsServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer tlsServer.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	closedAddr := listener.Addr().String()
+	listener.Close()
+
+	expired := "Bearer eyJhbGciOiJIUzI1NiJ9." +
+		base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(-time.Hour).Unix()))) + ".sig"
+
+	tests := []struct {
+		name  string
+		info  *config.RequestInfo
+		opts  Options
+		stage Stage
+		code  int
+	}{
+		{"invalid scheme", &config.RequestInfo{URL: "ftp://example.com"}, Options{}, StageURL, 0},
+		{"connection refused", &config.RequestInfo{URL: "http://" + closedAddr}, Options{}, StageTCP, 0},
+		{"untrusted certificate", &config.RequestInfo{URL: tlsServer.URL}, Options{}, StageTLS, 0},
+		{"expired jwt", &config.RequestInfo{URL: tlsServer.URL, Headers: map[string]string{"Authorization": expired}}, Options{TLSConfig: trustServer(tlsServer)}, StageAuth, 0},
+		{"unauthorized", &config.RequestInfo{URL: tlsServer.URL}, Options{TLSConfig: trustServer(tlsServer)}, StageHTTP, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Proxy = noProxy
+			tt.opts.Timeout = 2 * time.Second
+			report := Run(context.Background(), tt.info, tt.opts)
+
+			failed := report.Failed()
+			if failed == nil {
+				t.Fatalf("Failed() = nil, want stage %s; results: %+v", tt.stage, report.Results)
+			}
+			if failed.Stage != tt.stage || failed.StatusCode != tt.code {
+				t.Errorf("Failed() = %+v, want stage %s with status code %d", failed, tt.stage, tt.code)
+			}
+			if last := report.Results[len(report.Results)-1]; last.Stage != tt.stage {
+				t.Errorf("checks continued after failure, last stage = %s", last.Stage)
+			}
+		})
+	}
+}
+
+func TestRun_MissingAuthHeaderWarns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	report := Run(context.Background(), &config.RequestInfo{URL: server.URL}, Options{Proxy: noProxy})
+	got := statuses(report)
+	if got[StageTLS] != Skip || got[StageAuth] != Warn || got[StageHTTP] != OK {
+		t.Errorf("statuses = %v, want tls skip, auth warn, http ok", got)
+	}
+}
+
+func TestRun_ViaProxy(t *testing.T) {
+	// 普通HTTP代理收到绝对URL形式的请求，目标主机名只需由代理解析
+	var requested string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	info := &config.RequestInfo{URL: "http://backend.invalid/cases", Headers: map[string]string{"Cookie": "sid=1"}}
+	report := Run(context.Background(), info, Options{Proxy: http.ProxyURL(proxyURL)})
+
+	if failed := report.Failed(); failed != nil {
+		t.Fatalf("Failed() = %+v, want nil", failed)
+	}
+	if got := statuses(report); got[StageDNS] != Skip {
+		t.Errorf("dns status = %q, want skip for IP proxy address", got[StageDNS])
+	}
+	if requested != info.URL {
+		t.Errorf("proxy received %q, want %q", requested, info.URL)
+	}
+}
@@ -0,0 +1,226 @@
+// Package drift 记录响应JSON每一层对象出现过的键，与 --baseline-schema 指定的基线比较，
+// 在抽取仍然成功时也能提前发现上游接口的结构变化
+package drift
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+)
+
+// Schema 响应结构：每一层对象出现过的键
+type Schema struct {
+	Levels []Level `json:"levels"`
+}
+
+// Level 一层对象出现过的键，按名称排序。根对象为第1层，数组不单独计层，数组中的对象与数组位于同一层
+type Level struct {
+	Level int      `json:"level"`
+	Keys  []string `json:"keys"`
+}
+
+// Change 一层中相对基线新增与缺少的键
+type Change struct {
+	Level   int
+	Added   []string
+	Removed []string
+}
+
+// Observe 统计响应JSON每一层对象的键；值为JSON对象或数组的字符串（如嵌套序列化的树）按解析后的结构统计
+func Observe(body []byte) (*Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, i18n.Errorf("响应不是有效的JSON，无法统计结构: %w", err)
+	}
+
+	var levels []map[string]bool
+	var walk func(value interface{}, level int)
+	walk = func(value interface{}, level int) {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			for len(levels) < level {
+				levels = append(levels, map[string]bool{})
+			}
+			for key, child := range v {
+				levels[level-1][key] = true
+				walk(child, level+1)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item, level)
+			}
+		case string:
+			if embedded, ok := parseEmbedded(v); ok {
+				walk(embedded, level)
+			}
+		}
+	}
+	walk(data, 1)
+
+	schema := &Schema{Levels: make([]Level, len(levels))}
+	for i, keys := range levels {
+		schema.Levels[i] = Level{Level: i + 1, Keys: sortedKeys(keys)}
+	}
+	return schema, nil
+}
+
+// parseEmbedded 解析内容为JSON对象或数组的字符串
+func parseEmbedded(text string) (interface{}, bool) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Load 读取 --save-schema 写入的结构文件
+func Load(path string) (*Schema, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取基线结构失败: %w", err)
+	}
+	var schema Schema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		return nil, i18n.Errorf("解析基线结构 %s 失败: %w", path, err)
+	}
+	return &schema, nil
+}
+
+// WriteFile 将结构以缩进JSON写入path
+func (s *Schema) WriteFile(path string) error {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return i18n.Errorf("写入响应结构失败: %w", err)
+	}
+	return nil
+}
+
+// keys 返回第level层的键集合，不存在该层时ok为false
+func (s *Schema) keys(level int) (map[string]bool, bool) {
+	for _, l := range s.Levels {
+		if l.Level == level {
+			set := make(map[string]bool, len(l.Keys))
+			for _, key := range l.Keys {
+				set[key] = true
+			}
+			return set, true
+		}
+	}
+	return nil, false
+}
+
+// Compare 比较两份结构中都存在的层，返回有变化的层；树的层数随数据增减，只出现在一方的层不比较
+func Compare(baseline, current *Schema) []Change {
+	var changes []Change
+	for _, level := range current.Levels {
+		base, ok := baseline.keys(level.Level)
+		if !ok {
+			continue
+		}
+		change := Change{Level: level.Level}
+		seen := make(map[string]bool, len(level.Keys))
+		for _, key := range level.Keys {
+			seen[key] = true
+			if !base[key] {
+				change.Added = append(change.Added, key)
+			}
+		}
+		change.Removed = sortedKeys(difference(base, seen))
+		if len(change.Added) > 0 || len(change.Removed) > 0 {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+func difference(a, b map[string]bool) map[string]bool {
+	result := make(map[string]bool)
+	for key := range a {
+		if !b[key] {
+			result[key] = true
+		}
+	}
+	return result
+}
+
+// Warnings 将变化格式化为警告文本
+func Warnings(changes []Change) []string {
+	var warnings []string
+	for _, change := range changes {
+		if len(change.Added) > 0 {
+			warnings = append(warnings, i18n.Sprintf("响应结构与基线不一致: 第%d层新增键 %s", change.Level, strings.Join(change.Added, ", ")))
+		}
+		if len(change.Removed) > 0 {
+			warnings = append(warnings, i18n.Sprintf("响应结构与基线不一致: 第%d层缺少键 %s", change.Level, strings.Join(change.Removed, ", ")))
+		}
+	}
+	return warnings
+}
+
+// Tracker 通过流水线钩子统计每次运行的响应结构，按需写入结构文件或与基线比较
+type Tracker struct {
+	baseline *Schema
+	savePath string
+	logger   *slog.Logger
+	warnings []string // 最近一次运行的警告
+}
+
+// NewTracker 创建结构跟踪器，baseline 为nil时不比较，savePath 为空时不写入结构文件
+func NewTracker(baseline *Schema, savePath string, logger *slog.Logger) *Tracker {
+	return &Tracker{baseline: baseline, savePath: savePath, logger: logger}
+}
+
+// Register 注册在 validate 阶段之后统计结构的钩子，以及在 extract 阶段之后将警告加入树的警告的钩子，
+// 运行报告中因此也会记录这些警告；抽取失败时警告同样会输出到日志
+func (t *Tracker) Register(hooks *pipeline.Hooks) {
+	hooks.After(pipeline.StageValidate, func(ctx context.Context, state *pipeline.State) error {
+		t.warnings = nil
+		schema, err := Observe(state.Body)
+		if err != nil {
+			return err
+		}
+		if t.savePath != "" {
+			if err := schema.WriteFile(t.savePath); err != nil {
+				return err
+			}
+		}
+		if t.baseline != nil {
+			t.warnings = Warnings(Compare(t.baseline, schema))
+			for _, warning := range t.warnings {
+				t.logger.Warn(warning)
+			}
+		}
+		return nil
+	})
+	hooks.After(pipeline.StageExtract, func(ctx context.Context, state *pipeline.State) error {
+		if state.Tree != nil {
+			state.Tree.Warnings = append(state.Tree.Warnings, t.warnings...)
+		}
+		return nil
+	})
+}
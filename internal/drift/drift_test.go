@@ -0,0 +1,67 @@
+package drift
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestObserve(t *testing.T) {
+	body := `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"根\"},\"children\":[{\"data\":{\"text\":\"子\",\"priority\":1},\"children\":[]}]}","items":[{"id":1},{"name":"a"}]}}`
+	schema, err := Observe([]byte(body))
+	if err != nil {
+		t.Fatalf("Observe() error = %v", err)
+	}
+	want := []Level{
+		{Level: 1, Keys: []string{"data", "errCode"}},
+		{Level: 2, Keys: []string{"TestCaseMind", "items"}},
+		{Level: 3, Keys: []string{"children", "data", "id", "name"}},
+		{Level: 4, Keys: []string{"children", "data", "text"}},
+		{Level: 5, Keys: []string{"priority", "text"}},
+	}
+	if !reflect.DeepEqual(schema.Levels, want) {
+		t.Errorf("Observe() = %+v, want %+v", schema.Levels, want)
+	}
+
+	if _, err := Observe([]byte("not json")); err == nil {
+		t.Error("Observe() of invalid JSON error = nil")
+	}
+}
+
+func TestCompare(t *testing.T) {
+	baseline := &Schema{Levels: []Level{
+		{Level: 1, Keys: []string{"code", "data"}},
+		{Level: 2, Keys: []string{"id", "name"}},
+	}}
+	current := &Schema{Levels: []Level{
+		{Level: 1, Keys: []string{"code", "data"}},
+		{Level: 2, Keys: []string{"id", "title", "url"}},
+		{Level: 3, Keys: []string{"extra"}},
+	}}
+	changes := Compare(baseline, current)
+	want := []Change{{Level: 2, Added: []string{"title", "url"}, Removed: []string{"name"}}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("Compare() = %+v, want %+v", changes, want)
+	}
+	if got := Warnings(changes); len(got) != 2 {
+		t.Errorf("Warnings() = %q, want one warning for added and one for removed keys", got)
+	}
+	if changes := Compare(current, current); len(changes) != 0 {
+		t.Errorf("Compare() of identical schemas = %+v, want none", changes)
+	}
+}
+
+func TestSchema_WriteLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "schema.json")
+	schema := &Schema{Levels: []Level{{Level: 1, Keys: []string{"data"}}}}
+	if err := schema.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, schema) {
+		t.Errorf("Load() = %+v, want %+v", loaded, schema)
+	}
+}
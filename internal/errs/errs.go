@@ -0,0 +1,67 @@
+// Package errs 定义处理流程中各类失败的错误值，由 pkg/curl2json 对外导出，
+// 调用者可以用 errors.Is/errors.As 判断失败类别，而不必匹配本地化的错误信息。
+package errs
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 失败类别
+var (
+	// ErrCurlParse cURL命令无法解析
+	ErrCurlParse = errors.New("curl parse error")
+	// ErrInvalidJSON 响应体不是有效的JSON
+	ErrInvalidJSON = errors.New("invalid JSON")
+	// ErrTruncatedJSON 响应体或其中嵌套的JSON字符串被截断
+	ErrTruncatedJSON = errors.New("truncated JSON")
+	// ErrEmptyTree 响应中没有可抽取的树结构，或抽取结果为空树
+	ErrEmptyTree = errors.New("empty tree")
+	// ErrSessionExpired 响应为HTML页面或登录跳转，登录态很可能已过期
+	ErrSessionExpired = errors.New("session expired")
+	// ErrLimitExceeded 响应体超过大小、嵌套深度或字符串长度上限
+	ErrLimitExceeded = errors.New("limit exceeded")
+)
+
+// ErrHTTPStatus 服务器返回了非2xx状态码，且响应无法用于抽取
+type ErrHTTPStatus struct {
+	Code int
+}
+
+func (e *ErrHTTPStatus) Error() string {
+	return i18n.Sprintf("服务器返回HTTP %d", e.Code)
+}
+
+// Mark 为err标记失败类别，错误信息保持不变，errors.Is/As 可以同时匹配err本身和kind
+func Mark(err, kind error) error {
+	if err == nil {
+		return nil
+	}
+	return &marked{err: err, kind: kind}
+}
+
+type marked struct {
+	err  error
+	kind error
+}
+
+func (m *marked) Error() string {
+	return m.err.Error()
+}
+
+func (m *marked) Unwrap() []error {
+	return []error{m.err, m.kind}
+}
+
+// IsTruncated 判断JSON解析错误是否由输入提前结束引起，
+// 同时适用于 json.Unmarshal 与 json.Decoder 返回的错误
+func IsTruncated(err error) bool {
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var syntaxErr *json.SyntaxError
+	return errors.As(err, &syntaxErr) && syntaxErr.Error() == "unexpected end of JSON input"
+}
@@ -0,0 +1,61 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// 进程退出码，CI脚本可据此区分失败类型
+const (
+	OK          = 0  // 成功
+	General     = 1  // 未分类的错误
+	Usage       = 2  // 命令行参数错误
+	Parse       = 3  // cURL命令解析失败
+	Network     = 4  // 网络错误（连接、超时、DNS等）
+	HTTPStatus  = 5  // 服务器返回非2xx状态码且响应不可用
+	Validation  = 6  // 响应校验失败（非JSON或业务错误响应）
+	EmptyTree   = 7  // 未能抽取出树状结构，或开启 --fail-empty 时结果为空树
+	OutputWrite = 8  // 写入输出文件失败
+	Assertion   = 9  // --assert 响应断言未通过
+	WaitTimeout = 10 // --wait-for 等待条件在 --poll-timeout 内未满足
+	Drift       = 11 // check 命令的抽取结果与golden树不一致
+)
+
+// Error 携带退出码的错误
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Wrap 为错误附加退出码，err 为nil时返回nil
+func Wrap(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Errorf 按格式创建携带退出码的错误
+func Errorf(code int, format string, args ...interface{}) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// From 返回错误对应的退出码，未分类的错误返回 General
+func From(err error) int {
+	if err == nil {
+		return OK
+	}
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return General
+}
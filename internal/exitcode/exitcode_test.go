@@ -0,0 +1,42 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFrom(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, OK},
+		{"未分类", errors.New("boom"), General},
+		{"直接包装", Wrap(Network, errors.New("timeout")), Network},
+		{"多层包装", fmt.Errorf("外层: %w", Errorf(Parse, "无效的cURL")), Parse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := From(tt.err); got != tt.want {
+				t.Errorf("From() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapKeepsMessage(t *testing.T) {
+	inner := errors.New("连接被拒绝")
+	err := Wrap(Network, inner)
+	if err.Error() != inner.Error() {
+		t.Errorf("Error() = %q, want %q", err.Error(), inner.Error())
+	}
+	if !errors.Is(err, inner) {
+		t.Error("errors.Is 应能找到被包装的错误")
+	}
+	if Wrap(Network, nil) != nil {
+		t.Error("Wrap(nil) 应返回nil")
+	}
+}
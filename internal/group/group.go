@@ -0,0 +1,170 @@
+// Package group 解析并执行 --group-by，在抽取前按字段值把扁平的数组元素分组为中间节点，
+// 例如 '$.data.cases[*].module' 将用例列表转换为 模块 → 用例 两层的树
+package group
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+)
+
+// Rule 一条分组规则
+type Rule struct {
+	expr  string
+	array *jsonpath.Path // 要分组的数组
+	field *jsonpath.Path // 分组字段，相对于数组中的每个元素
+}
+
+// Parse 解析形如 数组路径[*].字段路径 的规则，如 '$.data.cases[*].module' 或 '$.items[*].meta.group'
+func Parse(expr string) (*Rule, error) {
+	arrayExpr, fieldExpr, ok := strings.Cut(strings.TrimSpace(expr), "[*]")
+	if !ok || strings.Contains(fieldExpr, "[*]") {
+		return nil, i18n.Errorf("无效的分组规则 %q，格式应为 数组路径[*].字段，如 '$.data.cases[*].module'", expr)
+	}
+	array, err := jsonpath.Parse(arrayExpr)
+	if err != nil {
+		return nil, i18n.Errorf("无效的分组规则 %q: %w", expr, err)
+	}
+	field, err := jsonpath.Parse("$" + fieldExpr)
+	if err != nil {
+		return nil, i18n.Errorf("无效的分组规则 %q: %w", expr, err)
+	}
+	if field.String() == "$" {
+		return nil, i18n.Errorf("无效的分组规则 %q: [*] 之后缺少分组字段", expr)
+	}
+	return &Rule{expr: expr, array: array, field: field}, nil
+}
+
+// String 返回规则的原始表达式
+func (r *Rule) String() string {
+	return r.expr
+}
+
+// Grouper 按规则分组，分组节点的标题与子节点写入 titleKey 与 childrenKey
+type Grouper struct {
+	rules       []*Rule
+	titleKey    string
+	childrenKey string
+}
+
+// New 解析全部规则并创建分组器；作用于同一个数组的多条规则按给定的顺序逐层嵌套分组
+func New(exprs []string, titleKey, childrenKey string) (*Grouper, error) {
+	g := &Grouper{titleKey: titleKey, childrenKey: childrenKey}
+	for _, expr := range exprs {
+		rule, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		g.rules = append(g.rules, rule)
+	}
+	return g, nil
+}
+
+// Apply 执行分组并返回改写后的响应JSON，以及没有分组任何元素的规则；数字保持原样
+func (g *Grouper) Apply(body []byte) ([]byte, []*Rule, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, nil, i18n.Errorf("响应不是有效的JSON，无法分组: %w", err)
+	}
+
+	var unmatched []*Rule
+	for _, rules := range g.byArray() {
+		value, err := rules[0].array.Lookup(data)
+		items, ok := value.([]interface{})
+		if err != nil || !ok {
+			unmatched = append(unmatched, rules...)
+			continue
+		}
+		grouped, matched := g.group(items, rules)
+		for i, rule := range rules {
+			if !matched[i] {
+				unmatched = append(unmatched, rule)
+			}
+		}
+		if data, err = rules[0].array.Set(data, grouped); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, unmatched, nil
+}
+
+// byArray 按数组路径归并规则，保持各数组第一次出现的顺序
+func (g *Grouper) byArray() [][]*Rule {
+	var groups [][]*Rule
+	index := make(map[string]int)
+	for _, rule := range g.rules {
+		key := rule.array.String()
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], rule)
+	}
+	return groups
+}
+
+// group 按 rules[0] 的字段分组items，组内再按其余规则嵌套分组；
+// 分组节点按字段值第一次出现的顺序排列，缺少字段的元素保留在分组节点之后。matched 记录每条规则是否分组了元素
+func (g *Grouper) group(items []interface{}, rules []*Rule) ([]interface{}, []bool) {
+	matched := make([]bool, len(rules))
+	if len(rules) == 0 {
+		return items, matched
+	}
+
+	var order []string
+	members := make(map[string][]interface{})
+	var rest []interface{}
+	for _, item := range items {
+		key, ok := fieldValue(item, rules[0].field)
+		if !ok {
+			rest = append(rest, item)
+			continue
+		}
+		if _, exists := members[key]; !exists {
+			order = append(order, key)
+		}
+		members[key] = append(members[key], item)
+	}
+
+	result := make([]interface{}, 0, len(order)+len(rest))
+	for _, key := range order {
+		children, nested := g.group(members[key], rules[1:])
+		for i, ok := range nested {
+			matched[i+1] = matched[i+1] || ok
+		}
+		result = append(result, map[string]interface{}{
+			g.titleKey:    key,
+			g.childrenKey: children,
+		})
+	}
+	matched[0] = len(order) > 0
+	return append(result, rest...), matched
+}
+
+// fieldValue 返回元素分组字段的文字，字段不存在、为null、对象或数组时返回false
+func fieldValue(item interface{}, field *jsonpath.Path) (string, bool) {
+	value, err := field.Lookup(item)
+	if err != nil {
+		return "", false
+	}
+	switch v := value.(type) {
+	case string:
+		return v, v != ""
+	case json.Number, bool:
+		return fmt.Sprint(v), true
+	}
+	return "", false
+}
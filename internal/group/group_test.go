@@ -0,0 +1,63 @@
+package group
+
+import "testing"
+
+const body = `{"data":{"cases":[
+{"title":"登录成功","module":"账号","sub":"登录"},
+{"title":"创建门店","module":"门店","sub":"新建","id":12345678901234567890},
+{"title":"登录失败","module":"账号","sub":"登录"},
+{"title":"注销","module":"账号","sub":"注销"},
+{"title":"未分类"}
+]}}`
+
+func TestGrouper_Apply(t *testing.T) {
+	tests := []struct {
+		name      string
+		exprs     []string
+		want      string
+		unmatched int
+	}{
+		{
+			name:  "单层",
+			exprs: []string{"$.data.cases[*].module"},
+			want:  `{"data":{"cases":[{"children":[{"module":"账号","sub":"登录","title":"登录成功"},{"module":"账号","sub":"登录","title":"登录失败"},{"module":"账号","sub":"注销","title":"注销"}],"title":"账号"},{"children":[{"id":12345678901234567890,"module":"门店","sub":"新建","title":"创建门店"}],"title":"门店"},{"title":"未分类"}]}}`,
+		},
+		{
+			name:  "嵌套",
+			exprs: []string{"$.data.cases[*].module", "$.data.cases[*].sub"},
+			want:  `{"data":{"cases":[{"children":[{"children":[{"module":"账号","sub":"登录","title":"登录成功"},{"module":"账号","sub":"登录","title":"登录失败"}],"title":"登录"},{"children":[{"module":"账号","sub":"注销","title":"注销"}],"title":"注销"}],"title":"账号"},{"children":[{"children":[{"id":12345678901234567890,"module":"门店","sub":"新建","title":"创建门店"}],"title":"新建"}],"title":"门店"},{"title":"未分类"}]}}`,
+		},
+		{
+			name:      "未匹配",
+			exprs:     []string{"$.data.missing[*].module", "$.data.cases[*].owner"},
+			want:      `{"data":{"cases":[{"module":"账号","sub":"登录","title":"登录成功"},{"id":12345678901234567890,"module":"门店","sub":"新建","title":"创建门店"},{"module":"账号","sub":"登录","title":"登录失败"},{"module":"账号","sub":"注销","title":"注销"},{"title":"未分类"}]}}`,
+			unmatched: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := New(tt.exprs, "title", "children")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			got, unmatched, err := g.Apply([]byte(body))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Apply() = %s, want %s", got, tt.want)
+			}
+			if len(unmatched) != tt.unmatched {
+				t.Errorf("Apply() unmatched = %v, want %d rules", unmatched, tt.unmatched)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"$.cases.module", "$.cases[*]", "$.a[*].b[*].c", "cases[*].module", "$.cases[*]module"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", expr)
+		}
+	}
+}
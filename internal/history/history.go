@@ -0,0 +1,262 @@
+// Package history 将每次运行的请求指纹、抽取出的树与统计信息保存到SQLite数据库，
+// 用于长期跟踪同一接口的树结构随时间的变化。数据库通过本机的 sqlite3 命令行工具读写
+package history
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/report"
+)
+
+// schema 运行记录表，tree 为最终输出的树状JSON
+const schema = `CREATE TABLE IF NOT EXISTS runs (
+  id          INTEGER PRIMARY KEY AUTOINCREMENT,
+  created_at  TEXT    NOT NULL,
+  fingerprint TEXT    NOT NULL,
+  method      TEXT    NOT NULL,
+  url         TEXT    NOT NULL,
+  status      TEXT    NOT NULL,
+  status_code INTEGER NOT NULL DEFAULT 0,
+  nodes       INTEGER NOT NULL DEFAULT 0,
+  leaves      INTEGER NOT NULL DEFAULT 0,
+  depth       INTEGER NOT NULL DEFAULT 0,
+  duration_ms INTEGER NOT NULL DEFAULT 0,
+  error       TEXT    NOT NULL DEFAULT '',
+  tree        TEXT
+);
+CREATE INDEX IF NOT EXISTS runs_fingerprint ON runs (fingerprint, id);`
+
+// listColumns 列表中不包含树的列
+const listColumns = "id, created_at, fingerprint, method, url, status, status_code, nodes, leaves, depth, duration_ms, error"
+
+// DefaultPath 返回默认的数据库路径 ~/.curl2json/history.db
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".curl2json", "history.db")
+	}
+	return filepath.Join(home, ".curl2json", "history.db")
+}
+
+// Run 一次运行的记录
+type Run struct {
+	ID          int64           `json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Fingerprint string          `json:"fingerprint"`
+	Method      string          `json:"method"`
+	URL         string          `json:"url"` // 敏感查询参数已脱敏
+	Status      string          `json:"status"`
+	StatusCode  int             `json:"status_code,omitempty"`
+	Nodes       int             `json:"nodes"`
+	Leaves      int             `json:"leaves"`
+	Depth       int             `json:"depth"`
+	DurationMs  int64           `json:"duration_ms"`
+	Error       string          `json:"error,omitempty"`
+	Tree        json.RawMessage `json:"tree,omitempty"`
+}
+
+// row sqlite3 -json 输出的一行，时间与树均为文本
+type row struct {
+	Run
+	CreatedAt string  `json:"created_at"`
+	Tree      *string `json:"tree"`
+}
+
+func (r row) run() Run {
+	run := r.Run
+	run.CreatedAt, _ = time.Parse(time.RFC3339Nano, r.CreatedAt)
+	if r.Tree != nil {
+		run.Tree = json.RawMessage(*r.Tree)
+	}
+	return run
+}
+
+// Fingerprint 返回请求的指纹：方法、脱敏后的URL与请求体相同的请求指纹相同，
+// 因此token等凭据轮换后仍能匹配到同一接口的历史记录
+func Fingerprint(req *config.RequestInfo) string {
+	sum := sha256.Sum256([]byte(strings.ToUpper(req.Method) + "\n" + report.RedactURL(req.URL) + "\n" + req.Body))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Store 历史数据库
+type Store struct {
+	path string
+}
+
+// Open 打开数据库，不存在时创建数据库文件与所在目录
+func Open(path string) (*Store, error) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		return nil, i18n.Errorf("记录运行历史需要 sqlite3 命令行工具: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, i18n.Errorf("创建历史数据库目录失败: %w", err)
+	}
+	s := &Store{path: path}
+	if err := s.exec(context.Background(), schema, nil); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add 保存运行记录，返回记录ID
+func (s *Store) Add(ctx context.Context, run *Run) (int64, error) {
+	if run.CreatedAt.IsZero() {
+		run.CreatedAt = time.Now()
+	}
+	tree := "NULL"
+	if len(run.Tree) > 0 {
+		tree = quote(string(run.Tree))
+	}
+	sql := fmt.Sprintf(`INSERT INTO runs (created_at, fingerprint, method, url, status, status_code, nodes, leaves, depth, duration_ms, error, tree)
+VALUES (%s, %s, %s, %s, %s, %d, %d, %d, %d, %d, %s, %s);
+SELECT last_insert_rowid() AS id;`,
+		quote(run.CreatedAt.UTC().Format(time.RFC3339Nano)), quote(run.Fingerprint), quote(run.Method), quote(run.URL),
+		quote(run.Status), run.StatusCode, run.Nodes, run.Leaves, run.Depth, run.DurationMs, quote(run.Error), tree)
+
+	var rows []struct {
+		ID int64 `json:"id"`
+	}
+	if err := s.exec(ctx, sql, &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, i18n.Errorf("保存运行历史失败: 没有返回记录ID")
+	}
+	run.ID = rows[0].ID
+	return run.ID, nil
+}
+
+// List 按时间倒序列出最近limit条记录（不含树），fingerprint 非空时只列出该请求的记录
+func (s *Store) List(ctx context.Context, fingerprint string, limit int) ([]Run, error) {
+	where := ""
+	if fingerprint != "" {
+		where = "WHERE fingerprint = " + quote(fingerprint)
+	}
+	return s.query(ctx, fmt.Sprintf("SELECT %s FROM runs %s ORDER BY id DESC LIMIT %d;", listColumns, where, limit))
+}
+
+// Get 返回指定ID的记录（含树）
+func (s *Store) Get(ctx context.Context, id int64) (*Run, error) {
+	runs, err := s.query(ctx, fmt.Sprintf("SELECT * FROM runs WHERE id = %d;", id))
+	if err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 {
+		return nil, i18n.Errorf("运行历史中没有ID为 %d 的记录", id)
+	}
+	return &runs[0], nil
+}
+
+// Previous 返回同一请求在run之前最近一次有树的记录，不存在时返回nil
+func (s *Store) Previous(ctx context.Context, run *Run) (*Run, error) {
+	runs, err := s.query(ctx, fmt.Sprintf("SELECT * FROM runs WHERE fingerprint = %s AND id < %d AND tree IS NOT NULL ORDER BY id DESC LIMIT 1;",
+		quote(run.Fingerprint), run.ID))
+	if err != nil || len(runs) == 0 {
+		return nil, err
+	}
+	return &runs[0], nil
+}
+
+func (s *Store) query(ctx context.Context, sql string) ([]Run, error) {
+	var rows []row
+	if err := s.exec(ctx, sql, &rows); err != nil {
+		return nil, err
+	}
+	runs := make([]Run, len(rows))
+	for i, r := range rows {
+		runs[i] = r.run()
+	}
+	return runs, nil
+}
+
+// exec 通过标准输入向 sqlite3 传入SQL，out 非nil时将 -json 格式的查询结果解析到out
+func (s *Store) exec(ctx context.Context, sql string, out interface{}) error {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sqlite3", "-bail", "-json", s.path)
+	cmd.Stdin = strings.NewReader(sql)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return i18n.Errorf("访问历史数据库 %s 失败: %w: %s", s.path, err, msg)
+		}
+		return i18n.Errorf("访问历史数据库 %s 失败: %w", s.path, err)
+	}
+	// 查询没有结果时 sqlite3 不输出任何内容
+	if out == nil || len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), out); err != nil {
+		return i18n.Errorf("解析历史数据库的查询结果失败: %w", err)
+	}
+	return nil
+}
+
+// quote 将文本转换为SQL字符串字面量
+func quote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+// Recorder 通过流水线钩子记录请求指纹与最终输出，运行结束后与运行报告一起保存
+type Recorder struct {
+	store       *Store
+	fingerprint string
+	method      string
+	url         string
+	output      []byte
+}
+
+// Recorder 创建本次运行的记录器
+func (s *Store) Recorder() *Recorder {
+	return &Recorder{store: s}
+}
+
+// Register 注册记录请求与输出的钩子
+func (r *Recorder) Register(hooks *pipeline.Hooks) {
+	hooks.After(pipeline.StageParse, func(ctx context.Context, state *pipeline.State) error {
+		if req := state.Request; req != nil {
+			r.fingerprint, r.method, r.url = Fingerprint(req), strings.ToUpper(req.Method), report.RedactURL(req.URL)
+		}
+		return nil
+	})
+	hooks.After(pipeline.StageRender, func(ctx context.Context, state *pipeline.State) error {
+		r.output = state.Output
+		return nil
+	})
+}
+
+// Save 保存本次运行，请求未能解析时没有可以跟踪的接口，不保存并返回0
+func (r *Recorder) Save(ctx context.Context, rep *report.Report) (int64, error) {
+	if r.fingerprint == "" {
+		return 0, nil
+	}
+	run := &Run{
+		CreatedAt:   rep.StartedAt,
+		Fingerprint: r.fingerprint,
+		Method:      r.method,
+		URL:         r.url,
+		Status:      rep.Status,
+		DurationMs:  rep.DurationMs,
+		Error:       rep.Error,
+		Tree:        r.output,
+	}
+	if rep.Response != nil {
+		run.StatusCode = rep.Response.StatusCode
+	}
+	if ext := rep.Extraction; ext != nil {
+		run.Nodes, run.Leaves, run.Depth = ext.Nodes, ext.Leaves, ext.Depth
+	}
+	return r.store.Add(ctx, run)
+}
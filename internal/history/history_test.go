@@ -0,0 +1,90 @@
+package history
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/report"
+)
+
+func TestFingerprint(t *testing.T) {
+	req := &config.RequestInfo{Method: "post", URL: "https://api.example.com/mind?token=abc&id=1", Body: `{"id":1}`}
+	rotated := &config.RequestInfo{Method: "POST", URL: "https://api.example.com/mind?token=xyz&id=1", Body: `{"id":1}`}
+	other := &config.RequestInfo{Method: "POST", URL: "https://api.example.com/mind?token=abc&id=2", Body: `{"id":1}`}
+
+	if Fingerprint(req) != Fingerprint(rotated) {
+		t.Error("Fingerprint() differs after token rotation")
+	}
+	if Fingerprint(req) == Fingerprint(other) {
+		t.Error("Fingerprint() equal for different requests")
+	}
+}
+
+func TestStore(t *testing.T) {
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("未安装 sqlite3")
+	}
+	ctx := context.Background()
+	store, err := Open(filepath.Join(t.TempDir(), "nested", "history.db"))
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	// 通过流水线钩子记录两次成功和一次失败的运行
+	save := func(url, output string, runErr error) int64 {
+		recorder := store.Recorder()
+		var hooks pipeline.Hooks
+		recorder.Register(&hooks)
+		state := &pipeline.State{Request: &config.RequestInfo{Method: "GET", URL: url}}
+		hooks.RunAfter(ctx, pipeline.StageParse, state)
+		if runErr == nil {
+			state.Output = []byte(output)
+			hooks.RunAfter(ctx, pipeline.StageRender, state)
+		}
+		rep := report.New()
+		id, err := recorder.Save(ctx, rep.Finish(runErr))
+		if err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		return id
+	}
+	first := save("https://api.example.com/mind?token=a", `[{"name":"客户's详情","children":[]}]`, nil)
+	failed := save("https://api.example.com/mind?token=b", "", context.DeadlineExceeded)
+	save("https://api.example.com/other", `[]`, nil)
+	latest := save("https://api.example.com/mind?token=c", `[{"name":"客户详情","children":[]}]`, nil)
+
+	got, err := store.Get(ctx, first)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if string(got.Tree) != `[{"name":"客户's详情","children":[]}]` || got.URL != "https://api.example.com/mind?token=REDACTED" ||
+		got.Status != report.StatusSuccess || time.Since(got.CreatedAt) > time.Minute {
+		t.Errorf("Get() = %+v", got)
+	}
+
+	runs, err := store.List(ctx, got.Fingerprint, 10)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(runs) != 3 || runs[0].ID != latest || runs[1].ID != failed || runs[1].Status != report.StatusError || runs[0].Tree != nil {
+		t.Errorf("List() = %+v", runs)
+	}
+
+	// 上一次有树的记录跳过失败的运行
+	current, _ := store.Get(ctx, latest)
+	previous, err := store.Previous(ctx, current)
+	if err != nil || previous == nil || previous.ID != first {
+		t.Errorf("Previous() = %+v, %v, want #%d", previous, err, first)
+	}
+	if previous, err := store.Previous(ctx, got); err != nil || previous != nil {
+		t.Errorf("Previous() of first run = %+v, %v, want nil", previous, err)
+	}
+	if _, err := store.Get(ctx, 999); err == nil {
+		t.Error("Get() missing id error = nil, want error")
+	}
+}
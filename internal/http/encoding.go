@@ -0,0 +1,64 @@
+package http
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// acceptEncoding 为 --compressed 时请求的压缩格式，与cURL一致
+const acceptEncoding = "gzip, deflate, br"
+
+// decodeBody 按 Content-Encoding 返回解压后的响应体：多个编码按相反的顺序依次解压，
+// 解压后从header中移除 Content-Encoding 与 Content-Length。传输层已经自动解压gzip时 Content-Encoding 为空，原样返回
+func decodeBody(header http.Header, body io.Reader) (io.Reader, error) {
+	var encodings []string
+	for _, value := range header.Values("Content-Encoding") {
+		for _, encoding := range strings.Split(value, ",") {
+			if encoding = strings.ToLower(strings.TrimSpace(encoding)); encoding != "" && encoding != "identity" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+	if len(encodings) == 0 {
+		return body, nil
+	}
+
+	for i := len(encodings) - 1; i >= 0; i-- {
+		reader, err := decoder(encodings[i], body)
+		if err != nil {
+			return nil, i18n.Errorf("解压响应体失败: %w", err)
+		}
+		body = reader
+	}
+	header.Del("Content-Encoding")
+	header.Del("Content-Length")
+	return body, nil
+}
+
+// decoder 返回一种压缩格式的解压reader
+func decoder(encoding string, r io.Reader) (io.Reader, error) {
+	switch encoding {
+	case "gzip", "x-gzip":
+		return gzip.NewReader(r)
+	case "deflate":
+		// deflate 按规范为zlib格式，也有服务直接返回原始deflate数据，根据zlib头的校验位区分
+		buffered := bufio.NewReader(r)
+		if header, err := buffered.Peek(2); err == nil && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			return zlib.NewReader(buffered)
+		}
+		return flate.NewReader(buffered), nil
+	case "br":
+		return brotli.NewReader(r), nil
+	default:
+		return nil, i18n.Errorf("不支持的响应压缩格式 %s", encoding)
+	}
+}
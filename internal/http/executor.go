@@ -2,69 +2,165 @@ package http
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
-	"caseurl2md/internal/config"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/placeholder"
+	"github.com/wellkilo/Curl2json/internal/progress"
+	"github.com/wellkilo/Curl2json/internal/redact"
 )
 
 // Executor HTTP请求执行器
 type Executor struct {
-	timeout time.Duration
-	verbose bool
+	timeout   time.Duration
+	transport http.RoundTripper
+	client    *http.Client
+	logger    *slog.Logger
+	progress  io.Writer
+
+	noRedirects bool // 不跟随重定向，见 WithRedirects
+	noSecrets   bool // 不解析可能读取本机凭据的占位符，见 WithLocalSecrets
+
+	transportMu sync.Mutex
+	transports  map[transportOptions]*http.Transport // 按代理与TLS选项缓存的传输，见 transportFor
+}
+
+// Option HTTP执行器选项
+type Option func(*Executor)
+
+// WithTimeout 设置请求超时时间，0 表示不限制
+func WithTimeout(timeout time.Duration) Option {
+	return func(e *Executor) { e.timeout = timeout }
+}
+
+// WithTransport 设置底层传输，nil 时使用 http.DefaultTransport；需要在多个执行器间复用连接时传入 NewTransport 创建的传输
+func WithTransport(transport http.RoundTripper) Option {
+	return func(e *Executor) { e.transport = transport }
+}
+
+// WithLogger 设置日志器，默认不输出日志
+func WithLogger(l *slog.Logger) Option {
+	return func(e *Executor) {
+		if l != nil {
+			e.logger = l
+		}
+	}
+}
+
+// WithProgress 设置下载进度的输出位置，nil 表示不显示
+func WithProgress(w io.Writer) Option {
+	return func(e *Executor) { e.progress = w }
+}
+
+// WithLocalSecrets 设置是否解析 env、keychain 等可能读取本机环境变量与凭据的占位符，默认解析；
+// 执行来自网络或代理等不受信任来源的请求时关闭，关闭后只解析 now、uuid、random，其余占位符使请求失败
+func WithLocalSecrets(allow bool) Option {
+	return func(e *Executor) { e.noSecrets = !allow }
 }
 
 // New 创建新的HTTP执行器
-func New(timeout time.Duration, verbose bool) *Executor {
-	return &Executor{
-		timeout: timeout,
-		verbose: verbose,
+func New(opts ...Option) *Executor {
+	e := &Executor{logger: logger.Discard()}
+	for _, opt := range opts {
+		opt(e)
 	}
+	// 客户端在执行器的整个生命周期内复用，连接由传输的连接池管理
+	e.client = &http.Client{
+		Timeout:   e.timeout,
+		Transport: e.transport,
+	}
+	return e
+}
+
+// Response HTTP响应
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Header      http.Header
+	Body        []byte
+	Curl        string // 与实际发送的请求等效的单行cURL命令，凭据已脱敏
+}
+
+// OK 状态码是否为2xx
+func (r *Response) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Execute 执行HTTP请求并返回响应体
+func (e *Executor) Execute(ctx context.Context, info *config.RequestInfo) ([]byte, error) {
+	resp, err := e.Do(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
 }
 
-// Execute 执行HTTP请求
-func (e *Executor) Execute(info *config.RequestInfo) ([]byte, error) {
-	if e.verbose {
-		fmt.Printf("执行HTTP请求: %s %s\n", info.Method, info.URL)
-		fmt.Printf("=== DEBUG: Headers Count: %d ===\n", len(info.Headers))
+// Do 执行HTTP请求，非2xx状态码不视为错误，由调用者根据状态码决定如何处理；
+// ctx 的截止时间与取消会作用于连接、发送和读取响应体的全过程
+func (e *Executor) Do(ctx context.Context, info *config.RequestInfo) (*Response, error) {
+	e.logger.Debug(i18n.T("执行HTTP请求"), "method", info.Method, "url", info.URL, "headers", len(info.Headers), "user", info.User)
+	if e.logger.Enabled(ctx, slog.LevelDebug) {
 		for key, value := range info.Headers {
-			maskedValue := e.maskSensitiveHeader(key, value)
-			fmt.Printf("Header: %s: %s\n", key, maskedValue)
-			// 检查关键的API特定headers
-			if key == "servicefunc" || key == "service" || key == "projectid" || key == "x-trigger-source" || key == "x-onesite-space-id" {
-				fmt.Printf("  ⭐ 关键业务Header: %s = %s\n", key, maskedValue)
-			}
+			e.logger.Debug(i18n.T("请求头"), "key", key, "value", e.maskSensitiveHeader(key, value), "business", isBusinessHeader(key))
+		}
+		for _, field := range info.Form {
+			e.logger.Debug(i18n.T("表单字段"), "name", field.Name, "file", field.File, "length", len(field.Value))
 		}
 		if info.Body != "" {
-			fmt.Printf("Body: %s\n", info.Body)
-			fmt.Printf("Body Length: %d bytes\n", len(info.Body))
 			// 检查JSON格式
-			if strings.HasPrefix(info.Body, "{") {
-				fmt.Printf("✅ Body format: Valid JSON start\n")
-			} else {
-				fmt.Printf("❌ Body format: May not be valid JSON\n")
-			}
+			e.logger.Debug(i18n.T("请求体"), "body", redact.Body(info.Body), "length", len(info.Body), "json_start", strings.HasPrefix(info.Body, "{"))
 		}
 	}
 
-	// 创建请求体
+	// 创建请求体，其中的占位符（如 {{uuid}}、{{now:unixms}}）与请求头一样在发送时解析；
+	// 有表单字段时以 multipart/form-data 发送
 	var body io.Reader
-	if info.Body != "" {
-		body = bytes.NewBufferString(info.Body)
+	var formType, traceBody string
+	if len(info.Form) > 0 {
+		form, contentType, err := formBody(info.Form, e.expand)
+		if err != nil {
+			return nil, err
+		}
+		body, formType = form, contentType
+	} else if info.Body != "" {
+		resolvedBody, tracedBody, err := e.expand(info.Body)
+		if err != nil {
+			return nil, i18n.Errorf("解析请求体占位符失败: %w", err)
+		}
+		body, traceBody = bytes.NewBufferString(resolvedBody), tracedBody
 	}
 
 	// 创建HTTP请求
-	req, err := http.NewRequest(info.Method, info.URL, body)
+	req, err := http.NewRequestWithContext(ctx, info.Method, info.URL, body)
 	if err != nil {
-		return nil, fmt.Errorf("创建HTTP请求失败: %w", err)
+		return nil, i18n.Errorf("创建HTTP请求失败: %w", err)
 	}
 
-	// 设置请求头
+	// 设置请求头，占位符（如 {{env:API_TOKEN}}）在此时才解析，避免凭据出现在日志中
+	tracedHeaders := make(map[string]string, len(info.Headers))
 	for key, value := range info.Headers {
-		req.Header.Set(key, value)
+		resolved, traced, err := e.expand(value)
+		if err != nil {
+			return nil, i18n.Errorf("解析请求头占位符失败: %w", fmt.Errorf("%s: %w", key, err))
+		}
+		req.Header.Set(key, resolved)
+		tracedHeaders[http.CanonicalHeaderKey(key)] = traced
+	}
+	if err := e.setAuth(req, info); err != nil {
+		return nil, err
+	}
+	// 与cURL一样使用生成的boundary，替换复制来的Content-Type
+	if formType != "" {
+		req.Header.Set("Content-Type", formType)
 	}
 
 	// 如果没有设置Content-Type但有请求体，设置为application/json
@@ -72,67 +168,112 @@ func (e *Executor) Execute(info *config.RequestInfo) ([]byte, error) {
 		req.Header.Set("Content-Type", "application/json")
 	}
 
-	// 创建HTTP客户端
-	client := &http.Client{
-		Timeout: e.timeout,
-	}
+	// 与实际发送内容等效的cURL命令（已脱敏），便于复现
+	curl := curlLine(req, tracedHeaders, traceBody, info)
+	e.logger.Debug(i18n.T("开始发送请求"), "curl", curl)
 
-	if e.verbose {
-		fmt.Println("开始发送请求...")
+	// 在生成cURL命令之后设置，复现的命令以 --compressed 表示；显式设置的 Accept-Encoding 请求头优先。
+	// 设置了 Accept-Encoding 时传输层不会自动解压，响应由 decodeBody 解压
+	if info.Compressed && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
 	}
 
 	// 执行请求
+	client, err := e.clientFor(info)
+	if err != nil {
+		return nil, err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("HTTP请求执行失败: %w", err)
+		return nil, i18n.Errorf("HTTP请求执行失败: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if e.verbose {
-		fmt.Printf("收到响应，状态码: %d %s\n", resp.StatusCode, resp.Status)
-	}
+	e.logger.Debug(i18n.T("收到响应"), "status", resp.StatusCode)
 
 	// 读取响应体（无论状态码如何）
-	bodyBytes, err := io.ReadAll(resp.Body)
+	var reader io.Reader = resp.Body
+	if e.progress != nil {
+		pr := progress.NewReader(resp.Body, resp.ContentLength, e.progress)
+		defer pr.Finish()
+		reader = pr
+	}
+	if reader, err = decodeBody(resp.Header, reader); err != nil {
+		return nil, err
+	}
+	bodyBytes, err := io.ReadAll(reader)
 	if err != nil {
-		return nil, fmt.Errorf("读取响应体失败: %w", err)
+		return nil, i18n.Errorf("读取响应体失败: %w", err)
 	}
 
 	// 检查状态码但不立即返回错误，而是记录警告
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		if e.verbose {
-			fmt.Printf("警告: 服务器返回非2xx状态码: %d %s\n", resp.StatusCode, resp.Status)
-			fmt.Printf("响应体长度: %d 字节\n", len(bodyBytes))
-			if len(bodyBytes) > 0 {
-				preview := string(bodyBytes)
-				if len(preview) > 200 {
-					preview = preview[:200] + "..."
-				}
-				fmt.Printf("响应体预览: %s\n", preview)
+		e.logger.Warn(i18n.T("服务器返回非2xx状态码"), "status", resp.StatusCode, "size", len(bodyBytes))
+		if len(bodyBytes) > 0 {
+			preview := string(bodyBytes)
+			if len(preview) > 200 {
+				preview = preview[:200] + "..."
 			}
+			e.logger.Debug(i18n.T("响应体预览"), "preview", preview)
 		}
 		// 不要直接返回错误，继续处理响应体
 		// 调用者可以根据需要决定是否处理非2xx���应
 	}
 
-	if e.verbose {
-		fmt.Printf("成功读取响应体，大小: %d 字节\n", len(bodyBytes))
-	}
+	e.logger.Debug(i18n.T("成功读取响应体"), "size", len(bodyBytes))
 
-	return bodyBytes, nil
+	return &Response{StatusCode: resp.StatusCode, ContentType: resp.Header.Get("Content-Type"), Header: resp.Header, Body: bodyBytes, Curl: curl}, nil
 }
 
-// maskSensitiveHeader 遮蔽敏感header信息
-func (e *Executor) maskSensitiveHeader(key, value string) string {
-	lowerKey := strings.ToLower(key)
+// setAuth 按 -u/--user 写入认证请求头；与cURL一样，显式设置的 Authorization 请求头优先。
+// 用户名与密码中的占位符（如 {{env:API_PASSWORD}}）在此时解析
+func (e *Executor) setAuth(req *http.Request, info *config.RequestInfo) error {
+	if info.User == "" || req.Header.Get("Authorization") != "" {
+		return nil
+	}
+	switch strings.ToLower(info.AuthType) {
+	case "", "basic":
+	default:
+		return i18n.Errorf("暂不支持 %s 认证，请改用 --basic 或直接设置 Authorization 请求头", info.AuthType)
+	}
+	user, _, err := e.expand(info.User)
+	if err != nil {
+		return i18n.Errorf("解析认证信息占位符失败: %w", err)
+	}
+	password, _, err := e.expand(info.Password)
+	if err != nil {
+		return i18n.Errorf("解析认证信息占位符失败: %w", err)
+	}
+	req.SetBasicAuth(user, password)
+	return nil
+}
 
-	switch lowerKey {
-	case "authorization", "cookie", "set-cookie", "x-api-key", "x-auth-token":
-		if len(value) > 8 {
-			return value[:4] + "***" + value[len(value)-4:]
-		}
-		return "***"
+// expand 解析占位符，返回值与 placeholder.ExpandTrace 相同，见 WithLocalSecrets
+func (e *Executor) expand(value string) (resolved, trace string, err error) {
+	if e.noSecrets {
+		return placeholder.ExpandTraceUntrusted(value)
+	}
+	return placeholder.ExpandTrace(value)
+}
+
+// isBusinessHeader 检查是否为关键的API特定header
+func isBusinessHeader(key string) bool {
+	switch key {
+	case "servicefunc", "service", "projectid", "x-trigger-source", "x-onesite-space-id":
+		return true
 	default:
-		return value
+		return false
 	}
-}
\ No newline at end of file
+}
+
+// maskSensitiveHeader 遮蔽敏感header信息，名称规则见 redact.Sensitive（包括 --redact-rules 中的请求头）；
+// 其他请求头按脱敏规则文件中的正则表达式处理
+func (e *Executor) maskSensitiveHeader(key, value string) string {
+	if !redact.Sensitive(key) && !strings.EqualFold(key, "set-cookie") {
+		return redact.Text(value)
+	}
+	if len(value) > 8 {
+		return value[:4] + "***" + value[len(value)-4:]
+	}
+	return "***"
+}
@@ -0,0 +1,96 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// quoteEscaper 转义 Content-Disposition 中字段名与文件名的引号和反斜杠，与 mime/multipart 一致
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// formBody 将表单字段编码为 multipart/form-data 请求体，返回请求体与带boundary的Content-Type；
+// 文本字段中的占位符在此时由expand解析，文件在发送时读取
+func formBody(fields []config.FormField, expand func(string) (string, string, error)) (*bytes.Buffer, string, error) {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for _, field := range fields {
+		if err := writeFormField(w, field, expand); err != nil {
+			return nil, "", i18n.Errorf("表单字段 %s: %w", field.Name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return body, w.FormDataContentType(), nil
+}
+
+// writeFormField 写入一个表单字段
+func writeFormField(w *multipart.Writer, field config.FormField, expand func(string) (string, string, error)) error {
+	header := make(textproto.MIMEHeader)
+	disposition := fmt.Sprintf(`form-data; name="%s"`, quoteEscaper.Replace(field.Name))
+
+	if field.File != "" {
+		file, err := os.Open(field.File)
+		if err != nil {
+			return i18n.Errorf("读取上传文件失败: %w", err)
+		}
+		defer file.Close()
+
+		filename := field.Filename
+		if filename == "" {
+			filename = filepath.Base(field.File)
+		}
+		contentType := field.ContentType
+		if contentType == "" {
+			if contentType = mime.TypeByExtension(filepath.Ext(field.File)); contentType == "" {
+				contentType = "application/octet-stream"
+			}
+		}
+		header.Set("Content-Disposition", fmt.Sprintf(`%s; filename="%s"`, disposition, quoteEscaper.Replace(filename)))
+		header.Set("Content-Type", contentType)
+		part, err := w.CreatePart(header)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, file)
+		return err
+	}
+
+	value := field.Value
+	if field.ValueFile != "" {
+		content, err := os.ReadFile(field.ValueFile)
+		if err != nil {
+			return i18n.Errorf("读取字段内容文件失败: %w", err)
+		}
+		value = string(content)
+	} else {
+		expanded, _, err := expand(value)
+		if err != nil {
+			return i18n.Errorf("解析表单字段占位符失败: %w", err)
+		}
+		value = expanded
+	}
+	if field.Filename != "" {
+		disposition += fmt.Sprintf(`; filename="%s"`, quoteEscaper.Replace(field.Filename))
+	}
+	header.Set("Content-Disposition", disposition)
+	if field.ContentType != "" {
+		header.Set("Content-Type", field.ContentType)
+	}
+	part, err := w.CreatePart(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(part, value)
+	return err
+}
@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+// proxyFunc 返回按代理选项选择代理的函数：匹配 --noproxy 的主机直连，其余使用 -x 指定的代理，
+// 没有指定时按环境变量选择；-U 的用户信息同样用于环境变量中的代理
+func proxyFunc(opts transportOptions) (func(*http.Request) (*url.URL, error), error) {
+	var fixed *url.URL
+	if opts.proxy != "" {
+		u, err := url.Parse(opts.proxy)
+		if err != nil || u.Host == "" {
+			return nil, i18n.Errorf("无效的代理地址 %q", redact.URL(opts.proxy))
+		}
+		fixed = u
+	}
+	var noProxy []string
+	if opts.noProxy != "" {
+		noProxy = strings.Split(opts.noProxy, ",")
+	}
+
+	return func(req *http.Request) (*url.URL, error) {
+		if bypassProxy(req.URL.Hostname(), noProxy) {
+			return nil, nil
+		}
+		proxy := fixed
+		if proxy == nil {
+			env, err := http.ProxyFromEnvironment(req)
+			if err != nil || env == nil {
+				return env, err
+			}
+			proxy = env
+		}
+		if opts.user != "" {
+			copied := *proxy
+			if user, password, ok := strings.Cut(opts.user, ":"); ok {
+				copied.User = url.UserPassword(user, password)
+			} else {
+				copied.User = url.User(user)
+			}
+			proxy = &copied
+		}
+		return proxy, nil
+	}, nil
+}
+
+// bypassProxy 按cURL --noproxy 的规则判断主机是否直连：* 匹配全部主机，域名匹配自身及其子域名（可以有前导点），
+// IP地址与网段（如 10.0.0.0/8）匹配其中的地址
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	ip := net.ParseIP(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(entry), "."))
+		switch {
+		case entry == "":
+		case entry == "*":
+			return true
+		case strings.Contains(entry, "/"):
+			if _, network, err := net.ParseCIDR(entry); err == nil && ip != nil && network.Contains(ip) {
+				return true
+			}
+		default:
+			entry = strings.TrimPrefix(entry, ".")
+			if host == entry || strings.HasSuffix(host, "."+entry) {
+				return true
+			}
+			if entryIP := net.ParseIP(entry); entryIP != nil && ip != nil && entryIP.Equal(ip) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package http
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+// envProxy 由 TestMain 启动并写入 HTTP_PROXY 的代理。net/http 在第一次使用时缓存代理环境变量，
+// 因此必须在任何测试发送请求之前设置；访问 localhost 与回环地址的请求不经过环境变量中的代理，不影响其他测试
+var envProxy *recordingProxy
+
+func TestMain(m *testing.M) {
+	envProxy = newRecordingProxy("env")
+	os.Setenv("HTTP_PROXY", envProxy.URL)
+	os.Unsetenv("http_proxy")
+	os.Unsetenv("NO_PROXY")
+	os.Unsetenv("no_proxy")
+	code := m.Run()
+	envProxy.Close()
+	os.Exit(code)
+}
+
+// recordingProxy 记录收到的请求的HTTP代理，响应体为代理的名称
+type recordingProxy struct {
+	*httptest.Server
+	mu       sync.Mutex
+	urls     []string
+	authUser string
+}
+
+func newRecordingProxy(name string) *recordingProxy {
+	p := &recordingProxy{}
+	p.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.urls = append(p.urls, r.URL.String())
+		if auth, ok := strings.CutPrefix(r.Header.Get("Proxy-Authorization"), "Basic "); ok {
+			decoded, _ := base64.StdEncoding.DecodeString(auth)
+			p.authUser = string(decoded)
+		}
+		p.mu.Unlock()
+		io.WriteString(w, name)
+	}))
+	return p
+}
+
+// last 返回最后一个请求的URL与代理认证信息
+func (p *recordingProxy) last() (string, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.urls) == 0 {
+		return "", p.authUser
+	}
+	return p.urls[len(p.urls)-1], p.authUser
+}
+
+func TestBypassProxy(t *testing.T) {
+	tests := []struct {
+		host    string
+		noProxy string
+		want    bool
+	}{
+		{host: "api.example.com", noProxy: "", want: false},
+		{host: "api.example.com", noProxy: "*", want: true},
+		{host: "api.example.com", noProxy: "example.com", want: true},
+		{host: "api.example.com", noProxy: ".example.com", want: true},
+		{host: "example.com", noProxy: ".example.com", want: true},
+		{host: "API.Example.com.", noProxy: " example.COM ", want: true},
+		{host: "notexample.com", noProxy: "example.com", want: false},
+		{host: "example.com", noProxy: "api.example.com", want: false},
+		{host: "internal", noProxy: "localhost, .internal", want: true},
+		{host: "10.1.2.3", noProxy: "10.0.0.0/8", want: true},
+		{host: "11.1.2.3", noProxy: "10.0.0.0/8", want: false},
+		{host: "api.example.com", noProxy: "10.0.0.0/8", want: false},
+		{host: "192.168.1.10", noProxy: "192.168.1.10", want: true},
+		{host: "::1", noProxy: "0:0:0:0:0:0:0:1", want: true},
+		{host: "192.168.1.11", noProxy: "192.168.1.10", want: false},
+		{host: "api.example.com", noProxy: ",,", want: false},
+	}
+	for _, tt := range tests {
+		if got := bypassProxy(tt.host, strings.Split(tt.noProxy, ",")); got != tt.want {
+			t.Errorf("bypassProxy(%q, %q) = %v, want %v", tt.host, tt.noProxy, got, tt.want)
+		}
+	}
+}
+
+func TestExecutor_Proxy(t *testing.T) {
+	flagProxy := newRecordingProxy("flag")
+	defer flagProxy.Close()
+	direct := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "direct")
+	}))
+	defer direct.Close()
+
+	// api.example.test 不可解析，只有经过代理时请求才能成功
+	const remote = "http://api.example.test/cases?page=1"
+	tests := []struct {
+		name     string
+		info     config.RequestInfo
+		want     string
+		wantURL  string
+		wantUser string
+	}{
+		{
+			name:    "-x 指定的代理",
+			info:    config.RequestInfo{URL: remote, Proxy: flagProxy.URL},
+			want:    "flag",
+			wantURL: remote,
+		},
+		{
+			name:     "-x 与 -U",
+			info:     config.RequestInfo{URL: remote, Proxy: flagProxy.URL, ProxyUser: "alice:s3:cret"},
+			want:     "flag",
+			wantURL:  remote,
+			wantUser: "alice:s3:cret",
+		},
+		{
+			name:    "环境变量中的代理",
+			info:    config.RequestInfo{URL: remote},
+			want:    "env",
+			wantURL: remote,
+		},
+		{
+			name:     "-U 用于环境变量中的代理",
+			info:     config.RequestInfo{URL: remote, ProxyUser: "bob"},
+			want:     "env",
+			wantURL:  remote,
+			wantUser: "bob:", // 只有用户名时密码为空
+		},
+		{
+			name:    "-x 也代理回环地址",
+			info:    config.RequestInfo{URL: direct.URL + "/a", Proxy: flagProxy.URL},
+			want:    "flag",
+			wantURL: direct.URL + "/a",
+		},
+		{
+			name: "--noproxy 网段直连",
+			info: config.RequestInfo{URL: direct.URL + "/a", Proxy: flagProxy.URL, NoProxy: "127.0.0.0/8"},
+			want: "direct",
+		},
+		{
+			name: "--noproxy * 不使用环境变量中的代理",
+			info: config.RequestInfo{URL: direct.URL + "/a", NoProxy: "*"},
+			want: "direct",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flagProxy.authUser, envProxy.authUser = "", ""
+			info := tt.info
+			info.Method = http.MethodGet
+			body, err := New().Execute(context.Background(), &info)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Fatalf("Execute() went through %q, want %q", body, tt.want)
+			}
+			proxy := map[string]*recordingProxy{"flag": flagProxy, "env": envProxy}[tt.want]
+			if proxy == nil {
+				return
+			}
+			url, user := proxy.last()
+			if url != tt.wantURL || user != tt.wantUser {
+				t.Errorf("proxy got %q with user %q, want %q with user %q", url, user, tt.wantURL, tt.wantUser)
+			}
+		})
+	}
+
+	if _, err := New().Execute(context.Background(), &config.RequestInfo{Method: http.MethodGet, URL: remote, Proxy: "http://"}); err == nil {
+		t.Error("Execute() with an invalid proxy should fail")
+	}
+}
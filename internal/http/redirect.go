@@ -0,0 +1,74 @@
+package http
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+// 未指定 --max-redirs 时最多跟随的重定向次数
+const (
+	defaultMaxRedirects  = 10 // 与 net/http 的默认值一致，即未指定 -L 时以往的行为
+	locationMaxRedirects = 50 // -L 时与cURL的默认值一致
+)
+
+// WithRedirects 设置是否跟随重定向，默认跟随；关闭后3xx响应原样返回，由调用者按状态码处理
+func WithRedirects(follow bool) Option {
+	return func(e *Executor) { e.noRedirects = !follow }
+}
+
+// clientFor 返回本次请求使用的客户端：与执行器共用超时，传输按请求的代理与TLS选项选择（见 transportFor），按请求设置重定向规则。
+// 每次请求使用单独的cookie jar，重定向过程中服务端设置的cookie（如登录跳转）会带到下一跳，但不会影响其他请求
+func (e *Executor) clientFor(info *config.RequestInfo) (*http.Client, error) {
+	client := *e.client
+	transport, err := e.transportFor(info)
+	if err != nil {
+		return nil, err
+	}
+	client.Transport = transport
+	if e.noRedirects {
+		client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+		return &client, nil
+	}
+	client.Jar, _ = cookiejar.New(nil)
+	client.CheckRedirect = e.checkRedirect(info)
+	return &client, nil
+}
+
+// checkRedirect 返回按请求的 --max-redirs 限制次数的重定向检查。跨主机时 net/http 只去掉 Authorization、Cookie
+// 等标准的凭据请求头，这里按 redact.Sensitive 同样去掉自定义的token请求头；--location-trusted 时全部照常携带
+func (e *Executor) checkRedirect(info *config.RequestInfo) func(req *http.Request, via []*http.Request) error {
+	max := defaultMaxRedirects
+	switch {
+	case info.MaxRedirects != nil:
+		max = *info.MaxRedirects
+	case info.Location:
+		max = locationMaxRedirects
+	}
+
+	return func(req *http.Request, via []*http.Request) error {
+		if max >= 0 && len(via) > max {
+			return i18n.Errorf("重定向次数超过 %d 次", max)
+		}
+		e.logger.Debug(i18n.T("跟随重定向"), "status", req.Response.StatusCode, "location", redact.URL(req.URL.String()), "hop", len(via))
+
+		first := via[0]
+		if req.URL.Hostname() == first.URL.Hostname() {
+			return nil
+		}
+		for name, values := range first.Header {
+			switch {
+			case info.LocationTrusted:
+				req.Header[name] = values
+			case redact.Sensitive(name):
+				req.Header.Del(name)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+func TestExecutor_RedirectHeaders(t *testing.T) {
+	// 两个服务的主机名分别为 localhost 与 127.0.0.1，重定向到 other 即为跨主机
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, r.Header.Get("Authorization")+"|"+r.Header.Get("X-Access-Token")+"|"+r.Header.Get("X-Trace"))
+	}))
+	defer other.Close()
+	otherURL := strings.Replace(other.URL, "127.0.0.1", "localhost", 1)
+
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cross":
+			http.Redirect(w, r, otherURL+"/final", http.StatusFound)
+		case "/same":
+			http.Redirect(w, r, "/final", http.StatusFound)
+		default:
+			io.WriteString(w, r.Header.Get("Authorization")+"|"+r.Header.Get("X-Access-Token")+"|"+r.Header.Get("X-Trace"))
+		}
+	}))
+	defer origin.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		trusted bool
+		want    string
+	}{
+		{name: "跨主机去掉凭据请求头", path: "/cross", want: "||t1"},
+		{name: "--location-trusted 跨主机照常携带", path: "/cross", trusted: true, want: "Bearer abc|tok|t1"},
+		{name: "同一主机照常携带", path: "/same", want: "Bearer abc|tok|t1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := New().Execute(context.Background(), &config.RequestInfo{
+				Method: http.MethodGet,
+				URL:    origin.URL + tt.path,
+				Headers: map[string]string{
+					"Authorization":  "Bearer abc",
+					"X-Access-Token": "tok",
+					"X-Trace":        "t1",
+				},
+				Location:        true,
+				LocationTrusted: tt.trusted,
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if string(body) != tt.want {
+				t.Errorf("headers after redirect = %q, want %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecutor_RedirectCookies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "sid", Value: "s1", Path: "/"})
+			http.Redirect(w, r, "/home", http.StatusFound)
+		default:
+			if cookie, err := r.Cookie("sid"); err == nil {
+				io.WriteString(w, cookie.Value)
+			}
+		}
+	}))
+	defer server.Close()
+
+	e := New()
+	body, err := e.Execute(context.Background(), &config.RequestInfo{Method: http.MethodGet, URL: server.URL + "/login"})
+	if err != nil || string(body) != "s1" {
+		t.Fatalf("Execute(/login) = %q, %v, want the cookie set during the redirect", body, err)
+	}
+	// 每次请求使用单独的cookie jar，上一次请求得到的cookie不会带到下一次请求
+	body, err = e.Execute(context.Background(), &config.RequestInfo{Method: http.MethodGet, URL: server.URL + "/home"})
+	if err != nil || string(body) != "" {
+		t.Errorf("Execute(/home) = %q, %v, want no cookie from the previous request", body, err)
+	}
+}
+
+func TestExecutor_MaxRedirects(t *testing.T) {
+	// /hops/n 再重定向n次后返回 ok
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/hops/"))
+		if n > 0 {
+			http.Redirect(w, r, "/hops/"+strconv.Itoa(n-1), http.StatusFound)
+			return
+		}
+		io.WriteString(w, "ok")
+	}))
+	defer server.Close()
+
+	limit := func(n int) *int { return &n }
+	tests := []struct {
+		name     string
+		hops     int
+		location bool
+		max      *int
+		wantErr  bool
+	}{
+		{name: "默认最多10次", hops: 10},
+		{name: "默认超过10次", hops: 11, wantErr: true},
+		{name: "-L 最多50次", hops: 50, location: true},
+		{name: "-L 超过50次", hops: 51, location: true, wantErr: true},
+		{name: "--max-redirs 2", hops: 2, location: true, max: limit(2)},
+		{name: "--max-redirs 2 超过", hops: 3, location: true, max: limit(2), wantErr: true},
+		{name: "--max-redirs 0", hops: 1, location: true, max: limit(0), wantErr: true},
+		{name: "--max-redirs -1 不限制", hops: 60, location: true, max: limit(-1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := New().Execute(context.Background(), &config.RequestInfo{
+				Method:       http.MethodGet,
+				URL:          server.URL + "/hops/" + strconv.Itoa(tt.hops),
+				Location:     tt.location,
+				MaxRedirects: tt.max,
+			})
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Execute() = %q, want a redirect limit error", body)
+				}
+				return
+			}
+			if err != nil || string(body) != "ok" {
+				t.Errorf("Execute() = %q, %v, want ok", body, err)
+			}
+		})
+	}
+
+	resp, err := New(WithRedirects(false)).Do(context.Background(), &config.RequestInfo{Method: http.MethodGet, URL: server.URL + "/hops/1"})
+	if err != nil || resp.StatusCode != http.StatusFound {
+		t.Errorf("Do() without following redirects = %v, %v, want 302", resp, err)
+	}
+}
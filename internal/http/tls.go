@@ -0,0 +1,66 @@
+package http
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// TLSConfig 按请求的 -k、--cacert、--cert 与 --key 返回TLS配置，没有这些选项时返回nil（使用默认配置）
+func TLSConfig(info *config.RequestInfo) (*tls.Config, error) {
+	opts := transportOptionsFor(info)
+	if !opts.hasTLS() {
+		return nil, nil
+	}
+	return tlsConfigFor(nil, opts)
+}
+
+// hasTLS 是否有TLS相关的选项
+func (o transportOptions) hasTLS() bool {
+	return o.insecure || o.caCert != "" || o.cert != "" || o.key != ""
+}
+
+// tlsConfigFor 在base的副本上应用TLS选项，没有TLS选项时原样返回base：
+// -k 跳过服务端证书校验；--cacert 与cURL一样以文件中的CA证书代替系统根证书；
+// --cert 为PEM格式的客户端证书，--key 为其私钥，未指定 --key 时私钥与证书在同一个文件中
+func tlsConfigFor(base *tls.Config, opts transportOptions) (*tls.Config, error) {
+	if !opts.hasTLS() {
+		return base, nil
+	}
+	cfg := &tls.Config{}
+	if base != nil {
+		cfg = base.Clone()
+	}
+	cfg.InsecureSkipVerify = opts.insecure
+
+	if opts.caCert != "" {
+		content, err := os.ReadFile(opts.caCert)
+		if err != nil {
+			return nil, i18n.Errorf("读取CA证书失败: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, i18n.Errorf("CA证书文件 %s 中没有PEM格式的证书", opts.caCert)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if opts.key != "" && opts.cert == "" {
+		return nil, i18n.Errorf("--key 需要配合 --cert 使用")
+	}
+	if opts.cert != "" {
+		key := opts.key
+		if key == "" {
+			key = opts.cert
+		}
+		cert, err := tls.LoadX509KeyPair(opts.cert, key)
+		if err != nil {
+			return nil, i18n.Errorf("加载客户端证书失败: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+// curlLine 返回与实际发送的请求等效的单行cURL命令：请求头为占位符解析、认证与表单处理之后的值，
+// traced 为请求头名称（规范形式）对应的 placeholder.ExpandTrace 结果，body 同样为 trace 版本；
+// 凭据按 redact 的规则替换为 REDACTED；表单字段、--compressed、重定向、代理与TLS选项取自info
+func curlLine(req *http.Request, traced map[string]string, body string, info *config.RequestInfo) string {
+	form := info.Form
+	args := []string{"curl"}
+	if hasData := body != "" || len(form) > 0; req.Method != http.MethodGet && !(hasData && req.Method == http.MethodPost) {
+		args = append(args, "-X", req.Method)
+	}
+	args = append(args, shellQuote(redact.URL(req.URL.String())))
+	if info.Compressed {
+		args = append(args, "--compressed")
+	}
+	switch {
+	case info.LocationTrusted:
+		args = append(args, "--location-trusted")
+	case info.Location:
+		args = append(args, "-L")
+	}
+	if info.MaxRedirects != nil {
+		args = append(args, "--max-redirs", strconv.Itoa(*info.MaxRedirects))
+	}
+	if info.Proxy != "" {
+		args = append(args, "-x", shellQuote(redact.URL(info.Proxy)))
+	}
+	if info.ProxyUser != "" {
+		user, _, _ := strings.Cut(info.ProxyUser, ":")
+		args = append(args, "-U", shellQuote(user+":"+redact.Redacted))
+	}
+	if info.NoProxy != "" {
+		args = append(args, "--noproxy", shellQuote(info.NoProxy))
+	}
+	if info.Insecure {
+		args = append(args, "-k")
+	}
+	for _, option := range []struct{ flag, path string }{{"--cacert", info.CACert}, {"--cert", info.Cert}, {"--key", info.Key}} {
+		if option.path != "" {
+			args = append(args, option.flag, shellQuote(option.path))
+		}
+	}
+
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		// -F 会生成新的boundary，不写入原来的Content-Type
+		if len(form) > 0 && name == "Content-Type" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			if t, ok := traced[name]; ok {
+				value = t
+			}
+			if redact.Sensitive(name) {
+				value = redact.Redacted
+			} else {
+				value = redact.Text(value)
+			}
+			args = append(args, "-H", shellQuote(name+": "+value))
+		}
+	}
+
+	for _, field := range form {
+		args = append(args, formArgs(field)...)
+	}
+	if len(form) == 0 && body != "" {
+		args = append(args, "--data-raw", shellQuote(redact.Body(body)))
+	}
+	return strings.Join(args, " ")
+}
+
+// formArgs 返回表单字段对应的 -F 或 --form-string 参数
+func formArgs(field config.FormField) []string {
+	var value string
+	switch {
+	case field.File != "":
+		value = "@" + field.File
+	case field.ValueFile != "":
+		value = "<" + field.ValueFile
+	default:
+		value = redact.Text(field.Value)
+		if field.ContentType == "" && field.Filename == "" {
+			return []string{"--form-string", shellQuote(field.Name + "=" + value)}
+		}
+	}
+	if field.ContentType != "" {
+		value += ";type=" + field.ContentType
+	}
+	if field.Filename != "" {
+		value += ";filename=" + field.Filename
+	}
+	return []string{"-F", shellQuote(field.Name + "=" + value)}
+}
+
+// shellQuote 按bash的规则引用参数：只含安全字符时原样返回，含换行等控制字符时使用 $'...'，
+// 其他情况使用单引号
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_@%+=:,./-") == "" {
+		return s
+	}
+	if strings.IndexFunc(s, func(r rune) bool { return r < 0x20 || r == 0x7f }) < 0 {
+		return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+	}
+
+	var b strings.Builder
+	b.WriteString("$'")
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '\\', '\'':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				b.WriteString(`\x`)
+				b.WriteByte("0123456789abcdef"[c>>4])
+				b.WriteByte("0123456789abcdef"[c&0xf])
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+	b.WriteByte('\'')
+	return b.String()
+}
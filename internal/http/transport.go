@@ -0,0 +1,95 @@
+package http
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+// 共享传输的连接池与TLS会话缓存大小
+const (
+	maxIdleConns        = 100
+	maxIdleConnsPerHost = 16
+	tlsSessionCacheSize = 64
+)
+
+// NewTransport 创建供多个执行器共享的传输：在 http.DefaultTransport 的基础上放宽每个主机的空闲连接数
+// （默认只有2个），并缓存TLS会话以便新连接复用握手结果。批量模式下所有请求共用一个传输，
+// 对同一主机的大量请求无需反复建立连接与完整握手
+func NewTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = maxIdleConns
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.ClientSessionCache = tls.NewLRUClientSessionCache(tlsSessionCacheSize)
+	return transport
+}
+
+// transportOptions 请求中影响传输的cURL选项（代理与TLS），同时作为按请求创建的传输的缓存键
+type transportOptions struct {
+	proxy   string
+	user    string
+	noProxy string
+
+	insecure bool
+	caCert   string
+	cert     string
+	key      string
+}
+
+// transportOptionsFor 返回请求中影响传输的选项
+func transportOptionsFor(info *config.RequestInfo) transportOptions {
+	return transportOptions{
+		proxy: info.Proxy, user: info.ProxyUser, noProxy: info.NoProxy,
+		insecure: info.Insecure, caCert: info.CACert, cert: info.Cert, key: info.Key,
+	}
+}
+
+// transportFor 返回本次请求使用的传输：cURL命令中没有代理与TLS选项时为执行器的传输，其代理按 HTTP_PROXY、HTTPS_PROXY
+// 与 NO_PROXY 环境变量选择；有这些选项时复制执行器的传输（为nil时复制 http.DefaultTransport）并设置代理与TLS，
+// 按选项缓存，选项相同的请求复用连接。执行器的传输不是 *http.Transport（如回放抓包）时忽略这些选项
+func (e *Executor) transportFor(info *config.RequestInfo) (http.RoundTripper, error) {
+	opts := transportOptionsFor(info)
+	if opts == (transportOptions{}) {
+		return e.transport, nil
+	}
+
+	base, ok := e.transport.(*http.Transport)
+	if e.transport == nil {
+		base, ok = http.DefaultTransport.(*http.Transport)
+	}
+	if !ok {
+		e.logger.Debug(i18n.T("传输不支持代理与TLS选项，忽略这些选项"), "proxy", redact.URL(opts.proxy))
+		return e.transport, nil
+	}
+
+	e.transportMu.Lock()
+	defer e.transportMu.Unlock()
+	if transport, ok := e.transports[opts]; ok {
+		return transport, nil
+	}
+	transport := base.Clone()
+	if opts.proxy != "" || opts.user != "" || opts.noProxy != "" {
+		proxy, err := proxyFunc(opts)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = proxy
+		e.logger.Debug(i18n.T("使用代理"), "proxy", redact.URL(opts.proxy), "noproxy", opts.noProxy)
+	}
+	tlsConfig, err := tlsConfigFor(transport.TLSClientConfig, opts)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+	if e.transports == nil {
+		e.transports = make(map[transportOptions]*http.Transport)
+	}
+	e.transports[opts] = transport
+	return transport, nil
+}
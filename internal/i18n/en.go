@@ -0,0 +1,1124 @@
+package i18n
+
+// en 英文翻译表
+var en = map[string]string{
+	// assert
+	"无法解析断言 %s：body 之后应为 contains 或 !contains": "cannot parse assertion %s: body must be followed by contains or !contains",
+	"无法解析断言 %s：缺少比较运算符（==、!=、>、>=、<、<=）":       "cannot parse assertion %s: missing comparison operator (==, !=, >, >=, <, <=)",
+	"无法解析断言 %s：状态码应为整数":                        "cannot parse assertion %s: status code must be an integer",
+	"无法解析断言 %s: %w":                            "cannot parse assertion %s: %w",
+	"无法解析断言 %s：左侧应为 status 或以 $ 开头的JSONPath":   "cannot parse assertion %s: left side must be status or a JSONPath starting with $",
+	"响应体不是有效的JSON":                             "response body is not valid JSON",
+	"字段不存在":                                    "field not found",
+	"响应断言失败（%d/%d 未通过）:":                       "response assertions failed (%d/%d failed):",
+	"（实际: %s）":                                 " (actual: %s)",
+
+	// auth
+	"无效的凭据写入位置 %q，应为 header:名称 或 cookie:名称": "invalid credential target %q, expected header:NAME or cookie:NAME",
+	"使用刷新请求时必须指定新token的JSONPath":            "a JSONPath for the new token is required when using a refresh request",
+	"刷新请求失败: %w":       "refresh request failed: %w",
+	"刷新响应中没有找到 %s":     "%s not found in the refresh response",
+	"刷新响应中 %s 不是非空字符串": "%s in the refresh response is not a non-empty string",
+
+	// batch
+	"抽取结果为空树":            "extracted tree is empty",
+	"创建输出目录失败: %w":       "failed to create output directory: %w",
+	"执行cURL命令":           "executing cURL command",
+	"汇总报告序列化失败: %w":      "failed to serialize summary report: %w",
+	"写入汇总报告失败: %w":       "failed to write summary report: %w",
+	"读取汇总报告失败: %w":       "failed to read summary report: %w",
+	"解析汇总报告 %s 失败: %w":   "failed to parse summary report %s: %w",
+	"沿用上次执行的结果":          "reusing the result from the last run",
+	"CSV文件为空":            "CSV file is empty",
+	"读取CSV表头失败: %w":      "failed to read CSV header: %w",
+	"读取CSV数据失败: %w":      "failed to read CSV data: %w",
+	"CSV文件没有数据行":         "CSV file has no data rows",
+	"第 %d 行变量: %w":       "variables of row %d: %w",
+	"CSV中缺少变量: %s":       "missing variables in CSV: %s",
+	"内联选项无效: %w":         "invalid inline options: %w",
+	"内联选项 timeout 不能为负数": "inline option timeout must not be negative",
+	"第 %d 个请求的输出文件名 %q 只能是文件名，不能包含目录": "request %d: output name %q must be a file name without directories",
+	"第 %d 个请求的输出文件名 %s 与汇总报告重名":       "output name of request %d, %s, conflicts with the summary report",
+	"第 %d 个与第 %d 个请求的输出文件名都是 %s":      "requests %d and %d both write to %s",
+	"第 %d 行的内联选项不是完整的JSON对象":          "inline options at line %d are not a complete JSON object",
+	"第 %d 行的内联选项之后没有cURL命令":           "no cURL command after the inline options at line %d",
+	"第 %d 行: 同一个请求只能有一组内联选项":          "line %d: a request can only have one set of inline options",
+
+	// bodyedit
+	"无效的 --body-set %q，格式应为 路径=值":     "invalid --body-set %q, expected path=value",
+	"无效的 --body-set %q: %w":           "invalid --body-set %q: %w",
+	"无效的 --body-delete %q: %w":        "invalid --body-delete %q: %w",
+	"无效的 --body-delete %q: 不能删除整个请求体": "invalid --body-delete %q: cannot delete the whole request body",
+	"路径为空":              "empty path",
+	"请求没有请求体，无法修改":      "the request has no body to edit",
+	"请求体不是有效的JSON，无法修改": "the request body is not valid JSON and cannot be edited",
+	"%s: 请求体中不存在路径 %s":  "%s: path %s does not exist in the request body",
+
+	// cli
+	"开始批量执行": "starting batch run",
+	"  ✅ [%d] 第 %d 行 -> %s (%s)\n": `  ✅ [%d] line %d -> %s (%s)
+`,
+	"  ❌ [%d] 第 %d 行: %s\n": `  ❌ [%d] line %d: %s
+`,
+	"  ⏭️ [%d] 第 %d 行 -> %s (上次已完成)\n": `  ⏭️ [%d] line %d -> %s (completed last time)
+`,
+	"批量执行完成: 成功 %d，失败 %d，耗时 %s，汇总报告: %s\n": `Batch finished: %d succeeded, %d failed, took %s, summary report: %s
+`,
+	"其中 %d 个请求沿用了上次的结果\n": `%d of them reused the result from the last run
+`,
+	"输出目录中没有上次的汇总报告，执行全部请求":                                 "no summary report from a previous run in the output directory, running all requests",
+	"批量执行中有 %d 个请求失败":                                       "%d request(s) failed in batch run",
+	"读取批量文件失败: %w":                                          "failed to read batch file: %w",
+	"解析批量文件 %s 失败: %w":                                      "failed to parse batch file %s: %w",
+	"批量文件中没有cURL命令: %s":                                     "no cURL commands found in batch file: %s",
+	"--batch-data 需要配合cURL命令模板使用（--curl-file、--from-curl等）": "--batch-data requires a cURL command template (--curl-file, --from-curl, etc.)",
+	"cURL模板":            "cURL template",
+	"读取CSV变量文件失败: %w":   "failed to read CSV variable file: %w",
+	"%s × %s(%d行)":      "%s × %s (%d rows)",
+	"执行cURL请求并输出树状JSON": "Execute a cURL request and output tree JSON",
+	"启动MCP工具服务（stdio），供LLM Agent和IDE助手调用": "Start the MCP tool server (stdio) for LLM agents and IDE assistants",
+	`通过标准输入/输出提供Model Context Protocol服务，暴露两个工具：
+
+  fetch_and_extract_tree   执行cURL请求并抽取业务用例树
+  extract_tree_from_json   从已有JSON响应中抽取业务用例树
+
+在MCP客户端中配置命令 "caseurl2md mcp" 即可使用。`: `Serves the Model Context Protocol over standard input/output and exposes two tools:
+
+  fetch_and_extract_tree   execute a cURL request and extract the business case tree
+  extract_tree_from_json   extract the business case tree from an existing JSON response
+
+Configure the command "caseurl2md mcp" in your MCP client to use it.`,
+	`  # Claude Desktop / IDE 配置示例
+  {"mcpServers": {"caseurl2md": {"command": "caseurl2md", "args": ["mcp"]}}}`: `  # Claude Desktop / IDE configuration example
+  {"mcpServers": {"caseurl2md": {"command": "caseurl2md", "args": ["mcp"]}}}`,
+	"默认的节点内容字段候选键名":               "default candidate keys for node content",
+	"默认的子节点数组候选键名":                "default candidate keys for child node arrays",
+	"响应体最大字节数，超过时校验失败（0 表示不限制）":   "maximum response body size in bytes, larger responses fail validation (0 means unlimited)",
+	"响应JSON最大嵌套深度（0 表示不限制）":       "maximum nesting depth of the response JSON (0 means unlimited)",
+	"响应JSON中单个字符串的最大字节数（0 表示不限制）": "maximum size in bytes of a single string in the response JSON (0 means unlimited)",
+	"默认的HTTP请求超时时间（秒）":            "default HTTP request timeout (seconds)",
+	"cURL请求到树状JSON转换工具":           "Convert cURL requests into tree-structured JSON",
+	`将cURL命令转换为精简的树状JSON结构工具。
+
+该工具能够：
+1. 解析cURL命令
+2. 执行HTTP请求
+3. 从JSON响应中抽取树状结构
+4. 输出仅包含case_title和children的精简JSON
+
+支持三种输入方式：
+- 从stdin读取cURL命令
+- 从文件读取cURL命令
+- 通过命令行参数直接指定请求信息`: `Converts cURL commands into a compact tree-structured JSON.
+
+The tool can:
+1. Parse cURL commands
+2. Execute HTTP requests
+3. Extract a tree structure from the JSON response
+4. Output compact JSON containing only case_title and children
+
+Three input methods are supported:
+- Read the cURL command from stdin
+- Read the cURL command from a file
+- Specify request details directly via command-line flags`,
+	`  # 直接使用cURL命令
+  ./caseurl2md --from-curl 'curl "http://example.com/api" -H "Authorization: Bearer token"'
+
+  # 从文件读取cURL
+  ./caseurl2md --curl-file curl.txt --out result.json
+
+  # 批量执行文件中的多个cURL
+  ./caseurl2md --batch curls.txt --out results/
+
+  # 从剪贴板读取（浏览器中 Copy as cURL 后直接运行）
+  ./caseurl2md --from-clipboard
+
+  # 使用 -- 原样透传cURL参数，避免引号与flag冲突
+  ./caseurl2md fetch --out result.json -- curl "http://example.com/api" -H "Authorization: Bearer token"
+
+  # 手动指定参数
+  ./caseurl2md --url "http://api.example.com/data" --header "Content-Type: application/json" --method POST`: `  # Use a cURL command directly
+  ./caseurl2md --from-curl 'curl "http://example.com/api" -H "Authorization: Bearer token"'
+
+  # Read cURL from a file
+  ./caseurl2md --curl-file curl.txt --out result.json
+
+  # Run multiple cURL commands from a file
+  ./caseurl2md --batch curls.txt --out results/
+
+  # Read from the clipboard (run right after "Copy as cURL" in the browser)
+  ./caseurl2md --from-clipboard
+
+  # Pass cURL arguments verbatim after -- to avoid quoting and flag conflicts
+  ./caseurl2md fetch --out result.json -- curl "http://example.com/api" -H "Authorization: Bearer token"
+
+  # Specify request details manually
+  ./caseurl2md --url "http://api.example.com/data" --header "Content-Type: application/json" --method POST`,
+	"直接从命令行接收cURL命令":                        "cURL command passed directly on the command line",
+	"接收完整的cURL命令字符串（支持多行格式）":                "complete cURL command string (multi-line supported)",
+	"从文件读取cURL命令":                           "read the cURL command from a file",
+	"从系统剪贴板读取cURL命令（配合浏览器Copy as cURL使用）":   "read the cURL command from the system clipboard (use with the browser's Copy as cURL)",
+	"批量文件，每个非空行（或以---分隔的块）为一个cURL命令":        "batch file; each non-empty line (or block separated by ---) is one cURL command",
+	"CSV变量文件，每行数据渲染一次cURL模板中的{{.列名}}并执行":    "CSV variable file; each row renders {{.column}} in the cURL template and runs it",
+	"批量执行从上次中断或失败处继续：--out 目录中上次已成功的请求不再执行": "continue a batch run from where it was interrupted or failed: requests that succeeded last time in the --out directory are not run again",
+	"请求URL（不使用cURL时必需）":                     "request URL (required when not using cURL)",
+	"请求方法":                                  "request method",
+	"请求头，格式为'Key: Value'，可多次使用":             "request header in 'Key: Value' form, can be repeated",
+	"请求体数据": "request body data",
+	"cookies字符串，格式为'key1=value1; key2=value2'": "cookies string in 'key1=value1; key2=value2' form",
+	"输出文件路径（默认为output_{timestamp}.json），也可以是 s3://bucket/key 或 gs://bucket/key；批量模式下为输出目录": "output file path (defaults to output_{timestamp}.json), or an s3://bucket/key or gs://bucket/key object; output directory in batch mode",
+	"节点内容字段候选键名，按优先级排序": "candidate keys for node content, in priority order",
+	"指定层级优先使用的节点内容字段候选键名，格式为 层数=键名[,键名]，根节点为第1层，如 '1=module_name' '2=case_title'，可多次使用": "candidate keys for node content tried first at a given level, as level=key[,key] with the root at level 1, e.g. '1=module_name' '2=case_title'; can be repeated",
+	"子节点数组候选键名，按优先级排序":                                                            "candidate keys for child node arrays, in priority order",
+	"合并节点上所有匹配 --children-keys 的子节点数组（按候选键名的顺序），默认只使用第一个":                         "merge the child arrays under every key matching --children-keys on a node (in candidate key order); by default only the first is used",
+	"标题字段为数字或布尔值（如编号、版本号、开关）时也作为节点标题，默认只使用字符串":                                    "also use number and boolean title fields (ids, versions, flags) as node titles; by default only strings are used",
+	"数字与布尔值标题的模板，{{.key}} 替换为字段名，{{.value}} 替换为值，如 '{{.key}} {{.value}}'；默认直接使用值": "template for number and boolean titles, {{.key}} is replaced with the field name and {{.value}} with the value, e.g. '{{.key}} {{.value}}'; the value is used as is by default",
+	"布尔值标题的文字，格式为 真,假，如 '是,否'；默认为 true,false":                                     "text for boolean titles as true,false, e.g. 'yes,no'; defaults to true,false",
+	"节点名称的最大字符数，超过时截断并以 … 结尾（0 表示不限制）":                                            "maximum number of characters in a node name, longer names are truncated with … (0 means no limit)",
+	"树抽取的最大递归深度，更深的子节点被丢弃并在警告与运行报告中记录（0 表示使用默认值 100）":                             "maximum recursion depth of tree extraction; deeper children are dropped and recorded in the warnings and the run report (0 uses the default of 100)",
+	"为每个节点输出 order 字段，记录它在兄弟节点中的原始序号（从0开始），便于重新排序后恢复原来的顺序":                        "add an order field to every node with its original index among its siblings (from 0), so source ordering can be restored after re-sorting",
+	"将节点源对象中的这些字段转换为 tags 数组，每个标签为 字段名:值，如 'priority,owner'":                      "convert these fields of each node's source object into a tags array of field:value labels, e.g. 'priority,owner'",
+	"只输出从第N层开始的节点（根节点为第1层），第N层的节点成为新的根节点":                                         "output only nodes from level N on (the root is level 1); level-N nodes become the new roots",
+	"只输出到第N层为止的节点，更深的子节点被丢弃（0 表示不限制）":                                             "output only nodes down to level N, dropping deeper children (0 means no limit)",
+	"只输出叶子节点（去重后按原顺序排列为多根列表），不保留分组层级":                                             "output only the leaf nodes (deduplicated, in source order, as a multi-root list) without the grouping hierarchy",
+	"配合 --leaves-only 使用，叶子节点名称带上从根节点开始的路径，如 '门店管理 / 搜索 / 按名称搜索'":                 "with --leaves-only, prefix each leaf name with its path from the root, e.g. 'Stores / Search / By name'",
+	"HTTP请求超时时间（秒）":  "HTTP request timeout (seconds)",
+	"显示详细日志":         "show verbose logs",
+	"写入结果后打开交互式树浏览器": "open the interactive tree browser after writing the result",
+	"只在终端预览抽取结果：每层显示前N个节点名称与节点数，不写入输出文件": "only preview the result in the terminal: the first N node names and the node count of each level, without writing the output file",
+	"按指定间隔（如30s）重复执行请求并重写输出":             "re-run the request at the given interval (e.g. 30s) and rewrite the output",
+	"监听模式下每轮打印与上一轮的树结构差异":                "print tree differences from the previous round in watch mode",
+	"增量同步：与该文件中上一次的完整结果比较，--out（必填）只写入新增、修改的子树与删除的节点，全部检查通过后以本次的完整结果更新该文件": "incremental sync: compare with the previous full result in this file, write only added/changed subtrees and removed nodes to --out (required), then update the file with this run's full result once all checks pass",
+	"结束时向stdout输出一行JSON运行摘要（状态、输出路径、节点数、耗时）":                               "print a one-line JSON run summary (status, output path, node count, duration) to stdout at the end",
+	"运行结束（监听模式为每轮结束）时向该地址POST一条JSON摘要，兼容Slack等incoming webhook":            "POST a JSON summary to this URL when the run (or each watch cycle) completes; works with Slack-style incoming webhooks",
+	"不在stderr显示下载进度": "do not show download progress on stderr",
+	"日志级别：debug、info、warn、error（默认info，--verbose时为debug）": "log level: debug, info, warn, error (default info, debug with --verbose)",
+	"日志格式：text 或 json":                                                                                                           "log format: text or json",
+	"界面语言：zh 或 en（默认根据 LANG 等环境变量检测）":                                                                                            "interface language: zh or en (detected from LANG and related environment variables by default)",
+	"关闭终端颜色输出（也可设置 NO_COLOR 环境变量）":                                                                                               "disable colored terminal output (NO_COLOR is also honored)",
+	"抽取结果为空树时以非零退出码失败（结果文件仍会写入）":                                                                                                 "exit non-zero when the extracted tree is empty (the result file is still written)",
+	"请求完成后检查响应，如 'status==200'、'$.errCode==0'、'body contains 门店'，可多次使用，任一失败即中止":                                                  "check the response after the request, e.g. 'status==200', '$.errCode==0', 'body contains text'; repeatable, any failure aborts",
+	"发送前修改JSON请求体中的字段，格式为 路径=值，如 'project_id=1024'、'$.page.size=100'，值按JSON解析，可多次使用":                                             "edit a field of the JSON request body before sending, as path=value, e.g. 'project_id=1024' or '$.page.size=100'; the value is parsed as JSON; repeatable",
+	"发送前删除JSON请求体中的字段或数组元素，如 'debug'、'filters[0]'，可多次使用":                                                                         "delete a field or array element from the JSON request body before sending, e.g. 'debug' or 'filters[0]'; repeatable",
+	"写入前按内置结构校验输出（节点仅含name字符串和children数组）":                                                                                       "validate the output against the built-in schema before writing (nodes contain only a name string and a children array)",
+	"输出JSON的键名风格：snake（如 duration_ms，默认）、camel（durationMs）或 kebab（duration-ms），用于结果、--summary-json、--report、--envs 与 --sync 的输出": "key style of the output JSON: snake (e.g. duration_ms, default), camel (durationMs) or kebab (duration-ms); applies to the result, --summary-json, --report, --envs and --sync output",
+	"抽取结果为空树: %s":                                           "extracted tree is empty: %s",
+	"静默模式，仅输出错误":                                            "quiet mode, only print errors",
+	"--watch 与 --interactive 不能同时使用":                        "--watch and --interactive cannot be used together",
+	"--batch/--batch-data 不能与 --watch 或 --interactive 同时使用": "--batch/--batch-data cannot be used with --watch or --interactive",
+	"将本次运行的请求（已脱敏）、响应状态与耗时、校验与抽取情况写入JSON报告文件":                   "write the request (redacted), response status and timing, validation and extraction details of this run to a JSON report file",
+	"重复请求直到响应满足条件后再抽取，语法同 --assert，如 '$.data.status==\"done\"'": "repeat the request until the response meets the condition, then extract; same syntax as --assert, e.g. '$.data.status==\"done\"'",
+	"--wait-for 的重试间隔":                                 "retry interval for --wait-for",
+	"--wait-for 的最长等待时间，超时以退出码10失败":                    "maximum wait for --wait-for, fails with exit code 10 on timeout",
+	"--poll-interval 和 --poll-timeout 必须大于0":           "--poll-interval and --poll-timeout must be greater than 0",
+	"--report 不能与 --batch/--batch-data 或 --watch 同时使用": "--report cannot be used with --batch/--batch-data or --watch",
+	"已写入运行报告":                                          "run report written",
+	"--summary-json 不能与 --watch 或 --interactive 同时使用":  "--summary-json cannot be used with --watch or --interactive",
+	"使用 --raw-curl 参数接收完整cURL命令":                       "using the full cURL command from --raw-curl",
+	"使用 -- 之后的参数作为cURL命令":                              "using the arguments after -- as the cURL command",
+	"从命令行参数读取cURL命令":                                   "reading the cURL command from command-line arguments",
+	"读取cURL文件失败: %w":                                   "failed to read cURL file: %w",
+	"读取剪贴板失败: %w":                                      "failed to read clipboard: %w",
+	"从剪贴板读取cURL命令":                                     "reading the cURL command from the clipboard",
+	"使用参数模式":                                           "using flag mode",
+	"使用保存的原始响应，不发送请求":                                  "using the saved raw response instead of sending the request",
+	"从stdin读取失败: %w":                                   "failed to read from stdin: %w",
+	"从stdin读取cURL命令":                                   "reading the cURL command from stdin",
+	"写入输出文件失败: %w":                                     "failed to write output file: %w",
+	"成功将结果写入文件":                                        "result written to file",
+	"必须指定一种输入方式：--raw-curl, --from-curl, --curl-file, --from-clipboard, --batch, --chain, --import, --url, -- curl ..., 或者从stdin提供cURL命令": "an input method is required: --raw-curl, --from-curl, --curl-file, --from-clipboard, --batch, --chain, --import, --url, -- curl ..., or a cURL command on stdin",
+	"从抓包会话文件导入请求：Charles（.chlsj）、Fiddler（.saz）或 mitmproxy（.flow）":                                                                         "import the request from a captured session: Charles (.chlsj), Fiddler (.saz) or mitmproxy (.flow)",
+	"按URL子串挑选会话中的请求，多条匹配时使用最后一条":                                                                                                          "pick the session request whose URL contains this substring; the last one wins when several match",
+	"直接使用会话中记录的响应，不重新发送请求":                                                                                                                "use the response recorded in the session instead of sending the request again",
+	"--import 不能与 --batch-data 同时使用":                                                                                                      "--import cannot be used with --batch-data",
+	"--import-filter 和 --import-offline 需要配合 --import 使用":                                                                                 "--import-filter and --import-offline require --import",
+	"抓包文件中有多条请求匹配，使用最后一条":                                                                                                                 "several captured requests match; using the last one",
+	"响应重写规则没有匹配到任何字段":                                                                                                                     "response rewrite rule did not match any field",
+	"分组规则没有分组任何元素":                                                                                                                        "group rule did not group any item",
+	"从抓包文件导入请求":                                                                                                                           "importing request from capture file",
+	"输出格式：json（树状JSON）、markdown（Markdown嵌套列表）、testcasemind（还原为TestCaseMind脑图，可修改后重新上传）或 --plugin 加载的 renderer 插件名称": "output format: json (tree JSON), markdown (nested Markdown list), testcasemind (TestCaseMind mind map that can be edited and uploaded again) or the name of a renderer plugin loaded with --plugin",
+	"--format 不能与 --batch/--batch-data 同时使用": "--format cannot be used with --batch/--batch-data",
+	"不支持的输出格式 %q，可选 %s":                      "unsupported output format %q, expected one of %s",
+	"不支持的输出格式 %q":                            "unsupported output format %q",
+	"使用已安装的插件（见 plugin 命令）：strategy 插件代替内置抽取，converter 插件在抽取后调整树，可多次使用": "use an installed plugin (see the plugin command): strategy plugins replace the built-in extraction, converter plugins adjust the tree after extraction; repeatable",
+	"管理外部抽取插件": "Manage external extraction plugins",
+	`安装、列出和删除外部抽取插件。插件是包含 plugin.yaml 的目录，可以来自Git仓库、OCI制品或本地目录：
+  strategy   代替内置抽取器，从响应体中抽取树
+  converter  在内置抽取之后调整树
+安装时记录插件目录内容的摘要，每次通过 --plugin 使用前重新校验，文件被修改的插件拒绝加载。`: `Install, list and remove external extraction plugins. A plugin is a directory containing plugin.yaml,
+installed from a Git repository, an OCI artifact or a local directory:
+  strategy   replaces the built-in extractor and extracts the tree from the response body
+  converter  adjusts the tree after the built-in extraction
+The digest of the plugin directory is recorded at install time and verified before every use via
+--plugin; plugins whose files have been modified are refused.`,
+	"插件安装目录":         "plugin installation directory",
+	"列出已安装的插件并校验完整性": "list installed plugins and verify their integrity",
+	"从Git仓库（URL[#ref]）、OCI制品（oci://仓库:标签）或本地目录安装插件": "install a plugin from a Git repository (URL[#ref]), an OCI artifact (oci://repo:tag) or a local directory",
+	"期望的插件内容摘要，不一致时拒绝安装":                            "expected digest of the plugin contents; installation is refused on mismatch",
+	"覆盖已安装的同名插件":                                    "replace an installed plugin with the same name",
+	"删除已安装的插件":                                      "remove an installed plugin",
+	"没有安装插件":                                        "no plugins installed",
+	"名称\t版本\t类型\t状态\t安装时间\t来源":                      "NAME\tVERSION\tKIND\tSTATUS\tINSTALLED\tSOURCE",
+	"已安装插件 %s %s（%s）\n摘要: %s\n":                     "installed plugin %s %s (%s)\ndigest: %s\n",
+	"已删除插件 %s\n":                                    "removed plugin %s\n",
+	"只能使用一个 strategy 插件（%s 与 %s）":                   "only one strategy plugin can be used (%s and %s)",
+	"将同一请求并发发送到多个环境并输出合并报告，如 staging=https://stg.example.com,prod=https://prod.example.com（替换请求URL的协议与主机，第一个环境为比较基准）": "send the same request to several environments concurrently and write a combined report, e.g. staging=https://stg.example.com,prod=https://prod.example.com (replaces the scheme and host of the request URL; the first environment is the baseline)",
+	"--envs 不能与 --batch/--batch-data、--watch、--interactive 或 check 命令同时使用":                                            "--envs cannot be used with --batch/--batch-data, --watch, --interactive or the check command",
+	"--envs 输出合并报告，不能与 --report、--debug-bundle、--history、--publish 或 --format 同时使用":                                   "--envs writes a combined report and cannot be used with --report, --debug-bundle, --history, --publish or --format",
+	"无效的环境 %q，格式应为 名称=地址，如 staging=https://stg.example.com":                                                           "invalid environment %q, expected name=URL such as staging=https://stg.example.com",
+	"无效的 --title-key-level %q，格式应为 层数=键名[,键名]，层数从1开始":                                                                 "invalid --title-key-level %q, expected level=key[,key] with levels starting at 1",
+	"--title-key-level %q 没有指定键名":          "--title-key-level %q has no keys",
+	"环境 %s 重复":                             "duplicate environment %s",
+	"环境 %s 的地址 %q 无效，应为 http(s)://主机[:端口]": "environment %s has an invalid URL %q, expected http(s)://host[:port]",
+	"环境 %s 的地址 %q 只能包含协议与主机，请求的路径和参数保持不变":  "environment %s URL %q may only contain a scheme and host; the request path and query are kept",
+	"--envs 至少需要两个环境":                      "--envs requires at least two environments",
+	"无法替换请求URL %q 中的主机":                    "cannot replace the host of request URL %q",
+	"环境执行失败":                               "environment run failed",
+	"环境执行完成":                               "environment run finished",
+	"%d 个环境执行失败":                           "%d environments failed",
+	"  ✅ %s: %d 个节点 (%dms)\n": `  ✅ %s: %d nodes (%dms)
+`,
+	"%s -> %s: 树结构一致\n": `%s -> %s: tree structure is identical
+`,
+	"链式请求YAML文件，前面步骤提取的变量渲染进后续请求，最后一步的响应用于抽取":         "chain YAML file; variables extracted by earlier steps are rendered into later requests and the last response is extracted",
+	"--chain 不能与 --batch/--batch-data 或 --watch 同时使用": "--chain cannot be used with --batch/--batch-data or --watch",
+	"从文件读取链式请求":                      "reading chained requests from file",
+	"只能指定一种输入方式":                     "only one input method can be specified",
+	"启动HTTP服务，提供 POST /convert 转换接口": "Start an HTTP server exposing the POST /convert endpoint",
+	`启动HTTP服务，供Web前端或其他服务复用转换能力，无需调用命令行。
+
+接口：
+  POST /convert  请求体 {"curl": "curl ...", "options": {"title_keys": [...], "children_keys": [...], "timeout": 30}}
+                 请求头 Content-Type 须为 application/json，只接受本机请求；成功返回树状JSON，失败返回 {"error": "..."}
+  POST /render   请求体 {"tree": <树状JSON>, "format": "json|markdown|testcasemind"}，返回渲染后的文件内容
+  POST /ingest   接收浏览器扩展从开发者工具捕获的请求（HAR条目），只接受本机请求，返回树状JSON
+  GET  /healthz  健康检查
+
+浏览器访问 / 可以打开Web界面：粘贴cURL、调整抽取选项、交互式预览树并下载JSON或Markdown。`: `Starts an HTTP server so web frontends or other services can reuse the conversion without invoking the CLI.
+
+Endpoints:
+  POST /convert  body {"curl": "curl ...", "options": {"title_keys": [...], "children_keys": [...], "timeout": 30}}
+                 requires Content-Type: application/json, localhost only; returns tree JSON on success, {"error": "..."} on failure
+  POST /render   body {"tree": <tree JSON>, "format": "json|markdown|testcasemind"}, returns the rendered file content
+  POST /ingest   accepts a request captured from DevTools by a browser extension (HAR entry), localhost only, returns tree JSON
+  GET  /healthz  health check
+
+Open / in a browser for the web UI: paste a cURL, tweak extraction options, preview the tree interactively and download JSON or Markdown.`,
+	"监听地址":         "listen address",
+	"监听地址，默认只监听本机": "listen address, localhost only by default",
+	"将 /ingest 的结果另存到该目录（文件名为output_{timestamp}.json）": "also save /ingest results to this directory (as output_{timestamp}.json)",
+	"HTTP服务已启动，浏览器访问 / 使用Web界面，POST /convert 进行转换":     "HTTP server started, open / in a browser for the web UI or POST /convert to convert",
+	`  ./caseurl2md serve                    # 浏览器打开 http://localhost:8080/
+  curl -X POST localhost:8080/convert -H 'Content-Type: application/json' -d '{"curl":"curl https://api.example.com/cases -H \"x-jwt-token: xxx\""}'`: `  ./caseurl2md serve                    # open http://localhost:8080/ in a browser
+  curl -X POST localhost:8080/convert -H 'Content-Type: application/json' -d '{"curl":"curl https://api.example.com/cases -H \"x-jwt-token: xxx\""}'`,
+	"HTTP服务已退出": "HTTP server stopped",
+	"启动模拟服务，按请求指纹应答录制的响应": "Start a mock server that answers with recorded responses by request fingerprint",
+	`启动模拟服务，应答夹具目录中录制的响应，无需访问真实的内部接口即可调试和演示抽取参数。
+
+夹具目录中可以放置：
+  *.json                     --save-fixture 录制的夹具文件，响应体可以直接编辑
+  .chlsj、.saz、.flow 等     抓包工具导出的会话文件，其中记录了响应的请求都会加载
+
+请求按指纹匹配：方法、路径、查询参数与请求体相同即视为同一请求，不比较协议、主机与请求头；
+查询参数不分顺序，token等可能携带凭据的参数只比较名称，JSON请求体忽略键的顺序与空白。
+没有匹配的夹具时返回404，响应体中包含该请求的指纹。`: `Starts a mock server that answers with the responses recorded in the fixtures directory, so extraction flags can be developed and demoed without access to the real internal API.
+
+The fixtures directory may contain:
+  *.json                     fixture files recorded by --save-fixture; response bodies can be edited directly
+  .chlsj, .saz, .flow, etc.  session files exported by capture tools; every request with a recorded response is loaded
+
+Requests are matched by fingerprint: the same method, path, query parameters and body are treated as the same request; scheme, host and headers are not compared.
+Query parameter order does not matter, parameters that may carry credentials such as token are compared by name only, and key order and whitespace in JSON bodies are ignored.
+When no fixture matches, a 404 is returned whose body contains the request's fingerprint.`,
+	`  ./caseurl2md --curl-file curl.txt --out result.json --save-fixture fixtures/   # 录制
+  ./caseurl2md mock --fixtures fixtures/ --listen :9090
+  ./caseurl2md --url 'http://localhost:9090/api/cases?id=1' --title-key caseName --out result.json`: `  ./caseurl2md --curl-file curl.txt --out result.json --save-fixture fixtures/   # record
+  ./caseurl2md mock --fixtures fixtures/ --listen :9090
+  ./caseurl2md --url 'http://localhost:9090/api/cases?id=1' --title-key caseName --out result.json`,
+	"夹具目录（必填）":               "fixtures directory (required)",
+	"必须使用 --fixtures 指定夹具目录": "--fixtures must be set to the fixtures directory",
+	"已加载夹具":                  "fixture loaded",
+	"模拟服务已启动":                "mock server started",
+	"模拟服务已退出":                "mock server stopped",
+	"显示版本与构建信息":              "Show version and build information",
+	"查询最新发布版本并提示是否需要升级":      "check the latest release and report whether an upgrade is available",
+	"以JSON格式输出构建信息":          "print build information as JSON",
+	"发现新版本 %s（当前 %s），请前往 https://github.com/wellkilo/Curl2json/releases 下载\n": `New version %s available (current %s), download it from https://github.com/wellkilo/Curl2json/releases
+`,
+	"当前已是最新版本（最新发布: %s）\n": `Already up to date (latest release: %s)
+`,
+	"在终端中交互式浏览树状JSON": "Browse tree JSON interactively in the terminal",
+	`打开终端界面浏览树状JSON结果，支持展开/折叠、搜索和复制节点路径。
+
+按键：
+  ↑/↓ 或 j/k   移动光标
+  →/l ←/h      展开/折叠（折叠状态下←跳到父节点）
+  enter/空格   切换展开状态
+  e / c        全部展开 / 全部折叠
+  /            搜索，n/N 跳转下一个/上一个匹配
+  y            复制当前节点路径到剪贴板
+  q            退出`: `Opens a terminal UI to browse tree JSON results, with expand/collapse, search and node path copying.
+
+Keys:
+  ↑/↓ or j/k   move cursor
+  →/l ←/h      expand/collapse (← jumps to the parent when collapsed)
+  enter/space  toggle expansion
+  e / c        expand all / collapse all
+  /            search, n/N jump to next/previous match
+  y            copy the current node path to the clipboard
+  q            quit`,
+	"读取结果文件失败: %w":       "failed to read result file: %w",
+	"进入监听模式，按 Ctrl+C 退出": "entering watch mode, press Ctrl+C to exit",
+	"监听模式已退出":            "watch mode exited",
+	"本轮执行失败":             "round failed",
+	"本轮写入失败":             "round write failed",
+	"本轮结果解析失败":           "failed to parse round result",
+	"本轮完成，结果已写入":         "round completed, result written",
+	"本轮完成，树结构无变化":        "round completed, tree unchanged",
+	"[%s] 第 %d 轮完成，%s:\n": `[%s] round %d completed, %s:
+`,
+
+	"第%d层（%d 个节点）: %s\n": "level %d (%d nodes): %s\n",
+	"、":                  ", ",
+	" …（另有 %d 个）":        " … (%d more)",
+	"共 %d 个节点，%d 个叶子节点，最大 %d 层\n": "%d nodes, %d leaves, %d levels deep\n",
+
+	"诊断DNS、代理、TLS与认证请求头等环境问题": "Diagnose DNS, proxy, TLS and auth header problems",
+	`依次检查URL解析、代理检测、DNS解析、TCP连接、TLS握手、认证请求头和HTTP请求，
+并指出首个失败的阶段。大多数“HTTP请求执行失败”都源于网络环境问题，可先用本命令排查。`: `Checks URL parsing, proxy detection, DNS resolution, TCP connection, TLS handshake,
+auth headers and the HTTP request in order, and reports the first stage that fails.
+Most "HTTP request failed" errors are caused by the network environment; run this first.`,
+	"每个检查阶段的超时时间（秒）":                               "timeout for each check stage (seconds)",
+	"以JSON格式输出诊断报告":                                "print the diagnosis report as JSON",
+	"诊断失败于「%s」阶段: %s":                              `diagnosis failed at stage "%s": %s`,
+	"请通过 --url、--curl-file 或 --from-curl 指定要诊断的请求": "specify the request to diagnose with --url, --curl-file or --from-curl",
+	"诊断 %s\n":     "Diagnosing %s\n",
+	"诊断失败于「%s」阶段": `diagnosis failed at stage "%s"`,
+	"所有检查通过":      "all checks passed",
+
+	"请求返回401/419时执行的刷新cURL命令，提取新凭据写入原请求后重试一次":                               "refresh cURL command run when a request returns 401/419; the new credential is written into the original request, which is retried once",
+	"新token在刷新响应中的JSONPath，如 '$.data.token'":                                "JSONPath of the new token in the refresh response, e.g. '$.data.token'",
+	"新token的写入位置，如 'header:x-jwt-token' 或 'cookie:sid'，可用 {{.token}} 指定值模板": "where to write the new token, e.g. 'header:x-jwt-token' or 'cookie:sid'; use {{.token}} for a value template",
+	"--auth-refresh-token 需要与 --auth-refresh-curl 一起使用":                     "--auth-refresh-token requires --auth-refresh-curl",
+
+	"将运行报告、原始响应、debug日志与运行环境打包写入zip文件（凭据已脱敏），便于反馈问题":                            "write the run report, raw response, debug log and environment info into a zip file (credentials masked) for bug reports",
+	"抽取失败时保存原始响应 debug_response_*.json 的目录（默认仅在 --verbose 时保存到系统临时目录）":          "directory for debug_response_*.json raw responses saved when extraction fails (by default saved to the system temp directory only with --verbose)",
+	"将响应头与Set-Cookie下发的cookie写入JSON文件，便于在后续cURL中使用新的会话cookie":                   "write response headers and cookies set by Set-Cookie to a JSON file, so the fresh session cookie can be used in later cURL commands",
+	"--save-headers 写入时隐藏cookie的值与可能携带凭据的响应头":                                   "hide cookie values and response headers that may carry credentials when writing --save-headers",
+	"将未经转换的原始响应体写入文件，之后可以用 --replay-raw 换用其他参数离线重新抽取":                           "write the untouched response body to a file, so extraction can be re-run offline later with --replay-raw and different flags",
+	"将产物清单（各输出文件的SHA-256、请求URL的哈希与工具版本）写入JSON文件，便于审计生成的文档":                      "write an artifact manifest (SHA-256 of each produced file, hash of the request URL and tool version) to a JSON file for auditing generated documents",
+	"将请求与响应录制为夹具文件写入该目录，供 mock 命令的模拟服务应答":                                       "record the request and response as a fixture file in this directory, to be served by the mock command",
+	"将响应每一层对象出现的键写入JSON文件，作为之后 --baseline-schema 的基线":                           "write the keys seen at each object level of the response to a JSON file, to be used later as the --baseline-schema baseline",
+	"与 --save-schema 保存的基线比较响应结构，某一层新增或缺少键时输出警告（不影响抽取）":                         "compare the response shape with a baseline saved by --save-schema and warn when a level gains or loses keys (extraction is not affected)",
+	"不发送请求，使用 --save-raw 保存的响应体重新抽取（按扩展名识别 .xml、.yaml、.ndjson、.html，其他按JSON处理）": "re-run extraction on a body saved with --save-raw instead of sending the request (.xml, .yaml, .ndjson and .html are detected by extension, anything else is treated as JSON)",
+	"--debug-bundle 不能与 --batch/--batch-data 或 --watch 同时使用":                    "--debug-bundle cannot be used with --batch/--batch-data or --watch",
+	"--save-headers 不能与 --batch/--batch-data 或 --envs 同时使用":                     "--save-headers cannot be used with --batch/--batch-data or --envs",
+	"--manifest 不能与 --watch、--envs 或 --interactive 同时使用":                        "--manifest cannot be used with --watch, --envs or --interactive",
+	"--resume 需要配合 --batch/--batch-data 使用，并用 --out 指定上次的输出目录":                  "--resume requires --batch/--batch-data and --out pointing at the previous output directory",
+	"--save-schema 和 --baseline-schema 不能与 --batch/--batch-data 或 --envs 同时使用":  "--save-schema and --baseline-schema cannot be used with --batch/--batch-data or --envs",
+	"--redact-headers 需要配合 --save-headers 使用":                                   "--redact-headers requires --save-headers",
+	"读取脱敏规则文件失败: %w":        "failed to read redaction rules file: %w",
+	"脱敏规则文件 %s: %w":         "redaction rules file %s: %w",
+	"无效的 body_paths %q: %w": "invalid body_paths %q: %w",
+	"无效的 patterns %q: %w":   "invalid patterns %q: %w",
+	"脱敏规则文件（YAML或JSON），在内置规则之外指定视为凭据的请求头名称、请求体JSONPath与正则表达式，作用于详细日志、运行报告、调试包与 --redact-headers": "redaction rules file (YAML or JSON) listing extra credential header names, request body JSONPaths and regular expressions; applied to verbose logs, run reports, debug bundles and --redact-headers",
+	"允许 --wait-for 与 --watch 重复发送POST、PATCH等非幂等请求":                                                           "allow --wait-for and --watch to resend non-idempotent requests such as POST and PATCH",
+	"不跟随重定向，3xx响应按非2xx状态码处理；默认跟随，次数由cURL命令中的 -L 与 --max-redirs 决定":                                           "do not follow redirects and treat 3xx responses as non-2xx statuses; by default redirects are followed up to the limit set by -L and --max-redirs in the cURL command",
+	"业务文本规则：auto（按响应中占多数的语言自动选择）、zh、en、ja、ko":                                                                "business text profile: auto (pick by the dominant language of the response), zh, en, ja or ko",
+	"合并多个JSON文档的根节点时同名节点的处理方式：keep-all（全部保留）、keep-first、keep-last、suffix-index（加序号）、merge-children（逐层合并子节点）": "how to resolve same-named nodes when merging the roots of multiple JSON documents: keep-all, keep-first, keep-last, suffix-index (append an index) or merge-children (merge children level by level)",
+	"每次执行前在指定名称的请求头（通常为 Idempotency-Key）中写入新生成的幂等键，轮询时沿用同一个键，并允许重复发送非幂等请求":                                   "write a freshly generated idempotency key into the named request header (usually Idempotency-Key) before each run; polling reuses the same key, and non-idempotent requests may be resent",
+	"--max-title-length 不能为负数":               "--max-title-length must not be negative",
+	"--max-depth 不能为负数":                      "--max-depth must not be negative",
+	"--from-depth 必须大于0，--to-depth 不能为负数":    "--from-depth must be greater than 0 and --to-depth must not be negative",
+	"--to-depth (%d) 不能小于 --from-depth (%d)": "--to-depth (%d) cannot be less than --from-depth (%d)",
+	"--leaf-paths 需要配合 --leaves-only 使用":     "--leaf-paths requires --leaves-only",
+	"--with-order 不能与 --leaves-only 同时使用":    "--with-order cannot be combined with --leaves-only",
+	"--preview 不能为负数":                        "--preview must not be negative",
+	"--preview 不写入输出文件，不能与 --out、--batch/--batch-data、--watch、--envs、--interactive、--summary-json、--publish 或 check 命令同时使用": "--preview does not write an output file and cannot be used with --out, --batch/--batch-data, --watch, --envs, --interactive, --summary-json, --publish or the check command",
+	"--scalar-title-format 和 --scalar-title-bool 需要配合 --scalar-titles 使用":                                                   "--scalar-title-format and --scalar-title-bool require --scalar-titles",
+	"无效的 --scalar-title-bool %q，格式应为 真,假":                                                                                   "invalid --scalar-title-bool %q, expected true,false",
+	"--save-raw 和 --replay-raw 不能与 --batch/--batch-data 或 --envs 同时使用":                                                      "--save-raw and --replay-raw cannot be used with --batch/--batch-data or --envs",
+	"--replay-raw 不能与 --chain 或 --import-offline 同时使用":                                                                      "--replay-raw cannot be used with --chain or --import-offline",
+	"写入原始响应失败: %w": "failed to write raw response: %w",
+	"读取原始响应失败: %w": "failed to read raw response: %w",
+	"创建调试目录失败: %w": "failed to create debug directory: %w",
+	"已写入调试包":       "debug bundle written",
+	"已写入产物清单":      "artifact manifest written",
+
+	"抽取后、写入前对树执行的脚本：.js（node，导出以树为参数的函数）、.jq（jq过滤器）或 .cel（以 tree 为变量的CEL表达式）":                                                                                "script run on the tree after extraction and before writing: .js (node, exporting a function that takes the tree), .jq (jq filter) or .cel (CEL expression over the variable tree)",
+	"抽取前还原先经 gzip、zlib 或 zip 压缩再base64编码的字段，如 '$.data.tree'，内容为JSON时替换为解析后的值；路径可用 [*] 与 $..key，在 --response-rewrite 之前执行，可多次使用":                              "restore a field that was compressed with gzip, zlib or zip and then base64-encoded before extraction, e.g. '$.data.tree'; JSON content replaces the field as parsed JSON; paths may use [*] and $..key; runs before --response-rewrite; repeatable",
+	"抽取前按protobuf JSON约定规整响应：展开 {\"value\": X} 与oneof包装，删除 @type 和 *_UNSPECIFIED 枚举字段，64位整数字符串转换为数字（标题字段除外），在 --decompress-field 之后、--response-rewrite 之前执行": "normalize protobuf JSON conventions before extraction: unwrap {\"value\": X} and oneof wrappers, drop @type and *_UNSPECIFIED enum fields, turn 64-bit integer strings into numbers (except title fields); runs after --decompress-field and before --response-rewrite",
+	"抽取前按字段值将数组元素分组为中间节点，如 '$.data.cases[*].module'；同一数组的多条规则逐层嵌套分组，在 --response-rewrite 之后执行，可多次使用":                                                         "group array items into intermediate nodes by a field value before extraction, e.g. '$.data.cases[*].module'; several rules on the same array nest in order; runs after --response-rewrite; repeatable",
+	"抽取前改写响应JSON：'delete:路径'、'rename:路径=新键名' 或 'move:路径=目标路径'，路径可用 [*] 与 $..key，按顺序执行，可多次使用":                                                                 "rewrite the response JSON before extraction: 'delete:path', 'rename:path=newkey' or 'move:path=target'; paths may use [*] and $..key; applied in order; repeatable",
+
+	"将本次运行的CPU profile写入文件": "write the CPU profile of this run to a file",
+	"运行结束时将堆内存profile写入文件":  "write a heap profile to a file when the run finishes",
+
+	"文件不是UTF-8或UTF-16编码的文本，请以UTF-8编码重新保存": "the file is not UTF-8 or UTF-16 text, please save it as UTF-8",
+
+	"写入结果后发布到外部系统：confluence、jira、testrail、feishu":                                                    "publish the result to an external system after writing it: confluence, jira, testrail, feishu",
+	"同 --publish，如 --upload testrail":                                                                 "same as --publish, e.g. --upload testrail",
+	"只列出将要发布的页面或问题，不调用外部系统的写入接口":                                                                      "only list the pages or issues that would be published, without calling any write API",
+	"Jira地址，如 https://example.atlassian.net（默认读取 JIRA_URL）":                                           "Jira URL, e.g. https://example.atlassian.net (defaults to JIRA_URL)",
+	"创建问题的Jira项目key":                                                                                  "key of the Jira project to create issues in",
+	"Jira问题类型（默认 Task，指定 --jira-parent 时为 Sub-task）":                                                  "Jira issue type (defaults to Task, or Sub-task with --jira-parent)",
+	"父问题key，指定时每个叶子节点创建为其子任务":                                                                         "parent issue key; when set every leaf node becomes a sub-task of it",
+	"TestRail地址，如 https://example.testrail.io（默认读取 TESTRAIL_URL）":                                     "TestRail URL, e.g. https://example.testrail.io (defaults to TESTRAIL_URL)",
+	"上传到的TestRail项目ID":                                                                                "ID of the TestRail project to upload to",
+	"TestRail用例库ID（多用例库项目必须指定）":                                                                       "TestRail suite ID (required for multi-suite projects)",
+	"TestRail父分组ID，为0时根节点创建为顶层分组":                                                                     "TestRail parent section ID; with 0 root nodes become top-level sections",
+	"飞书开放平台地址，Lark为 https://open.larksuite.com（默认读取 FEISHU_URL，未设置时为 https://open.feishu.cn）":         "Feishu open platform URL, https://open.larksuite.com for Lark (defaults to FEISHU_URL, or https://open.feishu.cn when unset)",
+	"创建飞书文档的文件夹token（默认为应用的云空间根目录）":                                                                   "token of the folder to create the Feishu document in (defaults to the app's root folder)",
+	"--publish jira 需要 --jira-project":                                                                "--publish jira requires --jira-project",
+	"请通过 --jira-url（或 JIRA_URL）和 JIRA_TOKEN 环境变量提供Jira地址与访问令牌（Cloud还需设置 JIRA_USER 为账号邮箱）":             "provide the Jira URL via --jira-url (or JIRA_URL) and an access token via the JIRA_TOKEN environment variable (Cloud also needs JIRA_USER set to the account email)",
+	"--upload testrail 需要 --testrail-project":                                                         "--upload testrail requires --testrail-project",
+	"请通过 --testrail-url（或 TESTRAIL_URL）以及 TESTRAIL_USER、TESTRAIL_API_KEY 环境变量提供TestRail地址、账号与API key": "provide the TestRail URL via --testrail-url (or TESTRAIL_URL) and the account and API key via the TESTRAIL_USER and TESTRAIL_API_KEY environment variables",
+	"请通过 FEISHU_TENANT_TOKEN 或 FEISHU_APP_ID、FEISHU_APP_SECRET 环境变量提供飞书凭证":                            "provide Feishu credentials via FEISHU_TENANT_TOKEN or the FEISHU_APP_ID and FEISHU_APP_SECRET environment variables",
+	"发布条目": "published item",
+	"发布的页面标题（默认使用根节点名称）":                                                         "title of the published page (defaults to the root node name)",
+	"Confluence页面格式：storage（嵌套列表）或 markdown（Markdown宏）":                          "Confluence page format: storage (nested list) or markdown (Markdown macro)",
+	"Confluence地址，如 https://example.atlassian.net/wiki（默认读取 CONFLUENCE_URL）":     "Confluence URL, e.g. https://example.atlassian.net/wiki (defaults to CONFLUENCE_URL)",
+	"发布到的Confluence空间key":                                                        "key of the Confluence space to publish to",
+	"Confluence父页面ID或标题":                                                         "ID or title of the Confluence parent page",
+	"--publish confluence 需要 --confluence-url（或 CONFLUENCE_URL）和 --space":        "--publish confluence requires --confluence-url (or CONFLUENCE_URL) and --space",
+	"请通过 CONFLUENCE_TOKEN 环境变量提供Confluence访问令牌（Cloud还需设置 CONFLUENCE_USER 为账号邮箱）": "provide a Confluence access token via the CONFLUENCE_TOKEN environment variable (Cloud also needs CONFLUENCE_USER set to the account email)",
+	"不支持的发布目标: %s（可选 confluence、jira、testrail、feishu）":                           "unsupported publish target: %s (confluence, jira, testrail or feishu)",
+	"结果不是单根树，请通过 --publish-title 指定发布标题":                                         "the result is not a single-root tree, specify a title with --publish-title",
+	"发布到 %s 失败: %w": "failed to publish to %s: %w",
+	"发布完成":          "published",
+	"--publish 不能与 --batch/--batch-data 或 --watch 同时使用": "--publish cannot be used with --batch/--batch-data or --watch",
+	"发送完成通知失败": "failed to send completion notification",
+	"已发送完成通知":  "sent completion notification",
+	"将本次运行的请求指纹、树与统计信息保存到SQLite数据库，如 ~/.curl2json/history.db（需要sqlite3命令）": "save this run's request fingerprint, tree and stats to a SQLite database, e.g. ~/.curl2json/history.db (requires the sqlite3 command)",
+	"--history 不能与 --batch/--batch-data 或 --watch 同时使用":                    "--history cannot be used with --batch/--batch-data or --watch",
+	"--golden 不能与 --batch/--batch-data、--watch 或 --interactive 同时使用":       "--golden cannot be used with --batch/--batch-data, --watch or --interactive",
+	"批量模式下 --out 为输出目录，不支持对象存储地址":                                          "--out is an output directory in batch mode and cannot be an object storage URL",
+	"已记录运行历史":              "recorded run history",
+	"查看 --history 记录的运行历史": "Inspect the run history recorded by --history",
+	`列出、查看和比较通过 --history 保存到SQLite数据库中的运行记录。
+相同请求（方法、脱敏后的URL与请求体相同）的记录具有相同的指纹，可以跟踪接口的树结构随时间的变化。`: `List, show and compare the runs saved to the SQLite database by --history.
+Runs of the same request (same method, redacted URL and body) share a fingerprint, so you can
+track how an endpoint's tree changes over time.`,
+	`  ./caseurl2md history list
+  ./caseurl2md history list --fingerprint 3f2a9c0d1e4b5a67
+  ./caseurl2md history show 12 > result.json
+  ./caseurl2md history diff 12        # 与同一请求的上一次记录比较
+  ./caseurl2md history diff 8 12`: `  ./caseurl2md history list
+  ./caseurl2md history list --fingerprint 3f2a9c0d1e4b5a67
+  ./caseurl2md history show 12 > result.json
+  ./caseurl2md history diff 12        # compare with the previous run of the same request
+  ./caseurl2md history diff 8 12`,
+	"历史数据库路径":          "path of the history database",
+	"按时间倒序列出运行记录":      "list runs, newest first",
+	"只列出该请求指纹的记录":      "only list runs with this request fingerprint",
+	"最多列出的记录数":         "maximum number of runs to list",
+	"输出运行记录中的树状JSON":   "print the tree JSON of a run",
+	"输出包含请求与统计信息的完整记录": "print the full record including request and stats",
+	"比较两次运行的树结构，只指定一个ID时与同一请求的上一次记录比较": "compare the trees of two runs; with one ID, compare with the previous run of the same request",
+	"没有运行记录":                        "no runs recorded",
+	"ID\t时间\t状态\t节点\t指纹\t请求":        "ID\tTIME\tSTATUS\tNODES\tFINGERPRINT\tREQUEST",
+	"记录 #%d 没有树（运行失败: %s）":          "run #%d has no tree (run failed: %s)",
+	"记录 #%d 之前没有同一请求（指纹 %s）的记录":     "run #%d has no earlier run of the same request (fingerprint %s)",
+	"#%d -> #%d: 树结构无变化\n":          "#%d -> #%d: tree unchanged\n",
+	"无效的记录ID %q":                    "invalid run ID %q",
+	"将抽取结果与golden树比较，结构变化时以非零退出码失败": "Compare the extracted tree with a golden tree and fail on structural drift",
+	`执行请求并抽取树，与预先提交的golden树比较，用于在CI中检查接口返回的用例结构是否发生变化。
+
+比较前两棵树的节点名称都会去掉首尾空白、合并连续空白；比较按节点路径进行，与兄弟节点的顺序无关。
+有差异时在stderr打印新增（+）和删除（-）的节点路径，并以退出码11失败。
+默认不写入输出文件，指定 --out 时同时写入本次的抽取结果。`: `Fetches and extracts the tree and compares it with a committed golden tree, so CI can detect changes in the case structure returned by an API.
+
+Before comparing, node names in both trees are trimmed and runs of whitespace are collapsed; nodes are compared by path, regardless of sibling order.
+On drift, added (+) and removed (-) node paths are printed to stderr and the command exits with code 11.
+No output file is written unless --out is given.`,
+	`  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt
+  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt --update   # 接受当前结果
+  ./caseurl2md check --golden testdata/cases.golden.json -- curl "https://api.example.com/cases" -H "x-jwt-token: xxx"`: `  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt
+  ./caseurl2md check --golden testdata/cases.golden.json --curl-file curl.txt --update   # accept the current result
+  ./caseurl2md check --golden testdata/cases.golden.json -- curl "https://api.example.com/cases" -H "x-jwt-token: xxx"`,
+	"期望的树状JSON文件":                          "expected tree JSON file",
+	"用本次的抽取结果覆盖golden文件，而不是比较":             "overwrite the golden file with this run's result instead of comparing",
+	"写入golden文件失败: %w":                     "failed to write golden file: %w",
+	"已更新golden文件":                          "golden file updated",
+	"读取golden文件失败（首次使用可加 --update 生成）: %w": "failed to read golden file (use --update to create it): %w",
+	"golden文件 %s 不是有效的树状JSON: %w":          "golden file %s is not valid tree JSON: %w",
+	"抽取结果与golden树一致":                       "extracted tree matches the golden tree",
+	"抽取结果与golden树 %s 不一致（%s）：\n":           "extracted tree differs from golden tree %s (%s):\n",
+	"抽取结果与golden树 %s 不一致: %s":              "extracted tree differs from golden tree %s: %s",
+
+	// capture
+	"读取抓包文件失败: %w": "failed to read capture file: %w",
+	"无法识别抓包文件 %s 的格式，支持 Charles（.chlsj）、Fiddler（.saz）和 mitmproxy（.flow）": "unrecognized format of capture file %s; Charles (.chlsj), Fiddler (.saz) and mitmproxy (.flow) are supported",
+	"解析抓包文件 %s 失败: %w":                  "failed to parse capture file %s: %w",
+	"抓包文件 %s 中没有HTTP请求":                 "capture file %s contains no HTTP requests",
+	"抓包文件的 %d 条请求中没有URL包含 %q 的请求":       "none of the %d captured requests has a URL containing %q",
+	"无法回放 %s 的响应: %w":                   "cannot replay the response of %s: %w",
+	"抓包文件中没有记录 %s 的响应":                  "the capture file has no recorded response for %s",
+	"不支持的响应压缩格式 %s":                     "unsupported response encoding %s",
+	"解压响应体失败: %w":                       "failed to decompress response body: %w",
+	"不是有效的mitmproxy流文件（tnetstring格式错误）": "not a valid mitmproxy flow file (malformed tnetstring)",
+	// chain
+	"读取链式请求文件失败: %w":                          "failed to read chain file: %w",
+	"解析链式请求文件失败: %w":                          "failed to parse chain file: %w",
+	"链式请求文件中没有步骤":                             "chain file has no steps",
+	"无效的变量名 %q，只能包含字母、数字、下划线和连字符":             "invalid variable name %q, only letters, digits, underscores and hyphens are allowed",
+	"第 %d 步（%s）缺少curl":                        "step %d (%s) has no curl",
+	"最后一步（%s）的响应用于抽取树状结构，不能提取变量":              "the response of the last step (%s) is used for tree extraction and cannot extract variables",
+	"第 %d 步（%s）的变量 %s: %w":                    "step %d (%s) variable %s: %w",
+	"第 %d 步（%s）: %w":                          "step %d (%s): %w",
+	"执行链式请求步骤":                                "running chain step",
+	"第 %d 步（%s）失败: %w":                        "step %d (%s) failed: %w",
+	"响应体不是有效的JSON: %w":                        "response body is not valid JSON: %w",
+	"变量 %s 的路径 %s 在响应中不存在":                    "variable %s: path %s does not exist in the response",
+	"缺少变量: %s（需在 vars 中定义或由之前步骤的 extract 提取）": "missing variables: %s (define them in vars or extract them in an earlier step)",
+
+	// clipboard
+	"剪贴板为空":          "clipboard is empty",
+	"当前系统不支持剪贴板: %s": "clipboard is not supported on this system: %s",
+	"%s 执行失败: %w":    "%s failed: %w",
+	"未找到可用的剪贴板工具（linux需要wl-clipboard、xclip或xsel）: %w": "no usable clipboard tool found (linux requires wl-clipboard, xclip or xsel): %w",
+
+	// doctor
+	"URL解析":  "URL",
+	"代理检测":   "proxy",
+	"DNS解析":  "DNS",
+	"TCP连接":  "TCP connect",
+	"TLS握手":  "TLS handshake",
+	"认证请求头":  "auth headers",
+	"HTTP请求": "HTTP request",
+	"不支持的协议: %q（需要 http 或 https）": "unsupported scheme %q (http or https required)",
+	"URL中缺少主机名":                   "URL has no host",
+	"代理配置无效: %v":                  "invalid proxy configuration: %v",
+	"直连（未配置代理或命中 NO_PROXY）":       "direct (no proxy configured or matched NO_PROXY)",
+	"经由代理 %s":                     "via proxy %s",
+	"%s 为IP地址，无需解析":               "%s is an IP address, no lookup needed",
+	"无法解析 %s: %v":                 "cannot resolve %s: %v",
+	"无法连接 %s: %v":                 "cannot connect to %s: %v",
+	"已连接 %s（%s）":                  "connected to %s (%s)",
+	"非HTTPS请求":                    "not an HTTPS request",
+	"代理隧道建立失败: %v":                "failed to open proxy tunnel: %v",
+	"，证书 %s 有效期至 %s":              ", certificate %s valid until %s",
+	"（即将过期）":                      " (expiring soon)",
+	"解析请求头占位符失败: %v":              "failed to resolve header placeholders: %v",
+	"%s 中的JWT已于 %s 过期":            "JWT in %s expired at %s",
+	"未发现认证相关请求头（Authorization、Cookie、*token* 等），服务器可能拒绝请求": "no auth-related headers found (Authorization, Cookie, *token* etc.), the server may reject the request",
+	"HTTP %d，Content-Type: %s（%s）": "HTTP %d, Content-Type: %s (%s)",
+	"：认证失败，请检查token或cookie是否过期":    ": authentication failed, check whether the token or cookie has expired",
+	"代理返回 %s": "proxy returned %s",
+
+	// drift
+	"响应不是有效的JSON，无法统计结构: %w": "response is not valid JSON, cannot record its structure: %w",
+	"读取基线结构失败: %w":           "failed to read baseline schema: %w",
+	"解析基线结构 %s 失败: %w":       "failed to parse baseline schema %s: %w",
+	"写入响应结构失败: %w":           "failed to write response schema: %w",
+	"响应结构与基线不一致: 第%d层新增键 %s": "response shape differs from baseline: new keys at level %d: %s",
+	"响应结构与基线不一致: 第%d层缺少键 %s": "response shape differs from baseline: keys missing at level %d: %s",
+
+	// errs
+	"服务器返回HTTP %d": "server returned HTTP %d",
+
+	// extractor
+	"输出不是有效的JSON: %w":                  "output is not valid JSON: %w",
+	"另有 %d 个问题未列出":                     "%d more problems not listed",
+	"节点应为对象，实际为 %s":                    "node should be an object, got %s",
+	"存在未定义的字段 %s":                      "unexpected field %s",
+	"缺少字段 %s":                          "missing field %s",
+	"应为字符串，实际为 %s":                     "should be a string, got %s",
+	"应为非负整数，实际为 %s":                    "should be a non-negative integer, got %s",
+	"应为数组，实际为 %s":                      "should be an array, got %s",
+	"TestCaseMind JSON被截断，无法抽取完整的树状结构": "TestCaseMind JSON is truncated, cannot extract a complete tree",
+	"未找到有效的树状结构":                       "no valid tree structure found",
+	"结果序列化失败: %w":                      "failed to serialize result: %w",
+	"树的层数达到最大递归深度 %d，更深的节点可能已被截断":      "tree depth reached the maximum recursion depth %d, deeper nodes may have been cut off",
+	"%d 个子节点因达到最大递归深度 %d 被丢弃，最深的截断位置: %s，可以用 --max-depth 调大": "%d child nodes were dropped at the maximum recursion depth %d, deepest cut at: %s; raise it with --max-depth",
+	"%d 个节点名称为空":                               "%d nodes have an empty name",
+	"%d 个节点名称超过 %d 个字符，已截断":                    "%d node names were longer than %d characters and have been truncated",
+	"第 %d 个JSON文档: %w":                         "JSON document %d: %w",
+	"第 %d 个JSON文档: %s":                         "JSON document %d: %s",
+	"各文档中有 %d 个同名节点，已按 %s 处理":                  "%d nodes share a name across documents, resolved with %s",
+	"输入包含多个JSON文档，已合并各文档的根节点":                  "input contains multiple JSON documents, merged the roots of each document",
+	"%d 处重复的内容已合并为 %d 个节点，来源见运行报告的 duplicates": "%d duplicate occurrences were merged into %d nodes, see duplicates in the run report for their sources",
+	"结果写入失败: %w":                               "failed to write result: %w",
+	"结果为空":                                     "result is empty",
+	"解析树状结构失败: %w":                             "failed to parse tree structure: %w",
+	"开始抽取树状结构，标题候选键: %v, 子节点候选键: %v\n": `start extracting tree, title candidate keys: %v, children candidate keys: %v
+`,
+	"强制使用业务文本提取模式...":                             "forcing business text extraction mode...",
+	"树状结构抽取完成":                                    "tree extraction completed",
+	"创建默认树状结构...":                                 "creating default tree structure...",
+	"成功解析TestCaseMind结构":                          "parsed TestCaseMind structure",
+	"成功解析标准树结构":                                   "parsed standard tree structure",
+	"=== parseTestCaseMindStructureDirect 开始 ===": "=== parseTestCaseMindStructureDirect start ===",
+	"数据类型断言失败，期望map[string]interface{}，实际: %T\n": `type assertion failed, expected map[string]interface{}, got: %T
+`,
+	"未找到data字段": "data field not found",
+	"data字段类型断言失败，期望map[string]interface{}，实际: %T\n": `data field type assertion failed, expected map[string]interface{}, got: %T
+`,
+	"未找到TestCaseMind字段": "TestCaseMind field not found",
+	"TestCaseMind字段类型断言失败，期望string，实际: %T\n": `TestCaseMind field type assertion failed, expected string, got: %T
+`,
+	"TestCaseMind字符串长度: %d\n": `TestCaseMind string length: %d
+`,
+	"TestCaseMind前100字符: %s\n": `TestCaseMind first 100 chars: %s
+`,
+	"TestCaseMind后100字符: %s\n": `TestCaseMind last 100 chars: %s
+`,
+	"JSON括号平衡检查: 开括号{%d, 闭括号}%d\n": `JSON brace balance check: open {%d, close }%d
+`,
+	"JSON格式检查: 以{开始:%v, 以}结束:%v\n": `JSON format check: starts with {:%v, ends with }:%v
+`,
+	"TestCaseMind字符串为空": "TestCaseMind string is empty",
+	"解析TestCaseMind JSON失败: %v\n": `failed to parse TestCaseMind JSON: %v
+`,
+	"错误类型: %T\n": `error type: %T
+`,
+	"检测到'unexpected end of JSON input'错误，JSON可能被截断": "detected 'unexpected end of JSON input', the JSON may be truncated",
+	"最后有效JSON位置: %d\n": `last valid JSON position: %d
+`,
+	"截断的JSON片段: %s\n": `truncated JSON fragment: %s
+`,
+	"JSON解析成功，TestCaseMind数据结构:":                  "JSON parsed, TestCaseMind data structure:",
+	"=== parseTestCaseMindStructureDirect 成功 ===": "=== parseTestCaseMindStructureDirect succeeded ===",
+	"开始结构模式识别...":                                 "starting structure pattern recognition...",
+	"根节点text为空，解析为多根结构，共 %d 个顶级节点\n": `root text is empty, parsing as multi-root structure with %d top-level nodes
+`,
+	"找到第 %d 个有效根节点: %s\n": `found valid root node #%d: %s
+`,
+	"返回 %d 个有效根节点的数组\n": `returning array of %d valid root nodes
+`,
+	"没有找到有效的根节点": "no valid root node found",
+	"检测到标准单根结构，根节点: %s\n": `detected standard single-root structure, root: %s
+`,
+	"检测到纯多根结构，共 %d 个顶级节点\n": `detected pure multi-root structure with %d top-level nodes
+`,
+	"回退到原始解析逻辑": "falling back to the original parsing logic",
+	"根节点解析失败，尝试多根结构解析，子节点数: %d\n": `root node parsing failed, trying multi-root parsing, children: %d
+`,
+	"节点 '%s' 长度不合适: %d\n": `node '%s' has unsuitable length: %d
+`,
+	"节点 '%s' 不符合业务文本特征\n": `node '%s' does not look like business text
+`,
+	"节点 '%s' 技术词汇过多: %d/%d\n": `node '%s' has too many technical words: %d/%d
+`,
+	"节点 '%s' 缺少业务关键词\n": `node '%s' lacks business keywords
+`,
+	"根节点选择结果:\n": `root node selection result:
+`,
+	"  %s '%s': %.1f分 (%s)\n": `  %s '%s': %.1f points (%s)
+`,
+	"成功解析TestCaseMind %d层嵌套结构，标题: %s，子节点数: %d\n": `parsed TestCaseMind structure with %d levels, title: %s, children: %d
+`,
+	"提取到 %d 个唯一业务文本，标题: %s\n": `extracted %d unique business texts, title: %s
+`,
+	"子节点数量: %d\n": `children count: %d
+`,
+	"警告: 达到最大递归深度 %d，停止递归\n": `warning: reached max recursion depth %d, stopping
+`,
+	"%sparseTestCaseMindNode 开始，深度: %d\n": `%sparseTestCaseMindNode start, depth: %d
+`,
+	"%s未找到data字段或类型错误\n": `%sdata field missing or of wrong type
+`,
+	"%s找到richText数组，长度: %d\n": `%sfound richText array, length: %d
+`,
+	"%srichText文本: '%s', 是否业务文本: %v\n": `%srichText text: '%s', business text: %v
+`,
+	"%s使用richText作为标题: '%s'\n": `%susing richText as title: '%s'
+`,
+	"%s发现text字段: '%s', 长度: %d\n": `%sfound text field: '%s', length: %d
+`,
+	"%s使用text字段作为标题: '%s'\n": `%susing text field as title: '%s'
+`,
+	"%stext字段不是业务文本，跳过: '%s'\n": `%stext field is not business text, skipping: '%s'
+`,
+	"%s根节点无标题但有子节点，解析为多根结构\n": `%sroot node has no title but has children, parsing as multi-root structure
+`,
+	"%s从子节点中选择最佳根节点: '%s'\n": `%sselected best root node from children: '%s'
+`,
+	"%s从子节点推断标题: '%s'\n": `%sinferred title from children: '%s'
+`,
+	"%s无法推断标题，使用默认标题: '%s'\n": `%scould not infer title, using default title: '%s'
+`,
+	"%s未找到有效标题，跳过节点\n": `%sno valid title found, skipping node
+`,
+	"%s无children字段，返回节点: '%s'\n": `%sno children field, returning node: '%s'
+`,
+	"%schildren为空或格式错误，返回节点: '%s'\n": `%schildren empty or malformed, returning node: '%s'
+`,
+	"%s处理 %d 个子节点\n": `%sprocessing %d children
+`,
+	"%s子节点 %d 格式错误\n": `%schild %d is malformed
+`,
+	"%s添加子节点: '%s'\n": `%sadding child: '%s'
+`,
+	"%s完成节点解析: '%s', 子节点数: %d\n": `%sfinished parsing node: '%s', children: %d
+`,
+	"%s=== parseMultiRootNode 开始，子节点数: %d ===\n": `%s=== parseMultiRootNode start, children: %d ===
+`,
+	"%s找到有效根节点 %d: '%s'\n": `%sfound valid root node %d: '%s'
+`,
+	"%s=== parseMultiRootNode 完成，有效节点数: %d ===\n": `%s=== parseMultiRootNode finished, valid nodes: %d ===
+`,
+	"开始智能选择最佳业务根节点...": "selecting the best business root node...",
+	"节点 '%s': %d分 (%s)\n": `node '%s': %d points (%s)
+`,
+	"最终选择: '%s' (%d分)\n": `final choice: '%s' (%d points)
+`,
+	"识别业务动作文本: '%s' (包含关键词: '%s')\n": `recognized business action text: '%s' (keyword: '%s')
+`,
+	"识别时间相关业务文本: '%s' (包含关键词: '%s')\n": `recognized time-related business text: '%s' (keyword: '%s')
+`,
+	"识别埋点统计业务文本: '%s'\n": `recognized tracking/statistics business text: '%s'
+`,
+	"识别配置开关业务文本: '%s'\n": `recognized config switch business text: '%s'
+`,
+	"识别BD操作业务文本: '%s' (包含关键词: '%s')\n": `recognized BD operation business text: '%s' (keyword: '%s')
+`,
+	"识别UI交互文本: '%s' (匹配模式: '%s')\n": `recognized UI interaction text: '%s' (pattern: '%s')
+`,
+	"识别状态配置文本: '%s'\n": `recognized status configuration text: '%s'
+`,
+	"识别编号格式业务文本: '%s' (包含关键词: '%s')\n": `recognized numbered business text: '%s' (keyword: '%s')
+`,
+	"%s开始从子节点推断标题，子节点数: %d\n": `%sinferring title from children, children: %d
+`,
+	"%s找到子节点文本: '%s'\n": `%sfound child text: '%s'
+`,
+	"%s找到子节点richText: '%s'\n": `%sfound child richText: '%s'
+`,
+	"%s未找到有效的子节点文本\n": `%sno valid child text found
+`,
+	"%s子节点名称: %v\n": `%schild names: %v
+`,
+	"JSON解析失败: %w": "JSON parsing failed: %w",
+
+	// history
+	"记录运行历史需要 sqlite3 命令行工具: %w": "recording run history requires the sqlite3 command-line tool: %w",
+	"创建历史数据库目录失败: %w":            "failed to create the history database directory: %w",
+	"保存运行历史失败: 没有返回记录ID":         "failed to save run history: no record ID returned",
+	"运行历史中没有ID为 %d 的记录":          "run history has no record with ID %d",
+	"访问历史数据库 %s 失败: %w: %s":      "failed to access history database %s: %w: %s",
+	"访问历史数据库 %s 失败: %w":          "failed to access history database %s: %w",
+	"解析历史数据库的查询结果失败: %w":         "failed to parse the history database query result: %w",
+
+	// http
+	"执行HTTP请求":        "executing HTTP request",
+	"请求头":             "request header",
+	"请求体":             "request body",
+	"创建HTTP请求失败: %w":  "failed to create HTTP request: %w",
+	"解析请求头占位符失败: %w":  "failed to resolve header placeholders: %w",
+	"解析请求体占位符失败: %w":  "failed to resolve body placeholders: %w",
+	"表单字段":            "form field",
+	"表单字段 %s: %w":     "form field %s: %w",
+	"读取上传文件失败: %w":    "failed to read upload file: %w",
+	"读取字段内容文件失败: %w":  "failed to read form field content file: %w",
+	"解析表单字段占位符失败: %w": "failed to resolve form field placeholders: %w",
+	"暂不支持 %s 认证，请改用 --basic 或直接设置 Authorization 请求头": "%s authentication is not supported yet; use --basic or set the Authorization header directly",
+	"解析认证信息占位符失败: %w":                                "failed to resolve credential placeholders: %w",
+	"开始发送请求":                                         "sending request",
+	"HTTP请求执行失败: %w":                                 "HTTP request failed: %w",
+	"收到响应":                                           "response received",
+	"读取响应体失败: %w":                                    "failed to read response body: %w",
+	"服务器返回非2xx状态码":                                   "server returned non-2xx status code",
+	"响应体预览":                                          "response body preview",
+	"成功读取响应体":                                        "response body read",
+
+	// i18n
+	"不支持的语言: %s（可选 zh、en）": "unsupported language: %s (choose zh or en)",
+
+	// jsonpath
+	"JSONPath必须以 $ 开头: %s": "JSONPath must start with $: %s",
+	"JSONPath中存在空的键名: %s":  "JSONPath contains an empty key: %s",
+	"JSONPath中的 [ 未闭合: %s": "unclosed [ in JSONPath: %s",
+	"JSONPath中的下标无效: %s":   "invalid index in JSONPath: %s",
+	"JSONPath语法错误: %s":     "JSONPath syntax error: %s",
+	"不能删除JSON的根 $":         "cannot delete the JSON root $",
+
+	// logger
+	"不支持的日志格式: %s（可选 text、json）":             "unsupported log format: %s (choose text or json)",
+	"不支持的日志级别: %s（可选 debug、info、warn、error）": "unsupported log level: %s (choose debug, info, warn or error)",
+	"错误: ": "error: ",
+	"警告: ": "warning: ",
+
+	// mcp
+	"写入MCP响应失败: %w":   "failed to write MCP response: %w",
+	"读取MCP请求失败: %w":   "failed to read MCP request: %w",
+	"消息不是有效的JSON: %v": "message is not valid JSON: %v",
+	"无效的JSON-RPC请求":   "invalid JSON-RPC request",
+	"不支持的方法: %s":      "unsupported method: %s",
+	"无效的工具调用参数: %v":   "invalid tool call params: %v",
+	"无效的工具参数: %v":     "invalid tool arguments: %v",
+	"curl参数不能为空":      "curl argument must not be empty",
+	"json参数不能为空":      "json argument must not be empty",
+	"未知工具: %s":        "unknown tool: %s",
+	"完整的cURL命令（例如浏览器开发者工具中 Copy as cURL 的结果）": "full cURL command (e.g. the result of Copy as cURL in browser developer tools)",
+	"接口响应的原始JSON文本": "raw JSON text of the API response",
+	"执行cURL请求，并从JSON响应中抽取业务用例树（数组格式，节点包含name和children）": "Execute a cURL request and extract the business case tree from the JSON response (array format, nodes contain name and children)",
+	"从已有的JSON响应文本中抽取业务用例树（数组格式，节点包含name和children）":      "Extract the business case tree from existing JSON response text (array format, nodes contain name and children)",
+
+	// mock
+	"创建夹具目录失败: %w":                                                 "failed to create fixtures directory: %w",
+	"写入夹具文件失败: %w":                                                 "failed to write fixture file: %w",
+	"读取夹具目录失败: %w":                                                 "failed to read fixtures directory: %w",
+	"目录 %s 中没有夹具文件（*.json）或抓包会话文件":                                 "no fixture files (*.json) or capture session files in directory %s",
+	"读取夹具文件失败: %w":                                                 "failed to read fixture file: %w",
+	"解析夹具文件 %s 失败: %w":                                             "failed to parse fixture file %s: %w",
+	"夹具文件 %s 缺少 request.method、request.url 或 response.status_code": "fixture file %s is missing request.method, request.url or response.status_code",
+	"没有匹配的夹具":                                                      "no matching fixture",
+	"应答夹具":                                                         "serving fixture",
+	"模拟服务启动失败: %w":                                                 "failed to start mock server: %w",
+	"模拟服务关闭失败: %w":                                                 "failed to shut down mock server: %w",
+
+	// notify
+	"第 %d 轮":      "cycle %d",
+	"失败: %s":      "failed: %s",
+	"%d 个节点":      "%d nodes",
+	"成功 %d，失败 %d": "%d succeeded, %d failed",
+	"无效的webhook地址 %q，应为http或https地址": "invalid webhook URL %q, expected an http or https URL",
+	"webhook返回HTTP %d":               "webhook returned HTTP %d",
+
+	// objstore
+	"不支持的对象存储地址 %q，可选 s3://bucket/key 或 gs://bucket/key":  "unsupported object storage URL %q, use s3://bucket/key or gs://bucket/key",
+	"对象存储地址 %q 需要包含桶名与对象键，如 %s://bucket/path/output.json": "object storage URL %q must include a bucket and an object key, e.g. %s://bucket/path/output.json",
+	"上传到 %s:// 需要 %s 命令行工具: %w":                           "uploading to %s:// requires the %s command-line tool: %w",
+	"上传到 %s 失败: %w: %s":                                   "failed to upload to %s: %w: %s",
+	"上传到 %s 失败: %w":                                       "failed to upload to %s: %w",
+
+	// parser
+	"cURL命令为空":                    "cURL command is empty",
+	"解析cURL参数失败: %w":              "failed to parse cURL arguments: %w",
+	"未在cURL命令中找到URL":              "no URL found in cURL command",
+	"第 %d 个字符开始的单引号未闭合":           "unterminated single quote starting at character %d",
+	"第 %d 个字符开始的双引号未闭合":           "unterminated double quote starting at character %d",
+	"第 %d 个字符开始的 $'...' 未闭合":      "unterminated $'...' starting at character %d",
+	"选项 %s 缺少参数":                  "option %s requires an argument",
+	"无效的表单参数 %q，应为 name=value 形式": "invalid form argument %q, expected name=value",
+
+	// pipeline
+	"%s 阶段钩子执行失败: %w": "%s stage hook failed: %w",
+
+	// placeholder
+	"占位符 %s 解析失败: %w":               "failed to resolve placeholder %s: %w",
+	"环境变量名为空":                       "environment variable name is empty",
+	"环境变量 %s 未设置":                   "environment variable %s is not set",
+	"钥匙串占位符格式应为 service/key，实际: %s": "keychain placeholder must be service/key, got: %s",
+	"当前系统不支持钥匙串: %s":                "keychain is not supported on this system: %s",
+	"读取钥匙串 %s 失败: %w":               "failed to read keychain %s: %w",
+	"钥匙串 %s 中没有值":                   "keychain %s has no value",
+	"生成随机数失败: %w":                   "failed to generate random data: %w",
+	"随机字符串长度应为 1 到 %d 之间的整数，实际: %s": "random string length must be an integer between 1 and %d, got: %s",
+
+	// plugin
+	"读取插件清单失败: %w":                                     "failed to read plugin manifest: %w",
+	"解析插件清单失败: %w":                                     "failed to parse plugin manifest: %w",
+	"插件名称 %q 无效，只能包含小写字母、数字、- 和 _":                     "invalid plugin name %q, only lowercase letters, digits, - and _ are allowed",
+	"插件 %s 的类型 %q 无效，可选 strategy、converter 或 renderer": "plugin %s has an invalid kind %q, expected strategy, converter or renderer",
+	"renderer 插件 %s 与已有的输出格式同名":                        "renderer plugin %s has the same name as an existing output format",
+	"插件 %s 没有声明 command":                               "plugin %s does not declare a command",
+	"插件 %s 执行失败: %w: %s":                               "plugin %s failed: %w: %s",
+	"插件 %s 执行失败: %w":                                   "plugin %s failed: %w",
+	"插件 %s 的输出不是有效的树状JSON: %w":                         "output of plugin %s is not a valid tree JSON: %w",
+	"读取插件记录失败: %w":                                     "failed to read the plugin registry: %w",
+	"插件记录 %s 格式错误: %w":                                 "plugin registry %s is malformed: %w",
+	"写入插件记录失败: %w":                                     "failed to write the plugin registry: %w",
+	"插件 %s 未安装，可通过 plugin list 查看已安装的插件":               "plugin %s is not installed, see plugin list for installed plugins",
+	"插件 %s 的安装目录不存在，请重新安装":                             "the directory of plugin %s is missing, please reinstall it",
+	"插件 %s 的文件与安装时不一致（摘要 %s），可能已被篡改，请重新安装":             "files of plugin %s differ from the installed ones (digest %s) and may have been tampered with, please reinstall it",
+	"创建插件目录失败: %w":                                     "failed to create the plugin directory: %w",
+	"插件 %s 的摘要 %s 与期望的 %s 不一致，已取消安装":                   "plugin %s has digest %s instead of the expected %s, installation aborted",
+	"插件 %s 已安装（版本 %s），使用 --force 覆盖":                   "plugin %s is already installed (version %s), use --force to replace it",
+	"删除旧版本插件失败: %w":                                    "failed to remove the previous plugin version: %w",
+	"安装插件失败: %w":                                       "failed to install plugin: %w",
+	"删除插件失败: %w":                                       "failed to remove plugin: %w",
+	"读取插件仓库的提交失败: %w":                                  "failed to read the commit of the plugin repository: %w",
+	"获取插件需要 %s 命令行工具: %w":                              "fetching the plugin requires the %s command-line tool: %w",
+	"获取插件失败: %s %s: %w: %s":                            "failed to fetch plugin: %s %s: %w: %s",
+	"获取插件失败: %s %s: %w":                                "failed to fetch plugin: %s %s: %w",
+	"无效的插件版本引用 %q":                                     "invalid plugin ref %q",
+
+	// postprocess
+	"不支持的后处理脚本类型 %q，可选 .js（node）、.jq（jq）或 .cel（CEL表达式）": "unsupported post-process script type %q, expected .js (node), .jq (jq) or .cel (CEL expression)",
+	"后处理脚本 %s 编译失败: %w":           "failed to compile post-process script %s: %w",
+	"后处理脚本 %s 的结果无法转换为JSON: %w":   "the result of post-process script %s cannot be converted to JSON: %w",
+	"读取后处理脚本失败: %w":               "failed to read post-process script: %w",
+	"执行 %s 脚本需要 %s: %w":           "running %s scripts requires %s: %w",
+	"后处理脚本 %s 执行失败: %w: %s":       "post-process script %s failed: %w: %s",
+	"后处理脚本 %s 执行失败: %w":           "post-process script %s failed: %w",
+	"后处理脚本 %s 的输出不是有效的树状JSON: %w": "output of post-process script %s is not a valid tree JSON: %w",
+
+	// profile
+	"创建CPU profile文件失败: %w": "failed to create CPU profile file: %w",
+	"开始采集CPU profile失败: %w": "failed to start CPU profiling: %w",
+	"写入CPU profile失败: %w":   "failed to write CPU profile: %w",
+	"创建内存profile文件失败: %w":   "failed to create memory profile file: %w",
+	"写入内存profile失败: %w":     "failed to write memory profile: %w",
+
+	// publish
+	"请求 %s 失败: %w":                                         "request to %s failed: %w",
+	"%s %s 返回HTTP %d: %s":                                  "%s %s returned HTTP %d: %s",
+	"解析 %s 的响应失败: %w":                                      "failed to parse the response of %s: %w",
+	"发布到Confluence需要地址、空间和访问令牌":                            "publishing to Confluence requires a URL, a space and an access token",
+	"保存Confluence页面失败: %w":                                 "failed to save Confluence page: %w",
+	"不支持的Confluence页面格式: %s（可选 storage、markdown）":          "unsupported Confluence page format: %s (storage or markdown)",
+	"查找Confluence页面失败: %w":                                 "failed to look up Confluence page: %w",
+	"创建Jira问题需要项目key":                                      "creating Jira issues requires a project key",
+	"创建Jira问题需要地址和访问令牌":                                    "creating Jira issues requires a URL and an access token",
+	"树中没有叶子节点，无需创建Jira问题":                                  "the tree has no leaf nodes, no Jira issues to create",
+	"已创建 %d 个Jira问题，创建其余问题失败: %w":                          "created %d Jira issues, failed to create the rest: %w",
+	"已创建 %d 个Jira问题，%d 个创建失败，第一个失败: %s":                    "created %d Jira issues, %d failed, first failure: %s",
+	"上传到TestRail需要项目ID":                                    "uploading to TestRail requires a project ID",
+	"上传到TestRail需要地址、账号和API key":                           "uploading to TestRail requires a URL, an account and an API key",
+	"根节点 %q 没有子节点，请通过 --publish-title 指定其所在分组的名称":          "root node %q has no children, specify the name of its section with --publish-title",
+	"创建TestRail分组 %q 失败: %w":                               "failed to create TestRail section %q: %w",
+	"创建TestRail用例 %q 失败: %w":                               "failed to create TestRail case %q: %w",
+	"读取TestRail分组失败: %w":                                   "failed to read TestRail sections: %w",
+	"读取TestRail用例失败: %w":                                   "failed to read TestRail cases: %w",
+	"创建飞书文档失败: %w":                                         "failed to create Feishu document: %w",
+	"写入飞书文档 %s 失败: %w":                                     "failed to write Feishu document %s: %w",
+	"发布到飞书需要 tenant_access_token 或应用的 App ID 与 App Secret": "publishing to Feishu requires a tenant_access_token or an app ID and app secret",
+	"获取飞书 tenant_access_token 失败: %w":                      "failed to get Feishu tenant_access_token: %w",
+	"飞书返回了 %d 个块，应为 %d 个":                                  "Feishu returned %d blocks, expected %d",
+	"飞书接口返回错误 %d: %s":                                      "Feishu API returned error %d: %s",
+	"空间 %s 中没有标题为 %q 的父页面":                                 "space %s has no parent page titled %q",
+
+	// processor
+	"保存原始响应失败":                "failed to save raw response",
+	"认证已失效，执行刷新请求后重试":         "authentication expired, running refresh request and retrying",
+	"服务器返回HTTP %d，刷新认证失败: %w": "server returned HTTP %d, refreshing authentication failed: %w",
+	"响应已满足等待条件":               "response meets the wait condition",
+	"等待条件在 %s 内未满足（共请求 %d 次）": "wait condition not met within %s (%d requests)",
+	"响应尚未满足等待条件，稍后重试":         "response does not meet the wait condition yet, retrying",
+	"%s 会重复发送 %s 请求，可能在服务端重复执行操作；确认安全时加 --retry-unsafe，或用 --idempotency-key 携带幂等键": "%s would resend the %s request and may repeat the operation on the server; add --retry-unsafe if that is safe, or send an idempotency key with --idempotency-key",
+	"已写入幂等键":        "idempotency key set",
+	"读取请求体文件失败: %w": "failed to read request body file: %w",
+	"--max-redirs 的值 %q 无效，应为非负整数或 -1（不限制）":    "invalid --max-redirs value %q, expected a non-negative integer or -1 (unlimited)",
+	"重定向次数超过 %d 次":                             "maximum of %d redirects exceeded",
+	"跟随重定向":                                    "following redirect",
+	"无效的代理地址 %q":                               "invalid proxy address %q",
+	"不支持的代理协议 %s，可选 http、https、socks5、socks5h": "unsupported proxy scheme %s, expected http, https, socks5 or socks5h",
+	"使用代理": "using proxy",
+	"传输不支持代理与TLS选项，忽略这些选项":  "transport does not support proxy and TLS options, ignoring them",
+	"读取CA证书失败: %w":          "failed to read CA certificate: %w",
+	"CA证书文件 %s 中没有PEM格式的证书": "CA certificate file %s contains no PEM certificates",
+	"--key 需要配合 --cert 使用":  "--key requires --cert",
+	"加载客户端证书失败: %w":         "failed to load client certificate: %w",
+	"不允许引用本地文件 %q":          "referencing local file %q is not allowed",
+	"--sync 不能与 --batch/--batch-data、--watch、--envs、--preview 或 --format 同时使用": "--sync cannot be used with --batch/--batch-data, --watch, --envs, --preview or --format",
+	"--sync 只支持本地文件":         "--sync only supports local files",
+	"读取同步基准文件失败: %w":         "failed to read sync base file: %w",
+	"同步基准文件 %s 不是树状JSON: %w": "sync base file %s is not tree JSON: %w",
+	"增量同步":                    "incremental sync",
+	"更新同步基准文件失败: %w":          "failed to update sync base file: %w",
+	"已更新同步基准文件":               "sync base file updated",
+	"--sync 需要配合 --out 使用":    "--sync requires --out",
+	"--sync 与 --out 不能是同一个文件": "--sync and --out must be different files",
+	"不支持从标准输入读取请求体（@-），请改为引用文件": "reading the request body from stdin (@-) is not supported, reference a file instead",
+	"业务文本规则":                           "business text profile",
+	"--text-profile 只能是 auto 或 %s":     "--text-profile must be auto or %s",
+	"--on-conflict 只能是 %s":             "--on-conflict must be one of %s",
+	"不支持的键名风格 %q，可选 snake、camel、kebab": "unsupported key style %q, choose snake, camel or kebab",
+	"转换键名失败: %w":                       "failed to convert keys: %w",
+	"转换键名失败: JSON之后存在多余的内容":            "failed to convert keys: unexpected content after the JSON value",
+	"输出不符合树状JSON结构: %w":                "output does not match the tree JSON schema: %w",
+	"cURL解析失败: %w":                     "failed to parse cURL: %w",
+	"没有提供输入":                           "no input provided",
+	"服务器返回HTTP %d: 响应校验失败: %w":         "server returned HTTP %d: response validation failed: %w",
+	"响应校验失败: %w":                       "response validation failed: %w",
+	"响应重写失败: %w":                       "response rewrite failed: %w",
+	"服务器返回HTTP %d，无法提取业务数据":            "server returned HTTP %d, unable to extract business data",
+	"服务器返回错误响应，无法提取业务数据":               "server returned an error response, unable to extract business data",
+	"原始响应已保存":                          "raw response saved",
+	"树状结构抽取失败: %w":                     "tree extraction failed: %w",
+
+	"未知的流水线阶段: %s": "unknown pipeline stage: %s",
+
+	// report
+	"写入运行报告失败: %w":      "failed to write run report: %w",
+	"写入调试包失败: %w":       "failed to write debug bundle: %w",
+	"响应头序列化失败: %w":      "failed to serialize response headers: %w",
+	"写入响应头文件失败: %w":     "failed to write response headers file: %w",
+	"计算产物 %s 的哈希失败: %w": "failed to hash artifact %s: %w",
+	"写入产物清单失败: %w":      "failed to write artifact manifest: %w",
+
+	// rewrite
+	"无效的响应重写规则 %q，格式应为 操作:路径，操作为 delete、rename、move 或 decompress": "invalid response rewrite rule %q, expected op:path where op is delete, rename, move or decompress",
+	"无效的响应重写规则 %q，格式应为 rename:路径=新键名":                             "invalid response rewrite rule %q, expected rename:path=newkey",
+	"无效的响应重写规则 %q，格式应为 move:路径=目标路径":                              "invalid response rewrite rule %q, expected move:path=target",
+	"无效的响应重写规则 %q: 新键名为空":                                         "invalid response rewrite rule %q: empty new key",
+	"无效的响应重写规则 %q: %w":                                            "invalid response rewrite rule %q: %w",
+	"无效的响应重写规则 %q: 不支持的操作 %s（可选 delete、rename、move、decompress）":   "invalid response rewrite rule %q: unsupported operation %s (choose delete, rename, move or decompress)",
+	"move 的路径不能使用 $..":                                            "move paths cannot use $..",
+	"move 的路径不能使用 [*]":                                            "move paths cannot use [*]",
+	"$.. 之后应为单个键名: %s":                                            "$.. must be followed by a single key: %s",
+	"rename 的路径必须以对象键结尾":                                          "rename paths must end with an object key",
+	"不能删除整个响应":                                                    "cannot delete the whole response",
+	"decompress 的路径不能是整个响应":                                       "the decompress path cannot be the whole response",
+	"字段值不是有效的base64":                                              "field value is not valid base64",
+	"无法识别的压缩格式（支持 gzip、zlib、zip）":                                 "unrecognized compression format (gzip, zlib and zip are supported)",
+	"解压失败: %w":                           "decompression failed: %w",
+	"zip归档中没有文件":                         "zip archive contains no files",
+	"响应不是有效的JSON，无法重写: %w":               "the response is not valid JSON and cannot be rewritten: %w",
+	"响应不是有效的JSON，无法按protobuf JSON规整: %w": "the response is not valid JSON and cannot be normalized as protobuf JSON: %w",
+	"无效的分组规则 %q，格式应为 数组路径[*].字段，如 '$.data.cases[*].module'": "invalid group rule %q, expected array[*].field, e.g. '$.data.cases[*].module'",
+	"无效的分组规则 %q: %w":           "invalid group rule %q: %w",
+	"无效的分组规则 %q: [*] 之后缺少分组字段": "invalid group rule %q: missing the group field after [*]",
+	"响应不是有效的JSON，无法分组: %w":     "the response is not valid JSON and cannot be grouped: %w",
+	"%s: 无法写入目标路径 %s":          "%s: cannot write to target path %s",
+
+	// progress
+	"\r已下载 %s (%s)":                 "\rdownloaded %s (%s)",
+	"\r下载中 [%s] %3.0f%% %s/%s (%s)": "\rdownloading [%s] %3.0f%% %s/%s (%s)",
+
+	// server
+	"HTTP服务启动失败: %w":                         "failed to start HTTP server: %w",
+	"HTTP服务关闭失败: %w":                         "failed to shut down HTTP server: %w",
+	"仅支持POST请求":                              "only POST requests are supported",
+	"请求体不是有效的JSON: %v":                       "request body is not valid JSON: %v",
+	"curl字段不能为空":                             "curl field must not be empty",
+	"仅支持GET请求":                               "only GET requests are supported",
+	"tree字段不能为空":                             "tree field must not be empty",
+	"不支持的格式 %q，可选 %s":                        "unsupported format %q, expected one of %s",
+	"/ingest 只接受来自本机的请求":                     "/ingest only accepts requests from localhost",
+	"/ingest 不接受来自 %s 的请求":                   "/ingest does not accept requests from %s",
+	"%s 只接受来自本机的请求":                          "%s only accepts requests from localhost",
+	"%s 不接受来自 %s 的请求":                        "%s does not accept requests from %s",
+	"%s 的 Content-Type 必须为 application/json": "%s requires Content-Type: application/json",
+	"不允许在不受信任的请求中使用占位符 %s":                   "placeholder %s is not allowed in untrusted requests",
+	"request.url字段不能为空":                      "request.url field must not be empty",
+	"response.content 不是有效的base64: %v":       "response.content is not valid base64: %v",
+
+	// treediff
+	"新增 %d 个节点，删除 %d 个节点":           "%d node(s) added, %d node(s) removed",
+	"新增 %d 个子树，修改 %d 个节点，删除 %d 个子树": "%d subtree(s) added, %d node(s) changed, %d subtree(s) removed",
+
+	// tui
+	"树为空，无可浏览的节点":    "tree is empty, nothing to browse",
+	"交互式浏览器运行失败: %w": "interactive browser failed: %w",
+	"复制失败: %v":       "copy failed: %v",
+	"已复制路径: ":        "path copied: ",
+	"未找到: %s":        "not found: %s",
+	"匹配 %d/%d: %s":   "match %d/%d: %s",
+	"↑↓ 移动  ←→ 折叠/展开  e/c 全部展开/折叠  / 搜索  n/N 下/上一个  y 复制路径  q 退出": "↑↓ move  ←→ collapse/expand  e/c expand/collapse all  / search  n/N next/prev  y copy path  q quit",
+
+	// validator
+	"按Content-Type解码响应": "decoding response by Content-Type",
+	"接口返回了跳转到登录页（%s）的页面而不是JSON，登录态（cookie/token）已过期，请在浏览器中刷新登录后重新复制cURL命令": "the endpoint returned a page redirecting to the login page (%s) instead of JSON; your session (cookie/token) has expired, log in again in the browser and copy the cURL command again",
+	"接口返回了 %s 页面而不是JSON，登录态（cookie/token）可能已过期，请重新复制cURL命令":                "the endpoint returned a %s page instead of JSON; your session (cookie/token) may have expired, copy the cURL command again",
+	"%s 响应解析失败: %w":             "failed to parse %s response: %w",
+	"%s 响应转换为JSON失败: %w":        "failed to convert %s response to JSON: %w",
+	"第 %d 行: %w":                "line %d: %w",
+	"XML中没有根元素":                 "XML has no root element",
+	"响应体大小 %d 字节超过上限 %d 字节":     "response body size %d bytes exceeds the limit of %d bytes",
+	"JSON嵌套深度超过上限 %d":           "JSON nesting depth exceeds the limit of %d",
+	"JSON字符串长度 %d 字节超过上限 %d 字节": "JSON string length %d bytes exceeds the limit of %d bytes",
+	"响应体为空":                     "response body is empty",
+	"开始校验响应":                    "validating response",
+	"JSON解析失败":                  "JSON parsing failed",
+	"响应校验通过，格式为有效的JSON":         "response is valid JSON",
+
+	// version
+	"创建请求失败: %w":        "failed to create request: %w",
+	"查询最新版本失败: %w":      "failed to query latest version: %w",
+	"查询最新版本失败: HTTP %d": "failed to query latest version: HTTP %d",
+	"解析发布信息失败: %w":      "failed to parse release info: %w",
+	"发布信息中没有tag_name":   "release info has no tag_name",
+}
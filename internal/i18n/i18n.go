@@ -0,0 +1,94 @@
+package i18n
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 支持的语言
+const (
+	ZH = "zh"
+	EN = "en"
+)
+
+// catalogs 以中文原文为键的翻译表，中文为源语言无需翻译表
+var catalogs = map[string]map[string]string{
+	EN: en,
+}
+
+var current = ZH
+
+func init() {
+	// 帮助文本在命令注册时就需要确定语言，因此在flag解析之前从参数和环境变量中检测
+	current = Detect(os.Args[1:], os.Getenv)
+}
+
+// Detect 依次根据 --lang 参数、CASEURL2MD_LANG、LC_ALL、LC_MESSAGES、LANG 确定语言，无法识别时为中文
+func Detect(args []string, getenv func(string) string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if value, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return Normalize(value)
+		}
+		if arg == "--lang" && i+1 < len(args) {
+			return Normalize(args[i+1])
+		}
+	}
+
+	for _, key := range []string{"CASEURL2MD_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := getenv(key)
+		if value == "" || value == "C" || value == "POSIX" || strings.HasPrefix(value, "C.") {
+			continue
+		}
+		return Normalize(value)
+	}
+	return ZH
+}
+
+// Normalize 将 zh_CN.UTF-8、en-US 等区域设置归一化为支持的语言
+func Normalize(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if strings.HasPrefix(locale, EN) {
+		return EN
+	}
+	return ZH
+}
+
+// SetLang 设置当前语言
+func SetLang(lang string) error {
+	switch lang {
+	case ZH, EN:
+		current = lang
+		return nil
+	default:
+		return fmt.Errorf(T("不支持的语言: %s（可选 zh、en）"), lang)
+	}
+}
+
+// Lang 返回当前语言
+func Lang() string {
+	return current
+}
+
+// T 返回消息在当前语言下的文本，缺少翻译时返回原文
+func T(msg string) string {
+	if catalog, ok := catalogs[current]; ok {
+		if translated, ok := catalog[msg]; ok {
+			return translated
+		}
+	}
+	return msg
+}
+
+// Sprintf 翻译格式串后格式化
+func Sprintf(format string, args ...interface{}) string {
+	return fmt.Sprintf(T(format), args...)
+}
+
+// Errorf 翻译格式串后创建错误，支持 %w
+func Errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(T(format), args...)
+}
@@ -0,0 +1,72 @@
+package i18n
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		env  map[string]string
+		want string
+	}{
+		{"默认中文", nil, nil, ZH},
+		{"LANG英文", nil, map[string]string{"LANG": "en_US.UTF-8"}, EN},
+		{"LANG中文", nil, map[string]string{"LANG": "zh_CN.UTF-8"}, ZH},
+		{"C区域忽略", nil, map[string]string{"LANG": "C.UTF-8"}, ZH},
+		{"LC_ALL优先于LANG", nil, map[string]string{"LC_ALL": "en_GB", "LANG": "zh_CN"}, EN},
+		{"专用环境变量优先", nil, map[string]string{"CASEURL2MD_LANG": "zh", "LANG": "en_US"}, ZH},
+		{"参数优先于环境变量", []string{"--lang", "en"}, map[string]string{"LANG": "zh_CN"}, EN},
+		{"等号形式参数", []string{"--url", "x", "--lang=en"}, nil, EN},
+		{"--之后的参数不识别", []string{"--", "curl", "--lang", "en"}, nil, ZH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(key string) string { return tt.env[key] }
+			if got := Detect(tt.args, getenv); got != tt.want {
+				t.Errorf("Detect() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer SetLang(ZH)
+
+	if got := T("只能指定一种输入方式"); got != "只能指定一种输入方式" {
+		t.Errorf("中文下应返回原文，实际: %q", got)
+	}
+
+	if err := SetLang(EN); err != nil {
+		t.Fatalf("SetLang(en) error = %v", err)
+	}
+	if got := T("只能指定一种输入方式"); got != "only one input method can be specified" {
+		t.Errorf("T() = %q", got)
+	}
+	if got := T("没有翻译的消息"); got != "没有翻译的消息" {
+		t.Errorf("缺少翻译时应返回原文，实际: %q", got)
+	}
+	if got := Errorf("环境变量 %s 未设置", "TOKEN").Error(); got != "environment variable TOKEN is not set" {
+		t.Errorf("Errorf() = %q", got)
+	}
+
+	if err := SetLang("fr"); err == nil {
+		t.Error("SetLang(fr) 应返回错误")
+	}
+}
+
+// 翻译必须保留原文中的格式化动词，否则参数会错位
+func TestCatalogVerbs(t *testing.T) {
+	verb := regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z%]`)
+	for lang, catalog := range catalogs {
+		for key, value := range catalog {
+			if !reflect.DeepEqual(verb.FindAllString(key, -1), verb.FindAllString(value, -1)) {
+				t.Errorf("[%s] 格式化动词不一致: %q -> %q", lang, key, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,236 @@
+// Package jsonpath 实现JSONPath的常用子集（$、.key、['key']、[index]），用于从响应JSON中取值
+package jsonpath
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// ErrNotFound 路径在数据中不存在
+var ErrNotFound = errors.New("jsonpath: not found")
+
+// step 路径中的一级：对象键或数组下标
+type step struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// Path 已解析的路径
+type Path struct {
+	expr  string
+	steps []step
+}
+
+// Parse 解析形如 $.data.items[0].name 或 $['data']['items'][0] 的路径
+func Parse(expr string) (*Path, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, "$") {
+		return nil, i18n.Errorf("JSONPath必须以 $ 开头: %s", expr)
+	}
+
+	path := &Path{expr: expr}
+	rest := expr[1:]
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, i18n.Errorf("JSONPath中存在空的键名: %s", expr)
+			}
+			path.steps = append(path.steps, step{key: rest[:end], isKey: true})
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, i18n.Errorf("JSONPath中的 [ 未闭合: %s", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path.steps = append(path.steps, step{key: inner[1 : len(inner)-1], isKey: true})
+				continue
+			}
+			index, err := strconv.Atoi(inner)
+			if err != nil {
+				return nil, i18n.Errorf("JSONPath中的下标无效: %s", inner)
+			}
+			path.steps = append(path.steps, step{index: index})
+		default:
+			return nil, i18n.Errorf("JSONPath语法错误: %s", expr)
+		}
+	}
+	return path, nil
+}
+
+// String 返回原始路径表达式
+func (p *Path) String() string {
+	return p.expr
+}
+
+// Parent 返回最后一级为对象键的路径的上一级路径与该键名；路径为 $ 或以数组下标结尾时ok为false
+func (p *Path) Parent() (parent *Path, key string, ok bool) {
+	if len(p.steps) == 0 {
+		return nil, "", false
+	}
+	last := p.steps[len(p.steps)-1]
+	if !last.isKey {
+		return nil, "", false
+	}
+	steps := p.steps[: len(p.steps)-1 : len(p.steps)-1]
+	return &Path{expr: "$" + formatSteps(steps), steps: steps}, last.key, true
+}
+
+// formatSteps 将路径步骤格式化为 .key、['key'] 与 [index] 形式
+func formatSteps(steps []step) string {
+	var b strings.Builder
+	for _, s := range steps {
+		switch {
+		case !s.isKey:
+			b.WriteString("[" + strconv.Itoa(s.index) + "]")
+		case s.key != "" && !strings.ContainsAny(s.key, ".[]'\" "):
+			b.WriteString("." + s.key)
+		default:
+			b.WriteString("['" + s.key + "']")
+		}
+	}
+	return b.String()
+}
+
+// Lookup 在 json.Unmarshal 得到的数据中按路径取值，路径不存在时返回 ErrNotFound；
+// 负数下标从数组末尾计数
+func (p *Path) Lookup(data interface{}) (interface{}, error) {
+	current := data
+	for _, s := range p.steps {
+		if s.isKey {
+			obj, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, ErrNotFound
+			}
+			if current, ok = obj[s.key]; !ok {
+				return nil, ErrNotFound
+			}
+			continue
+		}
+
+		arr, index, err := element(current, s)
+		if err != nil {
+			return nil, err
+		}
+		current = arr[index]
+	}
+	return current, nil
+}
+
+// Set 将路径处的值设置为value，返回修改后的数据（路径为 $ 时即为value）。
+// 对象中不存在的键会被创建，途经的不存在的键创建为空对象；数组下标必须存在，否则返回 ErrNotFound
+func (p *Path) Set(data, value interface{}) (interface{}, error) {
+	return set(data, p.steps, value)
+}
+
+func set(current interface{}, steps []step, value interface{}) (interface{}, error) {
+	if len(steps) == 0 {
+		return value, nil
+	}
+	s := steps[0]
+	if s.isKey {
+		if current == nil {
+			current = map[string]interface{}{}
+		}
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, ErrNotFound
+		}
+		child, err := set(obj[s.key], steps[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		obj[s.key] = child
+		return obj, nil
+	}
+
+	arr, index, err := element(current, s)
+	if err != nil {
+		return nil, err
+	}
+	if arr[index], err = set(arr[index], steps[1:], value); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// Delete 删除路径处的对象键或数组元素，返回修改后的数据；路径不存在时返回 ErrNotFound，不能删除根 $
+func (p *Path) Delete(data interface{}) (interface{}, error) {
+	if len(p.steps) == 0 {
+		return nil, i18n.Errorf("不能删除JSON的根 $")
+	}
+	return remove(data, p.steps)
+}
+
+func remove(current interface{}, steps []step) (interface{}, error) {
+	s := steps[0]
+	if s.isKey {
+		obj, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, ErrNotFound
+		}
+		child, exists := obj[s.key]
+		if !exists {
+			return nil, ErrNotFound
+		}
+		if len(steps) == 1 {
+			delete(obj, s.key)
+			return obj, nil
+		}
+		child, err := remove(child, steps[1:])
+		if err != nil {
+			return nil, err
+		}
+		obj[s.key] = child
+		return obj, nil
+	}
+
+	arr, index, err := element(current, s)
+	if err != nil {
+		return nil, err
+	}
+	if len(steps) == 1 {
+		return append(arr[:index], arr[index+1:]...), nil
+	}
+	if arr[index], err = remove(arr[index], steps[1:]); err != nil {
+		return nil, err
+	}
+	return arr, nil
+}
+
+// element 返回数组与下标步骤对应的元素位置，负数下标从数组末尾计数
+func element(current interface{}, s step) ([]interface{}, int, error) {
+	arr, ok := current.([]interface{})
+	if !ok {
+		return nil, 0, ErrNotFound
+	}
+	index := s.index
+	if index < 0 {
+		index += len(arr)
+	}
+	if index < 0 || index >= len(arr) {
+		return nil, 0, ErrNotFound
+	}
+	return arr, index, nil
+}
+
+// Lookup 解析路径并取值
+func Lookup(data interface{}, expr string) (interface{}, error) {
+	path, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	return path.Lookup(data)
+}
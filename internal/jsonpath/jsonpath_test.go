@@ -0,0 +1,132 @@
+package jsonpath
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestLookup(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(`{"errCode":0,"data":{"items":[{"name":"A"},{"name":"B"}],"a.b":true}}`), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr error
+	}{
+		{path: "$", want: data},
+		{path: "$.errCode", want: float64(0)},
+		{path: "$.data.items[1].name", want: "B"},
+		{path: "$.data.items[-1].name", want: "B"},
+		{path: "$['data']['a.b']", want: true},
+		{path: "$.data.items[2]", wantErr: ErrNotFound},
+		{path: "$.missing", wantErr: ErrNotFound},
+		{path: "$.errCode.x", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := Lookup(data, tt.path)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Lookup() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{"data.items", "$.", "$.items[0", "$.items[x]", "$x"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", expr)
+		}
+	}
+}
+
+func TestPath_SetDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     string
+		value   interface{}
+		delete  string
+		want    string
+		wantErr error
+	}{
+		{name: "replace", set: "$.page.size", value: 100.0, want: `{"filters":["a","b"],"page":{"size":100}}`},
+		{name: "create", set: "$.extra.id", value: "x", want: `{"extra":{"id":"x"},"filters":["a","b"],"page":{"size":20}}`},
+		{name: "index", set: "$.filters[-1]", value: "c", want: `{"filters":["a","c"],"page":{"size":20}}`},
+		{name: "index out of range", set: "$.filters[2]", value: "c", wantErr: ErrNotFound},
+		{name: "set through scalar", set: "$.page.size.x", value: 1.0, wantErr: ErrNotFound},
+		{name: "delete key", delete: "$.page.size", want: `{"filters":["a","b"],"page":{}}`},
+		{name: "delete element", delete: "$.filters[0]", want: `{"filters":["b"],"page":{"size":20}}`},
+		{name: "delete missing", delete: "$.missing", wantErr: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data interface{}
+			if err := json.Unmarshal([]byte(`{"page":{"size":20},"filters":["a","b"]}`), &data); err != nil {
+				t.Fatal(err)
+			}
+			var err error
+			if tt.delete != "" {
+				data, err = mustParse(t, tt.delete).Delete(data)
+			} else {
+				data, err = mustParse(t, tt.set).Set(data, tt.value)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, _ := json.Marshal(data)
+			if string(got) != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := mustParse(t, "$").Delete(map[string]interface{}{}); err == nil {
+		t.Error("Delete($) error = nil, want error")
+	}
+}
+
+func mustParse(t *testing.T, expr string) *Path {
+	t.Helper()
+	p, err := Parse(expr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestPath_Parent(t *testing.T) {
+	tests := []struct {
+		path, parent, key string
+		ok                bool
+	}{
+		{path: "$.data.items[0].name", parent: "$.data.items[0]", key: "name", ok: true},
+		{path: "$['a.b']['c d']", parent: "$['a.b']", key: "c d", ok: true},
+		{path: "$.name", parent: "$", key: "name", ok: true},
+		{path: "$.items[0]"},
+		{path: "$"},
+	}
+
+	for _, tt := range tests {
+		parent, key, ok := mustParse(t, tt.path).Parent()
+		if ok != tt.ok {
+			t.Errorf("Parent(%s) ok = %v, want %v", tt.path, ok, tt.ok)
+			continue
+		}
+		if ok && (parent.String() != tt.parent || key != tt.key) {
+			t.Errorf("Parent(%s) = %s, %q, want %s, %q", tt.path, parent, key, tt.parent, tt.key)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package keystyle 按 --key-style 转换输出JSON的键名：snake_case、camelCase 或 kebab-case。
+// 内置输出的键名均为 snake_case，转换只改变键名，不改变键的顺序与值
+package keystyle
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Style 键名风格，空值表示保持原样
+type Style string
+
+// 可选的键名风格
+const (
+	Snake Style = "snake" // duration_ms
+	Camel Style = "camel" // durationMs
+	Kebab Style = "kebab" // duration-ms
+)
+
+// Styles 可选的键名风格，按 --key-style 帮助中的顺序排列
+var Styles = []Style{Snake, Camel, Kebab}
+
+// Parse 解析 --key-style 的值，空字符串返回空风格
+func Parse(value string) (Style, error) {
+	switch style := Style(strings.ToLower(strings.TrimSpace(value))); style {
+	case "", Snake, Camel, Kebab:
+		return style, nil
+	}
+	return "", i18n.Errorf("不支持的键名风格 %q，可选 snake、camel、kebab", value)
+}
+
+// Key 按风格转换单个键名。键名按 _、- 与小写字母之后的大写字母切分为单词，空风格时原样返回
+func (s Style) Key(key string) string {
+	if s == "" {
+		return key
+	}
+	words := split(key)
+	if len(words) == 0 {
+		return key
+	}
+	switch s {
+	case Camel:
+		for i := 1; i < len(words); i++ {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+		return strings.Join(words, "")
+	case Kebab:
+		return strings.Join(words, "-")
+	}
+	return strings.Join(words, "_")
+}
+
+// split 将键名切分为小写的单词
+func split(key string) []string {
+	var words []string
+	var word strings.Builder
+	flush := func() {
+		if word.Len() > 0 {
+			words = append(words, word.String())
+			word.Reset()
+		}
+	}
+	runes := []rune(key)
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-':
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])):
+			flush()
+		}
+		word.WriteRune(unicode.ToLower(r))
+	}
+	flush()
+	return words
+}
+
+// Convert 转换JSON中所有对象的键名，保持键的顺序与值不变；输入带换行时以两个空格缩进输出，否则输出单行JSON。
+// 空风格时原样返回
+func (s Style) Convert(data []byte) ([]byte, error) {
+	if s == "" {
+		return data, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var buf bytes.Buffer
+	if err := s.convertValue(decoder, &buf); err != nil {
+		return nil, i18n.Errorf("转换键名失败: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, i18n.Errorf("转换键名失败: JSON之后存在多余的内容")
+	}
+
+	trimmed := bytes.TrimRight(data, " \t\r\n")
+	trailing := data[len(trimmed):]
+	if !bytes.Contains(trimmed, []byte("\n")) {
+		return append(buf.Bytes(), trailing...), nil
+	}
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, buf.Bytes(), "", "  "); err != nil {
+		return nil, i18n.Errorf("转换键名失败: %w", err)
+	}
+	return append(indented.Bytes(), trailing...), nil
+}
+
+// convertValue 读取一个JSON值并以单行JSON写入buf，对象的键名按风格转换
+func (s Style) convertValue(decoder *json.Decoder, buf *bytes.Buffer) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return writeScalar(buf, token)
+	}
+
+	closing := byte(']')
+	if delim == '{' {
+		closing = '}'
+	}
+	buf.WriteByte(byte(delim))
+	for first := true; decoder.More(); first = false {
+		if !first {
+			buf.WriteByte(',')
+		}
+		if delim == '{' {
+			key, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			if err := writeScalar(buf, s.Key(key.(string))); err != nil {
+				return err
+			}
+			buf.WriteByte(':')
+		}
+		if err := s.convertValue(decoder, buf); err != nil {
+			return err
+		}
+	}
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	buf.WriteByte(closing)
+	return nil
+}
+
+// writeScalar 写入字符串、数字、布尔值或null，字符串中的 <、>、& 不转义
+func writeScalar(buf *bytes.Buffer, value interface{}) error {
+	if number, ok := value.(json.Number); ok {
+		buf.WriteString(number.String())
+		return nil
+	}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode 追加的换行
+	return nil
+}
@@ -0,0 +1,96 @@
+package keystyle
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Style
+		wantErr bool
+	}{
+		{value: "", want: ""},
+		{value: "snake", want: Snake},
+		{value: " Camel ", want: Camel},
+		{value: "kebab", want: Kebab},
+		{value: "pascal", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse(%q) = %q, %v, want %q, error %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestStyle_Key(t *testing.T) {
+	tests := []struct {
+		key   string
+		snake string
+		camel string
+		kebab string
+	}{
+		{key: "name", snake: "name", camel: "name", kebab: "name"},
+		{key: "duration_ms", snake: "duration_ms", camel: "durationMs", kebab: "duration-ms"},
+		{key: "durationMs", snake: "duration_ms", camel: "durationMs", kebab: "duration-ms"},
+		{key: "max-depth", snake: "max_depth", camel: "maxDepth", kebab: "max-depth"},
+		{key: "http2Enabled", snake: "http2_enabled", camel: "http2Enabled", kebab: "http2-enabled"},
+		{key: "URL", snake: "url", camel: "url", kebab: "url"},
+		{key: "_", snake: "_", camel: "_", kebab: "_"},
+	}
+	for _, tt := range tests {
+		for style, want := range map[Style]string{Snake: tt.snake, Camel: tt.camel, Kebab: tt.kebab} {
+			if got := style.Key(tt.key); got != want {
+				t.Errorf("%s.Key(%q) = %q, want %q", style, tt.key, got, want)
+			}
+		}
+	}
+	if got := Style("").Key("duration_ms"); got != "duration_ms" {
+		t.Errorf("empty style Key() = %q, want unchanged", got)
+	}
+}
+
+func TestStyle_Convert(t *testing.T) {
+	tests := []struct {
+		name    string
+		style   Style
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "单行JSON",
+			style: Camel,
+			input: `{"status":"success","duration_ms":12,"diff":{"added_nodes":1.50}}` + "\n",
+			want:  `{"status":"success","durationMs":12,"diff":{"addedNodes":1.50}}` + "\n",
+		},
+		{
+			name:  "缩进JSON保持键的顺序",
+			style: Kebab,
+			input: "{\n  \"max_depth\": 3,\n  \"deepest_path\": [\n    \"a<b>&c\"\n  ],\n  \"empty\": [],\n  \"node\": null\n}",
+			want:  "{\n  \"max-depth\": 3,\n  \"deepest-path\": [\n    \"a<b>&c\"\n  ],\n  \"empty\": [],\n  \"node\": null\n}",
+		},
+		{
+			name:  "数组中的对象",
+			style: Snake,
+			input: `[{"exitCode":1},{"exit-code":true}]`,
+			want:  `[{"exit_code":1},{"exit_code":true}]`,
+		},
+		{name: "空风格原样返回", input: `{"duration_ms" : 1}`, want: `{"duration_ms" : 1}`},
+		{name: "无效JSON", style: Camel, input: `{"a":`, wantErr: true},
+		{name: "多余的内容", style: Camel, input: `{} {}`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.style.Convert([]byte(tt.input))
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Convert() = %s, want error", got)
+				}
+				return
+			}
+			if err != nil || string(got) != tt.want {
+				t.Errorf("Convert() = %s, %v, want %s", got, err, tt.want)
+			}
+		})
+	}
+}
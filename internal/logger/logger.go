@@ -0,0 +1,211 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 日志格式
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// New 创建写入w的分级日志器，level 为 debug|info|warn|error，format 为 text|json
+func New(w io.Writer, level, format string) (*slog.Logger, error) {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(format) {
+	case "", FormatText:
+		return slog.New(NewConsoleHandler(w, lvl)), nil
+	case FormatJSON:
+		return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: lvl})), nil
+	default:
+		return nil, i18n.Errorf("不支持的日志格式: %s（可选 text、json）", format)
+	}
+}
+
+// Default 返回写入stderr的文本日志器，verbose 时输出debug级别
+func Default(verbose bool) *slog.Logger {
+	lvl := slog.LevelInfo
+	if verbose {
+		lvl = slog.LevelDebug
+	}
+	return slog.New(NewConsoleHandler(os.Stderr, lvl))
+}
+
+// Discard 返回丢弃所有输出的日志器
+func Discard() *slog.Logger {
+	return slog.New(NewConsoleHandler(io.Discard, slog.LevelError+1))
+}
+
+// ParseLevel 解析日志级别名称
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, i18n.Errorf("不支持的日志级别: %s（可选 debug、info、warn、error）", level)
+	}
+}
+
+// ConsoleHandler 面向终端的简洁文本格式：消息在前，属性以 key=value 追加
+type ConsoleHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+	color  color.Palette
+}
+
+// NewConsoleHandler 创建终端文本日志处理器，w为终端时错误和警告前缀着色
+func NewConsoleHandler(w io.Writer, level slog.Leveler) *ConsoleHandler {
+	return &ConsoleHandler{w: w, mu: &sync.Mutex{}, level: level, color: color.For(w)}
+}
+
+// Enabled 实现 slog.Handler
+func (h *ConsoleHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle 实现 slog.Handler
+func (h *ConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+
+	switch {
+	case r.Level >= slog.LevelError:
+		b.WriteString(h.color.Red(i18n.T("错误: ")))
+	case r.Level >= slog.LevelWarn:
+		b.WriteString(h.color.Yellow(i18n.T("警告: ")))
+	}
+	b.WriteString(r.Message)
+
+	prefix := strings.Join(h.groups, ".")
+	for _, attr := range h.attrs {
+		writeAttr(&b, prefix, attr)
+	}
+	r.Attrs(func(attr slog.Attr) bool {
+		writeAttr(&b, prefix, attr)
+		return true
+	})
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs 实现 slog.Handler
+func (h *ConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append(append([]slog.Attr{}, h.attrs...), attrs...)
+	return &clone
+}
+
+// WithGroup 实现 slog.Handler
+func (h *ConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.groups = append(append([]string{}, h.groups...), name)
+	return &clone
+}
+
+// writeAttr 以 key=value 形式写入属性，包含空白的值加引号
+func writeAttr(b *strings.Builder, prefix string, attr slog.Attr) {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return
+	}
+
+	key := attr.Key
+	if prefix != "" {
+		key = prefix + "." + key
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		for _, sub := range attr.Value.Group() {
+			writeAttr(b, key, sub)
+		}
+		return
+	}
+
+	value := attr.Value.String()
+	if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+		value = fmt.Sprintf("%q", value)
+	}
+	b.WriteString(" ")
+	b.WriteString(key)
+	b.WriteString("=")
+	b.WriteString(value)
+}
+
+// teeHandler 将日志记录同时交给多个处理器，各处理器按自身级别过滤
+type teeHandler []slog.Handler
+
+// Tee 返回将日志同时写入所有 handlers 的处理器
+func Tee(handlers ...slog.Handler) slog.Handler {
+	return teeHandler(handlers)
+}
+
+// Enabled 实现 slog.Handler
+func (t teeHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range t {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle 实现 slog.Handler
+func (t teeHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range t {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WithAttrs 实现 slog.Handler
+func (t teeHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := make(teeHandler, len(t))
+	for i, h := range t {
+		clone[i] = h.WithAttrs(attrs)
+	}
+	return clone
+}
+
+// WithGroup 实现 slog.Handler
+func (t teeHandler) WithGroup(name string) slog.Handler {
+	clone := make(teeHandler, len(t))
+	for i, h := range t {
+		clone[i] = h.WithGroup(name)
+	}
+	return clone
+}
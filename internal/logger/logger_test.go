@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "info", "text")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("不应输出")
+	log.Info("执行HTTP请求", "method", "POST", "url", "http://example.com/a b")
+	log.With("component", "http").Warn("非2xx状态码", "status", 401)
+
+	want := "执行HTTP请求 method=POST url=\"http://example.com/a b\"\n" +
+		"警告: 非2xx状态码 component=http status=401\n"
+	if buf.String() != want {
+		t.Errorf("输出 = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(&buf, "debug", "json")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	log.Debug("响应校验通过", "size", 42)
+
+	var record map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("输出不是JSON: %s", buf.String())
+	}
+	if record["level"] != "DEBUG" || record["msg"] != "响应校验通过" || record["size"] != float64(42) {
+		t.Errorf("record = %v", record)
+	}
+}
+
+func TestNew_Invalid(t *testing.T) {
+	if _, err := New(&bytes.Buffer{}, "trace", "text"); err == nil || !strings.Contains(err.Error(), "日志级别") {
+		t.Errorf("无效级别应返回错误, got %v", err)
+	}
+	if _, err := New(&bytes.Buffer{}, "info", "xml"); err == nil || !strings.Contains(err.Error(), "日志格式") {
+		t.Errorf("无效格式应返回错误, got %v", err)
+	}
+}
+
+func TestTee(t *testing.T) {
+	var info, debug bytes.Buffer
+	log := slog.New(Tee(NewConsoleHandler(&info, slog.LevelInfo), NewConsoleHandler(&debug, slog.LevelDebug)))
+
+	log.Debug("请求头", "key", "Accept")
+	log.With("component", "http").Info("收到响应", "status", 200)
+
+	if want := "收到响应 component=http status=200\n"; info.String() != want {
+		t.Errorf("info 输出 = %q, want %q", info.String(), want)
+	}
+	if want := "请求头 key=Accept\n收到响应 component=http status=200\n"; debug.String() != want {
+		t.Errorf("debug 输出 = %q, want %q", debug.String(), want)
+	}
+}
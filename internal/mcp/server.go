@@ -0,0 +1,220 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/processor"
+)
+
+// protocolVersion 默认支持的MCP协议版本
+const protocolVersion = "2024-11-05"
+
+// JSON-RPC 错误码
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// request JSON-RPC 请求或通知
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// response JSON-RPC 响应
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+// rpcError JSON-RPC 错误
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Server 基于stdio的MCP工具服务
+type Server struct {
+	defaults *config.Config
+	version  string
+}
+
+// New 创建MCP服务，defaults 为工具调用未指定参数时使用的配置
+func New(defaults *config.Config, version string) *Server {
+	cfg := *defaults
+	// 日志统一写入stderr，不会破坏stdout上的协议消息；关闭Verbose避免每次失败都落盘调试文件
+	cfg.Verbose = false
+	return &Server{
+		defaults: &cfg,
+		version:  version,
+	}
+}
+
+// Serve 从r逐行读取JSON-RPC消息，将响应写入w，直到输入结束；ctx 取消时中止进行中的工具调用
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64<<20)
+	encoder := json.NewEncoder(w)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		resp := s.handleMessage(ctx, line)
+		if resp == nil {
+			continue
+		}
+		if err := encoder.Encode(resp); err != nil {
+			return i18n.Errorf("写入MCP响应失败: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return i18n.Errorf("读取MCP请求失败: %w", err)
+	}
+	return nil
+}
+
+// handleMessage 处理单条消息，通知类消息返回nil
+func (s *Server) handleMessage(ctx context.Context, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return errorResponse(json.RawMessage("null"), codeParseError, i18n.Sprintf("消息不是有效的JSON: %v", err))
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, i18n.T("无效的JSON-RPC请求"))
+	}
+
+	isNotification := len(req.ID) == 0
+	result, rpcErr := s.dispatch(ctx, req)
+	if isNotification {
+		return nil
+	}
+	if rpcErr != nil {
+		return &response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+	return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+// dispatch 根据方法名分发请求
+func (s *Server) dispatch(ctx context.Context, req request) (interface{}, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		return s.initialize(req.Params), nil
+	case "notifications/initialized", "notifications/cancelled":
+		return nil, nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return map[string]interface{}{"tools": toolDefinitions()}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		return nil, &rpcError{Code: codeMethodNotFound, Message: i18n.Sprintf("不支持的方法: %s", req.Method)}
+	}
+}
+
+// initialize 返回服务能力声明
+func (s *Server) initialize(params json.RawMessage) interface{} {
+	version := protocolVersion
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	if err := json.Unmarshal(params, &p); err == nil && p.ProtocolVersion != "" {
+		version = p.ProtocolVersion
+	}
+
+	return map[string]interface{}{
+		"protocolVersion": version,
+		"capabilities": map[string]interface{}{
+			"tools": map[string]interface{}{},
+		},
+		"serverInfo": map[string]interface{}{
+			"name":    "caseurl2md",
+			"version": s.version,
+		},
+	}
+}
+
+// callTool 执行工具调用，业务失败通过 isError 返回而不是协议错误
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (interface{}, *rpcError) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: i18n.Sprintf("无效的工具调用参数: %v", err)}
+	}
+
+	var args toolArguments
+	if len(call.Arguments) > 0 {
+		if err := json.Unmarshal(call.Arguments, &args); err != nil {
+			return nil, &rpcError{Code: codeInvalidParams, Message: i18n.Sprintf("无效的工具参数: %v", err)}
+		}
+	}
+
+	var result []byte
+	var err error
+	switch call.Name {
+	case toolFetchAndExtract:
+		if args.Curl == "" {
+			return toolError(i18n.T("curl参数不能为空")), nil
+		}
+		result, err = processor.New(s.configFor(args)).Process(ctx, args.Curl, nil)
+	case toolExtractFromJSON:
+		if args.JSON == "" {
+			return toolError(i18n.T("json参数不能为空")), nil
+		}
+		// 先按安全上限校验，避免超大或嵌套过深的参数进入抽取
+		p := processor.New(s.configFor(args))
+		if err = p.ValidateOnly([]byte(args.JSON)); err == nil {
+			result, err = p.ExtractOnly(ctx, []byte(args.JSON))
+		}
+	default:
+		return nil, &rpcError{Code: codeInvalidParams, Message: i18n.Sprintf("未知工具: %s", call.Name)}
+	}
+
+	if err != nil {
+		return toolError(err.Error()), nil
+	}
+	return toolText(string(result), false), nil
+}
+
+// configFor 合并默认配置与工具参数
+func (s *Server) configFor(args toolArguments) *config.Config {
+	cfg := *s.defaults
+	// cURL命令来自客户端，不能借此读取本机文件、环境变量与钥匙串
+	cfg.NoFileAccess, cfg.NoLocalSecrets = true, true
+	if len(args.TitleKeys) > 0 {
+		cfg.TitleKeys = args.TitleKeys
+	}
+	if len(args.ChildrenKeys) > 0 {
+		cfg.ChildrenKeys = args.ChildrenKeys
+	}
+	if args.Timeout > 0 {
+		cfg.Timeout = time.Duration(args.Timeout) * time.Second
+	}
+	return &cfg
+}
+
+// errorResponse 构造协议错误响应
+func errorResponse(id json.RawMessage, code int, message string) *response {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	return &response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
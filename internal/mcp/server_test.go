@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+func TestServer_Serve(t *testing.T) {
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"extract_tree_from_json","arguments":{"json":"{\"case_title\":\"根节点\",\"children\":[{\"case_title\":\"子节点\"}]}"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"fetch_and_extract_tree","arguments":{}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"unknown"}`,
+	}, "\n")
+
+	var output bytes.Buffer
+	s := New(&config.Config{Verbose: true}, "test")
+	if err := s.Serve(context.Background(), strings.NewReader(input), &output); err != nil {
+		t.Fatalf("Serve() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("响应数量 = %d, want 5（通知不应有响应）:\n%s", len(lines), output.String())
+	}
+
+	var responses []map[string]interface{}
+	for _, line := range lines {
+		var resp map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatalf("响应不是有效JSON: %s", line)
+		}
+		responses = append(responses, resp)
+	}
+
+	initResult := responses[0]["result"].(map[string]interface{})
+	if initResult["protocolVersion"] != "2025-03-26" {
+		t.Errorf("protocolVersion = %v", initResult["protocolVersion"])
+	}
+
+	tools := responses[1]["result"].(map[string]interface{})["tools"].([]interface{})
+	if len(tools) != 2 {
+		t.Errorf("tools 数量 = %d, want 2", len(tools))
+	}
+
+	extractResult := responses[2]["result"].(map[string]interface{})
+	if extractResult["isError"] != false {
+		t.Errorf("extract_tree_from_json 返回错误: %v", extractResult)
+	}
+	text := extractResult["content"].([]interface{})[0].(map[string]interface{})["text"].(string)
+	if !strings.Contains(text, "根节点") || !strings.Contains(text, "子节点") {
+		t.Errorf("extract_tree_from_json 结果缺少节点: %s", text)
+	}
+
+	if responses[3]["result"].(map[string]interface{})["isError"] != true {
+		t.Errorf("缺少curl参数时应返回 isError")
+	}
+
+	if responses[4]["error"] == nil {
+		t.Errorf("未知方法应返回协议错误")
+	}
+}
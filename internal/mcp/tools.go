@@ -0,0 +1,89 @@
+package mcp
+
+import "github.com/wellkilo/Curl2json/internal/i18n"
+
+// 工具名称
+const (
+	toolFetchAndExtract = "fetch_and_extract_tree"
+	toolExtractFromJSON = "extract_tree_from_json"
+)
+
+// toolArguments 两个工具共用的参数
+type toolArguments struct {
+	Curl         string   `json:"curl"`
+	JSON         string   `json:"json"`
+	TitleKeys    []string `json:"title_keys"`
+	ChildrenKeys []string `json:"children_keys"`
+	Timeout      int      `json:"timeout"`
+}
+
+// extractionProperties 抽取规则相关的参数定义
+func extractionProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"title_keys": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": i18n.T("节点内容字段候选键名，按优先级排序"),
+		},
+		"children_keys": map[string]interface{}{
+			"type":        "array",
+			"items":       map[string]interface{}{"type": "string"},
+			"description": i18n.T("子节点数组候选键名，按优先级排序"),
+		},
+	}
+}
+
+// toolDefinitions 返回 tools/list 的工具列表
+func toolDefinitions() []map[string]interface{} {
+	fetchProps := extractionProperties()
+	fetchProps["curl"] = map[string]interface{}{
+		"type":        "string",
+		"description": i18n.T("完整的cURL命令（例如浏览器开发者工具中 Copy as cURL 的结果）"),
+	}
+	fetchProps["timeout"] = map[string]interface{}{
+		"type":        "integer",
+		"description": i18n.T("HTTP请求超时时间（秒）"),
+	}
+
+	extractProps := extractionProperties()
+	extractProps["json"] = map[string]interface{}{
+		"type":        "string",
+		"description": i18n.T("接口响应的原始JSON文本"),
+	}
+
+	return []map[string]interface{}{
+		{
+			"name":        toolFetchAndExtract,
+			"description": i18n.T("执行cURL请求，并从JSON响应中抽取业务用例树（数组格式，节点包含name和children）"),
+			"inputSchema": map[string]interface{}{
+				"type":       "object",
+				"properties": fetchProps,
+				"required":   []string{"curl"},
+			},
+		},
+		{
+			"name":        toolExtractFromJSON,
+			"description": i18n.T("从已有的JSON响应文本中抽取业务用例树（数组格式，节点包含name和children）"),
+			"inputSchema": map[string]interface{}{
+				"type":       "object",
+				"properties": extractProps,
+				"required":   []string{"json"},
+			},
+		},
+	}
+}
+
+// toolText 构造文本类型的工具结果
+func toolText(text string, isError bool) map[string]interface{} {
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": text},
+		},
+		"isError": isError,
+	}
+}
+
+// toolError 构造失败的工具结果
+func toolError(message string) map[string]interface{} {
+	return toolText(message, true)
+}
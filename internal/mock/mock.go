@@ -0,0 +1,326 @@
+// Package mock 开发用的模拟服务：按请求指纹应答录制的响应，无需访问真实的内部接口即可调试和演示抽取参数。
+// 录制的响应来自 --save-fixture 写入的夹具文件，或抓包工具导出的会话文件
+package mock
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/capture"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/report"
+)
+
+// maxRequestBodySize 模拟服务读取的请求体大小上限
+const maxRequestBodySize = 10 << 20
+
+// skippedHeaders 录制时不保存的响应头：由HTTP服务按应答内容重新生成，或携带会话凭据
+var skippedHeaders = map[string]bool{
+	"Content-Length":    true,
+	"Content-Encoding":  true,
+	"Transfer-Encoding": true,
+	"Connection":        true,
+	"Date":              true,
+	"Set-Cookie":        true,
+}
+
+// Fixture 一条录制的请求与响应
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest 录制的请求，URL中的敏感参数已脱敏
+type FixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// FixtureResponse 录制的响应。JSON响应体原样保存，便于直接编辑；
+// 其他响应体保存为JSON字符串，应答时写出字符串的内容
+type FixtureResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"headers,omitempty"`
+	Body       json.RawMessage `json:"body"`
+}
+
+// Fingerprint 返回请求的指纹：方法、路径、查询参数与请求体相同的请求指纹相同。
+// 不比较协议与主机，录制真实接口的响应后把请求指向模拟服务即可匹配；
+// 查询参数不分顺序，可能携带凭据的参数只比较名称，JSON请求体忽略键的顺序与空白
+func Fingerprint(method, rawURL, body string) string {
+	target := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		path := u.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		target = path
+		if query := u.Query(); len(query) > 0 {
+			target = report.RedactURL(path + "?" + query.Encode())
+		}
+	}
+	sum := sha256.Sum256([]byte(strings.ToUpper(method) + "\n" + target + "\n" + normalizeBody(body)))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// normalizeBody 将JSON请求体重新编码为键按名称排序的紧凑形式，其他请求体只去掉首尾空白
+func normalizeBody(body string) string {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil || decoder.More() {
+		return strings.TrimSpace(body)
+	}
+	normalized, err := json.Marshal(data)
+	if err != nil {
+		return strings.TrimSpace(body)
+	}
+	return string(normalized)
+}
+
+// NewFixture 由请求与响应创建夹具
+func NewFixture(method, rawURL, reqBody string, status int, header http.Header, body []byte) *Fixture {
+	f := &Fixture{
+		Request:  FixtureRequest{Method: strings.ToUpper(method), URL: report.RedactURL(rawURL), Body: reqBody},
+		Response: FixtureResponse{StatusCode: status, Header: http.Header{}},
+	}
+	for name, values := range header {
+		if !skippedHeaders[http.CanonicalHeaderKey(name)] {
+			f.Response.Header[name] = append([]string(nil), values...)
+		}
+	}
+	if len(f.Response.Header) == 0 {
+		f.Response.Header = nil
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] != '"' && json.Valid(trimmed) {
+		f.Response.Body = append(json.RawMessage(nil), trimmed...)
+	} else {
+		f.Response.Body = marshalString(string(body))
+	}
+	return f
+}
+
+// marshalString 将文本编码为JSON字符串，HTML字符不转义
+func marshalString(text string) json.RawMessage {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(text)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Fingerprint 返回夹具中请求的指纹
+func (f *Fixture) Fingerprint() string {
+	return Fingerprint(f.Request.Method, f.Request.URL, f.Request.Body)
+}
+
+// body 返回应答的响应体
+func (f *Fixture) body() []byte {
+	var text string
+	if err := json.Unmarshal(f.Response.Body, &text); err == nil {
+		return []byte(text)
+	}
+	return f.Response.Body
+}
+
+// Save 将夹具写入目录dir，文件名为 指纹.json，同一请求再次录制时覆盖；返回写入的文件路径
+func Save(dir string, f *Fixture) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", i18n.Errorf("创建夹具目录失败: %w", err)
+	}
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(f); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, f.Fingerprint()+".json")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", i18n.Errorf("写入夹具文件失败: %w", err)
+	}
+	return path, nil
+}
+
+// RecordHook 返回在 execute 阶段之后将请求与响应录制到目录dir的钩子，后续阶段失败时夹具也已写入
+func RecordHook(dir string) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		req := state.Request
+		_, err := Save(dir, NewFixture(req.Method, req.URL, req.Body, state.StatusCode, state.Header, state.Body))
+		return err
+	}
+}
+
+// captureExts 按抓包会话文件读取的扩展名
+var captureExts = map[string]bool{".chlsj": true, ".saz": true, ".flow": true, ".flows": true, ".mitm": true}
+
+// Load 读取目录dir中的夹具：*.json 为夹具文件，.chlsj、.saz、.flow 等为抓包会话文件（其中记录了响应的请求都会加载），
+// 不读取子目录与其他文件。文件按名称顺序读取，指纹相同时后读取的覆盖先读取的
+func Load(dir string) (map[string]*Fixture, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, i18n.Errorf("读取夹具目录失败: %w", err)
+	}
+
+	fixtures := make(map[string]*Fixture)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		ext := strings.ToLower(filepath.Ext(path))
+		switch {
+		case ext == ".json":
+			f, err := loadFixture(path)
+			if err != nil {
+				return nil, err
+			}
+			fixtures[f.Fingerprint()] = f
+		case captureExts[ext]:
+			recorded, err := capture.Load(path)
+			if err != nil {
+				return nil, err
+			}
+			for _, e := range recorded {
+				if e.Response == nil {
+					continue
+				}
+				f := NewFixture(e.Request.Method, e.Request.URL, e.Request.Body, e.Response.StatusCode, e.Response.Header, e.Response.Body)
+				fixtures[f.Fingerprint()] = f
+			}
+		}
+	}
+	if len(fixtures) == 0 {
+		return nil, i18n.Errorf("目录 %s 中没有夹具文件（*.json）或抓包会话文件", dir)
+	}
+	return fixtures, nil
+}
+
+// loadFixture 读取并校验一个夹具文件
+func loadFixture(path string) (*Fixture, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取夹具文件失败: %w", err)
+	}
+	var f Fixture
+	if err := json.Unmarshal(content, &f); err != nil {
+		return nil, i18n.Errorf("解析夹具文件 %s 失败: %w", filepath.Base(path), err)
+	}
+	if f.Request.Method == "" || f.Request.URL == "" || f.Response.StatusCode == 0 {
+		return nil, i18n.Errorf("夹具文件 %s 缺少 request.method、request.url 或 response.status_code", filepath.Base(path))
+	}
+	if len(f.Response.Body) == 0 {
+		f.Response.Body = json.RawMessage(`""`)
+	}
+	return &f, nil
+}
+
+// missResponse 没有匹配的夹具时的响应体
+type missResponse struct {
+	Error       string `json:"error"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Server 按请求指纹应答夹具的模拟服务
+type Server struct {
+	fixtures map[string]*Fixture
+	logger   *slog.Logger
+}
+
+// New 创建模拟服务
+func New(fixtures map[string]*Fixture, logger *slog.Logger) *Server {
+	return &Server{fixtures: fixtures, logger: logger}
+}
+
+// Fingerprints 返回已加载夹具的指纹，按请求的URL与方法排序
+func (s *Server) Fingerprints() []string {
+	fingerprints := make([]string, 0, len(s.fixtures))
+	for fp := range s.fixtures {
+		fingerprints = append(fingerprints, fp)
+	}
+	sort.Slice(fingerprints, func(i, j int) bool {
+		a, b := s.fixtures[fingerprints[i]].Request, s.fixtures[fingerprints[j]].Request
+		if a.URL != b.URL {
+			return a.URL < b.URL
+		}
+		return a.Method < b.Method
+	})
+	return fingerprints
+}
+
+// Fixture 返回指纹对应的夹具
+func (s *Server) Fixture(fingerprint string) *Fixture {
+	return s.fixtures[fingerprint]
+}
+
+// ServeHTTP 计算请求的指纹并应答对应夹具中的响应，没有匹配时返回404与该请求的指纹
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fp := Fingerprint(r.Method, r.URL.RequestURI(), string(body))
+
+	f, ok := s.fixtures[fp]
+	if !ok {
+		s.logger.Warn(i18n.T("没有匹配的夹具"), "method", r.Method, "url", report.RedactURL(r.URL.RequestURI()), "fingerprint", fp)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(missResponse{Error: i18n.T("没有匹配的夹具"), Fingerprint: fp})
+		return
+	}
+
+	s.logger.Debug(i18n.T("应答夹具"), "method", r.Method, "url", report.RedactURL(r.URL.RequestURI()), "fingerprint", fp, "status", f.Response.StatusCode)
+	for name, values := range f.Response.Header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(f.Response.StatusCode)
+	w.Write(f.body())
+}
+
+// ListenAndServe 监听地址并提供服务，ctx 取消时优雅退出
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return i18n.Errorf("模拟服务启动失败: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return i18n.Errorf("模拟服务关闭失败: %w", err)
+		}
+		if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
@@ -0,0 +1,146 @@
+package mock
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/logger"
+)
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint("POST", "https://api.example.com/cases?a=1&b=2&token=abc", `{"page":1,"size":20}`)
+	same := []struct {
+		name, method, url, body string
+	}{
+		{"other host and lower-case method", "post", "http://localhost:9090/cases?a=1&b=2&token=abc", `{"page":1,"size":20}`},
+		{"query order", "POST", "https://api.example.com/cases?b=2&token=abc&a=1", `{"page":1,"size":20}`},
+		{"rotated token", "POST", "https://api.example.com/cases?a=1&b=2&token=xyz", `{"page":1,"size":20}`},
+		{"JSON key order and whitespace", "POST", "https://api.example.com/cases?a=1&b=2&token=abc", "{\n  \"size\": 20,\n  \"page\": 1\n}"},
+	}
+	for _, tt := range same {
+		if got := Fingerprint(tt.method, tt.url, tt.body); got != base {
+			t.Errorf("%s: Fingerprint() = %s, want %s", tt.name, got, base)
+		}
+	}
+
+	different := []struct {
+		name, method, url, body string
+	}{
+		{"method", "PUT", "https://api.example.com/cases?a=1&b=2&token=abc", `{"page":1,"size":20}`},
+		{"path", "POST", "https://api.example.com/cases/1?a=1&b=2&token=abc", `{"page":1,"size":20}`},
+		{"query value", "POST", "https://api.example.com/cases?a=2&b=2&token=abc", `{"page":1,"size":20}`},
+		{"body", "POST", "https://api.example.com/cases?a=1&b=2&token=abc", `{"page":2,"size":20}`},
+	}
+	for _, tt := range different {
+		if got := Fingerprint(tt.method, tt.url, tt.body); got == base {
+			t.Errorf("%s: Fingerprint() = %s, want a different fingerprint", tt.name, got)
+		}
+	}
+}
+
+func TestNewFixture_Body(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		wantJSON string
+	}{
+		{"JSON object is kept as is", `{"data":[1,2]}`, `{"data":[1,2]}`},
+		{"XML is stored as a string", `<a>1</a>`, `"<a>1</a>"`},
+		{"JSON string literal is stored as a string", `"text"`, `"\"text\""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFixture("GET", "https://api.example.com/a", "", 200, nil, []byte(tt.body))
+			if string(f.Response.Body) != tt.wantJSON {
+				t.Errorf("Response.Body = %s, want %s", f.Response.Body, tt.wantJSON)
+			}
+			if got := string(f.body()); got != tt.body {
+				t.Errorf("body() = %q, want the original %q", got, tt.body)
+			}
+		})
+	}
+}
+
+func TestSaveLoadServe(t *testing.T) {
+	dir := t.TempDir()
+	header := http.Header{
+		"Content-Type":   {"application/json"},
+		"Set-Cookie":     {"sid=secret"},
+		"Content-Length": {"42"},
+		"X-Request-Id":   {"r-1"},
+	}
+	f := NewFixture("POST", "https://api.example.com/cases?access_token=abc", `{"id":1}`, 201, header, []byte(`{"name":"根"}`))
+	path, err := Save(dir, f)
+	if err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if filepath.Base(path) != f.Fingerprint()+".json" {
+		t.Errorf("Save() path = %s, want named after the fingerprint", path)
+	}
+	content, _ := os.ReadFile(path)
+	if strings.Contains(string(content), "abc") || strings.Contains(string(content), "secret") {
+		t.Errorf("fixture leaks credentials: %s", content)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fixtures, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(fixtures) != 1 {
+		t.Fatalf("Load() = %d fixtures, want 1", len(fixtures))
+	}
+	server := httptest.NewServer(New(fixtures, logger.Discard()))
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/cases?access_token=rotated", "application/json", strings.NewReader(`{ "id": 1 }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var compact bytes.Buffer
+	json.Compact(&compact, body)
+	if resp.StatusCode != 201 || compact.String() != `{"name":"根"}` {
+		t.Errorf("response = %d %s, want the recorded 201 response", resp.StatusCode, body)
+	}
+	if resp.Header.Get("X-Request-Id") != "r-1" || resp.Header.Get("Set-Cookie") != "" {
+		t.Errorf("response headers = %v, want recorded headers without Set-Cookie", resp.Header)
+	}
+
+	resp, err = http.Get(server.URL + "/cases")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var miss missResponse
+	if err := json.NewDecoder(resp.Body).Decode(&miss); err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != http.StatusNotFound || miss.Fingerprint != Fingerprint("GET", "/cases", "") {
+		t.Errorf("unmatched request = %d %+v, want 404 with its fingerprint", resp.StatusCode, miss)
+	}
+}
+
+func TestLoad_Errors(t *testing.T) {
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("Load() of an empty directory error = nil")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "bad.json"), []byte(`{"request":{"method":"GET"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "bad.json") {
+		t.Errorf("Load() with an incomplete fixture error = %v, want it to name the file", err)
+	}
+}
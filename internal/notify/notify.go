@@ -0,0 +1,121 @@
+// Package notify 在单次运行、批量运行或监听模式的每一轮结束时向webhook发送JSON摘要，
+// 让长时间的批量任务与定时监听把结果推送到团队聊天工具
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/treediff"
+)
+
+// defaultTimeout 发送通知的超时，避免webhook无响应时阻塞运行结束
+const defaultTimeout = 10 * time.Second
+
+// 运行模式
+const (
+	ModeRun   = "run"
+	ModeBatch = "batch"
+	ModeWatch = "watch"
+)
+
+// Event 通知内容，text 字段为一行可读摘要，Slack等incoming webhook直接显示该字段
+type Event struct {
+	Text       string          `json:"text"`
+	Status     string          `json:"status"` // success 或 error
+	Mode       string          `json:"mode"`
+	Cycle      int             `json:"cycle,omitempty"` // 监听模式的轮次
+	Host       string          `json:"host,omitempty"`
+	Output     string          `json:"output,omitempty"` // 输出文件或批量输出目录的绝对路径
+	URL        string          `json:"url,omitempty"`    // 发布到外部系统后的地址
+	Nodes      int             `json:"nodes"`
+	Succeeded  int             `json:"succeeded,omitempty"` // 批量模式成功的请求数
+	Failed     int             `json:"failed,omitempty"`    // 批量模式失败的请求数
+	Diff       *treediff.Stats `json:"diff,omitempty"`      // 与上一次结果相比的节点变化
+	DurationMs int64           `json:"duration_ms"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// summary 生成一行可读摘要
+func (e *Event) summary() string {
+	var parts []string
+	if e.Cycle > 0 {
+		parts = append(parts, i18n.Sprintf("第 %d 轮", e.Cycle))
+	}
+	if e.Error != "" {
+		parts = append(parts, i18n.Sprintf("失败: %s", e.Error))
+	} else {
+		parts = append(parts, i18n.Sprintf("%d 个节点", e.Nodes))
+	}
+	if e.Mode == ModeBatch {
+		parts = append(parts, i18n.Sprintf("成功 %d，失败 %d", e.Succeeded, e.Failed))
+	}
+	if e.Diff != nil {
+		parts = append(parts, e.Diff.String())
+	}
+	for _, link := range []string{e.Output, e.URL} {
+		if link != "" {
+			parts = append(parts, link)
+		}
+	}
+
+	icon := "✅"
+	if e.Error != "" {
+		icon = "❌"
+	}
+	return icon + " caseurl2md " + strings.Join(parts, " | ")
+}
+
+// Webhook 以POST发送JSON通知的webhook
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// New 创建webhook通知，地址必须为http或https
+func New(rawURL string) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, i18n.Errorf("无效的webhook地址 %q，应为http或https地址", rawURL)
+	}
+	return &Webhook{url: rawURL, client: &http.Client{Timeout: defaultTimeout}}, nil
+}
+
+// Send 补全摘要文本与主机名后发送通知，非2xx响应返回错误
+func (w *Webhook) Send(ctx context.Context, event *Event) error {
+	if event.Host == "" {
+		event.Host, _ = os.Hostname()
+	}
+	if event.Text == "" {
+		event.Text = event.summary()
+	}
+	content, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(content))
+	if err != nil {
+		return i18n.Errorf("创建HTTP请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return i18n.Errorf("HTTP请求执行失败: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return i18n.Errorf("webhook返回HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/treediff"
+)
+
+func TestWebhook_Send(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer server.Close()
+
+	webhook, err := New(server.URL)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	event := &Event{Status: "success", Mode: ModeWatch, Cycle: 3, Output: "/tmp/result.json", Nodes: 12, Diff: &treediff.Stats{Added: 2}}
+	if err := webhook.Send(context.Background(), event); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	want := "✅ caseurl2md 第 3 轮 | 12 个节点 | 新增 2 个节点，删除 0 个节点 | /tmp/result.json"
+	if received["text"] != want || received["nodes"] != float64(12) || received["host"] == "" {
+		t.Errorf("received = %v, want text %q", received, want)
+	}
+	if diff, _ := received["diff"].(map[string]interface{}); diff["added"] != float64(2) {
+		t.Errorf("diff = %v", received["diff"])
+	}
+
+	failed := &Event{Status: "error", Mode: ModeBatch, Succeeded: 1, Failed: 2, Error: "批量执行中有 2 个请求失败"}
+	if err := webhook.Send(context.Background(), failed); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if want := "❌ caseurl2md 失败: 批量执行中有 2 个请求失败 | 成功 1，失败 2"; received["text"] != want {
+		t.Errorf("text = %v, want %q", received["text"], want)
+	}
+}
+
+func TestWebhook_Errors(t *testing.T) {
+	for _, rawURL := range []string{"", "hooks.slack.com/services/x", "ftp://example.com"} {
+		if _, err := New(rawURL); err == nil {
+			t.Errorf("New(%q) error = nil, want error", rawURL)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+	webhook, _ := New(server.URL)
+	if err := webhook.Send(context.Background(), &Event{Status: "success"}); err == nil {
+		t.Error("Send() error = nil, want HTTP 403 error")
+	}
+}
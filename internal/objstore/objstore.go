@@ -0,0 +1,117 @@
+// Package objstore 将输出文件上传到对象存储（s3://bucket/key、gs://bucket/key），
+// 便于定时抽取直接发布产物。上传通过本机的 aws 与 gcloud/gsutil 命令行工具完成，
+// 凭据沿用这些工具的标准查找链（环境变量、配置文件、实例角色等），本工具不单独读取凭据
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 支持的地址前缀
+const (
+	SchemeS3  = "s3"
+	SchemeGCS = "gs"
+)
+
+// tools 各类地址需要的命令行工具，按顺序使用第一个已安装的
+var tools = map[string][]string{
+	SchemeS3:  {"aws"},
+	SchemeGCS: {"gcloud", "gsutil"},
+}
+
+// Location 对象存储中的对象地址
+type Location struct {
+	Scheme string
+	Bucket string
+	Key    string
+}
+
+// String 返回 scheme://bucket/key 形式的地址
+func (l *Location) String() string {
+	return l.Scheme + "://" + l.Bucket + "/" + l.Key
+}
+
+// IsRemote 判断输出路径是否为对象存储地址
+func IsRemote(dest string) bool {
+	scheme, _, ok := strings.Cut(dest, "://")
+	return ok && tools[strings.ToLower(scheme)] != nil
+}
+
+// Parse 解析 s3://bucket/key 或 gs://bucket/key，对象键不能为空或以 / 结尾
+func Parse(dest string) (*Location, error) {
+	scheme, rest, ok := strings.Cut(dest, "://")
+	scheme = strings.ToLower(scheme)
+	if !ok || tools[scheme] == nil {
+		return nil, i18n.Errorf("不支持的对象存储地址 %q，可选 s3://bucket/key 或 gs://bucket/key", dest)
+	}
+	bucket, key, _ := strings.Cut(rest, "/")
+	if bucket == "" || key == "" || strings.HasSuffix(key, "/") {
+		return nil, i18n.Errorf("对象存储地址 %q 需要包含桶名与对象键，如 %s://bucket/path/output.json", dest, scheme)
+	}
+	return &Location{Scheme: scheme, Bucket: bucket, Key: key}, nil
+}
+
+// Check 校验地址并检查所需的命令行工具已安装，在发送请求之前发现配置问题
+func Check(dest string) error {
+	loc, err := Parse(dest)
+	if err != nil {
+		return err
+	}
+	_, err = tool(loc.Scheme)
+	return err
+}
+
+// tool 返回地址类型对应的第一个已安装的命令行工具
+func tool(scheme string) (string, error) {
+	var lastErr error
+	for _, name := range tools[scheme] {
+		if _, err := exec.LookPath(name); err != nil {
+			lastErr = err
+			continue
+		}
+		return name, nil
+	}
+	return "", i18n.Errorf("上传到 %s:// 需要 %s 命令行工具: %w", scheme, strings.Join(tools[scheme], "/"), lastErr)
+}
+
+// command 返回从标准输入读取内容并写入对象的命令参数
+func command(name string, loc *Location, contentType string) []string {
+	switch name {
+	case "aws":
+		return []string{"aws", "s3", "cp", "-", loc.String(), "--content-type", contentType, "--only-show-errors"}
+	case "gcloud":
+		return []string{"gcloud", "storage", "cp", "--content-type=" + contentType, "-", loc.String()}
+	default:
+		return []string{"gsutil", "-q", "-h", "Content-Type:" + contentType, "cp", "-", loc.String()}
+	}
+}
+
+// Upload 将content写入dest指向的对象，已存在时覆盖
+func Upload(ctx context.Context, dest string, content []byte, contentType string) error {
+	loc, err := Parse(dest)
+	if err != nil {
+		return err
+	}
+	name, err := tool(loc.Scheme)
+	if err != nil {
+		return err
+	}
+
+	args := command(name, loc, contentType)
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return i18n.Errorf("上传到 %s 失败: %w: %s", dest, err, msg)
+		}
+		return i18n.Errorf("上传到 %s 失败: %w", dest, err)
+	}
+	return nil
+}
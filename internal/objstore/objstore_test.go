@@ -0,0 +1,103 @@
+package objstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		dest    string
+		want    Location
+		wantErr bool
+	}{
+		{dest: "s3://bucket/reports/output.json", want: Location{Scheme: "s3", Bucket: "bucket", Key: "reports/output.json"}},
+		{dest: "GS://bucket/output.json", want: Location{Scheme: "gs", Bucket: "bucket", Key: "output.json"}},
+		{dest: "s3://bucket", wantErr: true},
+		{dest: "s3://bucket/", wantErr: true},
+		{dest: "s3://bucket/reports/", wantErr: true},
+		{dest: "s3:///output.json", wantErr: true},
+		{dest: "azure://bucket/output.json", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dest, func(t *testing.T) {
+			loc, err := Parse(tt.dest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *loc != tt.want {
+				t.Errorf("Parse() = %+v, want %+v", *loc, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsRemote(t *testing.T) {
+	for dest, want := range map[string]bool{
+		"s3://bucket/output.json": true,
+		"gs://bucket/output.json": true,
+		"output.json":             false,
+		"results/s3/output.json":  false,
+		"https://example.com/a":   false,
+	} {
+		if got := IsRemote(dest); got != want {
+			t.Errorf("IsRemote(%q) = %v, want %v", dest, got, want)
+		}
+	}
+}
+
+// fakeTool 将PATH替换为只包含假命令行工具的目录，工具记录参数与（单行的）标准输入，只使用shell内置命令
+func fakeTool(t *testing.T, name string) (args, stdin string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake tools are shell scripts")
+	}
+	dir := t.TempDir()
+	args, stdin = filepath.Join(dir, "args"), filepath.Join(dir, "stdin")
+	script := "#!/bin/sh\necho \"$@\" > " + args + "\nIFS= read -r line\nprintf '%s' \"$line\" > " + stdin + "\n"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+	return args, stdin
+}
+
+func TestUpload(t *testing.T) {
+	tests := []struct {
+		tool     string
+		dest     string
+		wantArgs string
+	}{
+		{"aws", "s3://bucket/out.json", "s3 cp - s3://bucket/out.json --content-type application/json --only-show-errors"},
+		{"gcloud", "gs://bucket/out.json", "storage cp --content-type=application/json - gs://bucket/out.json"},
+		{"gsutil", "gs://bucket/out.json", "-q -h Content-Type:application/json cp - gs://bucket/out.json"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			argsFile, stdinFile := fakeTool(t, tt.tool)
+			if err := Upload(context.Background(), tt.dest, []byte(`{"name":"root"}`), "application/json"); err != nil {
+				t.Fatalf("Upload() error = %v", err)
+			}
+			args, _ := os.ReadFile(argsFile)
+			if got := strings.TrimSpace(string(args)); got != tt.wantArgs {
+				t.Errorf("args = %q, want %q", got, tt.wantArgs)
+			}
+			stdin, _ := os.ReadFile(stdinFile)
+			if string(stdin) != `{"name":"root"}` {
+				t.Errorf("stdin = %q", stdin)
+			}
+		})
+	}
+}
+
+func TestCheck_MissingTool(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	err := Check("gs://bucket/out.json")
+	if err == nil || !strings.Contains(err.Error(), "gcloud/gsutil") {
+		t.Errorf("Check() error = %v, want missing gcloud/gsutil", err)
+	}
+}
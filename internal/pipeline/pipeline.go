@@ -0,0 +1,104 @@
+// Package pipeline 定义处理流水线的阶段、阶段之间传递的状态以及各阶段前后的钩子
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// Stage 流水线阶段
+type Stage string
+
+// 流水线阶段，按执行顺序排列
+const (
+	StageParse    Stage = "parse"    // 解析cURL命令为请求
+	StageExecute  Stage = "execute"  // 执行HTTP请求
+	StageValidate Stage = "validate" // 校验响应
+	StageExtract  Stage = "extract"  // 抽取树状结构
+	StageRender   Stage = "render"   // 生成最终输出
+)
+
+// Stages 返回按执行顺序排列的全部阶段
+func Stages() []Stage {
+	return []Stage{StageParse, StageExecute, StageValidate, StageExtract, StageRender}
+}
+
+// State 在各阶段之间传递的数据，钩子可以读取和修改
+type State struct {
+	Input       string              // 原始cURL命令，为空时直接使用 Request
+	Request     *config.RequestInfo // parse 阶段之后可用
+	StatusCode  int                 // execute 阶段之后可用
+	ContentType string              // execute 阶段之后可用的响应Content-Type
+	Header      http.Header         // execute 阶段之后可用的响应头
+	Body        []byte              // execute 阶段之后可用的响应体，validate 阶段会将非JSON格式转换为JSON
+	Trace       []string            // execute 阶段发送的每个请求（包括轮询与认证刷新后的重试）的等效cURL命令，凭据已脱敏
+	Tree        *extractor.Tree     // extract 阶段之后可用的树，extract 的前置钩子设置时跳过内置抽取
+	Output      []byte              // render 阶段之后可用的最终输出
+}
+
+// Hook 阶段钩子，返回错误会中止流水线
+type Hook func(ctx context.Context, state *State) error
+
+// Hooks 各阶段前后的钩子，按注册顺序执行；零值可直接使用，
+// 注册与执行可以在多个goroutine中并发进行
+type Hooks struct {
+	mu     sync.RWMutex
+	before map[Stage][]Hook
+	after  map[Stage][]Hook
+}
+
+// Before 注册在stage执行前调用的钩子
+func (h *Hooks) Before(stage Stage, hook Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.before == nil {
+		h.before = make(map[Stage][]Hook)
+	}
+	h.before[stage] = append(h.before[stage], hook)
+}
+
+// After 注册在stage执行后调用的钩子
+func (h *Hooks) After(stage Stage, hook Hook) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.after == nil {
+		h.after = make(map[Stage][]Hook)
+	}
+	h.after[stage] = append(h.after[stage], hook)
+}
+
+// RunBefore 依次执行stage的前置钩子，h 为nil时不执行任何钩子
+func (h *Hooks) RunBefore(ctx context.Context, stage Stage, state *State) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	hooks := h.before[stage]
+	h.mu.RUnlock()
+	return run(ctx, stage, hooks, state)
+}
+
+// RunAfter 依次执行stage的后置钩子，h 为nil时不执行任何钩子
+func (h *Hooks) RunAfter(ctx context.Context, stage Stage, state *State) error {
+	if h == nil {
+		return nil
+	}
+	h.mu.RLock()
+	hooks := h.after[stage]
+	h.mu.RUnlock()
+	return run(ctx, stage, hooks, state)
+}
+
+func run(ctx context.Context, stage Stage, hooks []Hook, state *State) error {
+	for _, hook := range hooks {
+		if err := hook(ctx, state); err != nil {
+			return i18n.Errorf("%s 阶段钩子执行失败: %w", stage, err)
+		}
+	}
+	return nil
+}
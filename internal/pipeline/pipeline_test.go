@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestHooks_Order(t *testing.T) {
+	var calls []string
+	record := func(name string) Hook {
+		return func(ctx context.Context, state *State) error {
+			calls = append(calls, name)
+			return nil
+		}
+	}
+
+	var h Hooks
+	h.After(StageExecute, record("after-1"))
+	h.Before(StageExecute, record("before"))
+	h.After(StageExecute, record("after-2"))
+	h.After(StageRender, record("render"))
+
+	state := &State{}
+	if err := h.RunBefore(context.Background(), StageExecute, state); err != nil {
+		t.Fatal(err)
+	}
+	if err := h.RunAfter(context.Background(), StageExecute, state); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"before", "after-1", "after-2"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
+
+func TestHooks_ErrorStopsChain(t *testing.T) {
+	errAbort := errors.New("abort")
+	called := false
+
+	var h Hooks
+	h.Before(StageParse, func(ctx context.Context, state *State) error { return errAbort })
+	h.Before(StageParse, func(ctx context.Context, state *State) error {
+		called = true
+		return nil
+	})
+
+	err := h.RunBefore(context.Background(), StageParse, &State{})
+	if !errors.Is(err, errAbort) {
+		t.Errorf("RunBefore() error = %v, want wrapped errAbort", err)
+	}
+	if called {
+		t.Error("hook after the failing one was called")
+	}
+}
+
+func TestHooks_Nil(t *testing.T) {
+	var h *Hooks
+	if err := h.RunAfter(context.Background(), StageRender, &State{}); err != nil {
+		t.Errorf("RunAfter() on nil Hooks error = %v", err)
+	}
+}
+
+func TestHooks_ConcurrentRegister(t *testing.T) {
+	var h Hooks
+	noop := func(ctx context.Context, state *State) error { return nil }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			h.Before(StageExtract, noop)
+		}()
+		go func() {
+			defer wg.Done()
+			if err := h.RunBefore(context.Background(), StageExtract, &State{}); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,77 @@
+package placeholder
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// 随机字符串的默认长度、最大长度与字符集
+const (
+	defaultRandomLength = 16
+	maxRandomLength     = 1024
+	randomAlphabet      = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+)
+
+// now 返回当前时间：unix（秒）、unixms（毫秒）、unixnano、rfc3339（默认），
+// 其他参数按Go的时间格式（如 2006-01-02 15:04:05）格式化。
+// 与 uuid、random 一样每次解析都重新取值，重试与轮询的请求会使用新的值
+func now(format string) (string, error) {
+	t := time.Now()
+	switch strings.ToLower(format) {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10), nil
+	case "unixms":
+		return strconv.FormatInt(t.UnixMilli(), 10), nil
+	case "unixnano":
+		return strconv.FormatInt(t.UnixNano(), 10), nil
+	case "", "rfc3339":
+		return t.Format(time.RFC3339), nil
+	}
+	return t.Format(format), nil
+}
+
+// newUUID 返回随机生成的UUID（版本4）
+func newUUID(string) (string, error) {
+	return UUID()
+}
+
+// UUID 返回随机生成的UUID（版本4），供需要在多次请求间复用同一个值的场景使用（如幂等键）
+func UUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", i18n.Errorf("生成随机数失败: %w", err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// randomString 返回指定长度（默认16）的随机字母与数字
+func randomString(arg string) (string, error) {
+	length := defaultRandomLength
+	if arg != "" {
+		n, err := strconv.Atoi(arg)
+		if err != nil || n <= 0 || n > maxRandomLength {
+			return "", i18n.Errorf("随机字符串长度应为 1 到 %d 之间的整数，实际: %s", maxRandomLength, arg)
+		}
+		length = n
+	}
+
+	limit := big.NewInt(int64(len(randomAlphabet)))
+	var sb strings.Builder
+	sb.Grow(length)
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			return "", i18n.Errorf("生成随机数失败: %w", err)
+		}
+		sb.WriteByte(randomAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
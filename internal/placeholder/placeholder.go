@@ -0,0 +1,181 @@
+package placeholder
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// placeholderRe 匹配 {{provider:argument}} 或不带参数的 {{provider}} 形式的占位符
+var placeholderRe = regexp.MustCompile(`\{\{\s*([a-zA-Z]+)\s*(?::\s*([^{}]*?)\s*)?\}\}`)
+
+// Provider 占位符取值函数，参数为冒号后的内容，不带参数时为空
+type Provider func(arg string) (string, error)
+
+// providers 已注册的占位符提供者，由 providersMu 保护
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]Provider{
+		"env":      lookupEnv,
+		"keychain": lookupKeychain,
+		"now":      now,
+		"uuid":     newUUID,
+		"random":   randomString,
+	}
+)
+
+// Register 注册自定义占位符提供者，可与请求执行并发调用
+func Register(name string, provider Provider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers[name] = provider
+}
+
+// HasPlaceholder 检查字符串中是否包含占位符
+func HasPlaceholder(value string) bool {
+	return placeholderRe.MatchString(value)
+}
+
+// Expand 将字符串中的占位符替换为实际值
+// 未注册的提供者保持原样，便于与其他模板语法共存
+func Expand(value string) (string, error) {
+	resolved, _, err := ExpandTrace(value)
+	return resolved, err
+}
+
+// traceProviders 取值本身不是凭据的占位符，ExpandTrace 的 trace 中替换为本次取到的值
+var traceProviders = map[string]bool{"now": true, "uuid": true, "random": true}
+
+// ExpandTrace 与 Expand 相同，另外返回可以写入日志与重放命令的 trace：now、uuid、random 替换为本次取到的值，
+// 便于原样重现请求；env、keychain 与自定义提供者的值可能是凭据，保持占位符原样
+func ExpandTrace(value string) (resolved, trace string, err error) {
+	return expandTrace(value, false)
+}
+
+// ExpandTraceUntrusted 与 ExpandTrace 相同，用于来自网络或代理等不受信任来源的请求：只解析 now、uuid、random，
+// env、keychain 与自定义提供者可能读取本机凭据，遇到时返回错误
+func ExpandTraceUntrusted(value string) (resolved, trace string, err error) {
+	return expandTrace(value, true)
+}
+
+// expandTrace 解析占位符，untrusted 为true时拒绝取值可能是凭据的提供者
+func expandTrace(value string, untrusted bool) (resolved, trace string, err error) {
+	if !strings.Contains(value, "{{") {
+		return value, value, nil
+	}
+
+	var r, t strings.Builder
+	last := 0
+	for _, loc := range placeholderRe.FindAllStringSubmatchIndex(value, -1) {
+		match := value[loc[0]:loc[1]]
+		name := strings.ToLower(value[loc[2]:loc[3]])
+		arg := ""
+		if loc[4] >= 0 {
+			arg = value[loc[4]:loc[5]]
+		}
+		r.WriteString(value[last:loc[0]])
+		t.WriteString(value[last:loc[0]])
+		last = loc[1]
+
+		providersMu.RLock()
+		provider, ok := providers[name]
+		providersMu.RUnlock()
+		if !ok {
+			r.WriteString(match)
+			t.WriteString(match)
+			continue
+		}
+		if untrusted && !traceProviders[name] {
+			return "", "", i18n.Errorf("不允许在不受信任的请求中使用占位符 %s", match)
+		}
+		v, err := provider(arg)
+		if err != nil {
+			return "", "", i18n.Errorf("占位符 %s 解析失败: %w", match, err)
+		}
+		r.WriteString(v)
+		if traceProviders[name] {
+			t.WriteString(v)
+		} else {
+			t.WriteString(match)
+		}
+	}
+	r.WriteString(value[last:])
+	t.WriteString(value[last:])
+	return r.String(), t.String(), nil
+}
+
+// ExpandMap 替换map中所有值的占位符
+func ExpandMap(values map[string]string) (map[string]string, error) {
+	result := make(map[string]string, len(values))
+	for key, value := range values {
+		resolved, err := Expand(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+		result[key] = resolved
+	}
+	return result, nil
+}
+
+// lookupEnv 从环境变量读取值
+func lookupEnv(name string) (string, error) {
+	if name == "" {
+		return "", i18n.Errorf("环境变量名为空")
+	}
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", i18n.Errorf("环境变量 %s 未设置", name)
+	}
+	return value, nil
+}
+
+// keychainTargetEnv 向Windows的PowerShell脚本传递凭据目标名称的环境变量
+const keychainTargetEnv = "CURL2JSON_KEYCHAIN_TARGET"
+
+// lookupKeychain 从系统钥匙串读取值，参数格式为 service/key。
+// Windows下读取凭据管理器中目标名称为 service/key 的通用凭据，需要安装 CredentialManager 模块（Install-Module CredentialManager）
+func lookupKeychain(arg string) (string, error) {
+	service, key, ok := strings.Cut(arg, "/")
+	if !ok || service == "" || key == "" {
+		return "", i18n.Errorf("钥匙串占位符格式应为 service/key，实际: %s", arg)
+	}
+
+	cmd, err := keychainCommand(runtime.GOOS, service, key)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", i18n.Errorf("读取钥匙串 %s 失败: %w", arg, err)
+	}
+
+	value := strings.TrimRight(string(output), "\r\n")
+	if value == "" {
+		return "", i18n.Errorf("钥匙串 %s 中没有值", arg)
+	}
+	return value, nil
+}
+
+// keychainCommand 返回在goos上读取钥匙串的命令，service 与 key 只作为独立的参数或环境变量传入，不经过shell或脚本拼接
+func keychainCommand(goos, service, key string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		return exec.Command("security", "find-generic-password", "-s", service, "-a", key, "-w"), nil
+	case "linux":
+		return exec.Command("secret-tool", "lookup", "service", service, "key", key), nil
+	case "windows":
+		// Get-StoredCredential 来自第三方 CredentialManager 模块
+		cmd := exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			`(Get-StoredCredential -Target $env:`+keychainTargetEnv+`).GetNetworkCredential().Password`)
+		cmd.Env = append(os.Environ(), keychainTargetEnv+"="+service+"/"+key)
+		return cmd, nil
+	}
+	return nil, i18n.Errorf("当前系统不支持钥匙串: %s", goos)
+}
@@ -0,0 +1,148 @@
+package placeholder
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpand(t *testing.T) {
+	t.Setenv("CURL2JSON_TEST_TOKEN", "secret-value")
+
+	tests := []struct {
+		name    string
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "无占位符",
+			value: "Bearer abc",
+			want:  "Bearer abc",
+		},
+		{
+			name:  "环境变量占位符",
+			value: "Bearer {{env:CURL2JSON_TEST_TOKEN}}",
+			want:  "Bearer secret-value",
+		},
+		{
+			name:  "占位符内含空格",
+			value: "{{ env: CURL2JSON_TEST_TOKEN }}",
+			want:  "secret-value",
+		},
+		{
+			name:  "未知提供者保持原样",
+			value: "{{.project_id}} {{foo:bar}} {{foo}}",
+			want:  "{{.project_id}} {{foo:bar}} {{foo}}",
+		},
+		{
+			name:    "环境变量未设置",
+			value:   "{{env:CURL2JSON_TEST_MISSING}}",
+			wantErr: true,
+		},
+		{
+			name:    "钥匙串格式错误",
+			value:   "{{keychain:no-slash}}",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Expand(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpand_Dynamic(t *testing.T) {
+	tests := map[string]*regexp.Regexp{
+		"{{uuid}}":           regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`),
+		"n-{{random:8}}":     regexp.MustCompile(`^n-[0-9a-zA-Z]{8}$`),
+		"{{random}}":         regexp.MustCompile(`^[0-9a-zA-Z]{16}$`),
+		"{{now:unix}}":       regexp.MustCompile(`^\d{10}$`),
+		"{{now:2006-01-02}}": regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+	}
+	for value, want := range tests {
+		got, err := Expand(value)
+		if err != nil || !want.MatchString(got) {
+			t.Errorf("Expand(%q) = %q, %v, want match %s", value, got, err, want)
+		}
+	}
+
+	got, err := Expand("{{now:unixms}}")
+	ms, _ := strconv.ParseInt(got, 10, 64)
+	if err != nil || time.Since(time.UnixMilli(ms)).Abs() > time.Minute {
+		t.Errorf("Expand({{now:unixms}}) = %q, %v, want the current time", got, err)
+	}
+
+	first, _ := Expand("{{uuid}}")
+	second, _ := Expand("{{uuid}}")
+	if first == second {
+		t.Errorf("Expand({{uuid}}) returned %s twice", first)
+	}
+
+	for _, value := range []string{"{{random:0}}", "{{random:abc}}", "{{random:2000}}"} {
+		if _, err := Expand(value); err == nil {
+			t.Errorf("Expand(%q) should fail", value)
+		}
+	}
+}
+
+func TestExpandTrace(t *testing.T) {
+	t.Setenv("CURL2JSON_TEST_TOKEN", "secret-value")
+
+	resolved, trace, err := ExpandTrace("Bearer {{env:CURL2JSON_TEST_TOKEN}} {{uuid}} {{other:x}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := regexp.MustCompile(`[0-9a-f-]{36}`).FindString(resolved)
+	if want := "Bearer secret-value " + id + " {{other:x}}"; id == "" || resolved != want {
+		t.Errorf("resolved = %q, want %q", resolved, want)
+	}
+	if want := "Bearer {{env:CURL2JSON_TEST_TOKEN}} " + id + " {{other:x}}"; trace != want {
+		t.Errorf("trace = %q, want %q", trace, want)
+	}
+}
+
+func TestExpandTraceUntrusted(t *testing.T) {
+	t.Setenv("CURL2JSON_TEST_TOKEN", "secret-value")
+
+	resolved, _, err := ExpandTraceUntrusted("{{now:unix}} {{other:x}}")
+	if err != nil || !regexp.MustCompile(`^\d+ \{\{other:x\}\}$`).MatchString(resolved) {
+		t.Errorf("ExpandTraceUntrusted() = %q, %v", resolved, err)
+	}
+	for _, value := range []string{"{{env:CURL2JSON_TEST_TOKEN}}", "{{keychain:svc/key}}"} {
+		if resolved, _, err := ExpandTraceUntrusted(value); err == nil {
+			t.Errorf("ExpandTraceUntrusted(%q) = %q, want error", value, resolved)
+		}
+	}
+}
+
+func TestKeychainCommand(t *testing.T) {
+	service, key := "svc", "x'); Remove-Item C:\\ -Recurse; ('"
+	cmd, err := keychainCommand("windows", service, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, arg := range cmd.Args {
+		if strings.Contains(arg, "Remove-Item") {
+			t.Errorf("PowerShell 参数中不应拼接钥匙串名称: %q", cmd.Args)
+		}
+	}
+	if env := cmd.Env[len(cmd.Env)-1]; env != keychainTargetEnv+"="+service+"/"+key {
+		t.Errorf("env = %q", env)
+	}
+
+	if _, err := keychainCommand("plan9", service, key); err == nil {
+		t.Error("keychainCommand(plan9) error = nil, want unsupported")
+	}
+}
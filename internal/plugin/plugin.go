@@ -0,0 +1,187 @@
+// Package plugin 管理外部抽取插件，让针对特定站点的抽取逻辑可以在核心仓库之外开发和分发。
+// 插件是一个包含 plugin.yaml 的目录，由清单中声明的命令执行：通过标准输入接收JSON，
+// 通过标准输出返回树状JSON。插件分三类：
+//   - strategy：代替内置抽取器，输入为响应体
+//   - converter：在内置抽取之后调整树，输入为树状JSON
+//   - renderer：注册以插件名称命名的输出格式（--format），输入为树状JSON，标准输出即为输出文件的内容
+//
+// 插件安装在 ~/.curl2json/plugins/<名称>/ 下，安装时记录目录内容的摘要，每次加载前重新校验
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+// 插件类型
+const (
+	KindStrategy  = "strategy"
+	KindConverter = "converter"
+	KindRenderer  = "renderer"
+)
+
+// ManifestFile 插件目录中的清单文件名
+const ManifestFile = "plugin.yaml"
+
+// namePattern 插件名称只能包含小写字母、数字、- 和 _，同时作为安装目录名
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Manifest 插件清单
+//
+//	name: feishu-mind
+//	version: 1.0.0
+//	description: 飞书思维笔记
+//	kind: strategy
+//	command: [node, extract.js]
+type Manifest struct {
+	Name        string   `yaml:"name" json:"name"`
+	Version     string   `yaml:"version" json:"version,omitempty"`
+	Description string   `yaml:"description" json:"description,omitempty"`
+	Kind        string   `yaml:"kind" json:"kind"`
+	Command     []string `yaml:"command" json:"command"` // 在插件目录中执行，以 ./ 开头的路径相对于插件目录
+
+	// renderer 插件输出文件的扩展名（如 .xmind）与上传到对象存储时的Content-Type
+	Ext         string `yaml:"ext" json:"ext,omitempty"`
+	ContentType string `yaml:"content_type" json:"content_type,omitempty"`
+}
+
+// ReadManifest 读取并校验目录中的插件清单
+func ReadManifest(dir string) (*Manifest, error) {
+	content, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, i18n.Errorf("读取插件清单失败: %w", err)
+	}
+	var m Manifest
+	if err := yaml.Unmarshal(content, &m); err != nil {
+		return nil, i18n.Errorf("解析插件清单失败: %w", err)
+	}
+	if !namePattern.MatchString(m.Name) {
+		return nil, i18n.Errorf("插件名称 %q 无效，只能包含小写字母、数字、- 和 _", m.Name)
+	}
+	if m.Kind != KindStrategy && m.Kind != KindConverter && m.Kind != KindRenderer {
+		return nil, i18n.Errorf("插件 %s 的类型 %q 无效，可选 strategy、converter 或 renderer", m.Name, m.Kind)
+	}
+	if len(m.Command) == 0 || m.Command[0] == "" {
+		return nil, i18n.Errorf("插件 %s 没有声明 command", m.Name)
+	}
+	return &m, nil
+}
+
+// Plugin 已安装并通过完整性校验的插件
+type Plugin struct {
+	Manifest
+	dir string
+}
+
+// Strategy 插件抽取的树记录的抽取策略
+func (p *Plugin) Strategy() extractor.Strategy {
+	return extractor.Strategy("plugin:" + p.Name)
+}
+
+// Run 以input作为标准输入执行插件命令，返回标准输出
+func (p *Plugin) Run(ctx context.Context, input []byte) ([]byte, error) {
+	name := p.Command[0]
+	if strings.ContainsRune(name, '/') && !filepath.IsAbs(name) {
+		name = filepath.Join(p.dir, name)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, p.Command[1:]...)
+	cmd.Dir = p.dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, i18n.Errorf("插件 %s 执行失败: %w: %s", p.Name, err, msg)
+		}
+		return nil, i18n.Errorf("插件 %s 执行失败: %w", p.Name, err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// tree 执行插件并将输出解析为树，base 见 extractor.ParseTreeOutput
+func (p *Plugin) tree(ctx context.Context, input []byte, base *extractor.Tree) (*extractor.Tree, error) {
+	output, err := p.Run(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := extractor.ParseTreeOutput(output, base)
+	if err != nil {
+		return nil, i18n.Errorf("插件 %s 的输出不是有效的树状JSON: %w", p.Name, err)
+	}
+	return tree, nil
+}
+
+// Format 返回 renderer 插件注册的输出格式，名称为插件名称，没有声明扩展名时为 .out
+func (p *Plugin) Format() render.Format {
+	ext := p.Ext
+	if ext == "" {
+		ext = ".out"
+	} else if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return render.Format{Name: p.Name, Ext: ext, ContentType: p.ContentType, Renderer: p}
+}
+
+// Render 实现 render.Renderer：以树状JSON作为标准输入执行插件，标准输出原样写入w；opts.Context 取消时插件随之终止
+func (p *Plugin) Render(tree *extractor.Tree, w io.Writer, opts render.Options) error {
+	input, err := json.Marshal(tree)
+	if err != nil {
+		return err
+	}
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	output, err := p.Run(ctx, input)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
+
+// Register 注册插件的流水线钩子：strategy 插件在 extract 阶段之前设置树，内置抽取随之跳过；
+// converter 插件在 extract 阶段之后调整树，保留原来的抽取策略与警告；renderer 插件不参与流水线，见 Format
+func (p *Plugin) Register(hooks *pipeline.Hooks) {
+	switch p.Kind {
+	case KindRenderer:
+		return
+	case KindStrategy:
+		hooks.Before(pipeline.StageExtract, func(ctx context.Context, state *pipeline.State) error {
+			tree, err := p.tree(ctx, state.Body, nil)
+			if err != nil {
+				return err
+			}
+			tree.Strategy = p.Strategy()
+			state.Tree = tree
+			return nil
+		})
+		return
+	}
+	hooks.After(pipeline.StageExtract, func(ctx context.Context, state *pipeline.State) error {
+		input, err := json.Marshal(state.Tree)
+		if err != nil {
+			return err
+		}
+		tree, err := p.tree(ctx, input, state.Tree)
+		if err != nil {
+			return err
+		}
+		state.Tree = tree
+		return nil
+	})
+}
@@ -0,0 +1,209 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+// writePlugin 在dir中创建插件：清单与输出固定树状JSON的脚本
+func writePlugin(t *testing.T, dir, name, kind, output string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts are shell scripts")
+	}
+	manifest := "name: " + name + "\nversion: 1.0.0\nkind: " + kind + "\ncommand: [./run.sh]\n"
+	script := "#!/bin/sh\ncat > /dev/null\nprintf '%s' '" + output + "'\n"
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "run.sh"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReadManifest(t *testing.T) {
+	tests := map[string]string{
+		"name: Bad Name\nkind: strategy\ncommand: [x]\n": "名称",
+		"name: ok\nkind: exporter\ncommand: [x]\n":       "exporter",
+		"name: ok\nkind: converter\n":                    "command",
+	}
+	for manifest, want := range tests {
+		dir := t.TempDir()
+		os.WriteFile(filepath.Join(dir, ManifestFile), []byte(manifest), 0o644)
+		if _, err := ReadManifest(dir); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ReadManifest(%q) error = %v, want %q", manifest, err, want)
+		}
+	}
+}
+
+func TestRegistry_InstallLocal(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "src")
+	writePlugin(t, src, "demo", KindStrategy, `{"name":"插件根"}`)
+	registry := Open(filepath.Join(t.TempDir(), "plugins"))
+
+	if _, err := registry.Install(src, InstallOptions{Digest: "sha256:0000"}); err == nil {
+		t.Fatal("Install() with wrong digest should fail")
+	}
+	installed, err := registry.Install(src, InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	want, _ := Digest(src)
+	if installed.Name != "demo" || installed.Kind != KindStrategy || installed.Digest != want {
+		t.Errorf("Install() = %+v, want digest %s", installed, want)
+	}
+	if _, err := registry.Install(src, InstallOptions{}); err == nil {
+		t.Error("Install() of an installed plugin without Force should fail")
+	}
+	if _, err := registry.Install(src, InstallOptions{Force: true, Digest: want}); err != nil {
+		t.Errorf("Install() with Force error = %v", err)
+	}
+
+	list, err := registry.List()
+	if err != nil || len(list) != 1 || registry.Status(&list[0]) != StatusOK {
+		t.Fatalf("List() = %+v, %v", list, err)
+	}
+	if _, err := registry.Load("demo"); err != nil {
+		t.Errorf("Load() error = %v", err)
+	}
+
+	// 安装后修改插件文件，加载时拒绝
+	os.WriteFile(filepath.Join(registry.dir, "demo", "run.sh"), []byte("#!/bin/sh\necho hacked\n"), 0o755)
+	if registry.Status(&list[0]) != StatusModified {
+		t.Errorf("Status() = %s, want %s", registry.Status(&list[0]), StatusModified)
+	}
+	if _, err := registry.Load("demo"); err == nil {
+		t.Error("Load() of a modified plugin should fail")
+	}
+
+	if err := registry.Remove("demo"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if list, _ := registry.List(); len(list) != 0 {
+		t.Errorf("List() after Remove() = %+v", list)
+	}
+	if _, err := registry.Load("demo"); err == nil {
+		t.Error("Load() of a removed plugin should fail")
+	}
+}
+
+func TestRegistry_InstallGit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", repo, "-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+	}
+	git("init", "--quiet")
+	writePlugin(t, repo, "demo", KindConverter, `{"name":"v1"}`)
+	git("add", ".")
+	git("commit", "--quiet", "-m", "v1")
+	git("tag", "v1")
+	writePlugin(t, repo, "demo", KindConverter, `{"name":"v2"}`)
+	git("commit", "--quiet", "-am", "v2")
+
+	registry := Open(t.TempDir())
+	if _, err := registry.Install("file://"+repo+"#--orphan=x", InstallOptions{}); err == nil || !strings.Contains(err.Error(), "--orphan=x") {
+		t.Errorf("Install() with a ref starting with - error = %v", err)
+	}
+	installed, err := registry.Install("file://"+repo+"#v1", InstallOptions{})
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+	if len(installed.Ref) != 40 {
+		t.Errorf("Ref = %q, want the checked out commit", installed.Ref)
+	}
+	if _, err := os.Stat(filepath.Join(registry.dir, "demo", ".git")); !os.IsNotExist(err) {
+		t.Error(".git should not be kept in the plugin directory")
+	}
+	p, err := registry.Load("demo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	output, err := p.Run(context.Background(), []byte("{}"))
+	if err != nil || string(output) != `{"name":"v1"}` {
+		t.Errorf("Run() = %s, %v, want the v1 output", output, err)
+	}
+}
+
+func TestPlugin_Register(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, filepath.Join(dir, "strategy"), "site", KindStrategy, `{"name":"插件根","children":[{"name":"子节点","children":[]}]}`)
+	writePlugin(t, filepath.Join(dir, "converter"), "rename", KindConverter, `{"name":"重命名","children":[]}`)
+
+	var hooks pipeline.Hooks
+	for _, sub := range []string{"strategy", "converter"} {
+		manifest, err := ReadManifest(filepath.Join(dir, sub))
+		if err != nil {
+			t.Fatal(err)
+		}
+		p := &Plugin{Manifest: *manifest, dir: filepath.Join(dir, sub)}
+		p.Register(&hooks)
+	}
+
+	ctx := context.Background()
+	state := &pipeline.State{Body: []byte(`{"data":{}}`)}
+	if err := hooks.RunBefore(ctx, pipeline.StageExtract, state); err != nil {
+		t.Fatalf("RunBefore() error = %v", err)
+	}
+	if state.Tree == nil || state.Tree.Strategy != "plugin:site" || state.Tree.Stats.Nodes != 2 {
+		t.Fatalf("strategy plugin tree = %+v", state.Tree)
+	}
+
+	state.Tree.Warnings = []string{"warning"}
+	if err := hooks.RunAfter(ctx, pipeline.StageExtract, state); err != nil {
+		t.Fatalf("RunAfter() error = %v", err)
+	}
+	got, _ := json.Marshal(state.Tree)
+	if string(got) != `{"name":"重命名","children":[]}` || state.Tree.Strategy != "plugin:site" || len(state.Tree.Warnings) != 1 {
+		t.Errorf("converter plugin tree = %s (%s, %v)", got, state.Tree.Strategy, state.Tree.Warnings)
+	}
+}
+
+func TestPlugin_Render(t *testing.T) {
+	dir := t.TempDir()
+	writePlugin(t, dir, "opml", KindRenderer, `<opml version="2.0"/>`)
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest.Ext = "opml"
+	p := &Plugin{Manifest: *manifest, dir: dir}
+
+	format := p.Format()
+	if format.Name != "opml" || format.Ext != ".opml" {
+		t.Errorf("Format() = %+v", format)
+	}
+	var buf strings.Builder
+	tree := &extractor.Tree{Roots: []*extractor.SimplifiedNode{{Name: "根"}}}
+	if err := format.Renderer.Render(tree, &buf, render.Options{}); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if buf.String() != `<opml version="2.0"/>` {
+		t.Errorf("Render() = %s", buf.String())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := format.Renderer.Render(tree, &strings.Builder{}, render.Options{Context: ctx}); err == nil {
+		t.Error("Render() with a canceled context should fail")
+	}
+}
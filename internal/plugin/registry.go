@@ -0,0 +1,262 @@
+package plugin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// registryFile 插件目录中记录已安装插件的文件
+const registryFile = "plugins.json"
+
+// DefaultDir 返回默认的插件安装目录 ~/.curl2json/plugins
+func DefaultDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".curl2json", "plugins")
+	}
+	return filepath.Join(home, ".curl2json", "plugins")
+}
+
+// Installed 已安装插件的记录
+type Installed struct {
+	Manifest
+	Source      string    `json:"source"`
+	Ref         string    `json:"ref,omitempty"` // Git提交或OCI引用，本地目录安装时为空
+	Digest      string    `json:"digest"`        // 安装时目录内容的摘要，sha256:<hex>
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// 插件的完整性状态
+const (
+	StatusOK       = "ok"
+	StatusModified = "modified" // 安装后文件被修改
+	StatusMissing  = "missing"  // 安装目录不存在
+)
+
+// Registry 插件安装目录
+type Registry struct {
+	dir string
+}
+
+// Open 打开插件安装目录，目录不存在时在首次安装时创建
+func Open(dir string) *Registry {
+	return &Registry{dir: dir}
+}
+
+// List 按名称排序返回已安装的插件
+func (r *Registry) List() ([]Installed, error) {
+	content, err := os.ReadFile(filepath.Join(r.dir, registryFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, i18n.Errorf("读取插件记录失败: %w", err)
+	}
+	var installed []Installed
+	if err := json.Unmarshal(content, &installed); err != nil {
+		return nil, i18n.Errorf("插件记录 %s 格式错误: %w", filepath.Join(r.dir, registryFile), err)
+	}
+	sort.Slice(installed, func(i, j int) bool { return installed[i].Name < installed[j].Name })
+	return installed, nil
+}
+
+// save 写入插件记录
+func (r *Registry) save(installed []Installed) error {
+	content, err := json.MarshalIndent(installed, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(r.dir, registryFile), content, 0o644); err != nil {
+		return i18n.Errorf("写入插件记录失败: %w", err)
+	}
+	return nil
+}
+
+// find 返回名称为name的记录
+func (r *Registry) find(name string) (*Installed, error) {
+	installed, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range installed {
+		if installed[i].Name == name {
+			return &installed[i], nil
+		}
+	}
+	return nil, i18n.Errorf("插件 %s 未安装，可通过 plugin list 查看已安装的插件", name)
+}
+
+// Status 重新计算插件目录的摘要并与安装时的记录比较
+func (r *Registry) Status(p *Installed) string {
+	digest, err := Digest(filepath.Join(r.dir, p.Name))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return StatusMissing
+	case err != nil || digest != p.Digest:
+		return StatusModified
+	}
+	return StatusOK
+}
+
+// Load 加载已安装的插件，文件与安装时的摘要不一致时拒绝加载
+func (r *Registry) Load(name string) (*Plugin, error) {
+	record, err := r.find(name)
+	if err != nil {
+		return nil, err
+	}
+	switch r.Status(record) {
+	case StatusMissing:
+		return nil, i18n.Errorf("插件 %s 的安装目录不存在，请重新安装", name)
+	case StatusModified:
+		return nil, i18n.Errorf("插件 %s 的文件与安装时不一致（摘要 %s），可能已被篡改，请重新安装", name, record.Digest)
+	}
+	dir := filepath.Join(r.dir, name)
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &Plugin{Manifest: *manifest, dir: dir}, nil
+}
+
+// InstallOptions 安装参数
+type InstallOptions struct {
+	Digest string // 期望的目录内容摘要，非空时不一致则拒绝安装
+	Force  bool   // 覆盖已安装的同名插件
+}
+
+// Install 从来源获取插件并安装：Git仓库（URL[#ref]）、OCI制品（oci://仓库:标签）或本地目录
+func (r *Registry) Install(source string, opts InstallOptions) (*Installed, error) {
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return nil, i18n.Errorf("创建插件目录失败: %w", err)
+	}
+	staging, err := os.MkdirTemp(r.dir, ".install-")
+	if err != nil {
+		return nil, i18n.Errorf("创建插件目录失败: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	fetched := filepath.Join(staging, "plugin")
+	ref, err := fetch(source, fetched)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := ReadManifest(fetched)
+	if err != nil {
+		return nil, err
+	}
+	digest, err := Digest(fetched)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Digest != "" && !strings.EqualFold(strings.TrimPrefix(opts.Digest, "sha256:"), strings.TrimPrefix(digest, "sha256:")) {
+		return nil, i18n.Errorf("插件 %s 的摘要 %s 与期望的 %s 不一致，已取消安装", manifest.Name, digest, opts.Digest)
+	}
+
+	installed, err := r.List()
+	if err != nil {
+		return nil, err
+	}
+	kept := installed[:0]
+	for _, p := range installed {
+		if p.Name != manifest.Name {
+			kept = append(kept, p)
+		} else if !opts.Force {
+			return nil, i18n.Errorf("插件 %s 已安装（版本 %s），使用 --force 覆盖", p.Name, p.Version)
+		}
+	}
+
+	target := filepath.Join(r.dir, manifest.Name)
+	if err := os.RemoveAll(target); err != nil {
+		return nil, i18n.Errorf("删除旧版本插件失败: %w", err)
+	}
+	if err := os.Rename(fetched, target); err != nil {
+		return nil, i18n.Errorf("安装插件失败: %w", err)
+	}
+	record := Installed{Manifest: *manifest, Source: source, Ref: ref, Digest: digest, InstalledAt: time.Now().UTC()}
+	if err := r.save(append(kept, record)); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Remove 删除已安装的插件
+func (r *Registry) Remove(name string) error {
+	if _, err := r.find(name); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(filepath.Join(r.dir, name)); err != nil {
+		return i18n.Errorf("删除插件失败: %w", err)
+	}
+	installed, err := r.List()
+	if err != nil {
+		return err
+	}
+	kept := installed[:0]
+	for _, p := range installed {
+		if p.Name != name {
+			kept = append(kept, p)
+		}
+	}
+	return r.save(kept)
+}
+
+// Digest 计算目录内容的摘要：按相对路径排序，依次计入每个文件的路径、可执行位、大小与内容，
+// 跳过 .git 目录，结果与文件的修改时间无关
+func Digest(dir string) (string, error) {
+	if _, err := os.Stat(dir); err != nil {
+		return "", err
+	}
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, path := range files {
+		rel, _ := filepath.Rel(dir, path)
+		info, err := os.Stat(path)
+		if err != nil {
+			return "", err
+		}
+		mode := "-"
+		if info.Mode()&0o111 != 0 {
+			mode = "x"
+		}
+		fmt.Fprintf(h, "%s\x00%s\x00%d\x00", filepath.ToSlash(rel), mode, info.Size())
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
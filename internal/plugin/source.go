@@ -0,0 +1,109 @@
+package plugin
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// fetch 将来源中的插件获取到dir，返回实际安装的版本引用：
+//   - 本地目录：复制目录内容，引用为空
+//   - oci://仓库:标签：通过 oras pull 拉取，引用为去掉 oci:// 的制品地址
+//   - 其他地址视为Git仓库，可用 #分支、标签或提交 指定版本，引用为检出的提交
+func fetch(source, dir string) (string, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		return "", copyDir(source, dir)
+	}
+	if ref, ok := strings.CutPrefix(source, "oci://"); ok {
+		if err := run("oras", "pull", "--output", dir, ref); err != nil {
+			return "", err
+		}
+		return ref, nil
+	}
+
+	// 仓库地址与版本引用不能被git当作选项：地址放在 -- 之后，以 - 开头的引用直接拒绝，
+	// 引用之后的 -- 使其不会被当作文件路径
+	repo, ref, _ := strings.Cut(source, "#")
+	if strings.HasPrefix(ref, "-") {
+		return "", i18n.Errorf("无效的插件版本引用 %q", ref)
+	}
+	if err := run("git", "clone", "--quiet", "--", repo, dir); err != nil {
+		return "", err
+	}
+	if ref != "" {
+		if err := run("git", "-C", dir, "checkout", "--quiet", ref, "--"); err != nil {
+			return "", err
+		}
+	}
+	var stdout bytes.Buffer
+	cmd := exec.Command("git", "-C", dir, "rev-parse", "HEAD")
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", i18n.Errorf("读取插件仓库的提交失败: %w", err)
+	}
+	// 插件目录中不保留仓库历史
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// run 执行获取插件的外部命令，失败时附带命令的错误输出
+func run(name string, args ...string) error {
+	if _, err := exec.LookPath(name); err != nil {
+		return i18n.Errorf("获取插件需要 %s 命令行工具: %w", name, err)
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return i18n.Errorf("获取插件失败: %s %s: %w: %s", name, args[0], err, msg)
+		}
+		return i18n.Errorf("获取插件失败: %s %s: %w", name, args[0], err)
+	}
+	return nil
+}
+
+// copyDir 复制本地插件目录，保留文件的可执行位，跳过 .git 目录
+func copyDir(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir() && d.Name() == ".git":
+			return filepath.SkipDir
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case !d.Type().IsRegular():
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,167 @@
+// Package postprocess 在树抽取之后、渲染之前执行用户脚本，对树做重命名、合并、裁剪等调整，
+// 满足不值得新增内置参数的团队定制需求。JS与jq脚本由外部解释器执行：通过标准输入接收树状JSON，
+// 通过标准输出返回新的树状JSON；CEL表达式在进程内求值，变量 tree 为解码后的树，表达式的值即新的树
+package postprocess
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// nodeWrapper 加载JS脚本导出的函数并以树为参数调用，函数返回undefined时输出原来的（可能已被修改的）树
+const nodeWrapper = `const mod = require(process.argv[1]);
+const fn = typeof mod === "function" ? mod : mod.default;
+if (typeof fn !== "function") {
+  console.error("script must export a function: module.exports = (tree) => tree");
+  process.exit(2);
+}
+let input = "";
+process.stdin.setEncoding("utf8");
+process.stdin.on("data", (chunk) => (input += chunk));
+process.stdin.on("end", async () => {
+  const tree = JSON.parse(input);
+  const result = await fn(tree);
+  process.stdout.write(JSON.stringify(result === undefined ? tree : result));
+});`
+
+// interpreters 按扩展名确定脚本的执行命令，脚本路径追加在参数末尾
+var interpreters = map[string][]string{
+	".js": {"node", "-e", nodeWrapper},
+	".jq": {"jq", "-c", "-f"},
+}
+
+// celExt CEL表达式脚本的扩展名，由进程内的cel-go求值，不需要外部解释器
+const celExt = ".cel"
+
+// Script 后处理脚本
+type Script struct {
+	path    string
+	args    []string
+	program cel.Program // .cel 脚本编译后的程序，其他脚本为nil
+}
+
+// Load 检查脚本文件与所需的解释器，支持 .js（node，导出以树为参数的函数）、.jq（jq过滤器）
+// 和 .cel（以 tree 为变量的CEL表达式，加载时即编译）
+func Load(path string) (*Script, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	args, ok := interpreters[ext]
+	if !ok && ext != celExt {
+		return nil, i18n.Errorf("不支持的后处理脚本类型 %q，可选 .js（node）、.jq（jq）或 .cel（CEL表达式）", ext)
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(abs); err != nil {
+		return nil, i18n.Errorf("读取后处理脚本失败: %w", err)
+	}
+	if ext == celExt {
+		return loadCEL(abs)
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		return nil, i18n.Errorf("执行 %s 脚本需要 %s: %w", ext, args[0], err)
+	}
+	return &Script{path: abs, args: append(append([]string{}, args...), abs)}, nil
+}
+
+// loadCEL 读取并编译CEL表达式脚本
+func loadCEL(path string) (*Script, error) {
+	source, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取后处理脚本失败: %w", err)
+	}
+	env, err := cel.NewEnv(cel.Variable("tree", cel.DynType), ext.Strings())
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(string(source))
+	if issues != nil && issues.Err() != nil {
+		return nil, i18n.Errorf("后处理脚本 %s 编译失败: %w", filepath.Base(path), issues.Err())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, i18n.Errorf("后处理脚本 %s 编译失败: %w", filepath.Base(path), err)
+	}
+	return &Script{path: path, program: program}, nil
+}
+
+// Run 以树状JSON作为输入执行脚本，返回脚本输出的JSON：外部脚本读取标准输入并返回标准输出，
+// CEL表达式以解码后的JSON为变量 tree 求值，返回表达式的值序列化后的JSON
+func (s *Script) Run(ctx context.Context, input []byte) ([]byte, error) {
+	if s.program != nil {
+		return s.eval(ctx, input)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, s.args[0], s.args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, i18n.Errorf("后处理脚本 %s 执行失败: %w: %s", filepath.Base(s.path), err, msg)
+		}
+		return nil, i18n.Errorf("后处理脚本 %s 执行失败: %w", filepath.Base(s.path), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// eval 求值CEL表达式，JSON中的数字在表达式中均为double
+func (s *Script) eval(ctx context.Context, input []byte) ([]byte, error) {
+	var tree interface{}
+	if err := json.Unmarshal(input, &tree); err != nil {
+		return nil, err
+	}
+	out, _, err := s.program.ContextEval(ctx, map[string]interface{}{"tree": tree})
+	if err != nil {
+		return nil, i18n.Errorf("后处理脚本 %s 执行失败: %w", filepath.Base(s.path), err)
+	}
+	value, err := out.ConvertToNative(reflect.TypeOf(&structpb.Value{}))
+	if err != nil {
+		return nil, i18n.Errorf("后处理脚本 %s 的结果无法转换为JSON: %w", filepath.Base(s.path), err)
+	}
+	return protojson.Marshal(value.(*structpb.Value))
+}
+
+// Apply 对树执行脚本并返回新的树，保留原来的抽取策略与警告，统计按新的树重新计算
+func (s *Script) Apply(ctx context.Context, tree *extractor.Tree) (*extractor.Tree, error) {
+	input, err := json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	output, err := s.Run(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := extractor.ParseTreeOutput(output, tree)
+	if err != nil {
+		return nil, i18n.Errorf("后处理脚本 %s 的输出不是有效的树状JSON: %w", filepath.Base(s.path), err)
+	}
+	return result, nil
+}
+
+// Hook 返回在 extract 阶段之后执行脚本的流水线钩子
+func (s *Script) Hook() pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		tree, err := s.Apply(ctx, state.Tree)
+		if err != nil {
+			return err
+		}
+		state.Tree = tree
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+package postprocess
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+const treeJSON = `{"name":"客户详情","children":[{"name":"门店搜索","children":[]},{"name":"待删除","children":[]}]}`
+
+// writeScript 写入脚本文件，解释器不存在时跳过测试
+func writeScript(t *testing.T, name, content string) *Script {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if args, ok := interpreters[filepath.Ext(name)]; ok {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			t.Skipf("未安装 %s 的解释器", name)
+		}
+	}
+	script, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	return script
+}
+
+func applyScript(t *testing.T, script *Script) (*extractor.Tree, error) {
+	t.Helper()
+	tree := &extractor.Tree{}
+	if err := json.Unmarshal([]byte(treeJSON), tree); err != nil {
+		t.Fatal(err)
+	}
+	tree.Strategy = extractor.StrategyStandard
+	return script.Apply(context.Background(), tree)
+}
+
+func TestScript_Apply(t *testing.T) {
+	scripts := map[string]string{
+		"prune.jq": `.name = "客户" | .children |= map(select(.name != "待删除"))`,
+		"prune.js": `module.exports = (tree) => {
+  tree.name = "客户";
+  tree.children = tree.children.filter((c) => c.name !== "待删除");
+};`,
+		"prune.cel": `{"name": tree.name.replace("详情", ""), "children": tree.children.filter(c, c.name != "待删除")}`,
+	}
+	for name, content := range scripts {
+		t.Run(name, func(t *testing.T) {
+			result, err := applyScript(t, writeScript(t, name, content))
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			output, _ := json.Marshal(result)
+			if want := `{"name":"客户","children":[{"name":"门店搜索","children":[]}]}`; string(output) != want {
+				t.Errorf("Apply() = %s, want %s", output, want)
+			}
+			if result.Stats.Nodes != 2 || result.Strategy != extractor.StrategyStandard {
+				t.Errorf("Stats = %+v, Strategy = %s", result.Stats, result.Strategy)
+			}
+		})
+	}
+}
+
+func TestScript_Errors(t *testing.T) {
+	scripts := map[string]string{
+		"invalid.jq":  `{"title": .name}`,
+		"syntax.jq":   `.name |`,
+		"throw.js":    `module.exports = () => { throw new Error("boom"); };`,
+		"invalid.cel": `{"title": tree.name}`,
+		"missing.cel": `tree.children[5]`,
+		"scalar.cel":  `tree.name`,
+	}
+	for name, content := range scripts {
+		t.Run(name, func(t *testing.T) {
+			_, err := applyScript(t, writeScript(t, name, content))
+			if err == nil || !strings.Contains(err.Error(), name) {
+				t.Errorf("Apply() error = %v, want error mentioning %s", err, name)
+			}
+		})
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rename.py")
+	os.WriteFile(path, []byte(`tree`), 0o644)
+	syntax := filepath.Join(dir, "syntax.cel")
+	os.WriteFile(syntax, []byte(`tree.name ==`), 0o644)
+
+	for _, path := range []string{path, syntax, filepath.Join(dir, "missing.jq"), filepath.Join(dir, "missing.cel")} {
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s) error = nil, want error", path)
+		}
+	}
+}
@@ -1,89 +1,403 @@
 package processor
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
-	"caseurl2md/internal/config"
-	"caseurl2md/internal/extractor"
-	"caseurl2md/internal/http"
-	"caseurl2md/internal/parser"
-	"caseurl2md/internal/validator"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/http"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/keystyle"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/placeholder"
+	"github.com/wellkilo/Curl2json/internal/validator"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/parser"
 )
 
-// Processor 主处理器
+// Processor 主处理器，创建后不再修改内部状态，可在多个goroutine中并发调用 Process；
+// 每次调用的中间数据都保存在各自的 pipeline.State 中
 type Processor struct {
-	config    *config.Config
-	curlParser *parser.CurlParser
-	httpExecutor *http.Executor
-	validator *validator.ResponseValidator
-	treeExtractor *extractor.TreeExtractor
+	verbose         bool
+	validateOutput  bool
+	keyStyle        keystyle.Style
+	debugDir        string
+	waitFor         func(statusCode int, body []byte) bool
+	pollInterval    time.Duration
+	pollTimeout     time.Duration
+	retryUnsafe     bool
+	idempotencyKey  string
+	authRefresh     func(ctx context.Context, req *config.RequestInfo) error
+	rewriteResponse func(body []byte) ([]byte, error)
+	curlParser      *parser.CurlParser
+	httpExecutor    *http.Executor
+	validator       *validator.ResponseValidator
+	treeExtractor   *extractor.TreeExtractor
+	logger          *slog.Logger
+	hooks           pipeline.Hooks
+}
+
+// 轮询等待的默认间隔与超时
+const (
+	defaultPollInterval = 5 * time.Second
+	defaultPollTimeout  = 5 * time.Minute
+)
+
+// durationOr 返回d，d不大于0时返回fallback
+func durationOr(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// textProfile 返回名称对应的业务文本规则，未知的名称（由调用方预先校验）按自动选择处理
+func textProfile(name string) *extractor.TextProfile {
+	profile, _ := extractor.LookupTextProfile(name)
+	return profile
 }
 
 // New 创建新的处理器
 func New(cfg *config.Config) *Processor {
+	log := cfg.Logger
+	if log == nil {
+		log = logger.Default(cfg.Verbose)
+	}
+
+	extractorOpts := []extractor.Option{
+		extractor.WithTitleKeys(cfg.TitleKeys...),
+		extractor.WithChildrenKeys(cfg.ChildrenKeys...),
+		extractor.WithMergeChildren(cfg.MergeChildren),
+		extractor.WithMaxDepth(cfg.MaxDepth),
+		extractor.WithMaxTitleLength(cfg.MaxTitleLength),
+		extractor.WithNodeOrder(cfg.NodeOrder),
+		extractor.WithTagFields(cfg.TagFields...),
+		extractor.WithTextProfile(textProfile(cfg.TextProfile)),
+		extractor.WithConflictStrategy(extractor.ConflictStrategy(cfg.OnConflict)),
+		extractor.WithLogger(log),
+	}
+	for level, keys := range cfg.LevelTitleKeys {
+		extractorOpts = append(extractorOpts, extractor.WithLevelTitleKeys(level, keys...))
+	}
+	if cfg.ScalarTitles {
+		extractorOpts = append(extractorOpts, extractor.WithScalarTitles(extractor.ScalarTitleFormat{
+			Template: cfg.ScalarTitleFormat,
+			True:     cfg.ScalarTitleTrue,
+			False:    cfg.ScalarTitleFalse,
+		}))
+	}
+
+	parserOpts := []parser.Option{parser.WithBaseDir(cfg.CurlDir)}
+	if cfg.NoFileAccess {
+		parserOpts = append(parserOpts, parser.WithoutFileAccess())
+	}
+
 	return &Processor{
-		config:       cfg,
-		curlParser:   parser.New(),
-		httpExecutor: http.New(cfg.Timeout, cfg.Verbose),
-		validator:    validator.New(cfg.Verbose),
-		treeExtractor: extractor.New(cfg.TitleKeys, cfg.ChildrenKeys, cfg.Verbose),
+		verbose:         cfg.Verbose,
+		validateOutput:  cfg.ValidateOutput,
+		keyStyle:        keystyle.Style(cfg.KeyStyle),
+		debugDir:        cfg.DebugDir,
+		waitFor:         cfg.WaitFor,
+		pollInterval:    durationOr(cfg.PollInterval, defaultPollInterval),
+		pollTimeout:     durationOr(cfg.PollTimeout, defaultPollTimeout),
+		retryUnsafe:     cfg.RetryUnsafe,
+		idempotencyKey:  cfg.IdempotencyKey,
+		authRefresh:     cfg.AuthRefresh,
+		rewriteResponse: cfg.RewriteResponse,
+		curlParser:      parser.New(parserOpts...),
+		httpExecutor: http.New(
+			http.WithTimeout(cfg.Timeout),
+			http.WithTransport(cfg.Transport),
+			http.WithLogger(log),
+			http.WithProgress(cfg.Progress),
+			http.WithRedirects(!cfg.NoRedirects),
+			http.WithLocalSecrets(!cfg.NoLocalSecrets),
+		),
+		validator: validator.New(
+			validator.WithLogger(log),
+			validator.WithLimits(validator.Limits{
+				MaxSize:         cfg.MaxResponseSize,
+				MaxDepth:        cfg.MaxJSONDepth,
+				MaxStringLength: cfg.MaxStringLength,
+			}),
+		),
+		treeExtractor: extractor.New(extractorOpts...),
+		logger:        log,
 	}
 }
 
-// Process 处理输入并返回结果
-func (p *Processor) Process(input string, requestInfo *config.RequestInfo) ([]byte, error) {
-	var req *config.RequestInfo
-	var err error
+// Hooks 返回流水线钩子注册表，需在调用 Process 之前注册
+func (p *Processor) Hooks() *pipeline.Hooks {
+	return &p.hooks
+}
 
-	if input != "" {
-		// 解析cURL命令
-		req, err = p.curlParser.Parse(input)
+// Process 处理输入并返回结果，依次执行 parse → execute → validate → extract → render 各阶段；
+// ctx 贯穿HTTP请求与树抽取
+func (p *Processor) Process(ctx context.Context, input string, requestInfo *config.RequestInfo) ([]byte, error) {
+	state := &pipeline.State{Input: input, Request: requestInfo}
+	if err := p.run(ctx, state, pipeline.Stages()); err != nil {
+		return nil, err
+	}
+	return state.Output, nil
+}
+
+// run 按顺序执行指定阶段，每个阶段前后调用已注册的钩子
+func (p *Processor) run(ctx context.Context, state *pipeline.State, stages []pipeline.Stage) error {
+	for _, stage := range stages {
+		if err := p.hooks.RunBefore(ctx, stage, state); err != nil {
+			return err
+		}
+		if err := p.runStage(ctx, stage, state); err != nil {
+			return err
+		}
+		if err := p.hooks.RunAfter(ctx, stage, state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Processor) runStage(ctx context.Context, stage pipeline.Stage, state *pipeline.State) error {
+	switch stage {
+	case pipeline.StageParse:
+		return p.parse(state)
+	case pipeline.StageExecute:
+		return p.execute(ctx, state)
+	case pipeline.StageValidate:
+		return p.validate(state)
+	case pipeline.StageExtract:
+		return p.extract(ctx, state)
+	case pipeline.StageRender:
+		return p.render(state)
+	default:
+		return i18n.Errorf("未知的流水线阶段: %s", stage)
+	}
+}
+
+// parse 解析cURL命令；没有cURL命令时使用调用方提供的请求信息
+func (p *Processor) parse(state *pipeline.State) error {
+	if state.Input != "" {
+		req, err := p.curlParser.Parse(state.Input)
 		if err != nil {
-			return nil, fmt.Errorf("cURL解析失败: %w", err)
+			return exitcode.Errorf(exitcode.Parse, i18n.T("cURL解析失败: %w"), err)
 		}
-	} else if requestInfo != nil {
-		// 使用提供的请求信息
-		req = requestInfo
-	} else {
-		return nil, fmt.Errorf("没有提供输入")
+		state.Request = req
+	}
+	if state.Request == nil {
+		return exitcode.Wrap(exitcode.Usage, i18n.Errorf("没有提供输入"))
 	}
+	return nil
+}
+
+// execute 执行HTTP请求；设置了 WaitFor 时重复请求直到响应满足条件，后续阶段使用最后一次的响应
+func (p *Processor) execute(ctx context.Context, state *pipeline.State) error {
+	if err := p.setIdempotencyKey(state); err != nil {
+		return err
+	}
+	if p.waitFor == nil {
+		return p.executeOnce(ctx, state)
+	}
+	if err := p.checkRepeatable(state.Request, "--wait-for"); err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(p.pollTimeout)
+	for attempt := 1; ; attempt++ {
+		if err := p.executeOnce(ctx, state); err != nil {
+			return err
+		}
+		if p.waitFor(state.StatusCode, state.Body) {
+			p.logger.Debug(i18n.T("响应已满足等待条件"), "attempts", attempt)
+			return nil
+		}
+		if time.Now().Add(p.pollInterval).After(deadline) {
+			return exitcode.Wrap(exitcode.WaitTimeout, i18n.Errorf("等待条件在 %s 内未满足（共请求 %d 次）", p.pollTimeout, attempt))
+		}
+
+		p.logger.Info(i18n.T("响应尚未满足等待条件，稍后重试"), "attempt", attempt, "status", state.StatusCode, "interval", p.pollInterval)
+		timer := time.NewTimer(p.pollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// CheckRepeatable 检查请求能否被重复发送（如监听模式），规则同 checkRepeatable；
+// 没有请求信息时先解析cURL命令
+func (p *Processor) CheckRepeatable(input string, requestInfo *config.RequestInfo, option string) error {
+	state := &pipeline.State{Input: input, Request: requestInfo}
+	if err := p.parse(state); err != nil {
+		return err
+	}
+	return p.checkRepeatable(state.Request, option)
+}
 
-	// 执行HTTP请求
-	responseData, err := p.httpExecutor.Execute(req)
+// checkRepeatable 非幂等的请求只有在设置了 RetryUnsafe 或 IdempotencyKey 时才允许由option重复发送，
+// 避免轮询时在服务端重复创建生成任务等
+func (p *Processor) checkRepeatable(req *config.RequestInfo, option string) error {
+	if p.retryUnsafe || p.idempotencyKey != "" || idempotent(req.Method) {
+		return nil
+	}
+	return exitcode.Wrap(exitcode.Usage, i18n.Errorf("%s 会重复发送 %s 请求，可能在服务端重复执行操作；确认安全时加 --retry-unsafe，或用 --idempotency-key 携带幂等键", option, strings.ToUpper(req.Method)))
+}
+
+// idempotent 判断请求方法是否为幂等方法（RFC 9110）
+func idempotent(method string) bool {
+	switch strings.ToUpper(method) {
+	case "", "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// setIdempotencyKey 设置了 IdempotencyKey 时为本次执行生成幂等键写入请求头，请求已带有该请求头时沿用原来的值；
+// 写入的是请求的副本，监听模式的每一轮使用新的键
+func (p *Processor) setIdempotencyKey(state *pipeline.State) error {
+	if p.idempotencyKey == "" {
+		return nil
+	}
+	for name := range state.Request.Headers {
+		if strings.EqualFold(name, p.idempotencyKey) {
+			return nil
+		}
+	}
+	key, err := placeholder.UUID()
 	if err != nil {
-		return nil, fmt.Errorf("HTTP请求执行失败: %w", err)
+		return err
 	}
+	state.Request = state.Request.Clone()
+	state.Request.Headers[p.idempotencyKey] = key
+	p.logger.Debug(i18n.T("已写入幂等键"), "header", p.idempotencyKey, "key", key)
+	return nil
+}
 
-	// 校验响应
-	if err := p.validator.Validate(responseData); err != nil {
-		return nil, fmt.Errorf("响应校验失败: %w", err)
+// executeOnce 执行一次HTTP请求并记录响应；认证失效且设置了 AuthRefresh 时刷新凭据后重试一次，
+// 之后的请求（如轮询）继续使用刷新后的凭据
+func (p *Processor) executeOnce(ctx context.Context, state *pipeline.State) error {
+	if err := p.do(ctx, state); err != nil {
+		return err
+	}
+	if p.authRefresh == nil || !authExpired(state.StatusCode) {
+		return nil
 	}
 
-	// 新增：检查是否为错误响应
-	if p.isErrorResponse(responseData) {
-		return nil, fmt.Errorf("服务器返回错误响应，无法提取业务数据")
+	p.logger.Warn(i18n.T("认证已失效，执行刷新请求后重试"), "status", state.StatusCode)
+	req := state.Request.Clone()
+	if err := p.authRefresh(ctx, req); err != nil {
+		return errs.Mark(exitcode.Errorf(exitcode.HTTPStatus, i18n.T("服务器返回HTTP %d，刷新认证失败: %w"), state.StatusCode, err), &errs.ErrHTTPStatus{Code: state.StatusCode})
 	}
+	state.Request = req
+	return p.do(ctx, state)
+}
 
-	// 抽取树状结构
-	result, err := p.treeExtractor.Extract(responseData)
+// do 发送请求并将响应写入state
+func (p *Processor) do(ctx context.Context, state *pipeline.State) error {
+	resp, err := p.httpExecutor.Do(ctx, state.Request)
 	if err != nil {
-		// 保存原始响应用于调试
-		if p.config.Verbose {
-			debugFile := fmt.Sprintf("debug_response_%s.json", time.Now().Format("20060102_150405"))
-			debugPath := filepath.Join(os.TempDir(), debugFile)
-			if writeErr := os.WriteFile(debugPath, responseData, 0644); writeErr == nil {
-				fmt.Printf("调试: 原始响应已保存到: %s\n", debugPath)
-			}
+		return exitcode.Errorf(exitcode.Network, i18n.T("HTTP请求执行失败: %w"), err)
+	}
+	state.StatusCode = resp.StatusCode
+	state.ContentType = resp.ContentType
+	state.Header = resp.Header
+	state.Body = resp.Body
+	state.Trace = append(state.Trace, resp.Curl)
+	return nil
+}
+
+// authExpired 判断状态码是否表示认证失效，419为部分框架使用的会话过期状态码
+func authExpired(statusCode int) bool {
+	return statusCode == 401 || statusCode == 419
+}
+
+// validate 按Content-Type解码并校验响应，设置了 RewriteResponse 时先改写响应再检查，非2xx响应无法使用时归类为状态码失败
+func (p *Processor) validate(state *pipeline.State) error {
+	ok := state.StatusCode >= 200 && state.StatusCode < 300
+
+	body, err := p.validator.Decode(state.ContentType, state.Body)
+	if err != nil {
+		if !ok {
+			return errs.Mark(exitcode.Errorf(exitcode.HTTPStatus, i18n.T("服务器返回HTTP %d: 响应校验失败: %w"), state.StatusCode, err), &errs.ErrHTTPStatus{Code: state.StatusCode})
+		}
+		return exitcode.Errorf(exitcode.Validation, i18n.T("响应校验失败: %w"), err)
+	}
+	if p.rewriteResponse != nil {
+		if body, err = p.rewriteResponse(body); err != nil {
+			return exitcode.Errorf(exitcode.Validation, i18n.T("响应重写失败: %w"), err)
 		}
-		return nil, fmt.Errorf("树状结构抽取失败: %w", err)
 	}
+	state.Body = body
 
-	return result, nil
+	// 检查是否为错误响应
+	if p.isErrorResponse(state.Body) {
+		if !ok {
+			return errs.Mark(exitcode.Errorf(exitcode.HTTPStatus, i18n.T("服务器返回HTTP %d，无法提取业务数据"), state.StatusCode), &errs.ErrHTTPStatus{Code: state.StatusCode})
+		}
+		return exitcode.Wrap(exitcode.Validation, i18n.Errorf("服务器返回错误响应，无法提取业务数据"))
+	}
+	return nil
+}
+
+// extract 抽取树状结构，失败且开启详细日志或指定了调试目录时保存原始响应用于调试
+func (p *Processor) extract(ctx context.Context, state *pipeline.State) error {
+	// 前置钩子（如抽取策略插件）已经设置树时不再使用内置抽取器
+	if state.Tree != nil {
+		return nil
+	}
+	result, err := p.treeExtractor.Extract(ctx, state.Body)
+	if err != nil {
+		if p.verbose || p.debugDir != "" {
+			p.saveDebugResponse(state.Body)
+		}
+		return exitcode.Errorf(exitcode.EmptyTree, i18n.T("树状结构抽取失败: %w"), err)
+	}
+	for _, warning := range result.Warnings {
+		p.logger.Warn(warning)
+	}
+	state.Tree = result
+	return nil
+}
+
+// saveDebugResponse 将原始响应保存到调试目录（默认为系统临时目录），文件名带随机后缀，并发调用时互不覆盖
+func (p *Processor) saveDebugResponse(body []byte) {
+	pattern := fmt.Sprintf("debug_response_%s_*.json", time.Now().Format("20060102_150405"))
+	file, err := os.CreateTemp(p.debugDir, pattern)
+	if err != nil {
+		p.logger.Warn(i18n.T("保存原始响应失败"), "error", err)
+		return
+	}
+	defer file.Close()
+	if _, err := file.Write(body); err == nil {
+		p.logger.Debug(i18n.T("原始响应已保存"), "path", file.Name())
+	}
+}
+
+// render 将树序列化为缩进的JSON并按键名风格转换键名，开启输出校验时检查结果是否符合相同风格的输出结构
+func (p *Processor) render(state *pipeline.State) error {
+	output, err := json.MarshalIndent(state.Tree, "", "  ")
+	if err == nil {
+		output, err = p.keyStyle.Convert(output)
+	}
+	if err != nil {
+		return i18n.Errorf("结果序列化失败: %w", err)
+	}
+	if p.validateOutput {
+		if err := extractor.DefaultOutputSchema().MapKeys(p.keyStyle.Key).Validate(output); err != nil {
+			return exitcode.Errorf(exitcode.Validation, i18n.T("输出不符合树状JSON结构: %w"), err)
+		}
+	}
+	state.Output = output
+	return nil
 }
 
 // GetAnalysis 获取输入分析（用于调试）
@@ -112,14 +426,56 @@ func (p *Processor) GetAnalysis(input string) (map[string]interface{}, error) {
 	return analysis, nil
 }
 
+// Fetch 只执行 parse 与 execute 阶段并将响应按Content-Type解码为JSON，
+// 不执行钩子、不轮询等待，也不检查业务错误响应；用于链式请求中为后续请求提取变量的中间步骤
+func (p *Processor) Fetch(ctx context.Context, input string) ([]byte, error) {
+	state := &pipeline.State{Input: input}
+	if err := p.parse(state); err != nil {
+		return nil, err
+	}
+	if err := p.executeOnce(ctx, state); err != nil {
+		return nil, err
+	}
+	if state.StatusCode < 200 || state.StatusCode >= 300 {
+		return nil, exitcode.Wrap(exitcode.HTTPStatus, &errs.ErrHTTPStatus{Code: state.StatusCode})
+	}
+
+	body, err := p.validator.Decode(state.ContentType, state.Body)
+	if err != nil {
+		return nil, exitcode.Errorf(exitcode.Validation, i18n.T("响应校验失败: %w"), err)
+	}
+	return body, nil
+}
+
 // ValidateOnly 仅校验响应格式（用于测试）
 func (p *Processor) ValidateOnly(responseData []byte) error {
 	return p.validator.Validate(responseData)
 }
 
-// ExtractOnly 仅执行树抽取（用于测试）
-func (p *Processor) ExtractOnly(responseData []byte) ([]byte, error) {
-	return p.treeExtractor.Extract(responseData)
+// ExtractOnly 对已获取的响应体仅执行 extract 和 render 阶段
+func (p *Processor) ExtractOnly(ctx context.Context, responseData []byte) ([]byte, error) {
+	state, err := p.extractOnly(ctx, responseData)
+	if err != nil {
+		return nil, err
+	}
+	return state.Output, nil
+}
+
+// ExtractTree 与 ExtractOnly 相同，但返回抽取到的树而不是序列化后的输出
+func (p *Processor) ExtractTree(ctx context.Context, responseData []byte) (*extractor.Tree, error) {
+	state, err := p.extractOnly(ctx, responseData)
+	if err != nil {
+		return nil, err
+	}
+	return state.Tree, nil
+}
+
+func (p *Processor) extractOnly(ctx context.Context, responseData []byte) (*pipeline.State, error) {
+	state := &pipeline.State{Body: responseData}
+	if err := p.run(ctx, state, []pipeline.Stage{pipeline.StageExtract, pipeline.StageRender}); err != nil {
+		return nil, err
+	}
+	return state, nil
 }
 
 // ParseCurlOnly 仅解析cURL（用于测试）
@@ -132,10 +488,25 @@ func (p *Processor) GetExtractor() *extractor.TreeExtractor {
 	return p.treeExtractor
 }
 
-// isErrorResponse 检查响应是否为错误响应
+// isErrorResponse 检查响应是否为错误响应，响应包含多个JSON文档时任一文档为错误响应即视为错误响应。
+// 设置了 RewriteResponse 时响应的结构由分组、改写等选项决定，只按错误码与错误消息判断
 func (p *Processor) isErrorResponse(responseData []byte) bool {
+	docs, err := validator.Documents(responseData)
+	if err != nil {
+		return true
+	}
+	for _, doc := range docs {
+		if isErrorDocument(doc, p.rewriteResponse == nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorDocument 检查单个JSON文档是否为错误响应，strict 为true时还要求文档带有 data.TestCaseMind 结构
+func isErrorDocument(document []byte, strict bool) bool {
 	var response map[string]interface{}
-	if err := json.Unmarshal(responseData, &response); err != nil {
+	if err := json.Unmarshal(document, &response); err != nil {
 		return true // 如果无法解析为JSON，认为是错误响应
 	}
 
@@ -149,13 +520,17 @@ func (p *Processor) isErrorResponse(responseData []byte) bool {
 	// 检查是否包含错误消息
 	if message, exists := response["message"]; exists {
 		if messageStr, ok := message.(string); ok &&
-		   strings.Contains(strings.ToLower(messageStr), "error") ||
-		   strings.Contains(strings.ToLower(messageStr), "auth") ||
-		   strings.Contains(strings.ToLower(messageStr), "unauthorized") {
+			strings.Contains(strings.ToLower(messageStr), "error") ||
+			strings.Contains(strings.ToLower(messageStr), "auth") ||
+			strings.Contains(strings.ToLower(messageStr), "unauthorized") {
 			return true
 		}
 	}
 
+	if !strict {
+		return false
+	}
+
 	// 检查是否缺少关键的TestCaseMind结构
 	if data, exists := response["data"]; exists {
 		if dataMap, ok := data.(map[string]interface{}); ok {
@@ -173,4 +548,4 @@ func (p *Processor) isErrorResponse(responseData []byte) bool {
 // GuessStructure 尝试猜测JSON结构（用于调试）
 func (p *Processor) GuessStructure(jsonData []byte) (map[string]interface{}, error) {
 	return p.treeExtractor.GetStats(jsonData)
-}
\ No newline at end of file
+}
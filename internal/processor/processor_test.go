@@ -0,0 +1,562 @@
+package processor
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/group"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/parser"
+)
+
+const testCaseMindResponse = `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[{\"data\":{\"text\":\"输入存在的门店名称\"},\"children\":[]}]}]}"}}`
+
+func newTestProcessor(t testing.TB) (*Processor, *config.RequestInfo) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	t.Cleanup(server.Close)
+
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	return p, &config.RequestInfo{URL: server.URL, Method: "GET", Headers: map[string]string{}}
+}
+
+// TestProcessor_Concurrent 多个goroutine共用同一个 Processor，配合 go test -race 检查数据竞争
+func TestProcessor_Concurrent(t *testing.T) {
+	p, req := newTestProcessor(t)
+
+	var mu sync.Mutex
+	calls := 0
+	p.Hooks().After(pipeline.StageExtract, func(ctx context.Context, s *pipeline.State) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return nil
+	})
+
+	const workers = 16
+	var wg sync.WaitGroup
+	errCh := make(chan error, workers*2)
+	for i := 0; i < workers; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			result, err := p.Process(context.Background(), "", req)
+			if err == nil {
+				err = checkNodes(result)
+			}
+			errCh <- err
+		}()
+		go func() {
+			defer wg.Done()
+			result, err := p.ExtractOnly(context.Background(), []byte(testCaseMindResponse))
+			if err == nil {
+				err = checkNodes(result)
+			}
+			errCh <- err
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if calls != workers*2 {
+		t.Errorf("extract hook calls = %d, want %d", calls, workers*2)
+	}
+}
+
+func TestProcessor_WaitFor(t *testing.T) {
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		n := requests
+		mu.Unlock()
+		if n < 3 {
+			fmt.Fprint(w, `{"errCode":0,"data":{"status":"processing"}}`)
+			return
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+	req := &config.RequestInfo{URL: server.URL, Method: "GET", Headers: map[string]string{}}
+
+	ready := func(statusCode int, body []byte) bool {
+		return strings.Contains(string(body), "TestCaseMind")
+	}
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), WaitFor: ready, PollInterval: time.Millisecond, PollTimeout: time.Second})
+	result, err := p.Process(context.Background(), "", req)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if err := checkNodes(result); err != nil {
+		t.Error(err)
+	}
+	if requests != 3 {
+		t.Errorf("requests = %d, want 3", requests)
+	}
+
+	never := func(statusCode int, body []byte) bool { return false }
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), WaitFor: never, PollInterval: 10 * time.Millisecond, PollTimeout: 35 * time.Millisecond})
+	if _, err := p.Process(context.Background(), "", req); exitcode.From(err) != exitcode.WaitTimeout {
+		t.Errorf("Process() error = %v, want exit code %d", err, exitcode.WaitTimeout)
+	}
+}
+
+func TestProcessor_WaitForUnsafe(t *testing.T) {
+	var mu sync.Mutex
+	keys := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get("Idempotency-Key")]++
+		mu.Unlock()
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+	req := &config.RequestInfo{URL: server.URL, Method: "POST", Headers: map[string]string{}, Body: "{}"}
+	calls := 0
+	secondTry := func(statusCode int, body []byte) bool {
+		calls++
+		return calls%2 == 0
+	}
+
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), WaitFor: secondTry, PollInterval: time.Millisecond})
+	if _, err := p.Process(context.Background(), "", req); exitcode.From(err) != exitcode.Usage {
+		t.Fatalf("Process() error = %v, want exit code %d", err, exitcode.Usage)
+	}
+	if len(keys) != 0 {
+		t.Fatalf("requests sent = %v, want none", keys)
+	}
+
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), WaitFor: secondTry, PollInterval: time.Millisecond, IdempotencyKey: "Idempotency-Key"})
+	for i := 0; i < 2; i++ {
+		if _, err := p.Process(context.Background(), "", req); err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+	}
+	// 每次执行内的轮询沿用同一个键，两次执行使用不同的键，原请求不被修改
+	if len(keys) != 2 || keys[""] != 0 {
+		t.Errorf("keys = %v, want 2 distinct keys sent twice each", keys)
+	}
+	for key, n := range keys {
+		if n != 2 {
+			t.Errorf("key %s sent %d times, want 2", key, n)
+		}
+	}
+	if len(req.Headers) != 0 {
+		t.Errorf("request headers modified: %v", req.Headers)
+	}
+
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), RetryUnsafe: true})
+	if err := p.CheckRepeatable(`curl -X PATCH http://example.com`, nil, "--watch"); err != nil {
+		t.Errorf("CheckRepeatable() with RetryUnsafe error = %v", err)
+	}
+	if err := New(&config.Config{Logger: logger.Discard()}).CheckRepeatable(`curl -d a=1 http://example.com`, nil, "--watch"); exitcode.From(err) != exitcode.Usage {
+		t.Errorf("CheckRepeatable() error = %v, want exit code %d", err, exitcode.Usage)
+	}
+}
+
+func TestProcessor_Trace(t *testing.T) {
+	t.Setenv("CURL2JSON_TEST_TENANT", "tenant-secret")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+	req := &config.RequestInfo{
+		URL:     server.URL + "/cases?token=abc",
+		Method:  "POST",
+		Headers: map[string]string{"Authorization": "Bearer old", "X-Tenant": "{{env:CURL2JSON_TEST_TENANT}}"},
+		Body:    `{"id":"{{uuid}}","note":"it's\nok"}`,
+	}
+	refresh := func(ctx context.Context, r *config.RequestInfo) error {
+		r.Headers["Authorization"] = "Bearer new"
+		return nil
+	}
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), AuthRefresh: refresh})
+	var trace []string
+	p.Hooks().After(pipeline.StageExecute, func(ctx context.Context, state *pipeline.State) error {
+		trace = state.Trace
+		return nil
+	})
+	if _, err := p.Process(context.Background(), "", req); err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if len(trace) != 2 {
+		t.Fatalf("trace = %q, want 2 requests", trace)
+	}
+	for _, line := range trace {
+		if strings.Contains(line, "Bearer") || strings.Contains(line, "tenant-secret") || strings.Contains(line, "{{uuid}}") {
+			t.Errorf("trace line leaks credentials or unresolved placeholders: %s", line)
+		}
+		sent, err := parser.New().Parse(line)
+		if err != nil {
+			t.Fatalf("Parse(%s) error = %v", line, err)
+		}
+		if sent.Method != "POST" || sent.URL != server.URL+"/cases?token=REDACTED" || sent.Headers["X-Tenant"] != "{{env:CURL2JSON_TEST_TENANT}}" ||
+			sent.Headers["Authorization"] != "REDACTED" || !strings.HasSuffix(sent.Body, `","note":"it's\nok"}`) {
+			t.Errorf("trace line = %s, parsed = %+v", line, sent)
+		}
+	}
+}
+
+func TestProcessor_Compressed(t *testing.T) {
+	var gz, br bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	w.Write([]byte(testCaseMindResponse))
+	w.Close()
+	bw := brotli.NewWriter(&br)
+	bw.Write([]byte(testCaseMindResponse))
+	bw.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch accept := r.Header.Get("Accept-Encoding"); {
+		case strings.Contains(accept, "br"):
+			w.Header().Set("Content-Encoding", "br")
+			w.Write(br.Bytes())
+		case strings.Contains(accept, "gzip"):
+			w.Header().Set("Content-Encoding", "gzip")
+			w.Write(gz.Bytes())
+		default:
+			fmt.Fprint(w, testCaseMindResponse)
+		}
+	}))
+	defer server.Close()
+
+	for _, curl := range []string{
+		"curl " + server.URL + " --compressed",
+		"curl " + server.URL + " -H 'Accept-Encoding: gzip, deflate'",
+		"curl " + server.URL,
+	} {
+		p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+		var trace []string
+		p.Hooks().After(pipeline.StageExecute, func(ctx context.Context, state *pipeline.State) error {
+			trace = state.Trace
+			return nil
+		})
+		result, err := p.Process(context.Background(), curl, nil)
+		if err != nil {
+			t.Fatalf("Process(%s) error = %v", curl, err)
+		}
+		if !strings.Contains(string(result), "输入存在的门店名称") {
+			t.Errorf("Process(%s) = %s, want the decompressed tree", curl, result)
+		}
+		if compressed := strings.Contains(curl, "--compressed"); compressed != strings.Contains(trace[0], "--compressed") {
+			t.Errorf("trace line = %s, want --compressed %v", trace[0], compressed)
+		}
+	}
+}
+
+func TestProcessor_Redirects(t *testing.T) {
+	var gotToken, gotSession string
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotToken = r.Header.Get("X-Jwt-Token")
+		if c, err := r.Cookie("session"); err == nil {
+			gotSession = c.Value
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer target.Close()
+	// 跨主机：127.0.0.1 重定向到 localhost
+	crossHost := strings.Replace(target.URL, "127.0.0.1", "localhost", 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/login":
+			http.SetCookie(w, &http.Cookie{Name: "session", Value: "s1", Path: "/"})
+			http.Redirect(w, r, "/cases", http.StatusFound)
+		case "/loop":
+			http.Redirect(w, r, "/loop", http.StatusFound)
+		case "/away":
+			http.Redirect(w, r, crossHost+"/cases", http.StatusFound)
+		default:
+			target.Config.Handler.ServeHTTP(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		curl        string
+		noRedirects bool
+		wantErr     string
+		wantToken   string
+		wantSession string
+	}{
+		{name: "同主机携带请求头与重定向中设置的cookie", curl: "curl -L " + server.URL + "/login -H 'x-jwt-token: t1'", wantToken: "t1", wantSession: "s1"},
+		{name: "跨主机去掉凭据请求头", curl: "curl " + server.URL + "/away -H 'x-jwt-token: t1'"},
+		{name: "--location-trusted 跨主机携带", curl: "curl --location-trusted " + server.URL + "/away -H 'x-jwt-token: t1'", wantToken: "t1"},
+		{name: "超过 --max-redirs", curl: "curl -L --max-redirs 3 " + server.URL + "/loop", wantErr: "3"},
+		{name: "不跟随重定向", curl: "curl " + server.URL + "/login", noRedirects: true, wantErr: "302"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotToken, gotSession = "", ""
+			p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), NoRedirects: tt.noRedirects})
+			_, err := p.Process(context.Background(), tt.curl, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Process() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if gotToken != tt.wantToken || gotSession != tt.wantSession {
+				t.Errorf("target got token %q, session %q, want %q, %q", gotToken, gotSession, tt.wantToken, tt.wantSession)
+			}
+		})
+	}
+}
+
+func TestProcessor_RewriteResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			fmt.Fprint(w, `{"errCode":500,"data":{"cases":[]}}`)
+			return
+		}
+		fmt.Fprint(w, `{"errCode":0,"data":{"cases":[{"title":"登录成功","module":"登录"},{"title":"密码错误","module":"登录"},{"title":"下单","module":"订单"}]}}`)
+	}))
+	defer server.Close()
+
+	grouper, err := group.New([]string{"$.data.cases[*].module"}, "title", "children")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewriteResponse := func(body []byte) ([]byte, error) {
+		body, _, err := grouper.Apply(body)
+		return body, err
+	}
+
+	// 没有改写时要求 TestCaseMind 结构
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	if _, err := p.Process(context.Background(), "curl "+server.URL, nil); exitcode.From(err) != exitcode.Validation {
+		t.Errorf("Process() error = %v, want exit code %d", err, exitcode.Validation)
+	}
+
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), RewriteResponse: rewriteResponse})
+	result, err := p.Process(context.Background(), "curl "+server.URL, nil)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	for _, want := range []string{"登录", "订单", "密码错误"} {
+		if !strings.Contains(string(result), want) {
+			t.Errorf("Process() = %s, want %s", result, want)
+		}
+	}
+
+	// 错误码仍按错误响应处理
+	if _, err := p.Process(context.Background(), "curl "+server.URL+"/fail", nil); exitcode.From(err) != exitcode.Validation {
+		t.Errorf("Process(/fail) error = %v, want exit code %d", err, exitcode.Validation)
+	}
+}
+
+func TestProcessor_AuthRefresh(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"errCode":401}`)
+			return
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer server.Close()
+	req := &config.RequestInfo{URL: server.URL, Method: "GET", Headers: map[string]string{"Authorization": "Bearer old"}}
+
+	refreshes := 0
+	refresh := func(ctx context.Context, r *config.RequestInfo) error {
+		refreshes++
+		r.Headers["Authorization"] = "Bearer new"
+		return nil
+	}
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), AuthRefresh: refresh})
+	result, err := p.Process(context.Background(), "", req)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+	if err := checkNodes(result); err != nil {
+		t.Error(err)
+	}
+	if refreshes != 1 || req.Headers["Authorization"] != "Bearer old" {
+		t.Errorf("refreshes = %d, Authorization = %s, want 1 refresh without modifying the caller's request", refreshes, req.Headers["Authorization"])
+	}
+
+	// 刷新后仍然401时不再重试
+	stale := func(ctx context.Context, r *config.RequestInfo) error {
+		refreshes++
+		return nil
+	}
+	refreshes = 0
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), AuthRefresh: stale})
+	if _, err := p.Process(context.Background(), "", req); err == nil || refreshes != 1 {
+		t.Errorf("Process() error = %v, refreshes = %d, want error after 1 refresh", err, refreshes)
+	}
+
+	failing := func(ctx context.Context, r *config.RequestInfo) error { return errors.New("refresh failed") }
+	p = New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard(), AuthRefresh: failing})
+	_, err = p.Process(context.Background(), "", req)
+	var statusErr *errs.ErrHTTPStatus
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusUnauthorized || exitcode.From(err) != exitcode.HTTPStatus {
+		t.Errorf("Process() error = %v, want HTTP 401 status error", err)
+	}
+}
+
+func checkNodes(result []byte) error {
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		return err
+	}
+	if got := extractor.CountNodes(nodes); got != 3 {
+		return fmt.Errorf("CountNodes() = %d, want 3; result: %s", got, result)
+	}
+	return nil
+}
+
+func BenchmarkProcessor_Process(b *testing.B) {
+	p, req := newTestProcessor(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := p.Process(context.Background(), "", req); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkProcessor_ExtractOnly(b *testing.B) {
+	p, _ := newTestProcessor(b)
+	body := []byte(testCaseMindResponse)
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if _, err := p.ExtractOnly(context.Background(), body); err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func TestProcessor_Proxy(t *testing.T) {
+	var direct bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		direct = true
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer target.Close()
+
+	var gotURL, gotAuth string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL, gotAuth = r.URL.String(), r.Header.Get("Proxy-Authorization")
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	defer proxy.Close()
+	proxyAddr := strings.TrimPrefix(proxy.URL, "http://")
+
+	tests := []struct {
+		name       string
+		curl       string
+		wantURL    string
+		wantAuth   string
+		wantDirect bool
+	}{
+		{name: "经由代理", curl: "curl -x " + proxyAddr + " http://cases.example.test/api", wantURL: "http://cases.example.test/api"},
+		{
+			name: "代理认证", curl: "curl -x " + proxy.URL + " -U alice:secret http://cases.example.test/api",
+			wantURL: "http://cases.example.test/api", wantAuth: "Basic YWxpY2U6c2VjcmV0",
+		},
+		{name: "--noproxy 直连", curl: "curl -x " + proxy.URL + " --noproxy example.test,127.0.0.0/8 " + target.URL, wantDirect: true},
+	}
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			direct, gotURL, gotAuth = false, "", ""
+			if _, err := p.Process(context.Background(), tt.curl, nil); err != nil {
+				t.Fatalf("Process() error = %v", err)
+			}
+			if direct != tt.wantDirect || gotURL != tt.wantURL || gotAuth != tt.wantAuth {
+				t.Errorf("direct = %v, proxy got %q with auth %q, want %v, %q, %q", direct, gotURL, gotAuth, tt.wantDirect, tt.wantURL, tt.wantAuth)
+			}
+		})
+	}
+}
+
+func TestProcessor_TLS(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// 服务端的自签名证书同时作为CA证书与客户端证书
+	dir := t.TempDir()
+	cert := server.TLS.Certificates[0]
+	key, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: key}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		curl    string
+		wantErr bool
+	}{
+		{name: "不信任自签名证书", curl: "curl " + server.URL, wantErr: true},
+		{name: "缺少客户端证书", curl: "curl -k " + server.URL, wantErr: true},
+		{name: "-k 与客户端证书", curl: "curl -k --cert " + certFile + " --key " + keyFile + " " + server.URL},
+		{name: "--cacert 与客户端证书", curl: "curl --cacert " + certFile + " -E " + certFile + " --key " + keyFile + " " + server.URL},
+		{name: "无效的CA证书", curl: "curl --cacert " + keyFile + " " + server.URL, wantErr: true},
+	}
+	p := New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.Process(context.Background(), tt.curl, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Process() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
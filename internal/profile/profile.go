@@ -0,0 +1,63 @@
+// Package profile 为单次运行采集CPU与内存profile，用于诊断超大响应下的性能问题，
+// 生成的文件可用 go tool pprof 分析
+package profile
+
+import (
+	"os"
+	"runtime"
+	"runtime/pprof"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Profiler 按路径采集profile，路径为空时不采集对应的profile
+type Profiler struct {
+	CPUPath string // CPU profile输出路径
+	MemPath string // 运行结束时的堆内存profile输出路径
+
+	cpuFile *os.File
+}
+
+// Start 开始采集CPU profile
+func (p *Profiler) Start() error {
+	if p.CPUPath == "" {
+		return nil
+	}
+	file, err := os.Create(p.CPUPath)
+	if err != nil {
+		return i18n.Errorf("创建CPU profile文件失败: %w", err)
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return i18n.Errorf("开始采集CPU profile失败: %w", err)
+	}
+	p.cpuFile = file
+	return nil
+}
+
+// Stop 停止采集CPU profile并写入堆内存profile，未调用 Start 时只写入堆内存profile
+func (p *Profiler) Stop() error {
+	if p.cpuFile != nil {
+		pprof.StopCPUProfile()
+		err := p.cpuFile.Close()
+		p.cpuFile = nil
+		if err != nil {
+			return i18n.Errorf("写入CPU profile失败: %w", err)
+		}
+	}
+
+	if p.MemPath == "" {
+		return nil
+	}
+	file, err := os.Create(p.MemPath)
+	if err != nil {
+		return i18n.Errorf("创建内存profile文件失败: %w", err)
+	}
+	defer file.Close()
+	// 先执行GC，使profile反映运行结束时仍然存活的对象
+	runtime.GC()
+	if err := pprof.WriteHeapProfile(file); err != nil {
+		return i18n.Errorf("写入内存profile失败: %w", err)
+	}
+	return nil
+}
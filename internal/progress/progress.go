@@ -0,0 +1,102 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+const (
+	barWidth        = 30
+	defaultDelay    = 500 * time.Millisecond // 小响应不显示进度，避免闪烁
+	defaultInterval = 100 * time.Millisecond
+)
+
+// Reader 在读取过程中向w输出下载进度，total<=0 时仅显示已下载字节数
+type Reader struct {
+	r        io.Reader
+	w        io.Writer
+	total    int64
+	read     int64
+	start    time.Time
+	lastDraw time.Time
+	delay    time.Duration
+	interval time.Duration
+	drawn    bool
+	finished bool
+}
+
+// NewReader 创建带进度显示的Reader
+func NewReader(r io.Reader, total int64, w io.Writer) *Reader {
+	return &Reader{
+		r:        r,
+		w:        w,
+		total:    total,
+		start:    time.Now(),
+		delay:    defaultDelay,
+		interval: defaultInterval,
+	}
+}
+
+// Read 实现 io.Reader
+func (p *Reader) Read(buf []byte) (int, error) {
+	n, err := p.r.Read(buf)
+	p.read += int64(n)
+
+	now := time.Now()
+	if now.Sub(p.start) >= p.delay && now.Sub(p.lastDraw) >= p.interval {
+		p.draw(now)
+	}
+	if err == io.EOF {
+		p.Finish()
+	}
+	return n, err
+}
+
+// Finish 结束进度显示，已输出过进度时补齐最终状态并换行
+func (p *Reader) Finish() {
+	if p.finished {
+		return
+	}
+	p.finished = true
+	if p.drawn {
+		p.draw(time.Now())
+		fmt.Fprintln(p.w)
+	}
+}
+
+func (p *Reader) draw(now time.Time) {
+	p.drawn = true
+	p.lastDraw = now
+	elapsed := now.Sub(p.start).Round(100 * time.Millisecond)
+
+	if p.total <= 0 {
+		fmt.Fprintf(p.w, i18n.T("\r已下载 %s (%s)"), FormatBytes(p.read), elapsed)
+		return
+	}
+
+	ratio := float64(p.read) / float64(p.total)
+	if ratio > 1 {
+		ratio = 1
+	}
+	filled := int(ratio * barWidth)
+	bar := strings.Repeat("#", filled) + strings.Repeat("-", barWidth-filled)
+	fmt.Fprintf(p.w, i18n.T("\r下载中 [%s] %3.0f%% %s/%s (%s)"), bar, ratio*100, FormatBytes(p.read), FormatBytes(p.total), elapsed)
+}
+
+// FormatBytes 将字节数格式化为易读的单位
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,69 @@
+package progress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestReaderWithTotal(t *testing.T) {
+	var out bytes.Buffer
+	data := strings.Repeat("x", 4096)
+	r := NewReader(strings.NewReader(data), int64(len(data)), &out)
+	r.delay, r.interval = 0, 0
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if string(got) != data {
+		t.Fatal("读取内容与原始数据不一致")
+	}
+	if !strings.Contains(out.String(), "100%") {
+		t.Errorf("进度输出缺少100%%: %q", out.String())
+	}
+	if !strings.HasSuffix(out.String(), "\n") {
+		t.Error("结束后应换行")
+	}
+}
+
+func TestReaderUnknownTotal(t *testing.T) {
+	var out bytes.Buffer
+	r := NewReader(strings.NewReader("hello"), -1, &out)
+	r.delay, r.interval = 0, 0
+
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "已下载 5 B") {
+		t.Errorf("进度输出 = %q", out.String())
+	}
+}
+
+func TestReaderQuietBeforeDelay(t *testing.T) {
+	var out bytes.Buffer
+	r := NewReader(strings.NewReader("hello"), 5, &out)
+
+	if _, err := io.ReadAll(r); err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("延迟内不应输出进度，实际: %q", out.String())
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1024:            "1.0 KB",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+	}
+	for n, want := range tests {
+		if got := FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
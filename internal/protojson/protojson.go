@@ -0,0 +1,117 @@
+// Package protojson 按 protobuf JSON 映射的约定规整响应（--proto-json），
+// 使 gRPC-gateway 等服务返回的JSON不需要手写改写规则就能直接抽取
+package protojson
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"regexp"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// typeKey google.protobuf.Any 展开后记录消息类型的键
+const typeKey = "@type"
+
+var (
+	// enumUnspecified 枚举的零值，按 protobuf 风格指南命名为 类型前缀_UNSPECIFIED
+	enumUnspecified = regexp.MustCompile(`^[A-Z][A-Z0-9_]*_UNSPECIFIED$`)
+	// integerString int64/uint64 在 proto3 JSON 中序列化成的十进制字符串
+	integerString = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+)
+
+// int64/uint64 的取值范围，超出范围的数字字符串不是由64位整数序列化而来，保持为字符串
+var (
+	minInt64  = big.NewInt(-1 << 63)
+	maxUint64 = new(big.Int).SetUint64(1<<64 - 1)
+)
+
+// Normalizer 规整 protobuf JSON
+type Normalizer struct {
+	// keep 值始终保持为字符串的键，通常是标题候选键，避免 "2024" 这样的标题被转换为数字
+	keep map[string]bool
+}
+
+// New 创建规整器，keepKeys 中的键的值即使是数字字符串也不会转换
+func New(keepKeys ...string) *Normalizer {
+	n := &Normalizer{keep: make(map[string]bool, len(keepKeys))}
+	for _, key := range keepKeys {
+		n.keep[key] = true
+	}
+	return n
+}
+
+// Normalize 规整响应JSON，返回规整后的JSON：
+//
+//   - 只有 value 一个键的包装对象（google.protobuf.*Value）替换为其中的值
+//   - 只有 case 与 value 两个键、case 为字符串的 oneof 包装替换为 value
+//   - 删除 google.protobuf.Any 的 @type 键
+//   - 删除值为 *_UNSPECIFIED 的枚举字段，与 proto3 省略零值的行为一致
+//   - 64位整数序列化成的十进制字符串转换为数字，keepKeys 中的键除外
+//
+// 数字按原样保留，不会损失精度
+func (n *Normalizer) Normalize(body []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, i18n.Errorf("响应不是有效的JSON，无法按protobuf JSON规整: %w", err)
+	}
+	return json.Marshal(n.normalize(data, ""))
+}
+
+// normalize 递归规整value，key 为value所在的对象键，数组元素沿用数组的键
+func (n *Normalizer) normalize(value interface{}, key string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if unwrapped, ok := unwrap(v); ok {
+			return n.normalize(unwrapped, key)
+		}
+		delete(v, typeKey)
+		for k, item := range v {
+			if s, ok := item.(string); ok && enumUnspecified.MatchString(s) {
+				delete(v, k)
+				continue
+			}
+			v[k] = n.normalize(item, k)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = n.normalize(item, key)
+		}
+		return v
+	case string:
+		if !n.keep[key] && isInt64String(v) {
+			return json.Number(v)
+		}
+	}
+	return value
+}
+
+// unwrap 识别 value 包装对象与 oneof 包装，返回被包装的值
+func unwrap(obj map[string]interface{}) (interface{}, bool) {
+	value, ok := obj["value"]
+	if !ok {
+		return nil, false
+	}
+	switch len(obj) {
+	case 1:
+		return value, true
+	case 2:
+		if _, ok := obj["case"].(string); ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// isInt64String 判断s是否为64位有符号或无符号整数范围内的十进制字符串
+func isInt64String(s string) bool {
+	if !integerString.MatchString(s) {
+		return false
+	}
+	i, ok := new(big.Int).SetString(s, 10)
+	return ok && i.Cmp(minInt64) >= 0 && i.Cmp(maxUint64) <= 0
+}
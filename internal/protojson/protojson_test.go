@@ -0,0 +1,61 @@
+package protojson
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		keep []string
+		body string
+		want string
+	}{
+		{
+			name: "value包装",
+			body: `{"title":{"value":"根"},"count":{"value":"12"}}`,
+			want: `{"count":12,"title":"根"}`,
+		},
+		{
+			name: "oneof包装",
+			body: `{"node":{"case":"folder","value":{"title":"模块","id":"9223372036854775807"}}}`,
+			want: `{"node":{"id":9223372036854775807,"title":"模块"}}`,
+		},
+		{
+			name: "Any与未指定的枚举",
+			body: `{"@type":"type.googleapis.com/tree.Node","kind":"NODE_KIND_UNSPECIFIED","status":"STATUS_ACTIVE","tags":["TAG_UNSPECIFIED"]}`,
+			want: `{"status":"STATUS_ACTIVE","tags":["TAG_UNSPECIFIED"]}`,
+		},
+		{
+			name: "64位整数字符串",
+			body: `{"id":"18446744073709551615","big":"18446744073709551616","neg":"-12","lead":"007","ids":["1","2"]}`,
+			want: `{"big":"18446744073709551616","id":18446744073709551615,"ids":[1,2],"lead":"007","neg":-12}`,
+		},
+		{
+			name: "标题字段保持为字符串",
+			keep: []string{"title"},
+			body: `{"title":"2024","children":[{"title":{"value":"2025"},"id":"3"}]}`,
+			want: `{"children":[{"id":3,"title":"2025"}],"title":"2024"}`,
+		},
+		{
+			name: "普通对象不受影响",
+			body: `{"value":1,"label":"x"}`,
+			want: `{"label":"x","value":1}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.keep...).Normalize([]byte(tt.body))
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Normalize() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalize_InvalidJSON(t *testing.T) {
+	if _, err := New().Normalize([]byte(`{"a":`)); err == nil {
+		t.Error("Normalize() error = nil, want error")
+	}
+}
@@ -0,0 +1,210 @@
+package publish
+
+import (
+	"context"
+	"html"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// Confluence页面正文格式
+const (
+	FormatStorage  = "storage"  // 嵌套列表（Confluence存储格式）
+	FormatMarkdown = "markdown" // Markdown宏中的Markdown嵌套列表
+)
+
+// Confluence 通过REST API在指定空间创建或更新页面
+type Confluence struct {
+	BaseURL string // Confluence地址，Cloud为 https://xxx.atlassian.net/wiki
+	Space   string // 空间key
+	Parent  string // 父页面ID或标题，为空时创建在空间根目录
+	User    string // Cloud账号邮箱，与Token组成Basic认证；为空时Token作为个人访问令牌使用Bearer认证
+	Token   string
+	Format  string // FormatStorage 或 FormatMarkdown，为空时使用 FormatStorage
+	DryRun  bool   // 只生成页面正文，不调用接口
+	Client  *http.Client
+}
+
+// confluencePage REST API中的页面
+type confluencePage struct {
+	ID        string                 `json:"id,omitempty"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Space     *confluenceSpace       `json:"space,omitempty"`
+	Ancestors []confluenceAncestor   `json:"ancestors,omitempty"`
+	Body      *confluenceBody        `json:"body,omitempty"`
+	Version   *confluenceVersion     `json:"version,omitempty"`
+	Links     map[string]interface{} `json:"_links,omitempty"`
+}
+
+type confluenceSpace struct {
+	Key string `json:"key"`
+}
+
+type confluenceAncestor struct {
+	ID string `json:"id"`
+}
+
+type confluenceBody struct {
+	Storage confluenceStorage `json:"storage"`
+}
+
+type confluenceStorage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+type confluenceVersion struct {
+	Number int `json:"number"`
+}
+
+// Publish 实现 Publisher：空间中已有同名页面时更新（版本号加一），否则在父页面下创建
+func (c *Confluence) Publish(ctx context.Context, title string, tree *extractor.Tree) (*Result, error) {
+	if c.BaseURL == "" || c.Space == "" || c.Token == "" {
+		return nil, i18n.Errorf("发布到Confluence需要地址、空间和访问令牌")
+	}
+	body, err := c.render(tree)
+	if err != nil {
+		return nil, err
+	}
+	if c.DryRun {
+		return &Result{Target: "confluence", Action: ActionDryRun, Items: []Item{{Title: title}}}, nil
+	}
+	api := newClient(c.Client, c.authorize)
+
+	page := &confluencePage{
+		Type:  "page",
+		Title: title,
+		Space: &confluenceSpace{Key: c.Space},
+		Body:  &confluenceBody{Storage: confluenceStorage{Value: body, Representation: "storage"}},
+	}
+	if c.Parent != "" {
+		parentID, err := c.parentID(ctx, api)
+		if err != nil {
+			return nil, err
+		}
+		page.Ancestors = []confluenceAncestor{{ID: parentID}}
+	}
+
+	existing, err := c.find(ctx, api, title)
+	if err != nil {
+		return nil, err
+	}
+	var saved confluencePage
+	result := &Result{Target: "confluence", Action: ActionCreated}
+	if existing == nil {
+		err = api.do(ctx, http.MethodPost, c.endpoint("/rest/api/content"), page, &saved)
+	} else {
+		page.ID = existing.ID
+		page.Version = &confluenceVersion{Number: existing.Version.Number + 1}
+		result.Action = ActionUpdated
+		err = api.do(ctx, http.MethodPut, c.endpoint("/rest/api/content/"+url.PathEscape(existing.ID)), page, &saved)
+	}
+	if err != nil {
+		return nil, i18n.Errorf("保存Confluence页面失败: %w", err)
+	}
+
+	result.ID = saved.ID
+	result.URL = saved.link()
+	return result, nil
+}
+
+// render 按 Format 生成页面正文
+func (c *Confluence) render(tree *extractor.Tree) (string, error) {
+	switch c.Format {
+	case "", FormatStorage:
+		var b strings.Builder
+		writeStorageList(&b, tree.Roots)
+		return b.String(), nil
+	case FormatMarkdown:
+		// CDATA 中不能出现 ]]>，拆分到两个CDATA段中
+		markdown := strings.ReplaceAll(string(tree.MarshalMarkdown()), "]]>", "]]]]><![CDATA[>")
+		return `<ac:structured-macro ac:name="markdown"><ac:plain-text-body><![CDATA[` + markdown + `]]></ac:plain-text-body></ac:structured-macro>`, nil
+	default:
+		return "", i18n.Errorf("不支持的Confluence页面格式: %s（可选 storage、markdown）", c.Format)
+	}
+}
+
+// writeStorageList 将节点写为嵌套的 <ul> 列表
+func writeStorageList(b *strings.Builder, nodes []*extractor.SimplifiedNode) {
+	b.WriteString("<ul>")
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		b.WriteString("<li>")
+		b.WriteString(html.EscapeString(node.Name))
+		if len(node.Children) > 0 {
+			writeStorageList(b, node.Children)
+		}
+		b.WriteString("</li>")
+	}
+	b.WriteString("</ul>")
+}
+
+// authorize 设置认证请求头
+func (c *Confluence) authorize(req *http.Request) {
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+}
+
+func (c *Confluence) endpoint(path string) string {
+	return strings.TrimRight(c.BaseURL, "/") + path
+}
+
+// find 按标题查找空间中的页面，不存在时返回nil
+func (c *Confluence) find(ctx context.Context, api *client, title string) (*confluencePage, error) {
+	query := url.Values{
+		"spaceKey": {c.Space},
+		"title":    {title},
+		"type":     {"page"},
+		"expand":   {"version"},
+	}
+	var found struct {
+		Results []confluencePage `json:"results"`
+	}
+	if err := api.do(ctx, http.MethodGet, c.endpoint("/rest/api/content?"+query.Encode()), nil, &found); err != nil {
+		return nil, i18n.Errorf("查找Confluence页面失败: %w", err)
+	}
+	if len(found.Results) == 0 {
+		return nil, nil
+	}
+	page := found.Results[0]
+	if page.Version == nil {
+		page.Version = &confluenceVersion{Number: 1}
+	}
+	return &page, nil
+}
+
+// parentID 返回父页面ID，Parent 不是数字时按标题查找
+func (c *Confluence) parentID(ctx context.Context, api *client) (string, error) {
+	if _, err := strconv.ParseUint(c.Parent, 10, 64); err == nil {
+		return c.Parent, nil
+	}
+	parent, err := c.find(ctx, api, c.Parent)
+	if err != nil {
+		return "", err
+	}
+	if parent == nil {
+		return "", i18n.Errorf("空间 %s 中没有标题为 %q 的父页面", c.Space, c.Parent)
+	}
+	return parent.ID, nil
+}
+
+// link 返回页面的浏览器地址
+func (p *confluencePage) link() string {
+	base, _ := p.Links["base"].(string)
+	webui, _ := p.Links["webui"].(string)
+	if webui == "" {
+		return ""
+	}
+	return base + webui
+}
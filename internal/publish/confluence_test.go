@@ -0,0 +1,116 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// fakeConfluence 在内存中保存页面的Confluence REST API
+type fakeConfluence struct {
+	mu    sync.Mutex
+	pages map[string]*confluencePage // 标题 -> 页面
+	auth  string
+}
+
+func (f *fakeConfluence) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.auth = r.Header.Get("Authorization")
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/wiki/rest/api/content":
+		results := []*confluencePage{}
+		if page, ok := f.pages[r.URL.Query().Get("title")]; ok {
+			results = append(results, page)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+	case r.Method == http.MethodPost && r.URL.Path == "/wiki/rest/api/content",
+		r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/wiki/rest/api/content/"):
+		var page confluencePage
+		json.NewDecoder(r.Body).Decode(&page)
+		if page.ID == "" {
+			page.ID = "100"
+		} else if page.Version.Number != f.pages[page.Title].Version.Number+1 {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		if page.Version == nil {
+			page.Version = &confluenceVersion{Number: 1}
+		}
+		page.Links = map[string]interface{}{"base": "https://wiki.example.com/wiki", "webui": "/pages/" + page.ID}
+		f.pages[page.Title] = &page
+		json.NewEncoder(w).Encode(page)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func testTree() *extractor.Tree {
+	tree := &extractor.Tree{}
+	json.Unmarshal([]byte(`{"name":"客户详情","children":[{"name":"门店<搜索>","children":[{"name":"输入名称","children":[]}]}]}`), tree)
+	return tree
+}
+
+func TestConfluence_Publish(t *testing.T) {
+	fake := &fakeConfluence{pages: map[string]*confluencePage{
+		"测试计划": {ID: "42", Title: "测试计划", Version: &confluenceVersion{Number: 3}},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	c := &Confluence{BaseURL: server.URL + "/wiki/", Space: "QA", Parent: "测试计划", User: "qa@example.com", Token: "secret"}
+	tree := testTree()
+	result, err := c.Publish(context.Background(), Title(tree), tree)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if result.Action != ActionCreated || result.ID != "100" || result.URL != "https://wiki.example.com/wiki/pages/100" {
+		t.Errorf("Publish() = %+v", result)
+	}
+	page := fake.pages["客户详情"]
+	if want := "<ul><li>客户详情<ul><li>门店&lt;搜索&gt;<ul><li>输入名称</li></ul></li></ul></li></ul>"; page.Body.Storage.Value != want {
+		t.Errorf("body = %s, want %s", page.Body.Storage.Value, want)
+	}
+	if len(page.Ancestors) != 1 || page.Ancestors[0].ID != "42" || !strings.HasPrefix(fake.auth, "Basic ") {
+		t.Errorf("ancestors = %+v, auth = %s", page.Ancestors, fake.auth)
+	}
+
+	// 再次发布时更新已有页面
+	c.User, c.Format = "", FormatMarkdown
+	result, err = c.Publish(context.Background(), "客户详情", tree)
+	if err != nil {
+		t.Fatalf("Publish() update error = %v", err)
+	}
+	if result.Action != ActionUpdated || fake.pages["客户详情"].Version.Number != 2 || fake.auth != "Bearer secret" {
+		t.Errorf("Publish() = %+v, version = %d, auth = %s", result, fake.pages["客户详情"].Version.Number, fake.auth)
+	}
+	if body := fake.pages["客户详情"].Body.Storage.Value; !strings.Contains(body, `ac:name="markdown"`) || !strings.Contains(body, "  - 门店<搜索>") {
+		t.Errorf("markdown body = %s", body)
+	}
+}
+
+func TestConfluence_Errors(t *testing.T) {
+	server := httptest.NewServer(&fakeConfluence{pages: map[string]*confluencePage{}})
+	defer server.Close()
+
+	tests := map[string]*Confluence{
+		"缺少空间":   {BaseURL: server.URL + "/wiki", Token: "t"},
+		"父页面不存在": {BaseURL: server.URL + "/wiki", Space: "QA", Parent: "不存在", Token: "t"},
+		"格式错误":   {BaseURL: server.URL + "/wiki", Space: "QA", Token: "t", Format: "wiki"},
+		"接口错误":   {BaseURL: server.URL + "/missing", Space: "QA", Token: "t"},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := c.Publish(context.Background(), "标题", testTree()); err == nil {
+				t.Error("Publish() error = nil, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// FeishuURL 飞书开放平台地址，Lark国际版为 https://open.larksuite.com
+const FeishuURL = "https://open.feishu.cn"
+
+// 飞书限制
+const (
+	feishuBulletBlock   = 12 // 无序列表块的 block_type
+	feishuChildrenLimit = 50 // 单次最多创建的子块数
+)
+
+// Feishu 通过开放平台在云空间中创建新版文档（docx），正文为与树结构一致的嵌套无序列表
+type Feishu struct {
+	BaseURL     string // 开放平台地址，为空时使用 FeishuURL
+	Folder      string // 文件夹token，为空时创建在应用的云空间根目录
+	TenantToken string // tenant_access_token，为空时通过 AppID 与 AppSecret 获取
+	AppID       string
+	AppSecret   string
+	DryRun      bool // 只生成文档标题，不调用接口
+	Client      *http.Client
+}
+
+// feishuResponse 开放平台接口的通用响应，code 非0表示失败
+type feishuResponse struct {
+	Code int             `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type feishuBlock struct {
+	BlockID   string      `json:"block_id,omitempty"`
+	BlockType int         `json:"block_type"`
+	Bullet    *feishuText `json:"bullet,omitempty"`
+}
+
+type feishuText struct {
+	Elements []feishuElement `json:"elements"`
+}
+
+type feishuElement struct {
+	TextRun feishuTextRun `json:"text_run"`
+}
+
+type feishuTextRun struct {
+	Content string `json:"content"`
+}
+
+// Publish 实现 Publisher：每次发布都创建一篇新文档
+func (f *Feishu) Publish(ctx context.Context, title string, tree *extractor.Tree) (*Result, error) {
+	if f.DryRun {
+		return &Result{Target: "feishu", Action: ActionDryRun, Items: []Item{{Title: title}}}, nil
+	}
+
+	api := newClient(f.Client, nil)
+	token, err := f.token(ctx, api)
+	if err != nil {
+		return nil, err
+	}
+	api.auth = func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+
+	var created struct {
+		Document struct {
+			DocumentID string `json:"document_id"`
+		} `json:"document"`
+	}
+	body := map[string]string{"title": title}
+	if f.Folder != "" {
+		body["folder_token"] = f.Folder
+	}
+	if err := f.call(ctx, api, "/open-apis/docx/v1/documents", body, &created); err != nil {
+		return nil, i18n.Errorf("创建飞书文档失败: %w", err)
+	}
+	id := created.Document.DocumentID
+
+	// 文档根块的ID与文档ID相同
+	if err := f.writeList(ctx, api, id, id, tree.Roots); err != nil {
+		return nil, i18n.Errorf("写入飞书文档 %s 失败: %w", id, err)
+	}
+	return &Result{Target: "feishu", Action: ActionCreated, ID: id}, nil
+}
+
+// token 返回 TenantToken，未指定时通过自建应用凭证获取
+func (f *Feishu) token(ctx context.Context, api *client) (string, error) {
+	if f.TenantToken != "" {
+		return f.TenantToken, nil
+	}
+	if f.AppID == "" || f.AppSecret == "" {
+		return "", i18n.Errorf("发布到飞书需要 tenant_access_token 或应用的 App ID 与 App Secret")
+	}
+
+	// 该接口的 tenant_access_token 与 code 位于响应顶层
+	var resp struct {
+		Code  int    `json:"code"`
+		Msg   string `json:"msg"`
+		Token string `json:"tenant_access_token"`
+	}
+	body := map[string]string{"app_id": f.AppID, "app_secret": f.AppSecret}
+	if err := api.do(ctx, http.MethodPost, f.endpoint("/open-apis/auth/v3/tenant_access_token/internal"), body, &resp); err != nil {
+		return "", i18n.Errorf("获取飞书 tenant_access_token 失败: %w", err)
+	}
+	if resp.Code != 0 {
+		return "", i18n.Errorf("获取飞书 tenant_access_token 失败: %w", feishuError(resp.Code, resp.Msg))
+	}
+	return resp.Token, nil
+}
+
+// writeList 在parent块下按顺序创建节点对应的列表块，再递归创建各块的子节点
+func (f *Feishu) writeList(ctx context.Context, api *client, document, parent string, nodes []*extractor.SimplifiedNode) error {
+	var valid []*extractor.SimplifiedNode
+	for _, node := range nodes {
+		if node != nil {
+			valid = append(valid, node)
+		}
+	}
+
+	for start := 0; start < len(valid); start += feishuChildrenLimit {
+		end := start + feishuChildrenLimit
+		if end > len(valid) {
+			end = len(valid)
+		}
+		batch := valid[start:end]
+		children := make([]feishuBlock, len(batch))
+		for i, node := range batch {
+			children[i] = feishuBlock{
+				BlockType: feishuBulletBlock,
+				Bullet:    &feishuText{Elements: []feishuElement{{TextRun: feishuTextRun{Content: node.Name}}}},
+			}
+		}
+
+		var created struct {
+			Children []feishuBlock `json:"children"`
+		}
+		path := "/open-apis/docx/v1/documents/" + url.PathEscape(document) + "/blocks/" + url.PathEscape(parent) + "/children?document_revision_id=-1"
+		if err := f.call(ctx, api, path, map[string]interface{}{"children": children, "index": start}, &created); err != nil {
+			return err
+		}
+		if len(created.Children) != len(batch) {
+			return i18n.Errorf("飞书返回了 %d 个块，应为 %d 个", len(created.Children), len(batch))
+		}
+
+		for i, node := range batch {
+			if len(node.Children) == 0 {
+				continue
+			}
+			if err := f.writeList(ctx, api, document, created.Children[i].BlockID, node.Children); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// call 以POST调用开放平台接口，将响应的 data 解析到out
+func (f *Feishu) call(ctx context.Context, api *client, path string, body, out interface{}) error {
+	var resp feishuResponse
+	if err := api.do(ctx, http.MethodPost, f.endpoint(path), body, &resp); err != nil {
+		return err
+	}
+	if resp.Code != 0 {
+		return feishuError(resp.Code, resp.Msg)
+	}
+	if out == nil || len(resp.Data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(resp.Data, out)
+}
+
+func feishuError(code int, msg string) error {
+	return i18n.Errorf("飞书接口返回错误 %d: %s", code, msg)
+}
+
+func (f *Feishu) endpoint(path string) string {
+	base := f.BaseURL
+	if base == "" {
+		base = FeishuURL
+	}
+	return strings.TrimRight(base, "/") + path
+}
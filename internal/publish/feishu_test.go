@@ -0,0 +1,90 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeFeishu 在内存中保存文档块的飞书开放平台
+type fakeFeishu struct {
+	mu       sync.Mutex
+	title    string
+	children map[string][]string // 块ID -> 子块ID
+	text     map[string]string   // 块ID -> 文本
+	auth     string
+}
+
+func (f *fakeFeishu) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]json.RawMessage
+	json.NewDecoder(r.Body).Decode(&body)
+	reply := func(data interface{}) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"code": 0, "msg": "success", "data": data})
+	}
+	switch {
+	case r.URL.Path == "/open-apis/auth/v3/tenant_access_token/internal":
+		if string(body["app_secret"]) != `"secret"` {
+			json.NewEncoder(w).Encode(map[string]interface{}{"code": 10014, "msg": "app secret invalid"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"code": 0, "tenant_access_token": "t-123", "expire": 7200})
+	case r.URL.Path == "/open-apis/docx/v1/documents":
+		f.auth = r.Header.Get("Authorization")
+		json.Unmarshal(body["title"], &f.title)
+		reply(map[string]interface{}{"document": map[string]string{"document_id": "doc1"}})
+	case strings.HasPrefix(r.URL.Path, "/open-apis/docx/v1/documents/doc1/blocks/"):
+		parent := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/open-apis/docx/v1/documents/doc1/blocks/"), "/children")
+		var blocks []feishuBlock
+		json.Unmarshal(body["children"], &blocks)
+		for i := range blocks {
+			blocks[i].BlockID = fmt.Sprintf("b%d", len(f.text)+1)
+			f.text[blocks[i].BlockID] = blocks[i].Bullet.Elements[0].TextRun.Content
+			f.children[parent] = append(f.children[parent], blocks[i].BlockID)
+		}
+		reply(map[string]interface{}{"children": blocks})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// dump 以缩进文本输出块结构
+func (f *fakeFeishu) dump(id, indent string) string {
+	var b strings.Builder
+	for _, child := range f.children[id] {
+		b.WriteString(indent + f.text[child] + "\n")
+		b.WriteString(f.dump(child, indent+"  "))
+	}
+	return b.String()
+}
+
+func TestFeishu_Publish(t *testing.T) {
+	fake := &fakeFeishu{children: map[string][]string{}, text: map[string]string{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	f := &Feishu{BaseURL: server.URL, AppID: "cli_a", AppSecret: "secret"}
+	result, err := f.Publish(context.Background(), "客户详情", jiraTestTree())
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if result.Action != ActionCreated || result.ID != "doc1" || fake.title != "客户详情" || fake.auth != "Bearer t-123" {
+		t.Errorf("Publish() = %+v, title = %s, auth = %s", result, fake.title, fake.auth)
+	}
+	want := "客户详情\n  门店搜索\n    输入存在的名称\n    失败\n  门店列表\n"
+	if got := fake.dump("doc1", ""); got != want {
+		t.Errorf("blocks =\n%s\nwant\n%s", got, want)
+	}
+
+	f.AppSecret = "wrong"
+	if _, err := f.Publish(context.Background(), "客户详情", jiraTestTree()); err == nil || !strings.Contains(err.Error(), "10014") {
+		t.Errorf("Publish() error = %v, want app secret error", err)
+	}
+}
@@ -0,0 +1,193 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// Jira限制
+const (
+	jiraSummaryLimit = 255 // 问题标题的最大长度
+	jiraBulkLimit    = 50  // 批量创建接口单次最多创建的问题数
+)
+
+// 默认问题类型
+const (
+	JiraIssueType   = "Task"
+	JiraSubTaskType = "Sub-task"
+)
+
+// Jira 通过REST API为树的每个叶子节点创建一个问题（指定 Parent 时为子任务），
+// 问题标题为 "[祖先路径] 叶子名称"，描述为完整路径
+type Jira struct {
+	BaseURL   string // Jira地址，如 https://xxx.atlassian.net
+	Project   string // 项目key
+	IssueType string // 问题类型名称，为空时使用 Task，指定 Parent 时使用 Sub-task
+	Parent    string // 父问题key，指定时创建为其子任务
+	User      string // Cloud账号邮箱，与Token组成Basic认证；为空时Token作为个人访问令牌使用Bearer认证
+	Token     string
+	DryRun    bool // 只生成问题标题，不调用接口
+	Client    *http.Client
+}
+
+// jiraFields 创建问题的字段
+type jiraFields struct {
+	Project     jiraRef  `json:"project"`
+	IssueType   jiraName `json:"issuetype"`
+	Parent      *jiraRef `json:"parent,omitempty"`
+	Summary     string   `json:"summary"`
+	Description string   `json:"description"`
+}
+
+type jiraRef struct {
+	Key string `json:"key"`
+}
+
+type jiraName struct {
+	Name string `json:"name"`
+}
+
+type jiraIssueUpdate struct {
+	Fields jiraFields `json:"fields"`
+}
+
+// jiraBulkResult 批量创建接口的响应，部分失败时 issues 与 errors 同时存在
+type jiraBulkResult struct {
+	Issues []jiraIssue     `json:"issues"`
+	Errors []jiraBulkError `json:"errors"`
+}
+
+type jiraIssue struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// jiraBulkError 单个问题的创建错误，FailedElementNumber 为其在请求中的下标
+type jiraBulkError struct {
+	FailedElementNumber int `json:"failedElementNumber"`
+	ElementErrors       struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	} `json:"elementErrors"`
+}
+
+// Publish 实现 Publisher：按深度优先顺序为每个叶子节点创建问题，title 不使用
+func (j *Jira) Publish(ctx context.Context, title string, tree *extractor.Tree) (*Result, error) {
+	if j.Project == "" {
+		return nil, i18n.Errorf("创建Jira问题需要项目key")
+	}
+	if !j.DryRun && (j.BaseURL == "" || j.Token == "") {
+		return nil, i18n.Errorf("创建Jira问题需要地址和访问令牌")
+	}
+	updates := j.issues(tree)
+	if len(updates) == 0 {
+		return nil, i18n.Errorf("树中没有叶子节点，无需创建Jira问题")
+	}
+
+	result := &Result{Target: "jira", Action: ActionCreated}
+	if j.DryRun {
+		result.Action = ActionDryRun
+		for _, update := range updates {
+			result.Items = append(result.Items, Item{Title: update.Fields.Summary})
+		}
+		return result, nil
+	}
+
+	api := newClient(j.Client, j.authorize)
+	for start := 0; start < len(updates); start += jiraBulkLimit {
+		end := start + jiraBulkLimit
+		if end > len(updates) {
+			end = len(updates)
+		}
+		var created jiraBulkResult
+		body := map[string]interface{}{"issueUpdates": updates[start:end]}
+		if err := api.do(ctx, http.MethodPost, j.endpoint("/rest/api/2/issue/bulk"), body, &created); err != nil {
+			return nil, i18n.Errorf("已创建 %d 个Jira问题，创建其余问题失败: %w", len(result.Items), err)
+		}
+		failed := make(map[int]bool, len(created.Errors))
+		for _, e := range created.Errors {
+			failed[e.FailedElementNumber] = true
+		}
+		summaries := make([]string, 0, end-start)
+		for i, update := range updates[start:end] {
+			if !failed[i] {
+				summaries = append(summaries, update.Fields.Summary)
+			}
+		}
+		for i, issue := range created.Issues {
+			item := Item{ID: issue.Key, URL: j.endpoint("/browse/" + issue.Key)}
+			if i < len(summaries) {
+				item.Title = summaries[i]
+			}
+			result.Items = append(result.Items, item)
+		}
+		if len(created.Errors) > 0 {
+			e := created.Errors[0]
+			return nil, i18n.Errorf("已创建 %d 个Jira问题，%d 个创建失败，第一个失败: %s", len(result.Items), len(created.Errors), jiraErrorText(e.ElementErrors.ErrorMessages, e.ElementErrors.Errors))
+		}
+	}
+	return result, nil
+}
+
+// issues 为每个叶子节点生成创建问题的字段
+func (j *Jira) issues(tree *extractor.Tree) []jiraIssueUpdate {
+	issueType := j.IssueType
+	if issueType == "" {
+		issueType = JiraIssueType
+		if j.Parent != "" {
+			issueType = JiraSubTaskType
+		}
+	}
+
+	var updates []jiraIssueUpdate
+	for _, l := range leaves(tree) {
+		summary := strings.TrimSpace(l.name)
+		if len(l.path) > 0 {
+			summary = "[" + strings.Join(l.path, " > ") + "] " + summary
+		}
+		fields := jiraFields{
+			Project:     jiraRef{Key: j.Project},
+			IssueType:   jiraName{Name: issueType},
+			Summary:     truncate(summary, jiraSummaryLimit),
+			Description: strings.Join(append(l.path, l.name), " > "),
+		}
+		if j.Parent != "" {
+			fields.Parent = &jiraRef{Key: j.Parent}
+		}
+		updates = append(updates, jiraIssueUpdate{Fields: fields})
+	}
+	return updates
+}
+
+// authorize 设置认证请求头
+func (j *Jira) authorize(req *http.Request) {
+	if j.User != "" {
+		req.SetBasicAuth(j.User, j.Token)
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+j.Token)
+}
+
+func (j *Jira) endpoint(path string) string {
+	return strings.TrimRight(j.BaseURL, "/") + path
+}
+
+// jiraErrorText 将Jira返回的错误信息合并为一行
+func jiraErrorText(messages []string, fields map[string]string) string {
+	parts := append([]string{}, messages...)
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	for _, field := range names {
+		parts = append(parts, fmt.Sprintf("%s: %s", field, fields[field]))
+	}
+	return strings.Join(parts, "; ")
+}
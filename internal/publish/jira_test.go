@@ -0,0 +1,107 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+func jiraTestTree() *extractor.Tree {
+	tree := &extractor.Tree{}
+	json.Unmarshal([]byte(`[{"name":"客户详情","children":[{"name":"门店搜索","children":[{"name":"输入存在的名称","children":[]},{"name":"失败","children":[]}]},{"name":"门店列表","children":[]}]}]`), tree)
+	return tree
+}
+
+func TestJira_Publish(t *testing.T) {
+	var requests []jiraIssueUpdate
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue/bulk" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		var body struct {
+			IssueUpdates []jiraIssueUpdate `json:"issueUpdates"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		var result jiraBulkResult
+		for i, update := range body.IssueUpdates {
+			requests = append(requests, update)
+			if strings.HasSuffix(update.Fields.Summary, "失败") {
+				failure := jiraBulkError{FailedElementNumber: i}
+				failure.ElementErrors.Errors = map[string]string{"summary": "invalid"}
+				result.Errors = append(result.Errors, failure)
+				continue
+			}
+			result.Issues = append(result.Issues, jiraIssue{ID: fmt.Sprint(10000 + len(requests)), Key: fmt.Sprintf("QA-%d", len(requests))})
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(result)
+	}))
+	defer server.Close()
+
+	j := &Jira{BaseURL: server.URL + "/", Project: "QA", Parent: "QA-1", User: "qa@example.com", Token: "secret"}
+	_, err := j.Publish(context.Background(), "", jiraTestTree())
+	if err == nil || !strings.Contains(err.Error(), "summary: invalid") {
+		t.Fatalf("Publish() error = %v, want partial failure", err)
+	}
+	if len(requests) != 3 || !strings.HasPrefix(auth, "Basic ") {
+		t.Fatalf("requests = %+v, auth = %s", requests, auth)
+	}
+	first := requests[0].Fields
+	if first.Summary != "[客户详情 > 门店搜索] 输入存在的名称" || first.Description != "客户详情 > 门店搜索 > 输入存在的名称" ||
+		first.IssueType.Name != JiraSubTaskType || first.Parent == nil || first.Parent.Key != "QA-1" || first.Project.Key != "QA" {
+		t.Errorf("fields = %+v", first)
+	}
+
+	// 没有失败的节点时返回创建的问题
+	requests = nil
+	j = &Jira{BaseURL: server.URL, Project: "QA", Token: "secret"}
+	tree := jiraTestTree()
+	tree.Roots[0].Children[0].Children = tree.Roots[0].Children[0].Children[:1]
+	result, err := j.Publish(context.Background(), "", tree)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	want := []Item{
+		{ID: "QA-1", Title: "[客户详情 > 门店搜索] 输入存在的名称", URL: server.URL + "/browse/QA-1"},
+		{ID: "QA-2", Title: "[客户详情] 门店列表", URL: server.URL + "/browse/QA-2"},
+	}
+	if result.Action != ActionCreated || fmt.Sprint(result.Items) != fmt.Sprint(want) {
+		t.Errorf("Publish() = %+v, want items %+v", result, want)
+	}
+	if requests[0].Fields.IssueType.Name != JiraIssueType || requests[0].Fields.Parent != nil || auth != "Bearer secret" {
+		t.Errorf("fields = %+v, auth = %s", requests[0].Fields, auth)
+	}
+}
+
+func TestJira_DryRun(t *testing.T) {
+	j := &Jira{Project: "QA", IssueType: "Story", DryRun: true}
+	result, err := j.Publish(context.Background(), "", jiraTestTree())
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if result.Action != ActionDryRun || len(result.Items) != 3 || result.Items[2].Title != "[客户详情] 门店列表" || result.Items[2].ID != "" {
+		t.Errorf("Publish() = %+v", result)
+	}
+
+	long := &extractor.Tree{Roots: []*extractor.SimplifiedNode{{Name: strings.Repeat("长", 300)}}}
+	result, err = j.Publish(context.Background(), "", long)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if n := len([]rune(result.Items[0].Title)); n != jiraSummaryLimit {
+		t.Errorf("summary length = %d, want %d", n, jiraSummaryLimit)
+	}
+
+	if _, err := (&Jira{DryRun: true}).Publish(context.Background(), "", long); err == nil {
+		t.Error("Publish() without project error = nil, want error")
+	}
+}
@@ -0,0 +1,155 @@
+// Package publish 将抽取到的树发布到团队使用的外部系统（如Confluence），
+// 各发布目标实现 Publisher 接口，由命令行的 --publish 参数选择
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// 发布动作
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDryRun  = "dry-run" // 只演练，没有调用写入接口
+)
+
+// defaultTimeout 发布请求的默认超时
+const defaultTimeout = 30 * time.Second
+
+// Publisher 发布目标
+type Publisher interface {
+	// Publish 以title为标题发布树，已存在同名页面时更新
+	Publish(ctx context.Context, title string, tree *extractor.Tree) (*Result, error)
+}
+
+// Result 发布结果
+type Result struct {
+	Target string `json:"target"`
+	Action string `json:"action"` // created、updated 或 dry-run
+	ID     string `json:"id,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Items  []Item `json:"items,omitempty"` // 按节点创建的条目（如Jira问题）
+}
+
+// Item 发布时创建的单个条目，演练时没有ID和地址
+type Item struct {
+	ID    string `json:"id,omitempty"`
+	Kind  string `json:"kind,omitempty"` // 条目类型，如TestRail的 section 或 case
+	Title string `json:"title"`
+	Path  string `json:"path,omitempty"` // 对应节点从根节点开始的路径
+	URL   string `json:"url,omitempty"`
+}
+
+// Title 返回默认的发布标题：单根树使用根节点名称，否则返回空字符串
+func Title(tree *extractor.Tree) string {
+	if len(tree.Roots) == 1 && tree.Roots[0] != nil {
+		return strings.TrimSpace(tree.Roots[0].Name)
+	}
+	return ""
+}
+
+// leaf 叶子节点及其从根节点开始的祖先路径
+type leaf struct {
+	path []string // 祖先节点名称，不含叶子本身
+	name string
+}
+
+// leaves 按深度优先顺序收集树中的叶子节点
+func leaves(tree *extractor.Tree) []leaf {
+	var result []leaf
+	var walk func(nodes []*extractor.SimplifiedNode, path []string)
+	walk = func(nodes []*extractor.SimplifiedNode, path []string) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			if len(node.Children) == 0 {
+				result = append(result, leaf{path: append([]string{}, path...), name: node.Name})
+				continue
+			}
+			walk(node.Children, append(path, node.Name))
+		}
+	}
+	walk(tree.Roots, nil)
+	return result
+}
+
+// truncate 将文本截断为最多limit个字符
+func truncate(text string, limit int) string {
+	if runes := []rune(text); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
+	}
+	return text
+}
+
+// client 发送JSON请求的HTTP客户端，供各发布目标共用
+type client struct {
+	http *http.Client
+	auth func(req *http.Request)
+}
+
+func newClient(httpClient *http.Client, auth func(req *http.Request)) *client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	return &client{http: httpClient, auth: auth}
+}
+
+// do 发送请求，body 非nil时序列化为JSON请求体，out 非nil时将响应解析到out；非2xx响应返回包含响应片段的错误
+func (c *client) do(ctx context.Context, method, url string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return i18n.Errorf("创建HTTP请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.auth != nil {
+		c.auth(req)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return i18n.Errorf("HTTP请求执行失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return i18n.Errorf("读取响应体失败: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return i18n.Errorf("%s %s 返回HTTP %d: %s", method, url, resp.StatusCode, snippet(content))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(content, out); err != nil {
+		return i18n.Errorf("解析 %s 的响应失败: %w", url, err)
+	}
+	return nil
+}
+
+// snippet 截取响应体开头用于错误信息
+func snippet(content []byte) string {
+	return truncate(strings.TrimSpace(string(content)), 300)
+}
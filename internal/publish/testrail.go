@@ -0,0 +1,263 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// TestRail条目类型
+const (
+	KindSection = "section"
+	KindCase    = "case"
+)
+
+// testrailNameLimit 分组名称与用例标题的最大长度
+const testrailNameLimit = 250
+
+// TestRail 通过API v2按树的层级创建分组和用例：有子节点的节点创建为分组，叶子节点创建为所在分组中的用例；
+// 同一父分组下已有同名分组、同一分组中已有同标题用例时直接复用，重复上传不会产生重复数据
+type TestRail struct {
+	BaseURL   string // TestRail地址，如 https://xxx.testrail.io
+	ProjectID int
+	SuiteID   int    // 用例库ID，单用例库模式的项目可以为0
+	SectionID int    // 父分组ID，为0时根节点创建为顶层分组
+	User      string // 账号邮箱，与Token组成Basic认证
+	Token     string // 密码或API key
+	DryRun    bool   // 只列出将要创建的分组和用例，不调用接口
+	Client    *http.Client
+}
+
+type testrailSection struct {
+	ID       int    `json:"id,omitempty"`
+	SuiteID  int    `json:"suite_id,omitempty"`
+	ParentID int    `json:"parent_id,omitempty"` // 顶层分组为null，解析为0
+	Name     string `json:"name"`
+}
+
+type testrailCase struct {
+	ID        int    `json:"id,omitempty"`
+	SectionID int    `json:"section_id,omitempty"`
+	Title     string `json:"title"`
+}
+
+// testrailKey 同一父分组下的名称
+type testrailKey struct {
+	parent int
+	name   string
+}
+
+// testrailUpload 单次上传的状态
+type testrailUpload struct {
+	*TestRail
+	api      *client
+	sections map[testrailKey]int  // 已有分组 -> ID
+	cases    map[testrailKey]bool // 已有用例（分组ID与标题）
+	result   *Result
+	title    string
+}
+
+// Publish 实现 Publisher：根节点为叶子节点时，以title为名称创建其所在的顶层分组
+func (t *TestRail) Publish(ctx context.Context, title string, tree *extractor.Tree) (*Result, error) {
+	if t.ProjectID <= 0 {
+		return nil, i18n.Errorf("上传到TestRail需要项目ID")
+	}
+	if !t.DryRun && (t.BaseURL == "" || t.User == "" || t.Token == "") {
+		return nil, i18n.Errorf("上传到TestRail需要地址、账号和API key")
+	}
+
+	u := &testrailUpload{
+		TestRail: t,
+		api:      newClient(t.Client, func(req *http.Request) { req.SetBasicAuth(t.User, t.Token) }),
+		sections: make(map[testrailKey]int),
+		cases:    make(map[testrailKey]bool),
+		result:   &Result{Target: "testrail", Action: ActionCreated},
+		title:    title,
+	}
+	if t.DryRun {
+		u.result.Action = ActionDryRun
+	} else {
+		if err := u.load(ctx); err != nil {
+			return nil, err
+		}
+		u.result.URL = t.endpoint(fmt.Sprintf("/index.php?/projects/overview/%d", t.ProjectID))
+		if t.SuiteID > 0 {
+			u.result.ID = fmt.Sprint(t.SuiteID)
+			u.result.URL = t.endpoint(fmt.Sprintf("/index.php?/suites/view/%d", t.SuiteID))
+		}
+	}
+
+	for _, root := range tree.Roots {
+		if root == nil {
+			continue
+		}
+		if err := u.upload(ctx, root, t.SectionID, nil); err != nil {
+			return nil, err
+		}
+	}
+	return u.result, nil
+}
+
+// upload 创建节点对应的分组或用例
+func (u *testrailUpload) upload(ctx context.Context, node *extractor.SimplifiedNode, parent int, path []string) error {
+	if len(node.Children) == 0 {
+		if len(path) == 0 {
+			if u.title == "" {
+				return i18n.Errorf("根节点 %q 没有子节点，请通过 --publish-title 指定其所在分组的名称", node.Name)
+			}
+			var err error
+			if parent, err = u.section(ctx, parent, u.title, nil); err != nil {
+				return err
+			}
+		}
+		return u.addCase(ctx, parent, node.Name, path)
+	}
+
+	id, err := u.section(ctx, parent, node.Name, path)
+	if err != nil {
+		return err
+	}
+	path = append(path, node.Name)
+	for _, child := range node.Children {
+		if child == nil {
+			continue
+		}
+		if err := u.upload(ctx, child, id, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// section 返回父分组下名为name的分组ID，不存在时创建
+func (u *testrailUpload) section(ctx context.Context, parent int, name string, path []string) (int, error) {
+	name = truncate(strings.TrimSpace(name), testrailNameLimit)
+	key := testrailKey{parent, name}
+	if id, ok := u.sections[key]; ok {
+		return id, nil
+	}
+	item := Item{Kind: KindSection, Title: name, Path: strings.Join(append(path, name), " > ")}
+
+	// 演练时以负数作为新分组的ID，使同名分组只列出一次
+	id := -len(u.sections) - 1
+	if !u.DryRun {
+		var created testrailSection
+		body := testrailSection{SuiteID: u.SuiteID, ParentID: parent, Name: name}
+		if err := u.api.do(ctx, http.MethodPost, u.method(fmt.Sprintf("add_section/%d", u.ProjectID)), body, &created); err != nil {
+			return 0, i18n.Errorf("创建TestRail分组 %q 失败: %w", item.Path, err)
+		}
+		id = created.ID
+		item.ID = fmt.Sprint(id)
+		item.URL = u.endpoint(fmt.Sprintf("/index.php?/suites/view/%d&group_id=%d", u.SuiteID, id))
+	}
+	u.sections[key] = id
+	u.result.Items = append(u.result.Items, item)
+	return id, nil
+}
+
+// addCase 在分组中创建用例，已有同标题用例时跳过
+func (u *testrailUpload) addCase(ctx context.Context, section int, title string, path []string) error {
+	title = truncate(strings.TrimSpace(title), testrailNameLimit)
+	key := testrailKey{section, title}
+	if u.cases[key] {
+		return nil
+	}
+	item := Item{Kind: KindCase, Title: title, Path: strings.Join(append(path, title), " > ")}
+	if !u.DryRun {
+		var created testrailCase
+		if err := u.api.do(ctx, http.MethodPost, u.method(fmt.Sprintf("add_case/%d", section)), testrailCase{Title: title}, &created); err != nil {
+			return i18n.Errorf("创建TestRail用例 %q 失败: %w", item.Path, err)
+		}
+		item.ID = fmt.Sprintf("C%d", created.ID)
+		item.URL = u.endpoint(fmt.Sprintf("/index.php?/cases/view/%d", created.ID))
+	}
+	u.cases[key] = true
+	u.result.Items = append(u.result.Items, item)
+	return nil
+}
+
+// load 读取用例库中已有的分组和用例
+func (u *testrailUpload) load(ctx context.Context) error {
+	filter := ""
+	if u.SuiteID > 0 {
+		filter = fmt.Sprintf("&suite_id=%d", u.SuiteID)
+	}
+
+	var sections []testrailSection
+	if err := u.list(ctx, fmt.Sprintf("get_sections/%d%s", u.ProjectID, filter), "sections", &sections); err != nil {
+		return i18n.Errorf("读取TestRail分组失败: %w", err)
+	}
+	for _, s := range sections {
+		u.sections[testrailKey{s.ParentID, s.Name}] = s.ID
+	}
+
+	var cases []testrailCase
+	if err := u.list(ctx, fmt.Sprintf("get_cases/%d%s", u.ProjectID, filter), "cases", &cases); err != nil {
+		return i18n.Errorf("读取TestRail用例失败: %w", err)
+	}
+	for _, c := range cases {
+		u.cases[testrailKey{c.SectionID, c.Title}] = true
+	}
+	return nil
+}
+
+// list 读取列表接口的全部结果：TestRail 6.7 起返回带 _links.next 的分页对象，更早的版本直接返回数组
+func (u *testrailUpload) list(ctx context.Context, method, key string, out interface{}) error {
+	var all []json.RawMessage
+	next := u.method(method)
+	for next != "" {
+		var raw json.RawMessage
+		if err := u.api.do(ctx, http.MethodGet, next, nil, &raw); err != nil {
+			return err
+		}
+		if trimmed := bytes.TrimSpace(raw); len(trimmed) > 0 && trimmed[0] == '[' {
+			return json.Unmarshal(trimmed, out)
+		}
+
+		var page struct {
+			Links struct {
+				Next string `json:"next"`
+			} `json:"_links"`
+		}
+		var items map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &page); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		var batch []json.RawMessage
+		if content, ok := items[key]; ok {
+			if err := json.Unmarshal(content, &batch); err != nil {
+				return err
+			}
+		}
+		all = append(all, batch...)
+
+		// next 为 /api/v2/get_cases/1&limit=250&offset=250 形式的相对地址
+		next = ""
+		if page.Links.Next != "" {
+			next = u.endpoint("/index.php?/" + strings.TrimPrefix(page.Links.Next, "/"))
+		}
+	}
+	content, err := json.Marshal(all)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(content, out)
+}
+
+// method 返回API v2方法的地址
+func (t *TestRail) method(name string) string {
+	return t.endpoint("/index.php?/api/v2/" + name)
+}
+
+func (t *TestRail) endpoint(path string) string {
+	return strings.TrimRight(t.BaseURL, "/") + path
+}
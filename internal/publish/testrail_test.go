@@ -0,0 +1,132 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// fakeTestRail 在内存中保存分组和用例的TestRail API，get_cases 使用分页响应，get_sections 使用旧版的数组响应
+type fakeTestRail struct {
+	mu       sync.Mutex
+	sections []testrailSection
+	cases    []testrailCase
+}
+
+func (f *fakeTestRail) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if user, key, ok := r.BasicAuth(); !ok || user != "qa@example.com" || key != "secret" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// 地址形如 index.php?/api/v2/get_cases/1&suite_id=2&offset=1
+	method, query, _ := strings.Cut(strings.TrimPrefix(r.URL.RawQuery, "/api/v2/"), "&")
+	params, _ := url.ParseQuery(query)
+	name, arg, _ := strings.Cut(method, "/")
+	id, _ := strconv.Atoi(arg)
+	switch {
+	case r.Method == http.MethodGet && name == "get_sections":
+		json.NewEncoder(w).Encode(f.sections)
+	case r.Method == http.MethodGet && name == "get_cases":
+		// 每页一个用例
+		offset, _ := strconv.Atoi(params.Get("offset"))
+		page := map[string]interface{}{"cases": f.cases[offset:min(offset+1, len(f.cases))]}
+		if offset+1 < len(f.cases) {
+			page["_links"] = map[string]interface{}{"next": fmt.Sprintf("/api/v2/get_cases/%d&limit=1&offset=%d", id, offset+1)}
+		}
+		json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodPost && name == "add_section":
+		var s testrailSection
+		json.NewDecoder(r.Body).Decode(&s)
+		s.ID = len(f.sections) + 1
+		f.sections = append(f.sections, s)
+		json.NewEncoder(w).Encode(s)
+	case r.Method == http.MethodPost && name == "add_case":
+		var c testrailCase
+		json.NewDecoder(r.Body).Decode(&c)
+		c.ID, c.SectionID = len(f.cases)+100, id
+		f.cases = append(f.cases, c)
+		json.NewEncoder(w).Encode(c)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestTestRail_Publish(t *testing.T) {
+	fake := &fakeTestRail{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	tr := &TestRail{BaseURL: server.URL, ProjectID: 1, SuiteID: 2, User: "qa@example.com", Token: "secret"}
+	result, err := tr.Publish(context.Background(), "", jiraTestTree())
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	var got []string
+	for _, item := range result.Items {
+		got = append(got, item.Kind+" "+item.ID+" "+item.Path)
+	}
+	want := []string{
+		"section 1 客户详情",
+		"section 2 客户详情 > 门店搜索",
+		"case C100 客户详情 > 门店搜索 > 输入存在的名称",
+		"case C101 客户详情 > 门店搜索 > 失败",
+		"case C102 客户详情 > 门店列表",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("items =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if fake.sections[1].ParentID != 1 || fake.sections[0].SuiteID != 2 || fake.cases[2].SectionID != 1 {
+		t.Errorf("sections = %+v, cases = %+v", fake.sections, fake.cases)
+	}
+	if result.URL != server.URL+"/index.php?/suites/view/2" || result.Items[2].URL != server.URL+"/index.php?/cases/view/100" {
+		t.Errorf("urls = %s, %s", result.URL, result.Items[2].URL)
+	}
+
+	// 再次上传时复用已有分组和用例，只创建新增的节点
+	tree := jiraTestTree()
+	tree.Roots[0].Children[1].Name = "门店详情"
+	result, err = tr.Publish(context.Background(), "", tree)
+	if err != nil {
+		t.Fatalf("Publish() again error = %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Path != "客户详情 > 门店详情" || len(fake.sections) != 2 || len(fake.cases) != 4 {
+		t.Errorf("Publish() again = %+v, sections = %d, cases = %d", result.Items, len(fake.sections), len(fake.cases))
+	}
+
+	tr.Token = "wrong"
+	if _, err := tr.Publish(context.Background(), "", tree); err == nil {
+		t.Error("Publish() with wrong key error = nil, want error")
+	}
+}
+
+func TestTestRail_DryRun(t *testing.T) {
+	tr := &TestRail{ProjectID: 1, DryRun: true}
+	leaf := &extractor.Tree{Roots: []*extractor.SimplifiedNode{{Name: "冒烟"}, {Name: "回归"}}}
+	if _, err := tr.Publish(context.Background(), "", leaf); err == nil {
+		t.Error("Publish() leaf root without title error = nil, want error")
+	}
+
+	result, err := tr.Publish(context.Background(), "测试计划", leaf)
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	var got []string
+	for _, item := range result.Items {
+		got = append(got, item.Kind+" "+item.ID+item.Path)
+	}
+	if result.Action != ActionDryRun || strings.Join(got, ",") != "section 测试计划,case 冒烟,case 回归" {
+		t.Errorf("Publish() = %s, %v", result.Action, got)
+	}
+}
@@ -0,0 +1,226 @@
+// Package redact 判断并隐藏请求中的凭据，供详细日志、运行报告、调试包与响应头文件统一使用；
+// 除内置的名称规则外，可以用 --redact-rules 指定的规则文件补充请求头名称、请求体JSONPath与正则表达式
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+)
+
+// Redacted 敏感值的替代文本
+const Redacted = "REDACTED"
+
+// Rules 规则文件的内容，YAML或JSON格式：
+//
+//	headers: [x-tenant-key, x-sign]        # 额外视为凭据的请求头、查询参数或cookie名称，不区分大小写
+//	body_paths: ["$.password", "$.auth.token"] # 请求体JSON中需要隐藏的字段
+//	patterns: ["sk-[A-Za-z0-9]{20,}", "ticket=([^&]+)"] # 在任意文本中隐藏的内容，有分组时只隐藏分组
+type Rules struct {
+	Headers   []string `yaml:"headers" json:"headers"`
+	BodyPaths []string `yaml:"body_paths" json:"body_paths"`
+	Patterns  []string `yaml:"patterns" json:"patterns"`
+
+	headers   map[string]bool
+	bodyPaths []*jsonpath.Path
+	patterns  []*regexp.Regexp
+}
+
+// active 当前生效的自定义规则，nil 表示只使用内置规则；在处理请求之前由 Use 设置
+var active *Rules
+
+// Load 读取并解析规则文件
+func Load(path string) (*Rules, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, i18n.Errorf("读取脱敏规则文件失败: %w", err)
+	}
+	rules, err := Parse(content)
+	if err != nil {
+		return nil, i18n.Errorf("脱敏规则文件 %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+// Parse 解析YAML或JSON格式的规则
+func Parse(content []byte) (*Rules, error) {
+	rules := &Rules{}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	rules.headers = make(map[string]bool, len(rules.Headers))
+	for _, name := range rules.Headers {
+		rules.headers[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	for _, expr := range rules.BodyPaths {
+		path, err := jsonpath.Parse(expr)
+		if err != nil {
+			return nil, i18n.Errorf("无效的 body_paths %q: %w", expr, err)
+		}
+		rules.bodyPaths = append(rules.bodyPaths, path)
+	}
+	for _, expr := range rules.Patterns {
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, i18n.Errorf("无效的 patterns %q: %w", expr, err)
+		}
+		rules.patterns = append(rules.patterns, pattern)
+	}
+	return rules, nil
+}
+
+// Use 设置之后所有脱敏使用的自定义规则，nil 恢复为只使用内置规则
+func Use(rules *Rules) {
+	active = rules
+}
+
+// Sensitive 判断请求头、查询参数或cookie名称是否可能携带凭据
+func Sensitive(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "authorization", "proxy-authorization", "cookie", "x-api-key":
+		return true
+	}
+	for _, keyword := range []string{"token", "secret", "password", "passwd", "jwt", "session", "signature", "apikey", "api_key"} {
+		if strings.Contains(name, keyword) {
+			return true
+		}
+	}
+	return active != nil && active.headers[name]
+}
+
+// Body 隐藏请求体中 body_paths 指定的字段（请求体为JSON时）以及匹配 patterns 的内容
+func Body(body string) string {
+	if active == nil || body == "" {
+		return body
+	}
+	if len(active.bodyPaths) > 0 {
+		if data, ok := decodeJSON(body); ok {
+			changed := false
+			for _, path := range active.bodyPaths {
+				if _, err := path.Lookup(data); err != nil {
+					continue
+				}
+				if result, err := path.Set(data, Redacted); err == nil {
+					data, changed = result, true
+				}
+			}
+			if changed {
+				if encoded, err := json.Marshal(data); err == nil {
+					body = string(encoded)
+				}
+			}
+		}
+	}
+	return Text(body)
+}
+
+// BodySecrets 返回请求体中 body_paths 指定字段的值，用于在其他文件中全文替换
+func BodySecrets(body string) []string {
+	if active == nil || len(active.bodyPaths) == 0 {
+		return nil
+	}
+	data, ok := decodeJSON(body)
+	if !ok {
+		return nil
+	}
+	var secrets []string
+	for _, path := range active.bodyPaths {
+		value, err := path.Lookup(data)
+		if err != nil {
+			continue
+		}
+		switch v := value.(type) {
+		case string, json.Number:
+			secrets = append(secrets, fmt.Sprint(v))
+		}
+	}
+	return secrets
+}
+
+// Text 隐藏文本中匹配 patterns 的内容：正则表达式有分组时只替换各分组，否则替换整个匹配
+func Text(text string) string {
+	if active == nil {
+		return text
+	}
+	for _, pattern := range active.patterns {
+		text = replace(pattern, text)
+	}
+	return text
+}
+
+// URL 隐藏URL中的用户信息与敏感查询参数（名称规则同 Sensitive），无法解析时原样返回
+func URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	if u.User != nil {
+		u.User = url.User(Redacted)
+	}
+	query := u.Query()
+	changed := false
+	for key := range query {
+		if Sensitive(key) {
+			query.Set(key, Redacted)
+			changed = true
+		}
+	}
+	if changed {
+		u.RawQuery = query.Encode()
+	}
+	return u.String()
+}
+
+// replace 按 Text 的规则替换pattern在text中的全部匹配
+func replace(pattern *regexp.Regexp, text string) string {
+	matches := pattern.FindAllStringSubmatchIndex(text, -1)
+	if len(matches) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, match := range matches {
+		spans := [][2]int{{match[0], match[1]}}
+		if len(match) > 2 {
+			spans = spans[:0]
+			for i := 2; i+1 < len(match); i += 2 {
+				if match[i] >= 0 && match[i] >= last {
+					spans = append(spans, [2]int{match[i], match[i+1]})
+				}
+			}
+		}
+		for _, span := range spans {
+			b.WriteString(text[last:span[0]])
+			b.WriteString(Redacted)
+			last = span[1]
+		}
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+// decodeJSON 解析JSON请求体，数字保持原样
+func decodeJSON(body string) (interface{}, bool) {
+	decoder := json.NewDecoder(strings.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, false
+	}
+	return data, true
+}
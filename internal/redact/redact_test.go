@@ -0,0 +1,63 @@
+package redact
+
+import (
+	"reflect"
+	"testing"
+)
+
+const rulesYAML = `
+headers: [X-Tenant-Key]
+body_paths: ["$.auth.password", "$.pin", "$.missing"]
+patterns: ["sk-[A-Za-z0-9]{8,}", "ticket=([^&]+)"]
+`
+
+func TestRules(t *testing.T) {
+	rules, err := Parse([]byte(rulesYAML))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	Use(rules)
+	defer Use(nil)
+
+	for name, want := range map[string]bool{"x-tenant-key": true, "Authorization": true, "x-jwt-token": true, "Content-Type": false} {
+		if got := Sensitive(name); got != want {
+			t.Errorf("Sensitive(%q) = %v, want %v", name, got, want)
+		}
+	}
+
+	body := `{"auth":{"user":"a","password":"p@ss"},"pin":123456,"note":"key sk-abcdef123456"}`
+	want := `{"auth":{"password":"REDACTED","user":"a"},"note":"key REDACTED","pin":"REDACTED"}`
+	if got := Body(body); got != want {
+		t.Errorf("Body() = %s, want %s", got, want)
+	}
+	if got, want := BodySecrets(body), []string{"p@ss", "123456"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("BodySecrets() = %v, want %v", got, want)
+	}
+	if got, want := Text("a=1&ticket=abc&ticket=def"), "a=1&ticket=REDACTED&ticket=REDACTED"; got != want {
+		t.Errorf("Text() = %s, want %s", got, want)
+	}
+	if got := Body("ticket=abc"); got != "ticket=REDACTED" {
+		t.Errorf("Body(non-JSON) = %s", got)
+	}
+}
+
+func TestBuiltinOnly(t *testing.T) {
+	Use(nil)
+	if Sensitive("x-tenant-key") {
+		t.Error("Sensitive(x-tenant-key) = true without rules")
+	}
+	if got := Body(`{"password":"x"}`); got != `{"password":"x"}` {
+		t.Errorf("Body() = %s, want unchanged without rules", got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, content := range []string{"body_paths: [data]", `patterns: ["("]`, "unknown: 1", "headers: x: y"} {
+		if _, err := Parse([]byte(content)); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", content)
+		}
+	}
+	if rules, err := Parse(nil); err != nil || rules == nil {
+		t.Errorf("Parse(empty) = %v, %v", rules, err)
+	}
+}
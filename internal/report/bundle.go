@@ -0,0 +1,233 @@
+package report
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/redact"
+	"github.com/wellkilo/Curl2json/internal/validator"
+	"github.com/wellkilo/Curl2json/internal/version"
+)
+
+// minSecretLength 短于该长度的值不作为凭据全文替换，避免误伤普通文本（如 lang=zh）
+const minSecretLength = 6
+
+// 文本中可能携带凭据的 "名称: 值" 与 "名称=值" 片段，用于无法从解析结果得知凭据时（如cURL解析失败）兜底脱敏
+var (
+	secretHeaderPattern = regexp.MustCompile(`(?i)\b((?:proxy-)?authorization|cookie|x-api-key|[\w-]*(?:token|secret|password|jwt|session)[\w-]*)(\s*:\s*)([^'"\\\n]+)`)
+	secretPairPattern   = regexp.MustCompile(`(?i)\b([\w-]*(?:token|secret|password|passwd|jwt|session|signature|apikey|api_key)[\w-]*)=([^'"\\\s&;,]+)`)
+)
+
+// Bundle 在运行报告之外收集原始响应、调试日志与运行环境，打包为便于附在问题反馈中的zip文件；
+// 请求中出现的凭据值在包内所有文件中都替换为 REDACTED
+type Bundle struct {
+	logs        bytes.Buffer
+	secrets     map[string]bool
+	contentType string
+	body        []byte
+}
+
+// NewBundle 创建调试包收集器
+func NewBundle() *Bundle {
+	return &Bundle{secrets: make(map[string]bool)}
+}
+
+// Logger 返回在log之外同时以debug级别把日志记入调试包的日志器
+func (b *Bundle) Logger(log *slog.Logger) *slog.Logger {
+	return slog.New(logger.Tee(log.Handler(), logger.NewConsoleHandler(&b.logs, slog.LevelDebug)))
+}
+
+// Register 注册收集请求凭据与原始响应的钩子，阶段耗时等由 Recorder 记录
+func (b *Bundle) Register(hooks *pipeline.Hooks) {
+	hooks.After(pipeline.StageParse, func(ctx context.Context, state *pipeline.State) error {
+		b.collectSecrets(state)
+		return nil
+	})
+	hooks.After(pipeline.StageExecute, func(ctx context.Context, state *pipeline.State) error {
+		// 刷新认证后请求中的凭据可能已经更新
+		b.collectSecrets(state)
+		b.contentType, b.body = state.ContentType, state.Body
+		return nil
+	})
+}
+
+// collectSecrets 记录请求中需要脱敏的值
+func (b *Bundle) collectSecrets(state *pipeline.State) {
+	req := state.Request
+	if req == nil {
+		return
+	}
+	for key, value := range req.Headers {
+		if !redact.Sensitive(key) {
+			continue
+		}
+		b.addSecret(value)
+		// "Bearer xxx" 中的token也可能单独出现
+		if _, token, ok := strings.Cut(value, " "); ok {
+			b.addSecret(token)
+		}
+		if strings.EqualFold(key, "Cookie") {
+			for _, cookie := range (&http.Request{Header: http.Header{"Cookie": {value}}}).Cookies() {
+				b.addSecret(cookie.Value)
+			}
+		}
+	}
+	for _, value := range req.Cookies {
+		b.addSecret(value)
+	}
+	if req.Password != "" {
+		b.addSecret(req.Password)
+		b.addSecret(base64.StdEncoding.EncodeToString([]byte(req.User + ":" + req.Password)))
+	}
+	for _, value := range redact.BodySecrets(req.Body) {
+		b.addSecret(value)
+	}
+	if u, err := url.Parse(req.URL); err == nil {
+		if password, ok := u.User.Password(); ok {
+			b.addSecret(password)
+		}
+		for key, values := range u.Query() {
+			if redact.Sensitive(key) {
+				for _, value := range values {
+					b.addSecret(value)
+				}
+			}
+		}
+	}
+}
+
+func (b *Bundle) addSecret(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) >= minSecretLength {
+		b.secrets[value] = true
+	}
+}
+
+// mask 替换内容中的凭据
+func (b *Bundle) mask(content []byte) []byte {
+	secrets := make([]string, 0, len(b.secrets))
+	for secret := range b.secrets {
+		secrets = append(secrets, secret)
+	}
+	// 先替换较长的值，避免其中包含的较短凭据先被替换后长值无法匹配
+	sort.Slice(secrets, func(i, j int) bool { return len(secrets[i]) > len(secrets[j]) })
+	for _, secret := range secrets {
+		content = bytes.ReplaceAll(content, []byte(secret), []byte(redacted))
+	}
+	return content
+}
+
+// maskText 在 mask 之外按名称与脱敏规则文件中的正则表达式脱敏日志和命令行参数中的凭据片段
+func (b *Bundle) maskText(text string) string {
+	text = redact.Text(string(b.mask([]byte(text))))
+	text = secretHeaderPattern.ReplaceAllString(text, "${1}${2}"+redacted)
+	return secretPairPattern.ReplaceAllString(text, "${1}="+redacted)
+}
+
+// Environment 运行环境信息
+type Environment struct {
+	Version   string            `json:"version"`
+	GoVersion string            `json:"go_version"`
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	Lang      string            `json:"lang"`
+	Args      []string          `json:"args"`
+	Env       map[string]string `json:"env,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// bundleEnvVars 影响运行结果、需要记录的环境变量
+var bundleEnvVars = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY", "http_proxy", "https_proxy", "no_proxy", "CASEURL2MD_LANG", "LANG", "LC_ALL", "NO_COLOR"}
+
+// environment 收集运行环境，参数与代理地址均已脱敏
+func (b *Bundle) environment() Environment {
+	env := Environment{
+		Version:   version.Version,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+		Lang:      i18n.Lang(),
+		Env:       make(map[string]string),
+		CreatedAt: time.Now(),
+	}
+	for _, arg := range os.Args {
+		env.Args = append(env.Args, b.maskText(arg))
+	}
+	for _, name := range bundleEnvVars {
+		if value, ok := os.LookupEnv(name); ok {
+			if strings.Contains(strings.ToLower(name), "proxy") {
+				value = RedactURL(value)
+			}
+			env.Env[name] = value
+		}
+	}
+	return env
+}
+
+// bundleFile 调试包中的单个文件
+type bundleFile struct {
+	name    string
+	content []byte
+}
+
+// WriteFile 将报告、原始响应、调试日志与运行环境写入zip文件path
+//
+//	report.json       运行报告（见 Report）
+//	response.<格式>   原始响应体，扩展名按Content-Type确定
+//	log.txt           debug级别的完整日志，包含解析、校验和抽取过程中的警告
+//	environment.json  版本、系统、命令行参数与相关环境变量
+func (b *Bundle) WriteFile(path string, rep *Report) error {
+	reportContent, err := json.MarshalIndent(rep, "", "  ")
+	if err != nil {
+		return err
+	}
+	envContent, err := json.MarshalIndent(b.environment(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	files := []bundleFile{
+		{"report.json", b.mask(append(reportContent, '\n'))},
+		{"log.txt", []byte(b.maskText(b.logs.String()))},
+		{"environment.json", append(envContent, '\n')},
+	}
+	if b.body != nil {
+		name := "response." + string(validator.DetectFormat(b.contentType))
+		files = append(files, bundleFile{name, b.mask(b.body)})
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	now := time.Now()
+	for _, f := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: now})
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(f.content); err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return i18n.Errorf("写入调试包失败: %w", err)
+	}
+	return nil
+}
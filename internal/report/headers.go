@@ -0,0 +1,136 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+// Headers 响应头与 Set-Cookie 下发的cookie，通过 --save-headers 与结果一同写入文件，
+// 便于在后续的cURL中使用新的会话cookie
+type Headers struct {
+	URL          string              `json:"url"`
+	StatusCode   int                 `json:"status_code"`
+	Headers      map[string][]string `json:"headers"`
+	Cookies      []Cookie            `json:"cookies"`
+	CookieHeader string              `json:"cookie_header,omitempty"` // 可直接用于 --cookies 或 -b 的 name=value 列表，脱敏时省略
+	Redacted     bool                `json:"redacted,omitempty"`
+	SavedAt      time.Time           `json:"saved_at"`
+}
+
+// Cookie 响应通过 Set-Cookie 下发的cookie
+type Cookie struct {
+	Name     string     `json:"name"`
+	Value    string     `json:"value"`
+	Domain   string     `json:"domain,omitempty"`
+	Path     string     `json:"path,omitempty"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	MaxAge   int        `json:"max_age,omitempty"`
+	Secure   bool       `json:"secure,omitempty"`
+	HttpOnly bool       `json:"http_only,omitempty"`
+	SameSite string     `json:"same_site,omitempty"`
+}
+
+// NewHeaders 从 execute 阶段之后的状态收集响应头与cookie，hide 为true时隐藏cookie的值、
+// 可能携带凭据的响应头以及URL中的敏感参数
+func NewHeaders(state *pipeline.State, hide bool) *Headers {
+	h := &Headers{
+		StatusCode: state.StatusCode,
+		Headers:    make(map[string][]string, len(state.Header)),
+		Cookies:    []Cookie{},
+		Redacted:   hide,
+		SavedAt:    time.Now(),
+	}
+	if state.Request != nil {
+		h.URL = state.Request.URL
+		if hide {
+			h.URL = RedactURL(h.URL)
+		}
+	}
+
+	for name, values := range state.Header {
+		values = append([]string(nil), values...)
+		if hide && (redact.Sensitive(name) || strings.EqualFold(name, "Set-Cookie")) {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+		h.Headers[name] = values
+	}
+
+	pairs := make([]string, 0)
+	for _, c := range (&http.Response{Header: state.Header}).Cookies() {
+		cookie := Cookie{
+			Name:     c.Name,
+			Value:    c.Value,
+			Domain:   c.Domain,
+			Path:     c.Path,
+			MaxAge:   c.MaxAge,
+			Secure:   c.Secure,
+			HttpOnly: c.HttpOnly,
+			SameSite: sameSite(c.SameSite),
+		}
+		if !c.Expires.IsZero() {
+			expires := c.Expires
+			cookie.Expires = &expires
+		}
+		if hide {
+			cookie.Value = redacted
+		}
+		h.Cookies = append(h.Cookies, cookie)
+		// 过期的cookie表示删除，不放入可直接使用的列表
+		if c.MaxAge >= 0 && (c.Expires.IsZero() || c.Expires.After(h.SavedAt)) {
+			pairs = append(pairs, c.Name+"="+c.Value)
+		}
+	}
+	sort.SliceStable(h.Cookies, func(i, j int) bool { return h.Cookies[i].Name < h.Cookies[j].Name })
+	if !hide {
+		h.CookieHeader = strings.Join(pairs, "; ")
+	}
+	return h
+}
+
+// sameSite 将 SameSite 属性转换为 Set-Cookie 中的写法，未设置时为空
+func sameSite(mode http.SameSite) string {
+	switch mode {
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return ""
+}
+
+// WriteFile 将响应头写入JSON文件，未脱敏时文件只对当前用户可读
+func (h *Headers) WriteFile(path string) error {
+	content, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return i18n.Errorf("响应头序列化失败: %w", err)
+	}
+	perm := os.FileMode(0o600)
+	if h.Redacted {
+		perm = 0o644
+	}
+	if err := os.WriteFile(path, content, perm); err != nil {
+		return i18n.Errorf("写入响应头文件失败: %w", err)
+	}
+	return nil
+}
+
+// HeadersHook 返回在 execute 阶段之后将响应头写入path的钩子，后续阶段失败时文件也已写入；
+// 监听模式下每轮覆盖上一轮的文件
+func HeadersHook(path string, hide bool) pipeline.Hook {
+	return func(ctx context.Context, state *pipeline.State) error {
+		return NewHeaders(state, hide).WriteFile(path)
+	}
+}
@@ -0,0 +1,150 @@
+package report
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/version"
+)
+
+// 清单中的产物类型
+const (
+	ArtifactOutput       = "output"        // --out 写入的结果文件
+	ArtifactReport       = "report"        // --report 运行报告
+	ArtifactDebugBundle  = "debug_bundle"  // --debug-bundle 调试包
+	ArtifactRawResponse  = "raw_response"  // --save-raw 原始响应
+	ArtifactHeaders      = "headers"       // --save-headers 响应头
+	ArtifactBatchSummary = "batch_summary" // 批量模式的汇总报告
+)
+
+// Manifest 一次运行的产物清单，用于审计生成的测试文档：每个产物的SHA-256、请求来源与工具版本
+type Manifest struct {
+	Tool        version.Info `json:"tool"`
+	GeneratedAt time.Time    `json:"generated_at"`
+	Sources     []Source     `json:"sources"`
+	Artifacts   []Artifact   `json:"artifacts"`
+}
+
+// Source 请求来源，只记录URL（含查询参数）的SHA-256，清单中不出现地址与凭据
+type Source struct {
+	Method    string `json:"method"`
+	URLSHA256 string `json:"url_sha256"`
+}
+
+// Artifact 一个产物文件
+type Artifact struct {
+	Kind   string `json:"kind"`
+	Path   string `json:"path"`
+	Bytes  int64  `json:"bytes"`
+	SHA256 string `json:"sha256"`
+}
+
+// ManifestRecorder 通过流水线钩子记录请求来源，并收集产物；可在批量模式的多个处理器中并发使用
+type ManifestRecorder struct {
+	mu        sync.Mutex
+	sources   []Source
+	artifacts []Artifact // 本地文件的哈希在写入清单时才计算
+}
+
+// NewManifest 创建产物清单记录器
+func NewManifest() *ManifestRecorder {
+	return &ManifestRecorder{}
+}
+
+// Register 注册在 parse 阶段之后记录请求来源的钩子
+func (m *ManifestRecorder) Register(hooks *pipeline.Hooks) {
+	hooks.After(pipeline.StageParse, func(ctx context.Context, state *pipeline.State) error {
+		if state.Request != nil {
+			sum := sha256.Sum256([]byte(state.Request.URL))
+			m.addSource(Source{Method: state.Request.Method, URLSHA256: hex.EncodeToString(sum[:])})
+		}
+		return nil
+	})
+}
+
+// addSource 记录请求来源，相同的来源只记录一次
+func (m *ManifestRecorder) addSource(source Source) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, s := range m.sources {
+		if s == source {
+			return
+		}
+	}
+	m.sources = append(m.sources, source)
+}
+
+// AddFile 记录本地产物文件，哈希在 WriteFile 时计算；path 为空时忽略
+func (m *ManifestRecorder) AddFile(kind, path string) {
+	if path == "" {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.artifacts = append(m.artifacts, Artifact{Kind: kind, Path: path})
+}
+
+// AddContent 记录无法从本地读取的产物（如上传到对象存储的结果），按写入的内容计算哈希
+func (m *ManifestRecorder) AddContent(kind, path string, content []byte) {
+	sum := sha256.Sum256(content)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.artifacts = append(m.artifacts, Artifact{Kind: kind, Path: path, Bytes: int64(len(content)), SHA256: hex.EncodeToString(sum[:])})
+}
+
+// WriteFile 计算各产物的哈希并将清单以缩进JSON写入path，产物按记录的顺序排列
+func (m *ManifestRecorder) WriteFile(path string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	manifest := Manifest{
+		Tool:        version.Get(),
+		GeneratedAt: time.Now().UTC(),
+		Sources:     m.sources,
+		Artifacts:   []Artifact{},
+	}
+	if manifest.Sources == nil {
+		manifest.Sources = []Source{}
+	}
+	for _, artifact := range m.artifacts {
+		if artifact.SHA256 == "" {
+			var err error
+			if artifact.Bytes, artifact.SHA256, err = hashFile(artifact.Path); err != nil {
+				return i18n.Errorf("计算产物 %s 的哈希失败: %w", artifact.Path, err)
+			}
+		}
+		manifest.Artifacts = append(manifest.Artifacts, artifact)
+	}
+
+	content, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return i18n.Errorf("写入产物清单失败: %w", err)
+	}
+	return nil
+}
+
+// hashFile 返回文件的字节数与SHA-256
+func hashFile(path string) (int64, string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, "", err
+	}
+	defer file.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, file)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,239 @@
+// Package report 记录单次运行的请求、响应、各阶段耗时与抽取结果，
+// 通过 --report 写入一个便于分享的JSON文件，排查问题时无需反复复现
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/keystyle"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/redact"
+	"github.com/wellkilo/Curl2json/internal/validator"
+	"github.com/wellkilo/Curl2json/internal/version"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// redacted 敏感值的替代文本
+const redacted = redact.Redacted
+
+// 运行状态
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+)
+
+// Report 单次运行的报告
+type Report struct {
+	Version     string        `json:"version"`
+	StartedAt   time.Time     `json:"started_at"`
+	DurationMs  int64         `json:"duration_ms"`
+	Status      string        `json:"status"`
+	ExitCode    int           `json:"exit_code"`
+	Error       string        `json:"error,omitempty"`
+	FailedStage string        `json:"failed_stage,omitempty"`
+	Request     *Request      `json:"request,omitempty"`
+	Trace       []string      `json:"trace,omitempty"` // 实际发送的每个请求的等效cURL命令，凭据已脱敏
+	Response    *Response     `json:"response,omitempty"`
+	Validation  *Validation   `json:"validation,omitempty"`
+	Extraction  *Extraction   `json:"extraction,omitempty"`
+	Stages      []StageTiming `json:"stages"`
+	Outputs     []string      `json:"outputs,omitempty"`
+}
+
+// Request 解析得到的请求，敏感的请求头、cookie和查询参数已脱敏
+type Request struct {
+	Method    string            `json:"method"`
+	URL       string            `json:"url"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	Cookies   []string          `json:"cookies,omitempty"` // 只保留cookie名称
+	BodyBytes int               `json:"body_bytes"`
+}
+
+// Response 响应概况
+type Response struct {
+	StatusCode  int    `json:"status_code"`
+	ContentType string `json:"content_type,omitempty"`
+	Bytes       int    `json:"bytes"`
+	DurationMs  int64  `json:"duration_ms"`
+}
+
+// Validation 响应校验结果
+type Validation struct {
+	Passed bool   `json:"passed"`
+	Format string `json:"format"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Extraction 树抽取结果
+type Extraction struct {
+	Strategy    string   `json:"strategy"`
+	TextProfile string   `json:"text_profile,omitempty"` // 使用的业务文本规则
+	Nodes       int      `json:"nodes"`
+	Leaves      int      `json:"leaves"`
+	Depth       int      `json:"depth"`
+	Warnings    []string `json:"warnings,omitempty"`
+
+	// Duplicates 因内容重复而合并的节点：保留节点的路径、出现次数与每次出现的JSONPath
+	Duplicates []extractor.Duplicate `json:"duplicates,omitempty"`
+
+	// DepthLimit 达到最大递归深度时被丢弃的子节点数与最深的截断位置
+	DepthLimit *extractor.DepthLimit `json:"depth_limit,omitempty"`
+}
+
+// StageTiming 单个阶段的耗时
+type StageTiming struct {
+	Stage      pipeline.Stage `json:"stage"`
+	DurationMs int64          `json:"duration_ms"`
+}
+
+// Recorder 通过流水线钩子收集报告内容，只记录一次运行，不能在多个goroutine中共用
+type Recorder struct {
+	report  Report
+	current pipeline.Stage
+	started time.Time
+	state   *pipeline.State // 执行阶段失败时也能取到已发送请求的记录
+}
+
+// New 创建报告记录器，开始计时
+func New() *Recorder {
+	return &Recorder{report: Report{
+		Version:   version.Version,
+		StartedAt: time.Now(),
+		Stages:    []StageTiming{},
+	}}
+}
+
+// Register 在各阶段前后注册记录钩子
+func (r *Recorder) Register(hooks *pipeline.Hooks) {
+	for _, stage := range pipeline.Stages() {
+		stage := stage
+		hooks.Before(stage, func(ctx context.Context, state *pipeline.State) error {
+			r.current, r.started, r.state = stage, time.Now(), state
+			return nil
+		})
+		hooks.After(stage, func(ctx context.Context, state *pipeline.State) error {
+			r.finishStage(state)
+			return nil
+		})
+	}
+}
+
+// finishStage 记录阶段耗时以及该阶段产生的数据
+func (r *Recorder) finishStage(state *pipeline.State) {
+	elapsed := time.Since(r.started).Milliseconds()
+	r.report.Stages = append(r.report.Stages, StageTiming{Stage: r.current, DurationMs: elapsed})
+
+	switch r.current {
+	case pipeline.StageParse:
+		r.report.Request = redactRequest(state)
+	case pipeline.StageExecute:
+		r.report.Response = &Response{
+			StatusCode:  state.StatusCode,
+			ContentType: state.ContentType,
+			Bytes:       len(state.Body),
+			DurationMs:  elapsed,
+		}
+	case pipeline.StageValidate:
+		r.report.Validation = &Validation{Passed: true, Format: string(validator.DetectFormat(state.ContentType))}
+	case pipeline.StageExtract:
+		if tree := state.Tree; tree != nil {
+			r.report.Extraction = &Extraction{
+				Strategy:    string(tree.Strategy),
+				TextProfile: tree.TextProfile,
+				Nodes:       tree.Stats.Nodes,
+				Leaves:      tree.Stats.Leaves,
+				Depth:       tree.Stats.Depth,
+				Warnings:    tree.Warnings,
+				Duplicates:  tree.Duplicates,
+				DepthLimit:  tree.DepthLimit,
+			}
+		}
+	}
+	r.current = ""
+}
+
+// AddOutput 记录写入的输出文件
+func (r *Recorder) AddOutput(path string) {
+	r.report.Outputs = append(r.report.Outputs, path)
+}
+
+// Finish 记录运行结果，err 为nil时表示成功；返回最终的报告
+func (r *Recorder) Finish(err error) *Report {
+	r.report.DurationMs = time.Since(r.report.StartedAt).Milliseconds()
+	if r.state != nil {
+		r.report.Trace = r.state.Trace
+	}
+	r.report.Status = StatusSuccess
+	if err == nil {
+		return &r.report
+	}
+
+	r.report.Status = StatusError
+	r.report.ExitCode = exitcode.From(err)
+	r.report.Error = err.Error()
+	// 已开始但没有结束的阶段即为失败的阶段
+	if r.current != "" {
+		r.report.FailedStage = string(r.current)
+		if r.current == pipeline.StageValidate && r.report.Response != nil {
+			r.report.Validation = &Validation{
+				Format: string(validator.DetectFormat(r.report.Response.ContentType)),
+				Error:  err.Error(),
+			}
+		}
+	}
+	return &r.report
+}
+
+// WriteFile 将报告以缩进JSON写入path，键名按 style 转换
+func (rep *Report) WriteFile(path string, style keystyle.Style) error {
+	content, err := json.MarshalIndent(rep, "", "  ")
+	if err == nil {
+		content, err = style.Convert(content)
+	}
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil {
+		return i18n.Errorf("写入运行报告失败: %w", err)
+	}
+	return nil
+}
+
+// redactRequest 复制请求信息并隐藏认证相关的值
+func redactRequest(state *pipeline.State) *Request {
+	req := state.Request
+	if req == nil {
+		return nil
+	}
+
+	out := &Request{
+		Method:    req.Method,
+		URL:       RedactURL(req.URL),
+		BodyBytes: len(req.Body),
+	}
+	if len(req.Headers) > 0 {
+		out.Headers = make(map[string]string, len(req.Headers))
+		for key, value := range req.Headers {
+			if redact.Sensitive(key) {
+				value = redacted
+			}
+			out.Headers[key] = value
+		}
+	}
+	for name := range req.Cookies {
+		out.Cookies = append(out.Cookies, name)
+	}
+	sort.Strings(out.Cookies)
+	return out
+}
+
+// RedactURL 隐藏URL中的用户信息与敏感查询参数，无法解析时原样返回，规则见 redact.URL
+func RedactURL(raw string) string {
+	return redact.URL(raw)
+}
@@ -0,0 +1,286 @@
+package report
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/internal/redact"
+)
+
+func runWithRecorder(t *testing.T, contentType, body string) (*Report, error) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+
+	p := processor.New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	recorder := New()
+	recorder.Register(p.Hooks())
+
+	_, err := p.Process(context.Background(), "", &config.RequestInfo{
+		URL:     server.URL + "/cases?id=1&access_token=abc",
+		Method:  "GET",
+		Headers: map[string]string{"Authorization": "Bearer secret", "Accept": "application/json"},
+		Cookies: map[string]string{"sid": "secret", "lang": "zh"},
+	})
+	return recorder.Finish(err), err
+}
+
+func TestRecorder_Success(t *testing.T) {
+	rep, err := runWithRecorder(t, "application/json", `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[]}]}"}}`)
+	if err != nil {
+		t.Fatalf("Process() error = %v", err)
+	}
+
+	if rep.Status != StatusSuccess || len(rep.Stages) != len(pipeline.Stages()) {
+		t.Errorf("Status = %s, Stages = %v", rep.Status, rep.Stages)
+	}
+	if rep.Response == nil || rep.Response.StatusCode != http.StatusOK {
+		t.Errorf("Response = %+v, want status 200", rep.Response)
+	}
+	if rep.Validation == nil || !rep.Validation.Passed || rep.Validation.Format != "json" {
+		t.Errorf("Validation = %+v", rep.Validation)
+	}
+	if rep.Extraction == nil || rep.Extraction.Strategy != "testcasemind" || rep.Extraction.Nodes != 2 {
+		t.Errorf("Extraction = %+v", rep.Extraction)
+	}
+
+	content, _ := json.Marshal(rep.Request)
+	for _, secret := range []string{"Bearer secret", "abc", `"secret"`} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("Request not redacted, contains %s: %s", secret, content)
+		}
+	}
+	if rep.Request.Headers["Accept"] != "application/json" || strings.Join(rep.Request.Cookies, ",") != "lang,sid" {
+		t.Errorf("Request = %s", content)
+	}
+}
+
+func TestRecorder_Failure(t *testing.T) {
+	rep, err := runWithRecorder(t, "text/html", "<html>请登录</html>")
+	if err == nil {
+		t.Fatal("Process() error = nil, want validation error")
+	}
+
+	if rep.Status != StatusError || rep.ExitCode != exitcode.Validation || rep.FailedStage != string(pipeline.StageValidate) {
+		t.Errorf("Status = %s, ExitCode = %d, FailedStage = %s", rep.Status, rep.ExitCode, rep.FailedStage)
+	}
+	if rep.Validation == nil || rep.Validation.Passed || rep.Validation.Format != "html" || rep.Validation.Error == "" {
+		t.Errorf("Validation = %+v", rep.Validation)
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := rep.WriteFile(path, ""); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	var decoded Report
+	if err := json.Unmarshal(content, &decoded); err != nil || decoded.FailedStage != rep.FailedStage {
+		t.Errorf("WriteFile() wrote %s, error = %v", content, err)
+	}
+}
+
+func TestBundle_WriteFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html>请登录 token=secret-cookie-value</html>")
+	}))
+	defer server.Close()
+
+	bundle := NewBundle()
+	log := bundle.Logger(logger.Discard())
+	p := processor.New(&config.Config{Timeout: 10 * time.Second, Logger: log})
+	recorder := New()
+	recorder.Register(p.Hooks())
+	bundle.Register(p.Hooks())
+
+	log.Debug("使用 --raw-curl 参数接收完整cURL命令", "curl", `curl -H 'x-jwt-token: jwt-secret-value' -H "Cookie: sid=secret-cookie-value"`)
+	_, err := p.Process(context.Background(), "", &config.RequestInfo{
+		URL:     server.URL + "/cases?access_token=query-secret-value",
+		Method:  "GET",
+		Headers: map[string]string{"x-jwt-token": "jwt-secret-value", "Cookie": "sid=secret-cookie-value; lang=zh"},
+	})
+	if err == nil {
+		t.Fatal("Process() error = nil, want validation error")
+	}
+
+	path := filepath.Join(t.TempDir(), "bundle.zip")
+	if err := bundle.WriteFile(path, recorder.Finish(err)); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	files := make(map[string]string)
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		files[f.Name] = string(content)
+	}
+
+	for _, name := range []string{"report.json", "log.txt", "environment.json", "response.html"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("bundle missing %s, got %v", name, archive.File)
+		}
+	}
+	for name, content := range files {
+		for _, secret := range []string{"jwt-secret-value", "secret-cookie-value", "query-secret-value"} {
+			if strings.Contains(content, secret) {
+				t.Errorf("%s contains %s:\n%s", name, secret, content)
+			}
+		}
+	}
+	if !strings.Contains(files["response.html"], "请登录") || !strings.Contains(files["log.txt"], "执行HTTP请求") {
+		t.Errorf("response = %s, log = %s", files["response.html"], files["log.txt"])
+	}
+}
+
+func TestBundle_RedactRules(t *testing.T) {
+	rules, err := redact.Parse([]byte("headers: [x-tenant]\nbody_paths: [$.pin]\npatterns: ['order-[0-9]+']\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	redact.Use(rules)
+	defer redact.Use(nil)
+
+	bundle := NewBundle()
+	bundle.collectSecrets(&pipeline.State{Request: &config.RequestInfo{
+		URL:     "http://example.com/?tenant=abc",
+		Headers: map[string]string{"X-Tenant": "tenant-secret"},
+		Body:    `{"pin":"pin-secret-value"}`,
+	}})
+	got := bundle.maskText("X-Tenant: tenant-secret, pin-secret-value, order-12345")
+	if want := "X-Tenant: REDACTED, REDACTED, REDACTED"; got != want {
+		t.Errorf("maskText() = %q, want %q", got, want)
+	}
+}
+
+func TestNewHeaders(t *testing.T) {
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	header.Set("X-Auth-Token", "token-value")
+	header.Add("Set-Cookie", "sid=session-value; Path=/; HttpOnly; SameSite=Lax")
+	header.Add("Set-Cookie", "csrftoken=csrf-value; Domain=example.com; Secure")
+	header.Add("Set-Cookie", "old=; Max-Age=0")
+	state := &pipeline.State{
+		Request:    &config.RequestInfo{URL: "https://example.com/cases?access_token=abc"},
+		StatusCode: http.StatusOK,
+		Header:     header,
+	}
+
+	h := NewHeaders(state, false)
+	if h.CookieHeader != "sid=session-value; csrftoken=csrf-value" {
+		t.Errorf("CookieHeader = %q", h.CookieHeader)
+	}
+	if len(h.Cookies) != 3 || h.Cookies[2].Name != "sid" || !h.Cookies[2].HttpOnly || h.Cookies[2].SameSite != "Lax" {
+		t.Errorf("Cookies = %+v", h.Cookies)
+	}
+	if h.Headers["X-Auth-Token"][0] != "token-value" || !strings.Contains(h.URL, "access_token=abc") {
+		t.Errorf("Headers = %v, URL = %s", h.Headers, h.URL)
+	}
+
+	path := filepath.Join(t.TempDir(), "headers.json")
+	if err := HeadersHook(path, true)(context.Background(), state); err != nil {
+		t.Fatalf("HeadersHook() error = %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	for _, secret := range []string{"session-value", "csrf-value", "token-value", "abc"} {
+		if strings.Contains(string(content), secret) {
+			t.Errorf("redacted headers contain %s:\n%s", secret, content)
+		}
+	}
+	if !strings.Contains(string(content), `"application/json"`) || strings.Contains(string(content), "cookie_header") {
+		t.Errorf("redacted headers = %s", content)
+	}
+}
+
+func TestManifestRecorder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情\"},\"children\":[]}"}}`)
+	}))
+	defer server.Close()
+
+	p := processor.New(&config.Config{Timeout: 10 * time.Second, Logger: logger.Discard()})
+	manifest := NewManifest()
+	manifest.Register(p.Hooks())
+	url := server.URL + "/cases?access_token=abc"
+	for i := 0; i < 2; i++ {
+		if _, err := p.Process(context.Background(), "", &config.RequestInfo{URL: url, Method: "GET"}); err != nil {
+			t.Fatalf("Process() error = %v", err)
+		}
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out.json")
+	if err := os.WriteFile(out, []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest.AddFile(ArtifactOutput, out)
+	manifest.AddFile(ArtifactReport, "")
+	manifest.AddContent(ArtifactOutput, "s3://bucket/out.json", []byte("hello"))
+	path := filepath.Join(dir, "manifest.json")
+	if err := manifest.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), "access_token") {
+		t.Errorf("manifest leaks the request URL: %s", content)
+	}
+	var got Manifest
+	if err := json.Unmarshal(content, &got); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	urlSum := sha256.Sum256([]byte(url))
+	if len(got.Sources) != 1 || got.Sources[0].Method != "GET" || got.Sources[0].URLSHA256 != hex.EncodeToString(urlSum[:]) {
+		t.Errorf("Sources = %+v, want one deduplicated GET source", got.Sources)
+	}
+	const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if len(got.Artifacts) != 2 {
+		t.Fatalf("Artifacts = %+v, want 2", got.Artifacts)
+	}
+	for _, artifact := range got.Artifacts {
+		if artifact.Kind != ArtifactOutput || artifact.Bytes != 5 || artifact.SHA256 != helloSHA256 {
+			t.Errorf("artifact = %+v, want output of 5 bytes with sha256 %s", artifact, helloSHA256)
+		}
+	}
+	if got.Tool.Version == "" || got.GeneratedAt.IsZero() {
+		t.Errorf("manifest = %+v, want tool version and generation time", got)
+	}
+
+	manifest.AddFile(ArtifactHeaders, filepath.Join(dir, "missing.json"))
+	if err := manifest.WriteFile(path); err == nil {
+		t.Error("WriteFile() with a missing artifact error = nil")
+	}
+}
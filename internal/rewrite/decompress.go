@@ -0,0 +1,103 @@
+package rewrite
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// base64Encodings 解码字段值时依次尝试的base64编码
+var base64Encodings = []*base64.Encoding{
+	base64.StdEncoding,
+	base64.RawStdEncoding,
+	base64.URLEncoding,
+	base64.RawURLEncoding,
+}
+
+// decodeField 还原先压缩再base64编码的字段值：按内容头识别 gzip、zlib 或 zip（取第一个文件），
+// 解压结果为JSON时解析为JSON值（数字保持原样），否则作为字符串返回
+func decodeField(value string) (interface{}, error) {
+	raw, err := decodeBase64(value)
+	if err != nil {
+		return nil, err
+	}
+	content, err := decompress(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil || decoder.More() {
+		return string(content), nil
+	}
+	return data, nil
+}
+
+// decodeBase64 按 base64Encodings 的顺序解码，忽略字段值中的换行
+func decodeBase64(value string) ([]byte, error) {
+	value = strings.Join(strings.Fields(value), "")
+	for _, encoding := range base64Encodings {
+		if raw, err := encoding.DecodeString(value); err == nil {
+			return raw, nil
+		}
+	}
+	return nil, i18n.Errorf("字段值不是有效的base64")
+}
+
+// decompress 按内容头识别压缩格式并解压
+func decompress(raw []byte) ([]byte, error) {
+	var content []byte
+	var err error
+	switch {
+	case bytes.HasPrefix(raw, []byte{0x1f, 0x8b}):
+		content, err = readAll(gzip.NewReader(bytes.NewReader(raw)))
+	case isZlib(raw):
+		content, err = readAll(zlib.NewReader(bytes.NewReader(raw)))
+	case bytes.HasPrefix(raw, []byte("PK\x03\x04")):
+		content, err = unzipFirst(raw)
+	default:
+		return nil, i18n.Errorf("无法识别的压缩格式（支持 gzip、zlib、zip）")
+	}
+	if err != nil {
+		return nil, i18n.Errorf("解压失败: %w", err)
+	}
+	return content, nil
+}
+
+// isZlib 检查zlib头：压缩方法为deflate，且前两个字节按大端序是31的倍数
+func isZlib(raw []byte) bool {
+	return len(raw) >= 2 && raw[0]&0x0f == 8 && (uint16(raw[0])<<8|uint16(raw[1]))%31 == 0
+}
+
+// readAll 读取并关闭r，err 为创建r时的错误，不为nil时直接返回
+func readAll(r io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// unzipFirst 返回zip归档中第一个文件的内容
+func unzipFirst(raw []byte) ([]byte, error) {
+	archive, err := zip.NewReader(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		return nil, err
+	}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		return readAll(file.Open())
+	}
+	return nil, i18n.Errorf("zip归档中没有文件")
+}
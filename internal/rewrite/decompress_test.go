@@ -0,0 +1,108 @@
+package rewrite
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"testing"
+)
+
+const tree = `{"title":"根","id":12345678901234567890,"children":[{"title":"子"}]}`
+
+func gzipped(t *testing.T, content string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(content))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func zlibbed(t *testing.T, content string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zlib.NewWriter(&buf)
+	w.Write([]byte(content))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes())
+}
+
+func zipped(t *testing.T, content string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	w.Create("dir/")
+	f, err := w.Create("dir/tree.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte(content))
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func TestApply_Decompress(t *testing.T) {
+	want := `{"data":{"tree":{"children":[{"title":"子"}],"id":12345678901234567890,"title":"根"}}}`
+	tests := []struct {
+		name  string
+		rule  string
+		value string
+		want  string
+	}{
+		{"gzip", "$.data.tree", gzipped(t, tree), want},
+		{"zlib URL安全base64", "$.data.tree", zlibbed(t, tree), want},
+		{"zip第一个文件", "$.data.tree", zipped(t, tree), want},
+		{"任意层级", "$..tree", gzipped(t, tree), want},
+		{"非JSON内容保持为字符串", "$.data.tree", gzipped(t, "纯文本"), `{"data":{"tree":"纯文本"}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Decompress(tt.rule)
+			if err != nil {
+				t.Fatalf("Decompress() error = %v", err)
+			}
+			input := fmt.Sprintf(`{"data":{"tree":%q}}`, tt.value)
+			got, unmatched, err := Apply([]byte(input), []*Rule{rule})
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != tt.want || len(unmatched) != 0 {
+				t.Errorf("Apply() = %s, %v, want %s", got, unmatched, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply_DecompressEachElement(t *testing.T) {
+	rules, err := ParseAll([]string{"decompress:$.items[*].payload", "rename:$..title=name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := fmt.Sprintf(`{"items":[{"payload":%q},{"payload":1}]}`, gzipped(t, `{"title":"A"}`))
+	got, _, err := Apply([]byte(input), rules)
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if want := `{"items":[{"payload":{"name":"A"}},{"payload":1}]}`; string(got) != want {
+		t.Errorf("Apply() = %s, want %s", got, want)
+	}
+}
+
+func TestApply_DecompressInvalid(t *testing.T) {
+	for _, value := range []string{"不是base64", base64.StdEncoding.EncodeToString([]byte("plain"))} {
+		rule, _ := Decompress("$.tree")
+		input := fmt.Sprintf(`{"tree":%q}`, value)
+		if _, _, err := Apply([]byte(input), []*Rule{rule}); err == nil {
+			t.Errorf("Apply(%q) error = nil, want error", value)
+		}
+	}
+}
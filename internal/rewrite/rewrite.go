@@ -0,0 +1,339 @@
+// Package rewrite 解析并执行 --response-rewrite 规则，在抽取前删除、重命名、移动或解压响应JSON中的字段，
+// 例如 'delete:$.meta'、'rename:$..node_name=name'、'move:$.result=$.data'、'decompress:$.data.tree'
+package rewrite
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/jsonpath"
+)
+
+// 支持的操作
+const (
+	opDelete = "delete"
+	opRename = "rename"
+	opMove   = "move"
+	// opDecompress 将先压缩再base64编码的字符串字段还原为JSON，对应 --decompress-field
+	opDecompress = "decompress"
+)
+
+// Rule 一条重写规则
+type Rule struct {
+	expr string
+	op   string
+	// segments 源路径按 [*] 切分后的各段，第二段起相对于数组中的每个元素
+	segments []*jsonpath.Path
+	// recursiveKey 源路径为 $..key 时的键名，作用于任意层级中名为key的字段
+	recursiveKey string
+	newKey       string         // rename 的新键名
+	target       *jsonpath.Path // move 的目标路径
+}
+
+// Parse 解析一条规则：
+//
+//	delete:路径         删除对象键或数组元素
+//	rename:路径=新键名  将对象键改名，值与位置不变
+//	move:路径=目标路径  将值移动到目标路径，目标路径为 $ 时替换整个响应，源路径为 $ 时将整个响应放入目标路径
+//	decompress:路径     将先经 gzip、zlib 或 zip 压缩再base64编码的字符串还原，内容为JSON时替换为解析后的值
+//
+// delete、rename 与 decompress 的路径可以用 [*] 表示数组中的每个元素，或写作 $..key 表示任意层级中名为key的字段
+func Parse(expr string) (*Rule, error) {
+	op, rest, ok := strings.Cut(expr, ":")
+	if !ok {
+		return nil, i18n.Errorf("无效的响应重写规则 %q，格式应为 操作:路径，操作为 delete、rename、move 或 decompress", expr)
+	}
+	rule := &Rule{expr: expr, op: strings.ToLower(strings.TrimSpace(op))}
+
+	source := rest
+	switch rule.op {
+	case opDelete, opDecompress:
+	case opRename, opMove:
+		i := strings.LastIndex(rest, "=")
+		if i < 0 && rule.op == opRename {
+			return nil, i18n.Errorf("无效的响应重写规则 %q，格式应为 rename:路径=新键名", expr)
+		}
+		if i < 0 {
+			return nil, i18n.Errorf("无效的响应重写规则 %q，格式应为 move:路径=目标路径", expr)
+		}
+		source = rest[:i]
+		target := strings.TrimSpace(rest[i+1:])
+		if rule.op == opRename {
+			if target == "" {
+				return nil, i18n.Errorf("无效的响应重写规则 %q: 新键名为空", expr)
+			}
+			rule.newKey = target
+			break
+		}
+		path, err := jsonpath.Parse(target)
+		if err != nil {
+			return nil, i18n.Errorf("无效的响应重写规则 %q: %w", expr, err)
+		}
+		rule.target = path
+	default:
+		return nil, i18n.Errorf("无效的响应重写规则 %q: 不支持的操作 %s（可选 delete、rename、move、decompress）", expr, op)
+	}
+
+	if err := rule.parseSource(strings.TrimSpace(source)); err != nil {
+		return nil, i18n.Errorf("无效的响应重写规则 %q: %w", expr, err)
+	}
+	return rule, nil
+}
+
+// parseSource 解析源路径
+func (r *Rule) parseSource(source string) error {
+	if key, ok := strings.CutPrefix(source, "$.."); ok {
+		if r.op == opMove {
+			return i18n.Errorf("move 的路径不能使用 $..")
+		}
+		if key == "" || strings.ContainsAny(key, ".[]") {
+			return i18n.Errorf("$.. 之后应为单个键名: %s", source)
+		}
+		r.recursiveKey = key
+		return nil
+	}
+
+	parts := strings.Split(source, "[*]")
+	if len(parts) > 1 && r.op == opMove {
+		return i18n.Errorf("move 的路径不能使用 [*]")
+	}
+	for i, part := range parts {
+		if i > 0 {
+			part = "$" + part
+		}
+		path, err := jsonpath.Parse(part)
+		if err != nil {
+			return err
+		}
+		r.segments = append(r.segments, path)
+	}
+
+	last := r.segments[len(r.segments)-1]
+	switch {
+	case r.op == opRename:
+		if _, _, ok := last.Parent(); !ok {
+			return i18n.Errorf("rename 的路径必须以对象键结尾")
+		}
+	case r.op == opDelete && last.String() == "$":
+		return i18n.Errorf("不能删除整个响应")
+	case r.op == opDecompress && last.String() == "$":
+		return i18n.Errorf("decompress 的路径不能是整个响应")
+	}
+	return nil
+}
+
+// Decompress 返回解压path处字段的规则，等同于 Parse("decompress:" + path)
+func Decompress(path string) (*Rule, error) {
+	return Parse(opDecompress + ":" + path)
+}
+
+// ParseAll 解析全部规则，执行时按给定的顺序
+func ParseAll(exprs []string) ([]*Rule, error) {
+	rules := make([]*Rule, 0, len(exprs))
+	for _, expr := range exprs {
+		rule, err := Parse(expr)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// String 返回规则的原始表达式
+func (r *Rule) String() string {
+	return r.expr
+}
+
+// Apply 依次执行规则并返回重写后的响应JSON，以及没有匹配到任何字段的规则；
+// 路径不存在时跳过该规则，数字保持原样
+func Apply(body []byte, rules []*Rule) ([]byte, []*Rule, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, nil, i18n.Errorf("响应不是有效的JSON，无法重写: %w", err)
+	}
+
+	var unmatched []*Rule
+	for _, rule := range rules {
+		var matched int
+		var err error
+		data, matched, err = rule.apply(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		if matched == 0 {
+			unmatched = append(unmatched, rule)
+		}
+	}
+
+	result, err := json.Marshal(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, unmatched, nil
+}
+
+// apply 执行规则，返回修改后的数据与匹配的字段数
+func (r *Rule) apply(data interface{}) (interface{}, int, error) {
+	if r.recursiveKey != "" {
+		count, err := r.applyRecursive(data)
+		return data, count, err
+	}
+	if r.op == opMove {
+		return r.move(data)
+	}
+	return eachElement(data, r.segments, r.applyAt)
+}
+
+// eachElement 对 [*] 展开后的每个元素调用fn，segments只有一段时直接作用于data
+func eachElement(data interface{}, segments []*jsonpath.Path, fn func(interface{}, *jsonpath.Path) (interface{}, int, error)) (interface{}, int, error) {
+	if len(segments) == 1 {
+		return fn(data, segments[0])
+	}
+	value, err := segments[0].Lookup(data)
+	if err != nil {
+		return data, 0, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return data, 0, nil
+	}
+	total := 0
+	for i, item := range items {
+		item, n, err := eachElement(item, segments[1:], fn)
+		if err != nil {
+			return nil, 0, err
+		}
+		items[i] = item
+		total += n
+	}
+	return data, total, nil
+}
+
+// applyAt 在一个位置执行 delete、rename 或 decompress
+func (r *Rule) applyAt(data interface{}, path *jsonpath.Path) (interface{}, int, error) {
+	if r.op == opDecompress {
+		value, err := path.Lookup(data)
+		if err != nil {
+			return data, 0, nil
+		}
+		text, ok := value.(string)
+		if !ok {
+			return data, 0, nil
+		}
+		decoded, err := decodeField(text)
+		if err != nil {
+			return nil, 0, i18n.Errorf("%s: %w", r.expr, err)
+		}
+		result, err := path.Set(data, decoded)
+		return result, 1, err
+	}
+	if r.op == opDelete {
+		result, err := path.Delete(data)
+		if errors.Is(err, jsonpath.ErrNotFound) {
+			return data, 0, nil
+		}
+		return result, 1, err
+	}
+
+	parent, key, _ := path.Parent()
+	value, err := parent.Lookup(data)
+	if err != nil {
+		return data, 0, nil
+	}
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		return data, 0, nil
+	}
+	if !renameKey(obj, key, r.newKey) {
+		return data, 0, nil
+	}
+	return data, 1, nil
+}
+
+// applyRecursive 对任意层级中名为 recursiveKey 的字段执行 delete、rename 或 decompress，返回匹配的字段数；
+// 解压得到的值不再继续查找
+func (r *Rule) applyRecursive(data interface{}) (int, error) {
+	count := 0
+	switch v := data.(type) {
+	case map[string]interface{}:
+		decompressed := false
+		switch r.op {
+		case opDelete:
+			if _, exists := v[r.recursiveKey]; exists {
+				delete(v, r.recursiveKey)
+				count++
+			}
+		case opDecompress:
+			if text, ok := v[r.recursiveKey].(string); ok {
+				decoded, err := decodeField(text)
+				if err != nil {
+					return 0, i18n.Errorf("%s: %w", r.expr, err)
+				}
+				v[r.recursiveKey] = decoded
+				decompressed = true
+				count++
+			}
+		default:
+			if renameKey(v, r.recursiveKey, r.newKey) {
+				count++
+			}
+		}
+		for key, value := range v {
+			if decompressed && key == r.recursiveKey {
+				continue
+			}
+			n, err := r.applyRecursive(value)
+			if err != nil {
+				return 0, err
+			}
+			count += n
+		}
+	case []interface{}:
+		for _, item := range v {
+			n, err := r.applyRecursive(item)
+			if err != nil {
+				return 0, err
+			}
+			count += n
+		}
+	}
+	return count, nil
+}
+
+// renameKey 将obj中的键key改名为newKey，newKey已存在时被覆盖；key不存在时返回false
+func renameKey(obj map[string]interface{}, key, newKey string) bool {
+	value, exists := obj[key]
+	if !exists {
+		return false
+	}
+	delete(obj, key)
+	obj[newKey] = value
+	return true
+}
+
+// move 将源路径的值移动到目标路径，源路径不存在时不修改
+func (r *Rule) move(data interface{}) (interface{}, int, error) {
+	source := r.segments[0]
+	value, err := source.Lookup(data)
+	if err != nil {
+		return data, 0, nil
+	}
+
+	var rest interface{}
+	if source.String() != "$" {
+		if rest, err = source.Delete(data); err != nil {
+			return nil, 0, err
+		}
+	}
+	result, err := r.target.Set(rest, value)
+	if err != nil {
+		return nil, 0, i18n.Errorf("%s: 无法写入目标路径 %s", r.expr, r.target)
+	}
+	return result, 1, nil
+}
@@ -0,0 +1,87 @@
+package rewrite
+
+import "testing"
+
+const body = `{"code":0,"meta":{"trace":"x"},"result":{"nodes":[{"node_name":"A","kids":[{"node_name":"A1","id":12345678901234567890}]},{"node_name":"B"}]}}`
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []string
+		want      string
+		unmatched int
+	}{
+		{
+			name:  "delete",
+			rules: []string{"delete:$.meta", "delete:$.result.nodes[-1]"},
+			want:  `{"code":0,"result":{"nodes":[{"kids":[{"id":12345678901234567890,"node_name":"A1"}],"node_name":"A"}]}}`,
+		},
+		{
+			name:  "rename each element",
+			rules: []string{"rename:$.result.nodes[*].node_name=name", "delete:$.result.nodes[*].kids"},
+			want:  `{"code":0,"meta":{"trace":"x"},"result":{"nodes":[{"name":"A"},{"name":"B"}]}}`,
+		},
+		{
+			name:  "rename recursive",
+			rules: []string{"rename:$..node_name=name", "rename:$..kids=children", "delete:$..id"},
+			want:  `{"code":0,"meta":{"trace":"x"},"result":{"nodes":[{"children":[{"name":"A1"}],"name":"A"},{"name":"B"}]}}`,
+		},
+		{
+			name:  "move envelope",
+			rules: []string{"move:$.result.nodes=$.data", "delete:$.result", "delete:$.meta", "delete:$..kids"},
+			want:  `{"code":0,"data":[{"node_name":"A"},{"node_name":"B"}]}`,
+		},
+		{
+			name:  "move to and from root",
+			rules: []string{"move:$.meta=$", "move:$=$.data.meta"},
+			want:  `{"data":{"meta":{"trace":"x"}}}`,
+		},
+		{
+			name:      "unmatched",
+			rules:     []string{"delete:$.missing", "rename:$..missing=x", "move:$.missing=$.data", "rename:$.code.x=y"},
+			want:      `{"code":0,"meta":{"trace":"x"},"result":{"nodes":[{"kids":[{"id":12345678901234567890,"node_name":"A1"}],"node_name":"A"},{"node_name":"B"}]}}`,
+			unmatched: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := ParseAll(tt.rules)
+			if err != nil {
+				t.Fatalf("ParseAll() error = %v", err)
+			}
+			got, unmatched, err := Apply([]byte(body), rules)
+			if err != nil {
+				t.Fatalf("Apply() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Apply() = %s, want %s", got, tt.want)
+			}
+			if len(unmatched) != tt.unmatched {
+				t.Errorf("Apply() unmatched = %v, want %d rules", unmatched, tt.unmatched)
+			}
+		})
+	}
+}
+
+func TestApply_MoveIntoArray(t *testing.T) {
+	rules, err := ParseAll([]string{"move:$.code=$.result.nodes[5]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Apply([]byte(body), rules); err == nil {
+		t.Error("Apply() error = nil, want error for a missing array index")
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"$.meta", "drop:$.meta", "delete:meta", "delete:$", "delete:$.items[*]",
+		"rename:$.a", "rename:$.a=", "rename:$.items[0]=x", "rename:$..=x", "rename:$..a.b=x",
+		"move:$.a", "move:$.a=b", "move:$..a=$.b", "move:$.items[*].a=$.b", "decompress:$",
+	} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q) error = nil, want error", expr)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/capture"
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/processor"
+)
+
+// IngestRequest POST /ingest 请求体，为浏览器扩展从开发者工具捕获的一条请求。
+// request/response 与HAR中的 entry 字段一致，扩展可以直接转发
+// chrome.devtools.network.onRequestFinished 得到的条目（响应体通过 getContent 填入 response.content）
+type IngestRequest struct {
+	Request  harRequest     `json:"request"`
+	Response *harResponse   `json:"response,omitempty"` // 含响应体时直接抽取，不再重新请求
+	Options  ConvertOptions `json:"options"`
+}
+
+type harRequest struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Headers  []harHeader `json:"headers"`
+	PostData *struct {
+		Text string `json:"text"`
+	} `json:"postData,omitempty"`
+}
+
+type harHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harResponse struct {
+	Content struct {
+		Text     string `json:"text"`
+		Encoding string `json:"encoding,omitempty"` // 二进制内容为 base64
+	} `json:"content"`
+}
+
+// requestInfo 转换为请求信息，跳过HTTP/2伪头（如 :authority）以及由HTTP客户端生成的请求头
+func (r *harRequest) requestInfo() *config.RequestInfo {
+	info := &config.RequestInfo{
+		URL:     r.URL,
+		Method:  strings.ToUpper(r.Method),
+		Headers: make(map[string]string),
+		Cookies: make(map[string]string),
+	}
+	if info.Method == "" {
+		info.Method = http.MethodGet
+	}
+	for _, h := range r.Headers {
+		if capture.SkipHeader(h.Name) {
+			continue
+		}
+		info.Headers[h.Name] = h.Value
+	}
+	if r.PostData != nil {
+		info.Body = r.PostData.Text
+	}
+	return info
+}
+
+// body 返回捕获到的响应体，没有捕获时返回nil
+func (r *harResponse) body() ([]byte, error) {
+	if r == nil || r.Content.Text == "" {
+		return nil, nil
+	}
+	if r.Content.Encoding == "base64" {
+		return base64.StdEncoding.DecodeString(r.Content.Text)
+	}
+	return []byte(r.Content.Text), nil
+}
+
+// extensionOrigin 判断是否为浏览器扩展的Origin
+func extensionOrigin(origin string) bool {
+	for _, scheme := range []string{"chrome-extension://", "moz-extension://", "safari-web-extension://"} {
+		if strings.HasPrefix(origin, scheme) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameOrigin 判断Origin是否为本服务自身，即内置Web界面发起的请求。Host 必须是本机地址，
+// 否则DNS重绑定到本机的网页（Origin 与 Host 均为其域名）会被误认为同源
+func sameOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host != r.Host {
+		return false
+	}
+	if host := u.Hostname(); host != "localhost" {
+		ip := net.ParseIP(host)
+		return ip != nil && ip.IsLoopback()
+	}
+	return true
+}
+
+// loopback 判断请求是否来自本机
+func loopback(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// handleIngest 接收浏览器扩展捕获的请求并返回树状JSON。只接受本机请求；带Origin时只接受浏览器扩展，
+// 防止普通网页借助本机服务以用户的身份发起请求
+func (s *Server) handleIngest(w http.ResponseWriter, r *http.Request) {
+	if !loopback(r) {
+		writeError(w, http.StatusForbidden, i18n.T("/ingest 只接受来自本机的请求"))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if !extensionOrigin(origin) {
+			writeError(w, http.StatusForbidden, i18n.Sprintf("/ingest 不接受来自 %s 的请求", origin))
+			return
+		}
+		w.Header().Set("Access-Control-Allow-Origin", origin)
+		w.Header().Set("Access-Control-Expose-Headers", "X-Output-Path")
+		w.Header().Add("Vary", "Origin")
+	}
+	if r.Method == http.MethodOptions {
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST, OPTIONS")
+		writeError(w, http.StatusMethodNotAllowed, i18n.T("仅支持POST请求"))
+		return
+	}
+
+	var req IngestRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, i18n.Sprintf("请求体不是有效的JSON: %v", err))
+		return
+	}
+	if req.Request.URL == "" {
+		writeError(w, http.StatusBadRequest, i18n.T("request.url字段不能为空"))
+		return
+	}
+	captured, err := req.Response.body()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, i18n.Sprintf("response.content 不是有效的base64: %v", err))
+		return
+	}
+
+	p := processor.New(s.configFor(req.Options))
+	var result []byte
+	if captured != nil {
+		// 先按安全上限校验，避免超大或嵌套过深的响应进入抽取
+		if err = p.ValidateOnly(captured); err == nil {
+			result, err = p.ExtractOnly(r.Context(), captured)
+		}
+	} else {
+		result, err = p.Process(r.Context(), "", req.Request.requestInfo())
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if s.ingestDir != "" {
+		path, err := s.saveIngested(result)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		w.Header().Set("X-Output-Path", path)
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(result)
+}
+
+// saveIngested 将结果写入 ingestDir 下以时间命名的文件
+func (s *Server) saveIngested(result []byte) (string, error) {
+	if err := os.MkdirAll(s.ingestDir, 0o755); err != nil {
+		return "", i18n.Errorf("创建输出目录失败: %w", err)
+	}
+	path := filepath.Join(s.ingestDir, fmt.Sprintf("output_%s.json", time.Now().Format("20060102_150405.000")))
+	if err := os.WriteFile(path, result, 0o644); err != nil {
+		return "", i18n.Errorf("写入输出文件失败: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+const ingestTree = `{"name":"根","children":[{"name":"用例"}]}`
+
+// ingestResponse 接口返回的TestCaseMind响应
+const ingestResponse = `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[]}]}"}}`
+
+func ingest(s *Server, origin, remoteAddr, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/ingest", strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	if origin != "" {
+		req.Header.Set("Origin", origin)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+func TestServer_Ingest(t *testing.T) {
+	var received *http.Request
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(ingestResponse))
+	}))
+	defer upstream.Close()
+
+	s := New(&config.Config{Timeout: 5 * time.Second})
+
+	t.Run("重新请求", func(t *testing.T) {
+		body := `{"request":{"method":"post","url":"` + upstream.URL + `/cases","headers":[
+			{"name":":authority","value":"example.com"},
+			{"name":"Accept-Encoding","value":"gzip, deflate, br, zstd"},
+			{"name":"x-jwt-token","value":"abc"}],
+			"postData":{"text":"{\"id\":1}"}}}`
+		rec := ingest(s, "chrome-extension://abcdef", "127.0.0.1:50000", body)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		if !strings.Contains(rec.Body.String(), "门店搜索") {
+			t.Errorf("body = %s", rec.Body.String())
+		}
+		if rec.Header().Get("Access-Control-Allow-Origin") != "chrome-extension://abcdef" {
+			t.Errorf("Access-Control-Allow-Origin = %q", rec.Header().Get("Access-Control-Allow-Origin"))
+		}
+		if received.Method != http.MethodPost || received.Header.Get("x-jwt-token") != "abc" {
+			t.Errorf("upstream request = %s %v", received.Method, received.Header)
+		}
+		if received.Header.Get("Accept-Encoding") == "gzip, deflate, br, zstd" {
+			t.Errorf("浏览器的 Accept-Encoding 不应原样转发")
+		}
+	})
+
+	t.Run("使用捕获的响应体", func(t *testing.T) {
+		received = nil
+		content := base64.StdEncoding.EncodeToString([]byte(ingestTree))
+		body := `{"request":{"method":"GET","url":"` + upstream.URL + `"},"response":{"content":{"text":"` + content + `","encoding":"base64"}}}`
+		rec := ingest(s, "", "[::1]:50000", body)
+		if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "用例") {
+			t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+		}
+		if received != nil {
+			t.Errorf("提供响应体时不应重新请求")
+		}
+	})
+
+	t.Run("预检请求", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/ingest", nil)
+		req.RemoteAddr = "127.0.0.1:50000"
+		req.Header.Set("Origin", "moz-extension://abcdef")
+		rec := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rec, req)
+		if rec.Code != http.StatusNoContent || rec.Header().Get("Access-Control-Allow-Methods") != http.MethodPost {
+			t.Errorf("status = %d, headers = %v", rec.Code, rec.Header())
+		}
+	})
+
+	rejected := []struct {
+		name       string
+		origin     string
+		remoteAddr string
+		body       string
+		wantStatus int
+	}{
+		{"非本机请求", "", "192.0.2.1:50000", `{"request":{"url":"` + upstream.URL + `"}}`, http.StatusForbidden},
+		{"普通网页", "https://evil.example.com", "127.0.0.1:50000", `{"request":{"url":"` + upstream.URL + `"}}`, http.StatusForbidden},
+		{"缺少url", "", "127.0.0.1:50000", `{"request":{}}`, http.StatusBadRequest},
+		{"读取环境变量", "", "127.0.0.1:50000", `{"request":{"url":"` + upstream.URL + `","headers":[{"name":"X-T","value":"{{env:HOME}}"}]}}`, http.StatusUnprocessableEntity},
+		{"无效base64", "", "127.0.0.1:50000", `{"request":{"url":"http://x"},"response":{"content":{"text":"%%","encoding":"base64"}}}`, http.StatusBadRequest},
+	}
+	for _, tt := range rejected {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := ingest(s, tt.origin, tt.remoteAddr, tt.body); rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_IngestDir(t *testing.T) {
+	dir := t.TempDir()
+	s := New(&config.Config{}, WithIngestDir(dir))
+
+	body := `{"request":{"url":"http://example.com"},"response":{"content":{"text":` + `"{\"name\":\"根\"}"` + `}}}`
+	rec := ingest(s, "", "127.0.0.1:50000", body)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", rec.Code, rec.Body.String())
+	}
+	path := rec.Header().Get("X-Output-Path")
+	content, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(content), "根") {
+		t.Errorf("X-Output-Path = %q, content = %s, err = %v", path, content, err)
+	}
+}
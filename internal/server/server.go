@@ -0,0 +1,265 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+// indexHTML 单页Web界面：粘贴cURL、调整抽取选项、交互式预览并下载结果
+//
+//go:embed ui/index.html
+var indexHTML []byte
+
+// maxRequestBodySize /convert 请求体大小上限
+const maxRequestBodySize = 10 << 20
+
+// ConvertOptions 单次转换可覆盖的抽取选项
+type ConvertOptions struct {
+	TitleKeys    []string `json:"title_keys,omitempty"`
+	ChildrenKeys []string `json:"children_keys,omitempty"`
+	Timeout      int      `json:"timeout,omitempty"` // 秒
+}
+
+// ConvertRequest POST /convert 请求体
+type ConvertRequest struct {
+	Curl    string         `json:"curl"`
+	Options ConvertOptions `json:"options"`
+}
+
+// 下载格式，另外可以使用内置的 render.TestCaseMind
+const (
+	FormatJSON     = render.JSON
+	FormatMarkdown = render.Markdown
+)
+
+// renderFormats /render 可用的格式。只开放内置格式：renderer 插件等注册的格式可能执行外部命令，不能由HTTP请求选择
+var renderFormats = []string{render.JSON, render.Markdown, render.TestCaseMind}
+
+// RenderRequest POST /render 请求体，将已转换的树渲染为下载格式，不再重新执行请求
+type RenderRequest struct {
+	Tree   json.RawMessage `json:"tree"`
+	Format string          `json:"format"` // 内置格式名称（见 renderFormats），为空时为 FormatJSON
+}
+
+// errorResponse 错误响应体
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server HTTP服务，对外提供cURL到树状JSON的转换接口
+type Server struct {
+	defaults  *config.Config
+	ingestDir string
+	mux       *http.ServeMux
+}
+
+// Option HTTP服务选项
+type Option func(*Server)
+
+// WithIngestDir 设置 /ingest 结果的保存目录，为空时只在响应中返回结果
+func WithIngestDir(dir string) Option {
+	return func(s *Server) { s.ingestDir = dir }
+}
+
+// New 创建HTTP服务，defaults 为未在请求中指定时使用的配置
+func New(defaults *config.Config, opts ...Option) *Server {
+	s := &Server{
+		defaults: defaults,
+		mux:      http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("/", s.handleIndex)
+	s.mux.HandleFunc("/convert", s.handleConvert)
+	s.mux.HandleFunc("/render", s.handleRender)
+	s.mux.HandleFunc("/ingest", s.handleIngest)
+	s.mux.HandleFunc("/healthz", s.handleHealth)
+	return s
+}
+
+// Handler 返回服务的HTTP处理器
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe 监听地址并提供服务，ctx 取消时优雅退出
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return i18n.Errorf("HTTP服务启动失败: %w", err)
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil {
+			return i18n.Errorf("HTTP服务关闭失败: %w", err)
+		}
+		if err := <-errCh; err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+// checkLocalPost 校验 /convert 与 /render 的请求：与 /ingest 一样只接受本机请求；带Origin时只接受内置Web界面（同源）
+// 与浏览器扩展，防止普通网页借助本机服务以用户的身份发起请求。校验失败时写入错误响应并返回false
+func checkLocalPost(w http.ResponseWriter, r *http.Request) bool {
+	if !loopback(r) {
+		writeError(w, http.StatusForbidden, i18n.Sprintf("%s 只接受来自本机的请求", r.URL.Path))
+		return false
+	}
+	if origin := r.Header.Get("Origin"); origin != "" && !extensionOrigin(origin) && !sameOrigin(r, origin) {
+		writeError(w, http.StatusForbidden, i18n.Sprintf("%s 不接受来自 %s 的请求", r.URL.Path, origin))
+		return false
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeError(w, http.StatusMethodNotAllowed, i18n.T("仅支持POST请求"))
+		return false
+	}
+	// 网页无需预检即可跨域提交 text/plain 等简单请求，要求JSON使跨域请求必须经过预检，而这些接口不响应预检
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, i18n.Sprintf("%s 的 Content-Type 必须为 application/json", r.URL.Path))
+		return false
+	}
+	return true
+}
+
+// handleConvert 执行完整的解析、请求、抽取流程并返回树状JSON，请求的校验见 checkLocalPost
+func (s *Server) handleConvert(w http.ResponseWriter, r *http.Request) {
+	if !checkLocalPost(w, r) {
+		return
+	}
+
+	var req ConvertRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, i18n.Sprintf("请求体不是有效的JSON: %v", err))
+		return
+	}
+	if req.Curl == "" {
+		writeError(w, http.StatusBadRequest, i18n.T("curl字段不能为空"))
+		return
+	}
+
+	result, err := processor.New(s.configFor(req.Options)).Process(r.Context(), req.Curl, nil)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(result)
+}
+
+// handleIndex 返回Web界面，其他未注册的路径返回404
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, i18n.T("仅支持GET请求"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+// handleRender 将树状JSON渲染为指定的下载格式，请求的校验见 checkLocalPost
+func (s *Server) handleRender(w http.ResponseWriter, r *http.Request) {
+	if !checkLocalPost(w, r) {
+		return
+	}
+
+	var req RenderRequest
+	body := http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+	if err := json.NewDecoder(body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, i18n.Sprintf("请求体不是有效的JSON: %v", err))
+		return
+	}
+	if len(req.Tree) == 0 {
+		writeError(w, http.StatusBadRequest, i18n.T("tree字段不能为空"))
+		return
+	}
+	tree := &extractor.Tree{}
+	if err := json.Unmarshal(req.Tree, tree); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if req.Format == "" {
+		req.Format = FormatJSON
+	}
+	format, ok := render.Lookup(req.Format)
+	if !ok || !slices.Contains(renderFormats, req.Format) {
+		writeError(w, http.StatusBadRequest, i18n.Sprintf("不支持的格式 %q，可选 %s", req.Format, strings.Join(renderFormats, "、")))
+		return
+	}
+	var content bytes.Buffer
+	if err := format.Renderer.Render(tree, &content, render.Options{Context: r.Context()}); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", format.ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(content.Bytes())
+}
+
+// handleHealth 健康检查
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	io.WriteString(w, "ok")
+}
+
+// configFor 合并默认配置与请求中的选项
+func (s *Server) configFor(opts ConvertOptions) *config.Config {
+	cfg := *s.defaults
+	// cURL命令来自客户端，不能借此读取本机文件、环境变量与钥匙串
+	cfg.NoFileAccess, cfg.NoLocalSecrets = true, true
+	if len(opts.TitleKeys) > 0 {
+		cfg.TitleKeys = opts.TitleKeys
+	}
+	if len(opts.ChildrenKeys) > 0 {
+		cfg.ChildrenKeys = opts.ChildrenKeys
+	}
+	if opts.Timeout > 0 {
+		cfg.Timeout = time.Duration(opts.Timeout) * time.Second
+	}
+	return &cfg
+}
+
+// writeError 输出JSON格式的错误信息
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
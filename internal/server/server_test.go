@@ -0,0 +1,269 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/render"
+)
+
+func TestServer_Convert(t *testing.T) {
+	s := New(&config.Config{Timeout: time.Second})
+
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		origin     string
+		host       string
+		remoteAddr string
+		mediaType  string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "非POST请求",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "无效JSON",
+			method:     http.MethodPost,
+			body:       `{invalid`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "缺少curl",
+			method:     http.MethodPost,
+			body:       `{"options":{}}`,
+			wantStatus: http.StatusBadRequest,
+			wantBody:   "curl字段不能为空",
+		},
+		{
+			name:       "cURL解析失败",
+			method:     http.MethodPost,
+			body:       `{"curl":"curl -H 'a: b'"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "cURL解析失败",
+		},
+		{
+			name:       "引用本地文件",
+			method:     http.MethodPost,
+			body:       `{"curl":"curl http://127.0.0.1:1/x --data-binary @/etc/hostname"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "本地文件",
+		},
+		{
+			name:       "读取环境变量",
+			method:     http.MethodPost,
+			body:       `{"curl":"curl http://127.0.0.1:1/x -H 'X-T: {{env:HOME}}'"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantBody:   "{{env:HOME}}",
+		},
+		{
+			name:       "非本机请求",
+			method:     http.MethodPost,
+			body:       `{invalid`,
+			remoteAddr: "192.0.2.1:50000",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "普通网页",
+			method:     http.MethodPost,
+			body:       `{invalid`,
+			origin:     "https://evil.example.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "DNS重绑定",
+			method:     http.MethodPost,
+			body:       `{invalid`,
+			origin:     "http://evil.example.com:8080",
+			host:       "evil.example.com:8080",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "跨域简单请求",
+			method:     http.MethodPost,
+			body:       `{"curl":"curl http://127.0.0.1:1/x"}`,
+			mediaType:  "text/plain",
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:       "内置Web界面",
+			method:     http.MethodPost,
+			body:       `{invalid`,
+			origin:     "http://localhost:8080",
+			host:       "localhost:8080",
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/convert", strings.NewReader(tt.body))
+			req.RemoteAddr = "127.0.0.1:50000"
+			req.Header.Set("Content-Type", "application/json; charset=utf-8")
+			if tt.mediaType != "" {
+				req.Header.Set("Content-Type", tt.mediaType)
+			}
+			if tt.remoteAddr != "" {
+				req.RemoteAddr = tt.remoteAddr
+			}
+			if tt.host != "" {
+				req.Host = tt.host
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantBody != "" && !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want contains %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestServer_ConfigFor(t *testing.T) {
+	s := New(&config.Config{Timeout: 30 * time.Second, TitleKeys: []string{"title"}})
+
+	cfg := s.configFor(ConvertOptions{TitleKeys: []string{"case_title"}, Timeout: 5})
+	if cfg.Timeout != 5*time.Second || cfg.TitleKeys[0] != "case_title" || !cfg.NoFileAccess || !cfg.NoLocalSecrets {
+		t.Errorf("configFor() = %+v", cfg)
+	}
+	if s.defaults.TitleKeys[0] != "title" {
+		t.Errorf("configFor() 不应修改默认配置")
+	}
+}
+
+func TestServer_Index(t *testing.T) {
+	s := New(&config.Config{})
+
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/html") {
+		t.Fatalf("GET / status = %d, content type = %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), `post("convert"`) {
+		t.Errorf("GET / 应返回调用 /convert 的页面")
+	}
+
+	rec = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET /unknown status = %d, want 404", rec.Code)
+	}
+}
+
+func TestServer_Render(t *testing.T) {
+	s := New(&config.Config{})
+	render.Register(render.Format{Name: "server-test-plugin", Renderer: render.RendererFunc(func(*extractor.Tree, io.Writer, render.Options) error {
+		t.Error("/render should not run registered non-built-in formats")
+		return nil
+	})})
+	tree := `{"name":"根","children":[{"name":"用例1"},{"name":"用例2","children":[{"name":"步骤"}]}]}`
+
+	tests := []struct {
+		name        string
+		body        string
+		origin      string
+		mediaType   string
+		wantStatus  int
+		wantType    string
+		wantContent string
+	}{
+		{
+			name:        "默认JSON",
+			body:        `{"tree":` + tree + `}`,
+			wantStatus:  http.StatusOK,
+			wantType:    "application/json",
+			wantContent: "{\n  \"name\": \"根\",\n  \"children\": [\n    {\n      \"name\": \"用例1\"",
+		},
+		{
+			name:        "多根保持数组",
+			body:        `{"tree":[{"name":"a"},{"name":"b"}],"format":"json"}`,
+			wantStatus:  http.StatusOK,
+			wantType:    "application/json",
+			wantContent: "[\n  {\n    \"name\": \"a\"",
+		},
+		{
+			name:        "Markdown",
+			body:        `{"tree":` + tree + `,"format":"markdown"}`,
+			wantStatus:  http.StatusOK,
+			wantType:    "text/markdown",
+			wantContent: "- 根\n  - 用例1\n  - 用例2\n    - 步骤\n",
+		},
+		{
+			name:       "缺少tree",
+			body:       `{"format":"markdown"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "不支持的格式",
+			body:       `{"tree":` + tree + `,"format":"xmind"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "非内置格式",
+			body:       `{"tree":` + tree + `,"format":"server-test-plugin"}`,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "普通网页",
+			body:       `{"tree":` + tree + `,"format":"markdown"}`,
+			origin:     "https://evil.example.com",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "跨域简单请求",
+			body:       `{"tree":` + tree + `,"format":"markdown"}`,
+			mediaType:  "text/plain",
+			wantStatus: http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/render", strings.NewReader(tt.body))
+			req.RemoteAddr = "127.0.0.1:50000"
+			req.Header.Set("Content-Type", "application/json")
+			if tt.mediaType != "" {
+				req.Header.Set("Content-Type", tt.mediaType)
+			}
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			rec := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantType != "" && !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.wantType) {
+				t.Errorf("Content-Type = %s, want %s", rec.Header().Get("Content-Type"), tt.wantType)
+			}
+			if !strings.HasPrefix(rec.Body.String(), tt.wantContent) {
+				t.Errorf("body = %q, want prefix %q", rec.Body.String(), tt.wantContent)
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/render", strings.NewReader(`{"tree":`+tree+`}`))
+	req.Header.Set("Content-Type", "application/json")
+	s.Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("non-loopback /render status = %d, want 403", rec.Code)
+	}
+}
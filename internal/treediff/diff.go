@@ -0,0 +1,164 @@
+package treediff
+
+import (
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// PathSeparator 节点路径的显示分隔符
+const PathSeparator = " > "
+
+// ChangeType 变更类型
+type ChangeType string
+
+const (
+	// Added 新增的节点路径
+	Added ChangeType = "added"
+	// Removed 删除的节点路径
+	Removed ChangeType = "removed"
+)
+
+// Change 单个节点路径的变更
+type Change struct {
+	Type ChangeType `json:"type"`
+	Path []string   `json:"path"`
+}
+
+// String 返回 "+ a > b" 形式的单行描述
+func (c Change) String() string {
+	marker := "+"
+	if c.Type == Removed {
+		marker = "-"
+	}
+	return marker + " " + strings.Join(c.Path, PathSeparator)
+}
+
+// Compare 按节点路径比较两棵树，返回新增和删除的路径
+// 同一路径出现多次时按出现次数比较；新增按新树顺序，删除按旧树顺序
+func Compare(oldRoots, newRoots []*extractor.SimplifiedNode) []Change {
+	oldPaths := Paths(oldRoots)
+	newPaths := Paths(newRoots)
+
+	oldCount := countPaths(oldPaths)
+	newCount := countPaths(newPaths)
+
+	var changes []Change
+	for _, path := range oldPaths {
+		key := pathKey(path)
+		if newCount[key] > 0 {
+			newCount[key]--
+			continue
+		}
+		changes = append(changes, Change{Type: Removed, Path: path})
+	}
+	for _, path := range newPaths {
+		key := pathKey(path)
+		if oldCount[key] > 0 {
+			oldCount[key]--
+			continue
+		}
+		changes = append(changes, Change{Type: Added, Path: path})
+	}
+	return changes
+}
+
+// Paths 以深度优先顺序列出所有节点路径
+func Paths(roots []*extractor.SimplifiedNode) [][]string {
+	var paths [][]string
+	var walk func(nodes []*extractor.SimplifiedNode, prefix []string)
+	walk = func(nodes []*extractor.SimplifiedNode, prefix []string) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			path := append(append([]string{}, prefix...), node.Name)
+			paths = append(paths, path)
+			walk(node.Children, path)
+		}
+	}
+	walk(roots, nil)
+	return paths
+}
+
+// Normalize 返回名称规范化后的树副本：去掉首尾空白并将连续空白合并为一个空格，
+// 只有空白差异的节点不视为变更；nil节点被丢弃
+func Normalize(roots []*extractor.SimplifiedNode) []*extractor.SimplifiedNode {
+	var result []*extractor.SimplifiedNode
+	for _, node := range roots {
+		if node == nil {
+			continue
+		}
+		result = append(result, &extractor.SimplifiedNode{
+			Name:     strings.Join(strings.Fields(node.Name), " "),
+			Children: Normalize(node.Children),
+		})
+	}
+	return result
+}
+
+// Stats 变更统计
+type Stats struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+}
+
+// Count 统计新增和删除的节点数
+func Count(changes []Change) Stats {
+	var stats Stats
+	for _, change := range changes {
+		if change.Type == Added {
+			stats.Added++
+		} else {
+			stats.Removed++
+		}
+	}
+	return stats
+}
+
+// String 返回变更统计描述
+func (s Stats) String() string {
+	return i18n.Sprintf("新增 %d 个节点，删除 %d 个节点", s.Added, s.Removed)
+}
+
+// Summary 返回变更统计描述
+func Summary(changes []Change) string {
+	return Count(changes).String()
+}
+
+// Format 将变更格式化为多行文本
+func Format(changes []Change) string {
+	return FormatColor(changes, color.Palette{})
+}
+
+// FormatColor 将变更格式化为多行文本，新增行绿色、删除行红色
+func FormatColor(changes []Change, palette color.Palette) string {
+	var b strings.Builder
+	for _, change := range changes {
+		line := change.String()
+		if change.Type == Removed {
+			line = palette.Red(line)
+		} else {
+			line = palette.Green(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// countPaths 统计每条路径出现的次数
+func countPaths(paths [][]string) map[string]int {
+	counts := make(map[string]int, len(paths))
+	for _, path := range paths {
+		counts[pathKey(path)]++
+	}
+	return counts
+}
+
+// pathKey 生成路径的唯一键
+func pathKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
@@ -0,0 +1,84 @@
+package treediff
+
+import (
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+func TestCompare(t *testing.T) {
+	oldTree := []*extractor.SimplifiedNode{
+		{Name: "门店搜索", Children: []*extractor.SimplifiedNode{
+			{Name: "输入存在的门店名称"},
+			{Name: "输入部分门店名称"},
+		}},
+	}
+	newTree := []*extractor.SimplifiedNode{
+		{Name: "门店搜索", Children: []*extractor.SimplifiedNode{
+			{Name: "输入存在的门店名称"},
+			{Name: "输入不存在的门店名称"},
+		}},
+	}
+
+	changes := Compare(oldTree, newTree)
+	want := []string{
+		"- 门店搜索 > 输入部分门店名称",
+		"+ 门店搜索 > 输入不存在的门店名称",
+	}
+
+	if len(changes) != len(want) {
+		t.Fatalf("Compare() = %v, want %v", changes, want)
+	}
+	for i, change := range changes {
+		if change.String() != want[i] {
+			t.Errorf("Compare()[%d] = %s, want %s", i, change, want[i])
+		}
+	}
+	if stats := Count(changes); stats != (Stats{Added: 1, Removed: 1}) {
+		t.Errorf("Count() = %+v, want one added and one removed", stats)
+	}
+}
+
+func TestCompare_DuplicatePaths(t *testing.T) {
+	oldTree := []*extractor.SimplifiedNode{{Name: "步骤"}, {Name: "步骤"}}
+	newTree := []*extractor.SimplifiedNode{{Name: "步骤"}}
+
+	changes := Compare(oldTree, newTree)
+	if len(changes) != 1 || changes[0].Type != Removed {
+		t.Errorf("Compare() = %v, want one removal", changes)
+	}
+}
+
+func TestFormatColor(t *testing.T) {
+	changes := []Change{
+		{Type: Added, Path: []string{"登录", "成功"}},
+		{Type: Removed, Path: []string{"登录", "失败"}},
+	}
+
+	plain := "+ 登录 > 成功\n- 登录 > 失败\n"
+	if got := FormatColor(changes, color.Palette{}); got != plain {
+		t.Errorf("FormatColor(disabled) = %q, want %q", got, plain)
+	}
+
+	colored := "\x1b[32m+ 登录 > 成功\x1b[0m\n\x1b[31m- 登录 > 失败\x1b[0m\n"
+	if got := FormatColor(changes, color.Palette{Enabled: true}); got != colored {
+		t.Errorf("FormatColor(enabled) = %q, want %q", got, colored)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	golden := []*extractor.SimplifiedNode{
+		{Name: "门店搜索", Children: []*extractor.SimplifiedNode{{Name: "输入 门店名称"}}},
+	}
+	actual := []*extractor.SimplifiedNode{
+		{Name: " 门店搜索\n", Children: []*extractor.SimplifiedNode{{Name: "输入\t 门店名称"}, nil}},
+	}
+
+	if changes := Compare(Normalize(golden), Normalize(actual)); len(changes) != 0 {
+		t.Errorf("Compare(Normalize) = %v, want no changes", changes)
+	}
+	if actual[0].Name != " 门店搜索\n" {
+		t.Errorf("Normalize() 不应修改原来的树")
+	}
+}
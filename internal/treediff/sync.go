@@ -0,0 +1,106 @@
+package treediff
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// Delta 增量同步的结果：与上一次的树相比新增、修改与删除的节点，供下游系统只导入变化的部分
+type Delta struct {
+	Added   []Subtree   `json:"added"`   // 新增的子树，只列出最上层的新增节点，其子节点随之一起新增
+	Changed []Subtree   `json:"changed"` // 名称路径不变但标签或序号变化的节点，不含子节点，子节点的变化单独列出
+	Removed []Tombstone `json:"removed"` // 删除的节点，只列出最上层的删除节点，其子节点随之一起删除
+}
+
+// Subtree 新增或修改的节点及其位置
+type Subtree struct {
+	Parent []string                  `json:"parent"` // 父节点的名称路径，根节点为空数组
+	Index  int                       `json:"index"`  // 在本次结果的兄弟节点中的位置，从0开始
+	Node   *extractor.SimplifiedNode `json:"node"`
+}
+
+// Tombstone 删除的节点
+type Tombstone struct {
+	Path []string `json:"path"` // 节点在上一次结果中的名称路径
+}
+
+// Empty 是否没有任何变化
+func (d *Delta) Empty() bool {
+	return len(d.Added) == 0 && len(d.Changed) == 0 && len(d.Removed) == 0
+}
+
+// String 返回变化统计描述
+func (d *Delta) String() string {
+	return i18n.Sprintf("新增 %d 个子树，修改 %d 个节点，删除 %d 个子树", len(d.Added), len(d.Changed), len(d.Removed))
+}
+
+// Sync 按名称路径比较两棵树，返回newRoots相对于oldRoots的增量。
+// 同一父节点下的同名节点按出现的先后依次对应；只有顺序变化的节点不视为变化（除非带有 order 字段）
+func Sync(oldRoots, newRoots []*extractor.SimplifiedNode) *Delta {
+	d := &Delta{Added: []Subtree{}, Changed: []Subtree{}, Removed: []Tombstone{}}
+	d.sync(oldRoots, newRoots, []string{})
+	return d
+}
+
+func (d *Delta) sync(oldNodes, newNodes []*extractor.SimplifiedNode, parent []string) {
+	olds := keyed(oldNodes)
+	oldByKey := make(map[string]*extractor.SimplifiedNode, len(olds))
+	for _, k := range olds {
+		oldByKey[k.key] = k.node
+	}
+
+	for index, k := range keyed(newNodes) {
+		node := k.node
+		old, ok := oldByKey[k.key]
+		if !ok {
+			d.Added = append(d.Added, Subtree{Parent: parent, Index: index, Node: node})
+			continue
+		}
+		delete(oldByKey, k.key)
+		if !slices.Equal(old.Tags, node.Tags) || !equalOrder(old.Order, node.Order) {
+			d.Changed = append(d.Changed, Subtree{Parent: parent, Index: index, Node: &extractor.SimplifiedNode{
+				Name: node.Name, Order: node.Order, Tags: node.Tags, Children: []*extractor.SimplifiedNode{},
+			}})
+		}
+		d.sync(old.Children, node.Children, appendPath(parent, node.Name))
+	}
+
+	// 删除的节点按上一次结果中的顺序列出
+	for _, k := range olds {
+		if _, ok := oldByKey[k.key]; ok {
+			d.Removed = append(d.Removed, Tombstone{Path: appendPath(parent, k.node.Name)})
+		}
+	}
+}
+
+// keyedNode 兄弟节点及其键：名称与它在同名兄弟节点中的序号
+type keyedNode struct {
+	key  string
+	node *extractor.SimplifiedNode
+}
+
+// keyed 按顺序返回兄弟节点及其键，nil节点被跳过
+func keyed(nodes []*extractor.SimplifiedNode) []keyedNode {
+	result := make([]keyedNode, 0, len(nodes))
+	seen := make(map[string]int)
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		result = append(result, keyedNode{key: node.Name + "\x00" + strconv.Itoa(seen[node.Name]), node: node})
+		seen[node.Name]++
+	}
+	return result
+}
+
+// appendPath 返回在parent之后追加name的新路径，不修改parent
+func appendPath(parent []string, name string) []string {
+	return append(append(make([]string, 0, len(parent)+1), parent...), name)
+}
+
+func equalOrder(a, b *int) bool {
+	return (a == nil && b == nil) || (a != nil && b != nil && *a == *b)
+}
@@ -0,0 +1,47 @@
+package treediff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+func TestSync(t *testing.T) {
+	oldTree := []*extractor.SimplifiedNode{
+		{Name: "门店搜索", Tags: []string{"priority:P1"}, Children: []*extractor.SimplifiedNode{
+			{Name: "输入存在的门店名称"},
+			{Name: "重复用例"},
+			{Name: "重复用例"},
+			{Name: "输入部分门店名称", Children: []*extractor.SimplifiedNode{{Name: "模糊匹配"}}},
+		}},
+		{Name: "门店详情"},
+	}
+	newTree := []*extractor.SimplifiedNode{
+		{Name: "门店搜索", Tags: []string{"priority:P0"}, Children: []*extractor.SimplifiedNode{
+			{Name: "输入存在的门店名称"},
+			{Name: "重复用例"},
+			{Name: "输入不存在的门店名称", Children: []*extractor.SimplifiedNode{{Name: "提示无结果"}}},
+		}},
+		nil,
+		{Name: "门店详情"},
+		{Name: "门店导出"},
+	}
+
+	got, err := json.Marshal(Sync(oldTree, newTree))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"added":[` +
+		`{"parent":["门店搜索"],"index":2,"node":{"name":"输入不存在的门店名称","children":[{"name":"提示无结果","children":null}]}},` +
+		`{"parent":[],"index":2,"node":{"name":"门店导出","children":null}}],` +
+		`"changed":[{"parent":[],"index":0,"node":{"name":"门店搜索","tags":["priority:P0"],"children":[]}}],` +
+		`"removed":[{"path":["门店搜索","重复用例"]},{"path":["门店搜索","输入部分门店名称"]}]}`
+	if string(got) != want {
+		t.Errorf("Sync() = %s\nwant %s", got, want)
+	}
+
+	if delta := Sync(newTree, newTree); !delta.Empty() {
+		t.Errorf("Sync() of identical trees = %+v, want empty", delta)
+	}
+}
@@ -0,0 +1,338 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/wellkilo/Curl2json/internal/clipboard"
+	"github.com/wellkilo/Curl2json/internal/color"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// pathSeparator 复制节点路径时使用的分隔符
+const pathSeparator = " > "
+
+// row 当前可见的一行
+type row struct {
+	node  *extractor.SimplifiedNode
+	depth int
+	path  []string
+}
+
+// model 树浏览器状态
+type model struct {
+	roots    []*extractor.SimplifiedNode
+	expanded map[*extractor.SimplifiedNode]bool
+	parents  map[*extractor.SimplifiedNode]*extractor.SimplifiedNode
+	rows     []row
+	cursor   int
+	offset   int
+	height   int
+
+	searching bool
+	query     string
+	matches   []*extractor.SimplifiedNode
+	matchIdx  int
+
+	status string
+	color  color.Palette
+}
+
+// Run 启动交互式树浏览器，阻塞直到用户退出
+func Run(roots []*extractor.SimplifiedNode) error {
+	if len(roots) == 0 {
+		return i18n.Errorf("树为空，无可浏览的节点")
+	}
+
+	m := newModel(roots)
+	m.color = color.For(os.Stdout)
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return i18n.Errorf("交互式浏览器运行失败: %w", err)
+	}
+	return nil
+}
+
+// newModel 创建浏览器状态，默认展开第一层
+func newModel(roots []*extractor.SimplifiedNode) *model {
+	m := &model{
+		roots:    roots,
+		expanded: make(map[*extractor.SimplifiedNode]bool),
+		parents:  make(map[*extractor.SimplifiedNode]*extractor.SimplifiedNode),
+		height:   20,
+	}
+	for _, root := range roots {
+		if root == nil {
+			continue
+		}
+		m.indexParents(root)
+		m.expanded[root] = true
+	}
+	m.rebuild()
+	return m
+}
+
+// indexParents 记录每个节点的父节点，用于搜索时展开祖先
+func (m *model) indexParents(node *extractor.SimplifiedNode) {
+	for _, child := range node.Children {
+		if child == nil {
+			continue
+		}
+		m.parents[child] = node
+		m.indexParents(child)
+	}
+}
+
+// rebuild 根据展开状态重新计算可见行
+func (m *model) rebuild() {
+	m.rows = m.rows[:0]
+	var walk func(nodes []*extractor.SimplifiedNode, depth int, path []string)
+	walk = func(nodes []*extractor.SimplifiedNode, depth int, path []string) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			nodePath := append(append([]string{}, path...), node.Name)
+			m.rows = append(m.rows, row{node: node, depth: depth, path: nodePath})
+			if m.expanded[node] {
+				walk(node.Children, depth+1, nodePath)
+			}
+		}
+	}
+	walk(m.roots, 0, nil)
+
+	if m.cursor >= len(m.rows) {
+		m.cursor = len(m.rows) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// Init 实现 tea.Model
+func (m *model) Init() tea.Cmd {
+	return nil
+}
+
+// Update 实现 tea.Model
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = max(1, msg.Height-3)
+	case tea.KeyMsg:
+		if m.searching {
+			return m.updateSearch(msg)
+		}
+		return m.updateBrowse(msg)
+	}
+	m.scroll()
+	return m, nil
+}
+
+// updateBrowse 处理浏览模式下的按键
+func (m *model) updateBrowse(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+	current := m.rows[m.cursor]
+
+	switch msg.String() {
+	case "q", "ctrl+c", "esc":
+		return m, tea.Quit
+	case "up", "k":
+		m.cursor = max(0, m.cursor-1)
+	case "down", "j":
+		m.cursor = min(len(m.rows)-1, m.cursor+1)
+	case "pgup":
+		m.cursor = max(0, m.cursor-m.height)
+	case "pgdown":
+		m.cursor = min(len(m.rows)-1, m.cursor+m.height)
+	case "home", "g":
+		m.cursor = 0
+	case "end", "G":
+		m.cursor = len(m.rows) - 1
+	case "right", "l":
+		m.expanded[current.node] = true
+		m.rebuild()
+	case "left", "h":
+		if m.expanded[current.node] && len(current.node.Children) > 0 {
+			m.expanded[current.node] = false
+			m.rebuild()
+		} else if parent, ok := m.parents[current.node]; ok {
+			m.moveTo(parent)
+		}
+	case "enter", " ":
+		m.expanded[current.node] = !m.expanded[current.node]
+		m.rebuild()
+	case "e":
+		m.setAll(true)
+	case "c":
+		m.setAll(false)
+	case "/":
+		m.searching = true
+		m.query = ""
+	case "n":
+		m.jumpMatch(1)
+	case "N":
+		m.jumpMatch(-1)
+	case "y":
+		path := strings.Join(current.path, pathSeparator)
+		if err := clipboard.Write(path); err != nil {
+			m.status = i18n.Sprintf("复制失败: %v", err)
+		} else {
+			m.status = i18n.T("已复制路径: ") + path
+		}
+	}
+
+	m.scroll()
+	return m, nil
+}
+
+// updateSearch 处理搜索输入
+func (m *model) updateSearch(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc, tea.KeyCtrlC:
+		m.searching = false
+		m.query = ""
+	case tea.KeyEnter:
+		m.searching = false
+		m.search()
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+	}
+	m.scroll()
+	return m, nil
+}
+
+// search 查找名称包含关键字的节点，并跳转到第一个匹配项
+func (m *model) search() {
+	m.matches = nil
+	m.matchIdx = 0
+	if m.query == "" {
+		return
+	}
+
+	needle := strings.ToLower(m.query)
+	var walk func(nodes []*extractor.SimplifiedNode)
+	walk = func(nodes []*extractor.SimplifiedNode) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			if strings.Contains(strings.ToLower(node.Name), needle) {
+				m.matches = append(m.matches, node)
+			}
+			walk(node.Children)
+		}
+	}
+	walk(m.roots)
+
+	if len(m.matches) == 0 {
+		m.status = i18n.Sprintf("未找到: %s", m.query)
+		return
+	}
+	m.jumpMatch(0)
+}
+
+// jumpMatch 跳转到相对当前位置的第delta个匹配项
+func (m *model) jumpMatch(delta int) {
+	if len(m.matches) == 0 {
+		return
+	}
+	m.matchIdx = (m.matchIdx + delta + len(m.matches)) % len(m.matches)
+	m.moveTo(m.matches[m.matchIdx])
+	m.status = i18n.Sprintf("匹配 %d/%d: %s", m.matchIdx+1, len(m.matches), m.query)
+}
+
+// moveTo 展开节点的所有祖先并将光标移动到该节点
+func (m *model) moveTo(target *extractor.SimplifiedNode) {
+	for parent, ok := m.parents[target]; ok; parent, ok = m.parents[parent] {
+		m.expanded[parent] = true
+	}
+	m.rebuild()
+	for i, r := range m.rows {
+		if r.node == target {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// setAll 展开或折叠所有节点
+func (m *model) setAll(expand bool) {
+	var walk func(nodes []*extractor.SimplifiedNode)
+	walk = func(nodes []*extractor.SimplifiedNode) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			m.expanded[node] = expand
+			walk(node.Children)
+		}
+	}
+	walk(m.roots)
+	m.rebuild()
+}
+
+// scroll 保证光标位于可视区域内
+func (m *model) scroll() {
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+}
+
+// View 实现 tea.Model
+func (m *model) View() string {
+	var b strings.Builder
+
+	end := min(len(m.rows), m.offset+m.height)
+	for i := m.offset; i < end; i++ {
+		r := m.rows[i]
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+
+		marker := "  "
+		if len(r.node.Children) > 0 {
+			if m.expanded[r.node] {
+				marker = "▾ "
+			} else {
+				marker = "▸ "
+			}
+		}
+
+		// 光标行使用反色显示，不再按层级着色
+		name := r.node.Name
+		if i != m.cursor {
+			name = m.color.Depth(r.depth, name)
+		}
+		line := cursor + strings.Repeat("  ", r.depth) + marker + name
+		if len(r.node.Children) > 0 && !m.expanded[r.node] {
+			line += fmt.Sprintf(" (%d)", len(r.node.Children))
+		}
+		if i == m.cursor {
+			line = "\x1b[7m" + line + "\x1b[0m"
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.searching:
+		b.WriteString("/" + m.query)
+	case m.status != "":
+		b.WriteString(m.status)
+	default:
+		b.WriteString(i18n.T("↑↓ 移动  ←→ 折叠/展开  e/c 全部展开/折叠  / 搜索  n/N 下/上一个  y 复制路径  q 退出"))
+	}
+	return b.String()
+}
@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+func TestModel_SearchExpandsAncestors(t *testing.T) {
+	leaf := &extractor.SimplifiedNode{Name: "搜索结果包含该门店"}
+	mid := &extractor.SimplifiedNode{Name: "门店搜索", Children: []*extractor.SimplifiedNode{leaf}}
+	root := &extractor.SimplifiedNode{Name: "客户详情", Children: []*extractor.SimplifiedNode{mid}}
+
+	m := newModel([]*extractor.SimplifiedNode{root})
+	if len(m.rows) != 2 {
+		t.Fatalf("默认应只展开第一层, rows = %d", len(m.rows))
+	}
+
+	m.query = "该门店"
+	m.search()
+
+	if len(m.matches) != 1 {
+		t.Fatalf("matches = %d, want 1", len(m.matches))
+	}
+	if got := m.rows[m.cursor].node; got != leaf {
+		t.Errorf("cursor node = %s, want %s", got.Name, leaf.Name)
+	}
+	if want := []string{"客户详情", "门店搜索", "搜索结果包含该门店"}; len(m.rows[m.cursor].path) != len(want) {
+		t.Errorf("path = %v, want %v", m.rows[m.cursor].path, want)
+	}
+}
+
+func TestModel_SetAll(t *testing.T) {
+	root := &extractor.SimplifiedNode{Name: "根", Children: []*extractor.SimplifiedNode{
+		{Name: "子1", Children: []*extractor.SimplifiedNode{{Name: "孙1"}}},
+		{Name: "子2"},
+	}}
+
+	m := newModel([]*extractor.SimplifiedNode{root})
+	m.setAll(true)
+	if len(m.rows) != 4 {
+		t.Errorf("全部展开后 rows = %d, want 4", len(m.rows))
+	}
+
+	m.setAll(false)
+	if len(m.rows) != 1 {
+		t.Errorf("全部折叠后 rows = %d, want 1", len(m.rows))
+	}
+}
+
+func TestModel_NilNodes(t *testing.T) {
+	root := &extractor.SimplifiedNode{Name: "根", Children: []*extractor.SimplifiedNode{nil, {Name: "子", Children: []*extractor.SimplifiedNode{nil}}}}
+
+	m := newModel([]*extractor.SimplifiedNode{nil, root})
+	m.setAll(true)
+	if len(m.rows) != 2 {
+		t.Errorf("rows = %d, want 2 without the nil nodes", len(m.rows))
+	}
+	m.query = "子"
+	m.search()
+	if len(m.matches) != 1 {
+		t.Errorf("matches = %d, want 1", len(m.matches))
+	}
+	m.View()
+}
@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Format 响应体格式
+type Format string
+
+// 根据Content-Type识别出的响应体格式
+const (
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+	FormatXML    Format = "xml"
+	FormatYAML   Format = "yaml"
+	FormatHTML   Format = "html"
+)
+
+// DetectFormat 根据Content-Type判断响应体格式，缺失或无法识别时按JSON处理
+func DetectFormat(contentType string) Format {
+	switch mediaType := mediaType(contentType); {
+	case mediaType == "application/x-ndjson", mediaType == "application/ndjson",
+		mediaType == "application/jsonl", mediaType == "application/x-jsonlines":
+		return FormatNDJSON
+	case mediaType == "text/html", mediaType == "application/xhtml+xml":
+		return FormatHTML
+	case mediaType == "application/xml", mediaType == "text/xml", strings.HasSuffix(mediaType, "+xml"):
+		return FormatXML
+	case mediaType == "application/yaml", mediaType == "application/x-yaml",
+		mediaType == "text/yaml", mediaType == "text/x-yaml", strings.HasSuffix(mediaType, "+yaml"):
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// mediaType 返回去掉charset等参数后的小写媒体类型
+func mediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.ToLower(strings.TrimSpace(contentType))
+	}
+	return mediaType
+}
+
+// Decode 按Content-Type解码响应体并转换为JSON，JSON响应原样返回；
+// HTML页面通常意味着登录态失效或请求被重定向到了登录页，直接返回有针对性的错误
+func (v *ResponseValidator) Decode(contentType string, data []byte) ([]byte, error) {
+	format := DetectFormat(contentType)
+	v.logger.Debug(i18n.T("按Content-Type解码响应"), "content_type", contentType, "format", format)
+
+	// 转换前先检查原始大小，避免解析超大的XML/YAML
+	if err := v.checkSize(data); err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	var err error
+	switch format {
+	case FormatJSON:
+		return data, v.Validate(data)
+	case FormatHTML:
+		return nil, sessionError(mediaType(contentType), data)
+	case FormatNDJSON:
+		value, err = decodeNDJSON(data)
+	case FormatXML:
+		value, err = decodeXML(data)
+	case FormatYAML:
+		value, err = decodeYAML(data)
+	}
+	if err != nil {
+		return nil, errs.Mark(i18n.Errorf("%s 响应解析失败: %w", format, err), errs.ErrInvalidJSON)
+	}
+
+	converted, err := json.Marshal(value)
+	if err != nil {
+		return nil, i18n.Errorf("%s 响应转换为JSON失败: %w", format, err)
+	}
+	return converted, v.Validate(converted)
+}
+
+// decodeNDJSON 将每行一个JSON值的响应转换为数组，空行会被忽略
+func decodeNDJSON(data []byte) (interface{}, error) {
+	items := []interface{}{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var item interface{}
+		if err := json.Unmarshal(text, &item); err != nil {
+			return nil, i18n.Errorf("第 %d 行: %w", line, err)
+		}
+		items = append(items, item)
+	}
+	return items, scanner.Err()
+}
+
+// decodeYAML 解析YAML并将键统一为字符串，便于序列化为JSON
+func decodeYAML(data []byte) (interface{}, error) {
+	var value interface{}
+	if err := yaml.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return normalizeYAML(value), nil
+}
+
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return m
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// decodeXML 将XML转换为JSON对象：元素名为键，属性以 @ 开头，
+// 同时包含文本与子元素时文本放在 #text 中，重复的同名元素合并为数组
+func decodeXML(data []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return nil, i18n.Errorf("XML中没有根元素")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			value, err := decodeXMLElement(decoder, start)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]interface{}{start.Name.Local: value}, nil
+		}
+	}
+}
+
+func decodeXMLElement(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	node := make(map[string]interface{})
+	for _, attr := range start.Attr {
+		node["@"+attr.Name.Local] = attr.Value
+	}
+
+	var text strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			child, err := decodeXMLElement(decoder, t)
+			if err != nil {
+				return nil, err
+			}
+			name := t.Name.Local
+			switch existing := node[name].(type) {
+			case nil:
+				node[name] = child
+			case []interface{}:
+				node[name] = append(existing, child)
+			default:
+				node[name] = []interface{}{existing, child}
+			}
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			content := strings.TrimSpace(text.String())
+			if len(node) == 0 {
+				return content, nil
+			}
+			if content != "" {
+				node["#text"] = content
+			}
+			return node, nil
+		}
+	}
+}
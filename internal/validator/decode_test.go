@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+)
+
+func TestDetectFormat(t *testing.T) {
+	tests := map[string]Format{
+		"":                                FormatJSON,
+		"application/json; charset=utf-8": FormatJSON,
+		"text/plain":                      FormatJSON,
+		"application/x-ndjson":            FormatNDJSON,
+		"text/xml; charset=gbk":           FormatXML,
+		"application/atom+xml":            FormatXML,
+		"application/x-yaml":              FormatYAML,
+		"TEXT/HTML; charset=UTF-8":        FormatHTML,
+	}
+	for contentType, want := range tests {
+		if got := DetectFormat(contentType); got != want {
+			t.Errorf("DetectFormat(%q) = %s, want %s", contentType, got, want)
+		}
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{
+			name:        "JSON原样返回",
+			contentType: "application/json",
+			body:        `{"title":"根"}`,
+			want:        `{"title":"根"}`,
+		},
+		{
+			name:        "NDJSON转换为数组",
+			contentType: "application/x-ndjson",
+			body:        "{\"title\":\"A\"}\n\n{\"title\":\"B\"}\n",
+			want:        `[{"title":"A"},{"title":"B"}]`,
+		},
+		{
+			name:        "YAML",
+			contentType: "application/yaml",
+			body:        "title: 根\nchildren:\n  - title: 子\n",
+			want:        `{"children":[{"title":"子"}],"title":"根"}`,
+		},
+		{
+			name:        "XML属性与重复元素",
+			contentType: "application/xml",
+			body:        `<case id="1"><title>根</title><children><title>A</title></children><children><title>B</title></children></case>`,
+			want:        `{"case":{"@id":"1","children":[{"title":"A"},{"title":"B"}],"title":"根"}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New().Decode(tt.contentType, []byte(tt.body))
+			if err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			var gotValue, wantValue interface{}
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatalf("Decode() returned invalid JSON: %s", got)
+			}
+			json.Unmarshal([]byte(tt.want), &wantValue)
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Errorf("Decode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, err := New().Decode("text/html; charset=utf-8", []byte("<html><body>请登录</body></html>"))
+	if err == nil || !strings.Contains(err.Error(), "text/html") {
+		t.Errorf("Decode(html) error = %v, want text/html hint", err)
+	}
+	if !errors.Is(err, errs.ErrInvalidJSON) {
+		t.Errorf("Decode(html) error = %v, want ErrInvalidJSON", err)
+	}
+
+	if _, err := New().Decode("application/x-ndjson", []byte("{\"a\":1}\nnot json\n")); !errors.Is(err, errs.ErrInvalidJSON) {
+		t.Errorf("Decode(bad ndjson) error = %v, want ErrInvalidJSON", err)
+	}
+}
+
+func TestDecode_MultipleDocuments(t *testing.T) {
+	body := "{\n  \"title\": \"A\"\n}\n{\n  \"title\": \"B\"\n}\n"
+	got, err := New().Decode("application/json", []byte(body))
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if string(got) != body {
+		t.Errorf("Decode() = %s, want unchanged body", got)
+	}
+
+	docs, err := Documents(got)
+	if err != nil || len(docs) != 2 {
+		t.Errorf("Documents() = %d documents, %v, want 2", len(docs), err)
+	}
+
+	if _, err := New().Decode("application/json", []byte(`{"title":"A"} {"title":`)); !errors.Is(err, errs.ErrTruncatedJSON) {
+		t.Errorf("Decode(truncated second document) error = %v, want ErrTruncatedJSON", err)
+	}
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Limits 响应体的安全上限，用于防止异常或恶意构造的响应耗尽内存与CPU；
+// 各字段为0时表示不限制
+type Limits struct {
+	MaxSize         int64 // 响应体最大字节数
+	MaxDepth        int   // JSON对象与数组的最大嵌套深度
+	MaxStringLength int   // 单个JSON字符串（包括键名）的最大字节数
+}
+
+// WithLimits 设置响应体的安全上限，默认不限制
+func WithLimits(limits Limits) Option {
+	return func(v *ResponseValidator) {
+		v.limits = limits
+	}
+}
+
+// checkSize 检查响应体大小是否超过上限
+func (v *ResponseValidator) checkSize(data []byte) error {
+	if v.limits.MaxSize > 0 && int64(len(data)) > v.limits.MaxSize {
+		return errs.Mark(i18n.Errorf("响应体大小 %d 字节超过上限 %d 字节", len(data), v.limits.MaxSize), errs.ErrLimitExceeded)
+	}
+	return nil
+}
+
+// checkStructure 逐个读取JSON token，检查嵌套深度与字符串长度是否超过上限；
+// 未设置相关上限时直接返回，不额外解析
+func (v *ResponseValidator) checkStructure(data []byte) error {
+	if v.limits.MaxDepth <= 0 && v.limits.MaxStringLength <= 0 {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case json.Delim:
+			if t == '{' || t == '[' {
+				depth++
+				if v.limits.MaxDepth > 0 && depth > v.limits.MaxDepth {
+					return errs.Mark(i18n.Errorf("JSON嵌套深度超过上限 %d", v.limits.MaxDepth), errs.ErrLimitExceeded)
+				}
+			} else {
+				depth--
+			}
+		case string:
+			if v.limits.MaxStringLength > 0 && len(t) > v.limits.MaxStringLength {
+				return errs.Mark(i18n.Errorf("JSON字符串长度 %d 字节超过上限 %d 字节", len(t), v.limits.MaxStringLength), errs.ErrLimitExceeded)
+			}
+		}
+	}
+}
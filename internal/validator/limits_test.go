@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+)
+
+func TestValidate_Limits(t *testing.T) {
+	limits := Limits{MaxSize: 64, MaxDepth: 3, MaxStringLength: 8}
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{name: "未超过上限", body: `{"title":"根","children":[{"title":"A"}]}`},
+		{name: "超过大小上限", body: `{"title":"` + strings.Repeat("a", 4) + `"}` + strings.Repeat(" ", 64), wantErr: true},
+		{name: "超过嵌套深度", body: `{"a":[{"b":[1]}]}`, wantErr: true},
+		{name: "字符串过长", body: `{"title":"123456789"}`, wantErr: true},
+		{name: "键名过长", body: `{"123456789":1}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New(WithLimits(limits)).Validate([]byte(tt.body))
+			if tt.wantErr != errors.Is(err, errs.ErrLimitExceeded) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecode_SizeLimit(t *testing.T) {
+	v := New(WithLimits(Limits{MaxSize: 16}))
+	if _, err := v.Decode("application/yaml", []byte(strings.Repeat("a: 1\n", 10))); !errors.Is(err, errs.ErrLimitExceeded) {
+		t.Errorf("Decode() error = %v, want ErrLimitExceeded", err)
+	}
+}
@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// sniffLimit 检测HTML和登录跳转时最多查看的响应字节数
+const sniffLimit = 64 * 1024
+
+// redirectPatterns 页面中常见的跳转写法：JS修改location与meta refresh
+var redirectPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)location(?:\.href)?\s*(?:=|\.replace\(|\.assign\()\s*["']([^"']+)["']`),
+	regexp.MustCompile(`(?i)<meta[^>]+http-equiv=["']?refresh["']?[^>]*url=([^"'>\s;]+)`),
+}
+
+// loginKeywords 跳转地址中代表登录页的关键字
+var loginKeywords = []string{"login", "signin", "sign-in", "sso", "passport", "cas/", "auth"}
+
+// isHTML 判断响应体是否为HTML页面
+func isHTML(data []byte) bool {
+	head := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")), " \t\r\n")
+	if len(head) > 512 {
+		head = head[:512]
+	}
+	head = bytes.ToLower(head)
+	return bytes.HasPrefix(head, []byte("<!doctype html")) ||
+		bytes.HasPrefix(head, []byte("<html")) ||
+		(bytes.HasPrefix(head, []byte("<")) && (bytes.Contains(head, []byte("<head")) || bytes.Contains(head, []byte("<body"))))
+}
+
+// loginRedirect 返回响应体中指向登录页的跳转地址，没有时返回空字符串
+func loginRedirect(data []byte) string {
+	if len(data) > sniffLimit {
+		data = data[:sniffLimit]
+	}
+	for _, pattern := range redirectPatterns {
+		for _, match := range pattern.FindAllSubmatch(data, -1) {
+			target := string(match[1])
+			lower := strings.ToLower(target)
+			for _, keyword := range loginKeywords {
+				if strings.Contains(lower, keyword) {
+					return target
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// sessionError 响应为HTML页面或包含登录跳转时返回有针对性的错误，
+// 同时标记为 ErrSessionExpired 和 ErrInvalidJSON；其他响应返回nil
+func sessionError(kind string, data []byte) error {
+	if target := loginRedirect(data); target != "" {
+		err := i18n.Errorf("接口返回了跳转到登录页（%s）的页面而不是JSON，登录态（cookie/token）已过期，请在浏览器中刷新登录后重新复制cURL命令", target)
+		return errs.Mark(errs.Mark(err, errs.ErrSessionExpired), errs.ErrInvalidJSON)
+	}
+	if kind == "" && !isHTML(data) {
+		return nil
+	}
+	if kind == "" {
+		kind = "HTML"
+	}
+	err := i18n.Errorf("接口返回了 %s 页面而不是JSON，登录态（cookie/token）可能已过期，请重新复制cURL命令", kind)
+	return errs.Mark(errs.Mark(err, errs.ErrSessionExpired), errs.ErrInvalidJSON)
+}
@@ -0,0 +1,54 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+)
+
+func TestValidate_SessionExpired(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "HTML页面",
+			body: "\ufeff\n<!DOCTYPE html><html><head><title>请登录</title></head></html>",
+			want: "HTML",
+		},
+		{
+			name: "JS跳转登录页",
+			body: `<script>window.location.href = "https://sso.example.com/login?from=api";</script>`,
+			want: "https://sso.example.com/login?from=api",
+		},
+		{
+			name: "meta refresh跳转",
+			body: `<html><head><meta http-equiv="refresh" content="0; url=/passport/signin"></head></html>`,
+			want: "/passport/signin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := New().Validate([]byte(tt.body))
+			if !errors.Is(err, errs.ErrSessionExpired) || !errors.Is(err, errs.ErrInvalidJSON) {
+				t.Fatalf("Validate() error = %v, want ErrSessionExpired and ErrInvalidJSON", err)
+			}
+			if !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Validate() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_NotSession(t *testing.T) {
+	for _, body := range []string{`{"title":`, `not json`, `<script>location.href="/cases/1"</script>`} {
+		err := New().Validate([]byte(body))
+		if err == nil || errors.Is(err, errs.ErrSessionExpired) {
+			t.Errorf("Validate(%q) error = %v, want plain JSON error", body, err)
+		}
+	}
+}
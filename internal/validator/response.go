@@ -1,9 +1,16 @@
 package validator
 
 import (
+	"bytes"
 	"encoding/json"
-	"fmt"
+	"errors"
+	"io"
+	"log/slog"
 	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/logger"
 )
 
 func min(a, b int) int {
@@ -15,45 +22,92 @@ func min(a, b int) int {
 
 // ResponseValidator 响应校验器
 type ResponseValidator struct {
-	verbose bool
+	logger *slog.Logger
+	limits Limits
+}
+
+// Option 响应校验器选项
+type Option func(*ResponseValidator)
+
+// WithLogger 设置日志器，默认不输出日志
+func WithLogger(l *slog.Logger) Option {
+	return func(v *ResponseValidator) {
+		if l != nil {
+			v.logger = l
+		}
+	}
 }
 
 // New 创建新的响应校验器
-func New(verbose bool) *ResponseValidator {
-	return &ResponseValidator{
-		verbose: verbose,
+func New(opts ...Option) *ResponseValidator {
+	v := &ResponseValidator{logger: logger.Discard()}
+	for _, opt := range opts {
+		opt(v)
 	}
+	return v
 }
 
 // Validate 校验HTTP响应
 func (v *ResponseValidator) Validate(data []byte) error {
 	if len(data) == 0 {
-		return fmt.Errorf("响应体为空")
+		return errs.Mark(i18n.Errorf("响应体为空"), errs.ErrInvalidJSON)
 	}
 
-	if v.verbose {
-		fmt.Printf("开始校验响应，响应体大小: %d 字节\n", len(data))
-		fmt.Printf("响应体前100字符: %s\n", string(data[:min(100, len(data))]))
+	v.logger.Debug(i18n.T("开始校验响应"), "size", len(data), "preview", string(data[:min(100, len(data))]))
+
+	if err := v.checkSize(data); err != nil {
+		return err
 	}
 
-	// 尝试解析JSON
-	var js json.RawMessage
-	if err := json.Unmarshal(data, &js); err != nil {
+	// 尝试解析JSON，允许首尾相接的多个文档
+	if _, err := Documents(data); err != nil {
 		// 输出详细的JSON解析错误信息
-		if v.verbose {
-			fmt.Printf("JSON解析失败: %v\n", err)
-			fmt.Printf("原始响应数据: %s\n", string(data[:min(500, len(data))]))
+		v.logger.Debug(i18n.T("JSON解析失败"), "error", err, "raw", string(data[:min(500, len(data))]))
+		// 登录态过期时接口常返回登录页或跳转脚本，给出比JSON语法错误更直接的提示
+		if sessionErr := sessionError("", data); sessionErr != nil {
+			return sessionErr
 		}
-		return fmt.Errorf("JSON解析失败: %w", err)
+		return errs.Mark(i18n.Errorf("JSON解析失败: %w", err), jsonErrorKind(err))
 	}
 
-	if v.verbose {
-		fmt.Println("响应校验通过，格式为有效的JSON")
+	if err := v.checkStructure(data); err != nil {
+		return err
 	}
 
+	v.logger.Debug(i18n.T("响应校验通过，格式为有效的JSON"))
+
 	return nil
 }
 
+// Documents 依次解码data中首尾相接的JSON文档（如多个格式化后的对象直接拼接在同一个文件中），
+// 按顺序返回每个文档的原始字节；没有任何文档时按被截断的JSON报错
+func Documents(data []byte) ([]json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var docs []json.RawMessage
+	for {
+		var doc json.RawMessage
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			if len(docs) == 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// jsonErrorKind 区分被截断的JSON与其他格式错误
+func jsonErrorKind(err error) error {
+	if errs.IsTruncated(err) {
+		return errs.ErrTruncatedJSON
+	}
+	return errs.ErrInvalidJSON
+}
+
 // IsJSONContentType 检查Content-Type是否为JSON
 func (v *ResponseValidator) IsJSONContentType(contentType string) bool {
 	if contentType == "" {
@@ -62,6 +116,6 @@ func (v *ResponseValidator) IsJSONContentType(contentType string) bool {
 
 	ct := strings.ToLower(contentType)
 	return strings.Contains(ct, "application/json") ||
-		   strings.Contains(ct, "text/json") ||
-		   strings.Contains(ct, "application/vnd.api+json")
-}
\ No newline at end of file
+		strings.Contains(ct, "text/json") ||
+		strings.Contains(ct, "application/vnd.api+json")
+}
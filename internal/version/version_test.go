@@ -0,0 +1,54 @@
+package version
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.1.0", "v2.1.0", 0},
+		{"2.1.0", "2.2.0", -1},
+		{"v2.10.0", "v2.9.3", 1},
+		{"2.1", "2.1.0", 0},
+		{"2.1.0-rc1", "2.1.0", 0},
+		{"dev", "1.0.0", -1},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestLatest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"tag_name":"v2.3.0","name":"v2.3.0"}`))
+	}))
+	defer srv.Close()
+
+	old := ReleaseURL
+	ReleaseURL = srv.URL
+	defer func() { ReleaseURL = old }()
+
+	tag, err := Latest(context.Background(), srv.Client())
+	if err != nil {
+		t.Fatalf("Latest() error = %v", err)
+	}
+	if tag != "v2.3.0" {
+		t.Errorf("Latest() = %q, want v2.3.0", tag)
+	}
+}
+
+func TestGetFillsUnknown(t *testing.T) {
+	info := Get()
+	if info.Version == "" || info.Commit == "" || info.Date == "" {
+		t.Errorf("Get() 存在空字段: %+v", info)
+	}
+}
@@ -1,12 +1,13 @@
 package main
 
 import (
-	"caseurl2md/internal/cli"
+	"github.com/wellkilo/Curl2json/internal/cli"
+	"github.com/wellkilo/Curl2json/internal/exitcode"
 	"os"
 )
 
 func main() {
 	if err := cli.Execute(); err != nil {
-		os.Exit(1)
+		os.Exit(exitcode.From(err))
 	}
-}
\ No newline at end of file
+}
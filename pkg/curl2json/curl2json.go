@@ -0,0 +1,160 @@
+// Package curl2json 将cURL命令转换为精简的树状JSON，供其他Go程序嵌入使用。
+//
+//	result, err := curl2json.Convert(ctx, `curl "https://api.example.com/cases" -H "x-jwt-token: xxx"`,
+//		curl2json.WithTimeout(10*time.Second))
+//
+// 返回值与命令行工具写入的输出文件内容一致，可用 json.Unmarshal 解析为 *Tree。
+package curl2json
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/logger"
+	"github.com/wellkilo/Curl2json/internal/pipeline"
+	"github.com/wellkilo/Curl2json/internal/processor"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/parser"
+)
+
+// 失败类别，可通过 errors.Is 判断：
+//
+//	if errors.Is(err, curl2json.ErrEmptyTree) { ... }
+var (
+	ErrCurlParse      = errs.ErrCurlParse      // cURL命令无法解析
+	ErrInvalidJSON    = errs.ErrInvalidJSON    // 响应体不是有效的JSON
+	ErrTruncatedJSON  = errs.ErrTruncatedJSON  // 响应体或其中嵌套的JSON字符串被截断
+	ErrEmptyTree      = errs.ErrEmptyTree      // 响应中没有可抽取的树结构
+	ErrSessionExpired = errs.ErrSessionExpired // 响应为HTML页面或登录跳转，登录态很可能已过期
+	ErrLimitExceeded  = errs.ErrLimitExceeded  // 响应体超过大小、嵌套深度或字符串长度上限
+)
+
+// ErrHTTPStatus 服务器返回了非2xx状态码且响应无法用于抽取，可通过 errors.As 获取状态码：
+//
+//	var statusErr *curl2json.ErrHTTPStatus
+//	if errors.As(err, &statusErr) && statusErr.Code == http.StatusUnauthorized { ... }
+type ErrHTTPStatus = errs.ErrHTTPStatus
+
+// DefaultTimeout 默认的HTTP请求超时时间
+const DefaultTimeout = 30 * time.Second
+
+// settings 由 Option 填充的转换设置
+type settings struct {
+	config config.Config
+	hooks  []func(*pipeline.Hooks)
+}
+
+// Option 转换选项
+type Option func(*settings)
+
+// WithTitleKeys 设置节点内容字段候选键名，默认为 extractor.DefaultTitleKeys()
+func WithTitleKeys(keys ...string) Option {
+	return func(s *settings) { s.config.TitleKeys = keys }
+}
+
+// WithChildrenKeys 设置子节点数组候选键名，默认为 extractor.DefaultChildrenKeys()
+func WithChildrenKeys(keys ...string) Option {
+	return func(s *settings) { s.config.ChildrenKeys = keys }
+}
+
+// WithTimeout 设置HTTP请求超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *settings) { s.config.Timeout = timeout }
+}
+
+// WithLogger 设置日志器，默认不输出任何日志
+func WithLogger(l *slog.Logger) Option {
+	return func(s *settings) { s.config.Logger = l }
+}
+
+// WithMaxDepth 设置树抽取的最大递归深度，默认为 extractor.DefaultMaxDepth
+func WithMaxDepth(depth int) Option {
+	return func(s *settings) { s.config.MaxDepth = depth }
+}
+
+// WithTransport 设置HTTP请求使用的传输层（如自定义代理、TLS或测试桩），默认为 http.DefaultTransport
+func WithTransport(transport http.RoundTripper) Option {
+	return func(s *settings) { s.config.Transport = transport }
+}
+
+// Stage 处理流水线阶段，依次为 parse → execute → validate → extract → render
+type Stage = pipeline.Stage
+
+// 流水线阶段
+const (
+	StageParse    = pipeline.StageParse
+	StageExecute  = pipeline.StageExecute
+	StageValidate = pipeline.StageValidate
+	StageExtract  = pipeline.StageExtract
+	StageRender   = pipeline.StageRender
+)
+
+// State 在各阶段之间传递的数据（请求、响应、树状JSON、最终输出），钩子可以读取和修改
+type State = pipeline.State
+
+// Hook 阶段钩子，返回错误会中止转换
+type Hook = pipeline.Hook
+
+// WithBefore 注册在stage执行前调用的钩子，例如在 execute 前刷新认证请求头
+func WithBefore(stage Stage, hook Hook) Option {
+	return func(s *settings) {
+		s.hooks = append(s.hooks, func(h *pipeline.Hooks) { h.Before(stage, hook) })
+	}
+}
+
+// WithAfter 注册在stage执行后调用的钩子，例如在 execute 后改写响应体、在 render 后追加处理
+func WithAfter(stage Stage, hook Hook) Option {
+	return func(s *settings) {
+		s.hooks = append(s.hooks, func(h *pipeline.Hooks) { h.After(stage, hook) })
+	}
+}
+
+// Convert 解析cURL命令、执行请求并从响应中抽取树状结构，返回树状JSON；
+// ctx 的截止时间与取消作用于整个过程
+func Convert(ctx context.Context, curlCmd string, opts ...Option) ([]byte, error) {
+	return newProcessor(opts).Process(ctx, curlCmd, nil)
+}
+
+// ConvertRequest 执行已解析的请求并抽取树状结构，返回树状JSON
+func ConvertRequest(ctx context.Context, req *parser.Request, opts ...Option) ([]byte, error) {
+	return newProcessor(opts).Process(ctx, "", req)
+}
+
+// Tree 抽取结果，包含根节点、统计和警告，可通过 json.Marshal 或 MarshalMarkdown 输出
+type Tree = extractor.Tree
+
+// ConvertResponse 不发起请求，直接从已获取的响应体中抽取树状结构
+func ConvertResponse(ctx context.Context, body []byte, opts ...Option) (*Tree, error) {
+	return newProcessor(opts).ExtractTree(ctx, body)
+}
+
+// Extract 从r流式读取已获取的响应体，将树状JSON直接写入w，适合通过管道处理大响应；
+// 仅使用抽取相关的选项，不经过流水线钩子
+//
+//	resp, _ := http.Get(url)
+//	defer resp.Body.Close()
+//	err := curl2json.Extract(ctx, resp.Body, os.Stdout)
+func Extract(ctx context.Context, r io.Reader, w io.Writer, opts ...Option) error {
+	return newProcessor(opts).GetExtractor().ExtractStream(ctx, r, w)
+}
+
+func newProcessor(opts []Option) *processor.Processor {
+	s := &settings{config: config.Config{
+		Timeout: DefaultTimeout,
+		Logger:  logger.Discard(),
+	}}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	p := processor.New(&s.config)
+	for _, register := range s.hooks {
+		register(p.Hooks())
+	}
+	return p
+}
@@ -0,0 +1,219 @@
+package curl2json
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+	"github.com/wellkilo/Curl2json/pkg/parser"
+)
+
+const testCaseMindResponse = `{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[{\"data\":{\"text\":\"输入存在的门店名称\"},\"children\":[]}]}]}"}}`
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-jwt-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, testCaseMindResponse)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestConvert_ParseError(t *testing.T) {
+	if _, err := Convert(context.Background(), `curl -H 'x-jwt-token: token'`); err == nil {
+		t.Error("Convert() without URL error = nil, want error")
+	}
+}
+
+func TestConvertRequest(t *testing.T) {
+	server := newServer(t)
+
+	req := &parser.Request{URL: server.URL, Method: "GET", Headers: map[string]string{"x-jwt-token": "token"}}
+	result, err := ConvertRequest(context.Background(), req)
+	if err != nil {
+		t.Fatalf("ConvertRequest() error = %v", err)
+	}
+	nodes, err := extractor.ParseNodes(result)
+	if err != nil {
+		t.Fatalf("ParseNodes() error = %v", err)
+	}
+	if got := extractor.CountNodes(nodes); got != 3 {
+		t.Errorf("CountNodes() = %d, want 3; result: %s", got, result)
+	}
+
+	req.Headers = nil
+	if _, err := ConvertRequest(context.Background(), req); err == nil {
+		t.Error("ConvertRequest() without token error = nil, want error")
+	}
+}
+
+func TestConvertResponse(t *testing.T) {
+	tree, err := ConvertResponse(context.Background(), []byte(testCaseMindResponse))
+	if err != nil {
+		t.Fatalf("ConvertResponse() error = %v", err)
+	}
+	if len(tree.Roots) != 1 || tree.Roots[0].Name != "客户详情-门店列表" {
+		t.Errorf("ConvertResponse() = %+v, want single root 客户详情-门店列表", tree.Roots)
+	}
+	if tree.Stats.Nodes != 3 || tree.Stats.Depth != 3 {
+		t.Errorf("ConvertResponse() stats = %+v, want 3 nodes and depth 3", tree.Stats)
+	}
+}
+
+func TestExtract(t *testing.T) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := io.Copy(pw, strings.NewReader(testCaseMindResponse))
+		pw.CloseWithError(err)
+	}()
+
+	var out strings.Builder
+	if err := Extract(context.Background(), pr, &out); err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	nodes, err := extractor.ParseNodes([]byte(out.String()))
+	if err != nil {
+		t.Fatalf("ParseNodes() error = %v", err)
+	}
+	if got := extractor.CountNodes(nodes); got != 3 {
+		t.Errorf("CountNodes() = %d, want 3; result: %s", got, out.String())
+	}
+
+	if err := Extract(context.Background(), strings.NewReader("not json"), io.Discard); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("Extract() error = %v, want ErrInvalidJSON", err)
+	}
+}
+
+// roundTripFunc 将函数适配为 http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestConvertRequest_WithTransport(t *testing.T) {
+	var requested string
+	transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = r.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(testCaseMindResponse)),
+			Request:    r,
+		}, nil
+	})
+
+	req := &parser.Request{URL: "https://cases.invalid/api", Method: "GET"}
+	if _, err := ConvertRequest(context.Background(), req, WithTransport(transport), WithMaxDepth(10)); err != nil {
+		t.Fatalf("ConvertRequest() error = %v", err)
+	}
+	if requested != req.URL {
+		t.Errorf("transport received %q, want %q", requested, req.URL)
+	}
+}
+
+func TestConvertRequest_ContextDeadline(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := ConvertRequest(ctx, &parser.Request{URL: server.URL, Method: "GET"})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("ConvertRequest() error = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestErrorClasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message":"Jwt validate failed"}`)
+		case "/html":
+			fmt.Fprint(w, `<html>login</html>`)
+		case "/truncated":
+			fmt.Fprint(w, `{"errCode":0,"data":{"TestCaseMind":"{}"`)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path string
+		want error
+	}{
+		{"/html", ErrInvalidJSON},
+		{"/truncated", ErrTruncatedJSON},
+	}
+	for _, tt := range tests {
+		_, err := ConvertRequest(context.Background(), &parser.Request{URL: server.URL + tt.path, Method: "GET"})
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: error = %v, want %v", tt.path, err, tt.want)
+		}
+	}
+
+	_, err := ConvertRequest(context.Background(), &parser.Request{URL: server.URL + "/unauthorized", Method: "GET"})
+	var statusErr *ErrHTTPStatus
+	if !errors.As(err, &statusErr) || statusErr.Code != http.StatusUnauthorized {
+		t.Errorf("/unauthorized: error = %v, want ErrHTTPStatus{401}", err)
+	}
+
+	if _, err := Convert(context.Background(), "curl -X POST"); !errors.Is(err, ErrCurlParse) {
+		t.Errorf("Convert() error = %v, want ErrCurlParse", err)
+	}
+	if _, err := ConvertResponse(context.Background(), []byte(`{"errCode":0,"data":{"TestCaseMind":"{\"data\":{\"text\":"}}`)); !errors.Is(err, ErrTruncatedJSON) {
+		t.Errorf("ConvertResponse() error = %v, want ErrTruncatedJSON", err)
+	}
+}
+
+func TestConvertRequest_Hooks(t *testing.T) {
+	server := newServer(t)
+
+	var rendered int
+	result, err := ConvertRequest(context.Background(), &parser.Request{URL: server.URL, Method: "GET"},
+		// 执行前补充认证请求头
+		WithBefore(StageExecute, func(ctx context.Context, state *State) error {
+			state.Request.Headers = map[string]string{"x-jwt-token": "token"}
+			return nil
+		}),
+		// 执行后改写响应中的节点名称
+		WithAfter(StageExecute, func(ctx context.Context, state *State) error {
+			state.Body = []byte(strings.ReplaceAll(string(state.Body), "门店搜索", "门店查询"))
+			return nil
+		}),
+		WithAfter(StageRender, func(ctx context.Context, state *State) error {
+			rendered = len(state.Output)
+			return nil
+		}),
+	)
+	if err != nil {
+		t.Fatalf("ConvertRequest() error = %v", err)
+	}
+	if !strings.Contains(string(result), "门店查询") {
+		t.Errorf("result = %s, want rewritten node name", result)
+	}
+	if rendered != len(result) {
+		t.Errorf("render hook saw %d bytes, want %d", rendered, len(result))
+	}
+
+	errAbort := errors.New("abort")
+	_, err = ConvertRequest(context.Background(), &parser.Request{URL: server.URL, Method: "GET"},
+		WithBefore(StageParse, func(ctx context.Context, state *State) error { return errAbort }))
+	if !errors.Is(err, errAbort) {
+		t.Errorf("ConvertRequest() error = %v, want errAbort", err)
+	}
+}
@@ -6,7 +6,7 @@ import (
 
 // TestIsUIBusinessText 测试UI业务文本识别
 func TestIsUIBusinessText(t *testing.T) {
-	e := New([]string{}, []string{}, false)
+	e := New()
 
 	tests := []struct {
 		name     string
@@ -83,7 +83,7 @@ func TestIsUIBusinessText(t *testing.T) {
 
 // TestIsBusinessText 测试业务文本识别
 func TestIsBusinessText(t *testing.T) {
-	e := New([]string{}, []string{}, false)
+	e := New()
 
 	tests := []struct {
 		name     string
@@ -130,4 +130,4 @@ func TestIsBusinessText(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
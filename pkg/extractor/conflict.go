@@ -0,0 +1,87 @@
+package extractor
+
+import (
+	"fmt"
+)
+
+// ConflictStrategy 合并多个来源的树时，同一路径上出现同名节点的处理方式
+type ConflictStrategy string
+
+// 同名节点的处理方式
+const (
+	ConflictKeepAll       ConflictStrategy = "keep-all"       // 全部保留，与未区分冲突时的行为一致
+	ConflictKeepFirst     ConflictStrategy = "keep-first"     // 保留先出现的节点，丢弃后来的同名节点及其子树
+	ConflictKeepLast      ConflictStrategy = "keep-last"      // 后来的节点替换先出现的节点，位置不变
+	ConflictSuffixIndex   ConflictStrategy = "suffix-index"   // 后来的节点名称加上序号，如 门店搜索 (2)
+	ConflictMergeChildren ConflictStrategy = "merge-children" // 合并为一个节点，子节点按同样的规则逐层合并
+)
+
+// ConflictStrategies 返回可用的同名节点处理方式名称
+func ConflictStrategies() []string {
+	return []string{
+		string(ConflictKeepAll), string(ConflictKeepFirst), string(ConflictKeepLast),
+		string(ConflictSuffixIndex), string(ConflictMergeChildren),
+	}
+}
+
+// WithConflictStrategy 设置合并多个JSON文档的根节点时同名节点的处理方式，为空时使用 ConflictKeepAll
+func WithConflictStrategy(strategy ConflictStrategy) Option {
+	return func(e *TreeExtractor) {
+		e.conflict = strategy
+	}
+}
+
+// MergeRoots 将incoming合并到base之后，返回合并结果与冲突的节点数。
+// 只比较incoming与base之间的同名节点，同一来源内的同名节点保持原样；会修改base中的节点
+func MergeRoots(base, incoming []*SimplifiedNode, strategy ConflictStrategy) ([]*SimplifiedNode, int) {
+	if strategy == "" || strategy == ConflictKeepAll {
+		return append(base, incoming...), 0
+	}
+
+	conflicts := 0
+	merged, prior := base, len(base)
+	for _, node := range incoming {
+		if node == nil {
+			continue
+		}
+		i := indexByName(merged[:prior], node.Name)
+		if i < 0 {
+			merged = append(merged, node)
+			continue
+		}
+		conflicts++
+		switch strategy {
+		case ConflictKeepFirst:
+		case ConflictKeepLast:
+			merged[i] = node
+		case ConflictSuffixIndex:
+			node.Name = suffixName(merged, node.Name)
+			merged = append(merged, node)
+		case ConflictMergeChildren:
+			var nested int
+			merged[i].Children, nested = MergeRoots(merged[i].Children, node.Children, strategy)
+			merged[i].Tags = mergeTags(merged[i].Tags, node.Tags)
+			conflicts += nested
+		}
+	}
+	return merged, conflicts
+}
+
+// indexByName 返回第一个名称为name的节点的位置，不存在时返回-1
+func indexByName(nodes []*SimplifiedNode, name string) int {
+	for i, node := range nodes {
+		if node != nil && node.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// suffixName 返回 name (2)、name (3) 等nodes中不存在的名称
+func suffixName(nodes []*SimplifiedNode, name string) string {
+	for index := 2; ; index++ {
+		if candidate := fmt.Sprintf("%s (%d)", name, index); indexByName(nodes, candidate) < 0 {
+			return candidate
+		}
+	}
+}
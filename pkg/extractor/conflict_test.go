@@ -0,0 +1,51 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestTreeExtractor_ConflictStrategy(t *testing.T) {
+	input := `{"title": "门店", "children": [{"title": "搜索"}, {"title": "列表"}]}
+{"title": "门店", "children": [{"title": "搜索", "children": [{"title": "按名称"}]}, {"title": "详情"}]}
+{"title": "订单"}`
+
+	tests := []struct {
+		strategy ConflictStrategy
+		want     string
+	}{
+		{"", `[{"name":"门店","children":[{"name":"搜索","children":[]},{"name":"列表","children":[]}]},{"name":"门店","children":[{"name":"搜索","children":[{"name":"按名称","children":[]}]},{"name":"详情","children":[]}]},{"name":"订单","children":[]}]`},
+		{ConflictKeepFirst, `[{"name":"门店","children":[{"name":"搜索","children":[]},{"name":"列表","children":[]}]},{"name":"订单","children":[]}]`},
+		{ConflictKeepLast, `[{"name":"门店","children":[{"name":"搜索","children":[{"name":"按名称","children":[]}]},{"name":"详情","children":[]}]},{"name":"订单","children":[]}]`},
+		{ConflictSuffixIndex, `[{"name":"门店","children":[{"name":"搜索","children":[]},{"name":"列表","children":[]}]},{"name":"门店 (2)","children":[{"name":"搜索","children":[{"name":"按名称","children":[]}]},{"name":"详情","children":[]}]},{"name":"订单","children":[]}]`},
+		{ConflictMergeChildren, `[{"name":"门店","children":[{"name":"搜索","children":[{"name":"按名称","children":[]}]},{"name":"列表","children":[]},{"name":"详情","children":[]}]},{"name":"订单","children":[]}]`},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			tree, err := New(WithConflictStrategy(tt.strategy)).Extract(context.Background(), []byte(input))
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			got, _ := json.Marshal(tree)
+			if string(got) != tt.want {
+				t.Errorf("Extract() = %s, want %s", got, tt.want)
+			}
+			if conflicted := tt.strategy != ""; conflicted != (len(tree.Warnings) > 0) {
+				t.Errorf("Warnings = %q", tree.Warnings)
+			}
+		})
+	}
+}
+
+func TestMergeRoots_SameSource(t *testing.T) {
+	base := []*SimplifiedNode{{Name: "A"}, {Name: "A"}}
+	merged, conflicts := MergeRoots(base, []*SimplifiedNode{{Name: "A"}, {Name: "A"}}, ConflictSuffixIndex)
+	var names []string
+	for _, node := range merged {
+		names = append(names, node.Name)
+	}
+	if conflicts != 2 || len(names) != 4 || names[2] != "A (2)" || names[3] != "A (3)" {
+		t.Errorf("MergeRoots() = %q, %d conflicts", names, conflicts)
+	}
+}
@@ -0,0 +1,47 @@
+package extractor
+
+// SliceDepth 只保留第from到第to层的节点（根节点为第1层），第from层的节点成为新的根节点，按深度优先的顺序排列，
+// 第to层的节点不再有子节点；to小于等于0时不限制最大层数
+func (t *Tree) SliceDepth(from, to int) {
+	var roots []*SimplifiedNode
+	var walk func(nodes []*SimplifiedNode, level int)
+	walk = func(nodes []*SimplifiedNode, level int) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			if level < from {
+				walk(node.Children, level+1)
+				continue
+			}
+			roots = append(roots, node)
+		}
+	}
+	walk(t.Roots, 1)
+	if to > 0 {
+		pruneDepth(roots, to-from+1)
+	}
+
+	if from > 1 {
+		if roots == nil {
+			roots = []*SimplifiedNode{}
+		}
+		t.Roots = roots
+		t.multiRoot = true
+	}
+	t.Stats = countStats(t.Roots, 1)
+}
+
+// pruneDepth 删除nodes中第depth层以下的子节点，nodes为第1层
+func pruneDepth(nodes []*SimplifiedNode, depth int) {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		if depth <= 1 {
+			node.Children = []*SimplifiedNode{}
+			continue
+		}
+		pruneDepth(node.Children, depth-1)
+	}
+}
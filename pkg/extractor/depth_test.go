@@ -0,0 +1,40 @@
+package extractor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTree_SliceDepth(t *testing.T) {
+	const tree = `{"name":"包装","children":[{"name":"搜索","children":[{"name":"按名称","children":[{"name":"步骤","children":[]}]}]},{"name":"详情","children":[]}]}`
+
+	tests := []struct {
+		name     string
+		from, to int
+		want     string
+	}{
+		{name: "不截取", from: 1, want: tree},
+		{name: "最大层数", from: 1, to: 2, want: `{"name":"包装","children":[{"name":"搜索","children":[]},{"name":"详情","children":[]}]}`},
+		{name: "起始层数", from: 2, want: `[{"name":"搜索","children":[{"name":"按名称","children":[{"name":"步骤","children":[]}]}]},{"name":"详情","children":[]}]`},
+		{name: "中间两层", from: 2, to: 3, want: `[{"name":"搜索","children":[{"name":"按名称","children":[]}]},{"name":"详情","children":[]}]`},
+		{name: "只有一层", from: 3, to: 3, want: `[{"name":"按名称","children":[]}]`},
+		{name: "超过最大层数", from: 5, want: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parsed Tree
+			if err := json.Unmarshal([]byte(tree), &parsed); err != nil {
+				t.Fatal(err)
+			}
+			parsed.SliceDepth(tt.from, tt.to)
+			got, _ := json.Marshal(&parsed)
+			if string(got) != tt.want {
+				t.Errorf("SliceDepth(%d, %d) = %s, want %s", tt.from, tt.to, got, tt.want)
+			}
+			if want := countStats(parsed.Roots, 1); parsed.Stats != want {
+				t.Errorf("Stats = %+v, want %+v", parsed.Stats, want)
+			}
+		})
+	}
+}
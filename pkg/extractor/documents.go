@@ -0,0 +1,88 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// decodeDocuments 依次解码r中首尾相接的JSON文档（如多个格式化后的对象直接拼接在同一个文件中），
+// 文档之间可以有任意空白；没有任何文档时按被截断的JSON报错，与 json.Unmarshal 处理空输入一致
+func decodeDocuments(r io.Reader) ([]interface{}, error) {
+	decoder := json.NewDecoder(r)
+	var docs []interface{}
+	for {
+		var doc interface{}
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			if len(docs) == 0 {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return docs, nil
+		}
+		if err != nil {
+			if len(docs) > 0 {
+				return nil, i18n.Errorf("第 %d 个JSON文档: %w", len(docs)+1, err)
+			}
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// extractDocuments 分别抽取每个文档，按文档顺序将各自的根节点合并为多根树，同名节点按 WithConflictStrategy 处理；
+// 只有一个文档时与直接抽取该文档相同。警告带上文档序号，抽取策略与业务文本规则取第一个文档的
+func (e *TreeExtractor) extractDocuments(ctx context.Context, docs []interface{}) (*Tree, error) {
+	if len(docs) == 1 {
+		return e.extract(ctx, docs[0])
+	}
+
+	roots := []*SimplifiedNode{}
+	conflicts := 0
+	var merged Tree
+	for i, doc := range docs {
+		tree, err := e.extract(ctx, doc)
+		if err != nil {
+			return nil, i18n.Errorf("第 %d 个JSON文档: %w", i+1, err)
+		}
+		if i == 0 {
+			merged.Strategy, merged.TextProfile = tree.Strategy, tree.TextProfile
+		}
+		var n int
+		roots, n = MergeRoots(roots, tree.Roots, e.conflict)
+		conflicts += n
+		for _, warning := range tree.Warnings {
+			merged.warn("第 %d 个JSON文档: %s", i+1, warning)
+		}
+		merged.Duplicates = append(merged.Duplicates, tree.Duplicates...)
+		if limit := tree.DepthLimit; limit != nil {
+			if merged.DepthLimit == nil {
+				merged.DepthLimit = &DepthLimit{MaxDepth: limit.MaxDepth}
+			}
+			merged.DepthLimit.Hits += limit.Hits
+			if len(limit.DeepestPath) > len(merged.DepthLimit.DeepestPath) {
+				merged.DepthLimit.DeepestPath = limit.DeepestPath
+			}
+		}
+	}
+
+	if conflicts > 0 {
+		merged.warn("各文档中有 %d 个同名节点，已按 %s 处理", conflicts, e.conflict)
+	}
+
+	// 每个文档的根节点序号都从0开始，合并后按在结果中的位置重新编号
+	if e.nodeOrder {
+		setOrder(roots)
+	}
+
+	tree := newTree(roots)
+	tree.Strategy, tree.TextProfile = merged.Strategy, merged.TextProfile
+	tree.Warnings = merged.Warnings
+	tree.Duplicates = merged.Duplicates
+	tree.DepthLimit = merged.DepthLimit
+	e.logger.Debug(i18n.T("输入包含多个JSON文档，已合并各文档的根节点"), "documents", len(docs), "roots", len(roots))
+	return tree, nil
+}
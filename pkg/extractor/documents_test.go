@@ -0,0 +1,64 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+)
+
+func TestTreeExtractor_MultipleDocuments(t *testing.T) {
+	input := `{
+  "title": "模块A",
+  "children": [{"title": "A1"}]
+}
+{
+  "title": "模块B"
+}`
+	want := `[{"name":"模块A","order":0,"children":[{"name":"A1","order":0,"children":[]}]},{"name":"模块B","order":1,"children":[]}]`
+
+	tree, err := New(WithNodeOrder(true)).Extract(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, _ := json.Marshal(tree)
+	if string(got) != want {
+		t.Errorf("Extract() = %s, want %s", got, want)
+	}
+	if tree.Stats.Nodes != 3 {
+		t.Errorf("Stats.Nodes = %d, want 3", tree.Stats.Nodes)
+	}
+
+	var buf bytes.Buffer
+	if err := New(WithNodeOrder(true)).ExtractStream(context.Background(), strings.NewReader(input), &buf); err != nil {
+		t.Fatalf("ExtractStream() error = %v", err)
+	}
+	indented, _ := json.MarshalIndent(tree, "", "  ")
+	if buf.String() != string(indented)+"\n" {
+		t.Errorf("ExtractStream() = %s, want %s", buf.String(), indented)
+	}
+}
+
+func TestTreeExtractor_MultipleDocumentsErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  error
+	}{
+		{"第二个文档无效", `{"title":"A"} {"title":`, errs.ErrTruncatedJSON},
+		{"第二个文档不是JSON", `{"title":"A"} title`, errs.ErrInvalidJSON},
+		{"只有空白", "  \n", errs.ErrTruncatedJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New().Extract(context.Background(), []byte(tt.input))
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Extract() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package extractor
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Duplicate 抽取时因内容重复而合并的节点：响应中多处出现的同一段文本只保留第一处
+type Duplicate struct {
+	Path    []string `json:"path"`    // 保留的节点在结果中的路径，从根节点开始
+	Count   int      `json:"count"`   // 该文本在响应中出现的次数，丢弃了其中 Count-1 处
+	Sources []string `json:"sources"` // 每次出现的JSONPath，第一个为保留的位置
+}
+
+// textSource 从响应中提取的文本及其JSONPath
+type textSource struct {
+	text string
+	path string
+}
+
+// identifierRe 可以直接用 .key 表示的JSONPath键名
+var identifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// keyPath 返回path下键key的JSONPath，键名不是标识符时使用 ['key']
+func keyPath(path, key string) string {
+	if identifierRe.MatchString(key) {
+		return path + "." + key
+	}
+	return path + "['" + key + "']"
+}
+
+// indexPath 返回path下第index个元素的JSONPath
+func indexPath(path string, index int) string {
+	return path + "[" + strconv.Itoa(index) + "]"
+}
+
+// collectTexts 按 ExtractTextContent 的规则提取业务文本，同时记录每段文本的JSONPath
+func (e *TreeExtractor) collectTexts(data interface{}, path string) []textSource {
+	var texts []textSource
+
+	switch v := data.(type) {
+	case string:
+		if v != "" && e.isBusinessText(v) {
+			texts = append(texts, textSource{text: v, path: path})
+		}
+	case map[string]interface{}:
+		// 优先查找richText数组中的text字段
+		if richTextArray, exists := v["richText"]; exists {
+			if richTextItems, ok := richTextArray.([]interface{}); ok {
+				for i, item := range richTextItems {
+					if richTextObj, ok := item.(map[string]interface{}); ok {
+						if textVal, textExists := richTextObj["text"]; textExists {
+							if textStr, ok := textVal.(string); ok && textStr != "" && e.isBusinessText(textStr) {
+								texts = append(texts, textSource{text: textStr, path: keyPath(indexPath(keyPath(path, "richText"), i), "text")})
+							}
+						}
+					}
+				}
+			}
+		}
+
+		// 查找其他可能的text字段
+		for key, value := range v {
+			// 只关注包含text的字段
+			if key == "text" || strings.Contains(key, "text") ||
+				key == "title" || key == "name" || key == "label" || key == "message" || key == "description" {
+				if textVal, ok := value.(string); ok && textVal != "" && e.isBusinessText(textVal) {
+					texts = append(texts, textSource{text: textVal, path: keyPath(path, key)})
+				}
+			} else {
+				// 递归处理嵌套结构
+				texts = append(texts, e.collectTexts(value, keyPath(path, key))...)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			texts = append(texts, e.collectTexts(item, indexPath(path, i))...)
+		}
+	default:
+		// 对于其他类型，不处理，避免技术字段混入
+	}
+
+	return texts
+}
+
+// dedupTexts 按首次出现的顺序返回不重复的业务文本，以及每段文本出现的所有位置；
+// richText 中的文本会被提取两次，同一位置只记录一次
+func (e *TreeExtractor) dedupTexts(sources []textSource) ([]string, map[string][]string) {
+	var texts []string
+	occurrences := make(map[string][]string)
+	for _, source := range sources {
+		if !e.isBusinessText(source.text) {
+			continue
+		}
+		paths, seen := occurrences[source.text]
+		if !seen {
+			texts = append(texts, source.text)
+		}
+		if !containsString(paths, source.path) {
+			occurrences[source.text] = append(paths, source.path)
+		}
+	}
+	return texts, occurrences
+}
+
+// containsString 判断values中是否包含value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// countDuplicates 返回合并的节点数与丢弃的重复次数
+func countDuplicates(duplicates []Duplicate) (nodes, dropped int) {
+	for _, d := range duplicates {
+		nodes++
+		dropped += d.Count - 1
+	}
+	return nodes, dropped
+}
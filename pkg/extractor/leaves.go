@@ -0,0 +1,45 @@
+package extractor
+
+import "strings"
+
+// LeafPathSeparator 叶子节点带路径输出时，各层节点名称之间的分隔符
+const LeafPathSeparator = " / "
+
+// FlattenLeaves 将树替换为只包含叶子节点的多根列表，按深度优先的顺序排列并去除重复，名称为空的叶子被丢弃；
+// withPath 为true时叶子的名称为从根节点开始、以 LeafPathSeparator 连接的路径，路径相同时才视为重复
+func (t *Tree) FlattenLeaves(withPath bool) {
+	var leaves []*SimplifiedNode
+	seen := make(map[string]bool)
+	var walk func(nodes []*SimplifiedNode, path []string)
+	walk = func(nodes []*SimplifiedNode, path []string) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			if len(node.Children) > 0 {
+				walk(node.Children, append(path, node.Name))
+				continue
+			}
+			if strings.TrimSpace(node.Name) == "" {
+				continue
+			}
+			name := node.Name
+			if withPath {
+				name = strings.Join(append(path, node.Name), LeafPathSeparator)
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			leaves = append(leaves, &SimplifiedNode{Name: name, Tags: node.Tags, Children: []*SimplifiedNode{}})
+		}
+	}
+	walk(t.Roots, nil)
+
+	if leaves == nil {
+		leaves = []*SimplifiedNode{}
+	}
+	t.Roots = leaves
+	t.multiRoot = true
+	t.Stats = countStats(leaves, 1)
+}
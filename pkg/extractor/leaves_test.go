@@ -0,0 +1,42 @@
+package extractor
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTree_FlattenLeaves(t *testing.T) {
+	const tree = `[{"name":"门店","children":[{"name":"搜索","children":[{"name":"按名称","children":[]},{"name":" ","children":[]}]},{"name":"详情","children":[{"name":"按名称","children":[]}]}]},{"name":"按名称","children":[]}]`
+
+	tests := []struct {
+		name     string
+		withPath bool
+		want     string
+	}{
+		{name: "名称", want: `[{"name":"按名称","children":[]}]`},
+		{name: "路径", withPath: true, want: `[{"name":"门店 / 搜索 / 按名称","children":[]},{"name":"门店 / 详情 / 按名称","children":[]},{"name":"按名称","children":[]}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var parsed Tree
+			if err := json.Unmarshal([]byte(tree), &parsed); err != nil {
+				t.Fatal(err)
+			}
+			parsed.FlattenLeaves(tt.withPath)
+			got, _ := json.Marshal(&parsed)
+			if string(got) != tt.want {
+				t.Errorf("FlattenLeaves() = %s, want %s", got, tt.want)
+			}
+			if parsed.Stats.Nodes != parsed.Stats.Leaves || parsed.Stats.Leaves != len(parsed.Roots) {
+				t.Errorf("Stats = %+v, want only leaves", parsed.Stats)
+			}
+		})
+	}
+
+	empty := newTree(nil)
+	empty.FlattenLeaves(false)
+	if got, _ := json.Marshal(empty); string(got) != "[]" {
+		t.Errorf("FlattenLeaves() on an empty tree = %s, want []", got)
+	}
+}
@@ -0,0 +1,45 @@
+package extractor
+
+import (
+	"sort"
+	"strconv"
+)
+
+// childList 将子节点的原始值转换为有序列表：数组原样返回；键全部为非负整数的对象
+// （如 {"0": {...}, "1": {...}}，部分后端序列化数组或删除过元素的稀疏数组时会得到这种形式）按键的数值排序后返回值。
+// 其他值返回false
+func childList(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case map[string]interface{}:
+		return numericKeyedList(v)
+	}
+	return nil, false
+}
+
+// numericKeyedList 对象的键全部为十进制非负整数（不含前导零与符号）时，按键的数值顺序返回对象的值
+func numericKeyedList(obj map[string]interface{}) ([]interface{}, bool) {
+	if len(obj) == 0 {
+		return nil, false
+	}
+	type entry struct {
+		index int
+		value interface{}
+	}
+	entries := make([]entry, 0, len(obj))
+	for key, value := range obj {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || strconv.Itoa(index) != key {
+			return nil, false
+		}
+		entries = append(entries, entry{index, value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+
+	list := make([]interface{}, len(entries))
+	for i, e := range entries {
+		list[i] = e.value
+	}
+	return list, true
+}
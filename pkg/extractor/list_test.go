@@ -0,0 +1,65 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestChildList(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		want   []interface{}
+		wantOK bool
+	}{
+		{"数组", []interface{}{"a", "b"}, []interface{}{"a", "b"}, true},
+		{"数字键按数值排序", map[string]interface{}{"10": "c", "2": "b", "0": "a"}, []interface{}{"a", "b", "c"}, true},
+		{"稀疏的数字键", map[string]interface{}{"5": "b", "1": "a"}, []interface{}{"a", "b"}, true},
+		{"含非数字键", map[string]interface{}{"0": "a", "name": "b"}, nil, false},
+		{"前导零", map[string]interface{}{"01": "a"}, nil, false},
+		{"负数", map[string]interface{}{"-1": "a"}, nil, false},
+		{"空对象", map[string]interface{}{}, nil, false},
+		{"字符串", "a", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := childList(tt.value)
+			if ok != tt.wantOK || !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("childList() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestTreeExtractor_NumericKeyedChildren(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			"标准树结构",
+			`{"title":"根","children":{"2":{"title":"C"},"0":{"title":"A","children":{"0":{"title":"A1"}}},"1":{"title":"B"}}}`,
+			`{"name":"根","children":[{"name":"A","children":[{"name":"A1","children":[]}]},{"name":"B","children":[]},{"name":"C","children":[]}]}`,
+		},
+		{
+			"TestCaseMind",
+			`{"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":{\"1\":{\"data\":{\"text\":\"门店详情\"}},\"0\":{\"data\":{\"text\":\"门店搜索\"}}}}"}}`,
+			`[{"name":"客户详情-门店列表","children":[{"name":"门店搜索","children":[]},{"name":"门店详情","children":[]}]}]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := New().Extract(context.Background(), []byte(tt.input))
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			got, _ := json.Marshal(tree)
+			if string(got) != tt.want {
+				t.Errorf("Extract() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
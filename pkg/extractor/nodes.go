@@ -0,0 +1,61 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// ParseNodes 解析抽取结果JSON，兼容数组格式和单个根节点格式；数组中的 null 节点被丢弃
+func ParseNodes(data []byte) ([]*SimplifiedNode, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, i18n.Errorf("结果为空")
+	}
+
+	// 抽取不到任何节点时结果为 null，视为空树
+	if bytes.Equal(trimmed, []byte("null")) {
+		return []*SimplifiedNode{}, nil
+	}
+
+	if trimmed[0] == '[' {
+		var nodes []*SimplifiedNode
+		if err := json.Unmarshal(trimmed, &nodes); err != nil {
+			return nil, i18n.Errorf("解析树状结构失败: %w", err)
+		}
+		return dropNil(nodes), nil
+	}
+
+	var node SimplifiedNode
+	if err := json.Unmarshal(trimmed, &node); err != nil {
+		return nil, i18n.Errorf("解析树状结构失败: %w", err)
+	}
+	node.Children = dropNil(node.Children)
+	return []*SimplifiedNode{&node}, nil
+}
+
+// dropNil 就地去掉各层中的 null 节点
+func dropNil(nodes []*SimplifiedNode) []*SimplifiedNode {
+	kept := nodes[:0]
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		node.Children = dropNil(node.Children)
+		kept = append(kept, node)
+	}
+	return kept
+}
+
+// CountNodes 统计树中的节点总数（含所有层级）
+func CountNodes(nodes []*SimplifiedNode) int {
+	count := 0
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		count += 1 + CountNodes(node.Children)
+	}
+	return count
+}
@@ -0,0 +1,73 @@
+package extractor
+
+import "testing"
+
+func TestParseNodesAndCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "单个根节点",
+			input: `{"name":"根","children":[{"name":"A","children":[]},{"name":"B","children":[{"name":"B1","children":[]}]}]}`,
+			want:  4,
+		},
+		{
+			name:  "多根数组",
+			input: `[{"name":"A","children":[]},{"name":"B","children":[]}]`,
+			want:  2,
+		},
+		{
+			name:  "空数组",
+			input: `[]`,
+			want:  0,
+		},
+		{
+			name:  "null视为空树",
+			input: `null`,
+			want:  0,
+		},
+		{
+			name:  "数组中的null节点",
+			input: `[null,{"name":"A","children":[]}]`,
+			want:  1,
+		},
+		{
+			name:  "子节点中的null",
+			input: `{"name":"a","children":[null,{"name":"b","children":[null]}]}`,
+			want:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, err := ParseNodes([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("ParseNodes() error = %v", err)
+			}
+			if got := CountNodes(nodes); got != tt.want {
+				t.Errorf("CountNodes() = %d, want %d", got, tt.want)
+			}
+			if hasNil(nodes) {
+				t.Error("ParseNodes() should drop null nodes")
+			}
+		})
+	}
+}
+
+func TestParseNodesEmpty(t *testing.T) {
+	if _, err := ParseNodes([]byte("  ")); err == nil {
+		t.Error("ParseNodes() 期望空输入返回错误")
+	}
+}
+
+// hasNil 判断树中是否还有 null 节点
+func hasNil(nodes []*SimplifiedNode) bool {
+	for _, node := range nodes {
+		if node == nil || hasNil(node.Children) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+package extractor
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TextProfile 按语言区分的业务文本规则：通用业务文本提取与脑图根节点选择据此判断文本是否为业务文本。
+// 中文规则（zh）即一直以来的内置规则（见 isBusinessText），其余字段不生效；其他语言的文本按文字、长度与停用词过滤，
+// 避免非中文接口的文本被整体过滤掉
+type TextProfile struct {
+	Name      string
+	MinLength int      // 业务文本的最少字符数（不含首尾空白）
+	MaxLength int      // 业务文本的最多字符数，0 表示不限制
+	Stopwords []string // 整段文本（不区分大小写）为这些词时视为状态值等技术字段
+
+	// script 判断字符是否属于该语言的文字，文本中至少要有一个这样的字符
+	script func(r rune) bool
+	// spaced 为true时单词以空格分隔，单个单词的文本还需要像普通单词而不是标识符或枚举值
+	spaced bool
+}
+
+// 各语言的文本规则，auto 表示按响应内容自动选择
+const (
+	ProfileAuto = "auto"
+	ProfileZh   = "zh"
+	ProfileEn   = "en"
+	ProfileJa   = "ja"
+	ProfileKo   = "ko"
+)
+
+// 常见的状态值与技术词汇，不作为业务文本
+var commonStopwords = []string{
+	"ok", "success", "succeeded", "fail", "failed", "failure", "error", "true", "false", "null", "nil", "none",
+	"undefined", "unknown", "pending", "active", "inactive", "enabled", "disabled", "default", "yes", "no",
+	"api", "url", "http", "https", "get", "post", "put", "patch", "delete", "json", "id", "uuid",
+	"unauthorized", "forbidden", "not found", "bad request", "internal server error",
+}
+
+var textProfiles = map[string]*TextProfile{
+	ProfileZh: {Name: ProfileZh, script: isHan},
+	ProfileEn: {
+		Name: ProfileEn, MinLength: 3, MaxLength: 500, Stopwords: commonStopwords, spaced: true,
+		// 其他以空格分词的语言（俄文、德文等）同样适用
+		script: unicode.IsLetter,
+	},
+	ProfileJa: {
+		Name: ProfileJa, MinLength: 2, MaxLength: 300,
+		Stopwords: append([]string{"成功", "失敗", "エラー", "なし", "有効", "無効"}, commonStopwords...),
+		script:    func(r rune) bool { return isKana(r) || isHan(r) },
+	},
+	ProfileKo: {
+		Name: ProfileKo, MinLength: 2, MaxLength: 300,
+		Stopwords: append([]string{"성공", "실패", "오류", "없음", "사용", "미사용"}, commonStopwords...),
+		script:    func(r rune) bool { return unicode.Is(unicode.Hangul, r) },
+	},
+}
+
+// TextProfiles 返回可用的文本规则名称，不含 auto
+func TextProfiles() []string {
+	names := make([]string, 0, len(textProfiles))
+	for name := range textProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTextProfile 按名称返回文本规则，不存在时ok为false；auto 与空字符串返回nil表示自动选择
+func LookupTextProfile(name string) (profile *TextProfile, ok bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == ProfileAuto {
+		return nil, true
+	}
+	profile, ok = textProfiles[name]
+	return profile, ok
+}
+
+// WithTextProfile 指定业务文本规则（见 TextProfiles），nil 或未设置时按响应内容中占多数的语言自动选择
+func WithTextProfile(profile *TextProfile) Option {
+	return func(e *TreeExtractor) {
+		e.textProfile = profile
+	}
+}
+
+// accepts 按语言规则判断文本是否为业务文本，用于中文以外的语言
+func (p *TextProfile) accepts(text string) bool {
+	text = strings.TrimSpace(text)
+	length := len([]rune(text))
+	if length < p.MinLength || (p.MaxLength > 0 && length > p.MaxLength) {
+		return false
+	}
+	for _, word := range p.Stopwords {
+		if strings.EqualFold(text, word) {
+			return false
+		}
+	}
+	if strings.Contains(text, "://") || strings.HasPrefix(text, "{") || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "map[") {
+		return false
+	}
+
+	// 时间、版本号、ID等以数字和符号为主的文本不是业务文本
+	letters, inScript, visible := 0, 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		visible++
+		if unicode.IsLetter(r) {
+			letters++
+		}
+		if p.script(r) {
+			inScript++
+		}
+	}
+	if inScript == 0 || letters*2 < visible {
+		return false
+	}
+
+	if p.spaced && !strings.ContainsFunc(text, unicode.IsSpace) {
+		return isPlainWord(text)
+	}
+	return true
+}
+
+// isPlainWord 判断单个单词是否像普通单词（如 Dashboard），而不是标识符或枚举值（如 userName、order_id、ACTIVE）
+func isPlainWord(word string) bool {
+	if len([]rune(word)) < 4 || strings.ContainsAny(word, "_$#@/\\=") {
+		return false
+	}
+	upper, prev := 0, rune(0)
+	for i, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			return false
+		case unicode.IsUpper(r):
+			upper++
+			// 小写字母之后的大写字母为驼峰命名
+			if i > 0 && unicode.IsLower(prev) {
+				return false
+			}
+		}
+		prev = r
+	}
+	return upper <= 1
+}
+
+// isHan 判断字符是否为汉字（CJK统一表意文字基本区）
+func isHan(r rune) bool {
+	return r >= 0x4e00 && r <= 0x9fff
+}
+
+// isKana 判断字符是否为日文假名
+func isKana(r rune) bool {
+	return unicode.In(r, unicode.Hiragana, unicode.Katakana)
+}
+
+// DetectTextProfile 统计响应中像自然语言的字符串值（含空白或非ASCII字符，排除URL；嵌套在字符串中的JSON按其中的值统计）
+// 使用的文字，返回占多数的语言的规则；无法判断时使用中文规则，与未区分语言时的行为一致。
+// 拉丁字母按4个折算一个汉字，接口中常见的英文枚举与说明不会使中文响应被误判为英文
+func DetectTextProfile(data interface{}) *TextProfile {
+	var han, kana, hangul, letters int
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, value := range v {
+				walk(value)
+			}
+		case []interface{}:
+			for _, item := range v {
+				walk(item)
+			}
+		case string:
+			// 脑图等嵌套在字符串中的JSON，只统计其中的值
+			if strings.HasPrefix(v, "{") || strings.HasPrefix(v, "[") {
+				var nested interface{}
+				if json.Unmarshal([]byte(v), &nested) == nil {
+					walk(nested)
+				}
+				return
+			}
+			if strings.Contains(v, "://") || !strings.ContainsFunc(v, func(r rune) bool { return unicode.IsSpace(r) || r > unicode.MaxASCII }) {
+				return
+			}
+			for _, r := range v {
+				switch {
+				case isHan(r):
+					han++
+				case isKana(r):
+					kana++
+				case unicode.Is(unicode.Hangul, r):
+					hangul++
+				case unicode.IsLetter(r):
+					letters++
+				}
+			}
+		}
+	}
+	walk(data)
+
+	switch {
+	case kana > 0 && kana*5 >= han:
+		return textProfiles[ProfileJa]
+	case han > 0 && han >= hangul && han*4 >= letters:
+		return textProfiles[ProfileZh]
+	case hangul > 0 && hangul*4 >= letters:
+		return textProfiles[ProfileKo]
+	case letters > 0:
+		return textProfiles[ProfileEn]
+	}
+	return textProfiles[ProfileZh]
+}
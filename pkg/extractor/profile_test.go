@@ -0,0 +1,71 @@
+package extractor
+
+import (
+	"context"
+	"testing"
+)
+
+func TestDetectTextProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		data interface{}
+		want string
+	}{
+		{"中文", map[string]interface{}{"title": "门店搜索", "status": "ACTIVE", "desc": "按门店名称搜索", "hint": "search by name"}, ProfileZh},
+		{"英文", map[string]interface{}{"title": "Store search", "items": []interface{}{"Search by store name", "zh-CN"}}, ProfileEn},
+		{"日文", map[string]interface{}{"title": "店舗検索", "desc": "店舗名で検索できること"}, ProfileJa},
+		{"韩文", map[string]interface{}{"title": "매장 검색", "desc": "매장 이름으로 검색"}, ProfileKo},
+		{"嵌套的JSON文本", map[string]interface{}{"mind": `{"data":{"text":"Checkout flow"},"children":[]}`}, ProfileEn},
+		{"没有自然语言文本", map[string]interface{}{"id": "a1b2", "count": 3.0}, ProfileZh},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectTextProfile(tt.data).Name; got != tt.want {
+				t.Errorf("DetectTextProfile() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextProfile_Accepts(t *testing.T) {
+	en := textProfiles[ProfileEn]
+	for text, want := range map[string]bool{
+		"Search by store name":       true,
+		"Dashboard":                  true,
+		"Login failed message shown": true,
+		"userName":                   false,
+		"order_id":                   false,
+		"ACTIVE":                     false,
+		"success":                    false,
+		"2024-05-20 10:00:00":        false,
+		"https://example.com/a b":    false,
+		"v1.2.3":                     false,
+	} {
+		if got := en.accepts(text); got != want {
+			t.Errorf("en.accepts(%q) = %v, want %v", text, got, want)
+		}
+	}
+
+	ko := textProfiles[ProfileKo]
+	if !ko.accepts("매장 검색") || ko.accepts("성공") || ko.accepts("Store search") {
+		t.Error("ko profile should accept Korean text only, excluding stopwords")
+	}
+}
+
+func TestTreeExtractor_TextProfile(t *testing.T) {
+	data := []byte(`{"code":0,"data":{"items":[{"title":"Store search"},{"title":"Search by store name"},{"title":"Sort by distance"}],"status":"ACTIVE"}}`)
+
+	tree, err := New().Extract(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if tree.TextProfile != ProfileEn || tree.Stats.Nodes < 3 {
+		t.Errorf("Extract() profile = %s, nodes = %d, want en with all titles", tree.TextProfile, tree.Stats.Nodes)
+	}
+
+	zh, _ := LookupTextProfile("zh")
+	tree, err = New(WithTextProfile(zh)).Extract(context.Background(), data)
+	if err == nil && tree.TextProfile != ProfileZh {
+		t.Errorf("Extract() with zh profile = %s, want the configured profile", tree.TextProfile)
+	}
+}
@@ -0,0 +1,240 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Strategy 抽取树结构时命中的策略
+type Strategy string
+
+// 抽取策略，按尝试顺序排列
+const (
+	StrategyTestCaseMind Strategy = "testcasemind" // 解析 data.TestCaseMind 中嵌套的脑图JSON
+	StrategyStandard     Strategy = "standard"     // 按标题与子节点候选键识别标准树结构
+	StrategyGeneric      Strategy = "generic"      // 回退为通用的业务文本提取
+)
+
+// Tree 树抽取结果
+type Tree struct {
+	Roots       []*SimplifiedNode // 根节点，单根结构时只有一个元素
+	Stats       Stats             // 节点统计
+	Warnings    []string          // 抽取过程中发现的问题，不影响结果可用性
+	Duplicates  []Duplicate       // 因内容重复而合并的节点，便于核对被丢弃的内容
+	Strategy    Strategy          // 命中的抽取策略，由 Extract 设置
+	TextProfile string            // 使用的业务文本规则名称（见 TextProfiles），由 Extract 设置
+	DepthLimit  *DepthLimit       // 因达到最大递归深度而被截断的子树，没有截断时为nil
+
+	// multiRoot 为true时序列化为数组，否则单根结构序列化为对象、空树序列化为null，
+	// 与命令行工具一直以来的输出格式保持一致
+	multiRoot bool
+}
+
+// DepthLimit 达到最大递归深度的统计
+type DepthLimit struct {
+	MaxDepth    int      `json:"max_depth"`    // 生效的最大递归深度
+	Hits        int      `json:"hits"`         // 因此被丢弃的子节点数
+	DeepestPath []string `json:"deepest_path"` // 层数最深的被截断节点在结果中的路径，从根节点开始
+}
+
+// Stats 树的节点统计
+type Stats struct {
+	Nodes  int `json:"nodes"`  // 节点总数
+	Leaves int `json:"leaves"` // 叶子节点数
+	Depth  int `json:"depth"`  // 最大层数，单个节点为1
+}
+
+// newTree 根据抽取到的原始结果（单个节点或节点数组）构建 Tree
+func newTree(result interface{}) *Tree {
+	tree := &Tree{}
+	switch v := result.(type) {
+	case *SimplifiedNode:
+		if v != nil {
+			tree.Roots = []*SimplifiedNode{v}
+		}
+	case []*SimplifiedNode:
+		tree.Roots = v
+		tree.multiRoot = true
+	}
+	tree.Stats = countStats(tree.Roots, 1)
+	return tree
+}
+
+// countStats 统计 nodes 及其子树，level 为 nodes 所在的层数
+func countStats(nodes []*SimplifiedNode, level int) Stats {
+	var stats Stats
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		stats.Nodes++
+		if len(node.Children) == 0 {
+			stats.Leaves++
+			stats.Depth = max(stats.Depth, level)
+			continue
+		}
+		child := countStats(node.Children, level+1)
+		stats.Nodes += child.Nodes
+		stats.Leaves += child.Leaves
+		stats.Depth = max(stats.Depth, child.Depth)
+	}
+	return stats
+}
+
+// warn 记录一条警告
+func (t *Tree) warn(format string, args ...interface{}) {
+	t.Warnings = append(t.Warnings, i18n.Sprintf(format, args...))
+}
+
+// MarshalJSON 序列化为只包含 name 和 children 的树状JSON，统计和警告不会输出
+func (t *Tree) MarshalJSON() ([]byte, error) {
+	if t.multiRoot {
+		roots := t.Roots
+		if roots == nil {
+			roots = []*SimplifiedNode{}
+		}
+		return json.Marshal(roots)
+	}
+	if len(t.Roots) == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Roots[0])
+}
+
+// UnmarshalJSON 解析树状JSON（数组、单个根节点或null），并重新计算统计
+func (t *Tree) UnmarshalJSON(data []byte) error {
+	roots, err := ParseNodes(data)
+	if err != nil {
+		return err
+	}
+	*t = Tree{
+		Roots:     roots,
+		Stats:     countStats(roots, 1),
+		multiRoot: bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")),
+	}
+	return nil
+}
+
+// ParseTreeOutput 校验并解析外部程序（后处理脚本、插件）输出的树状JSON，统计按新的树计算；
+// base 不为nil时新的树沿用其抽取策略、警告与重复节点
+func ParseTreeOutput(output []byte, base *Tree) (*Tree, error) {
+	if err := DefaultOutputSchema().Validate(output); err != nil {
+		return nil, err
+	}
+	tree := &Tree{}
+	if err := json.Unmarshal(output, tree); err != nil {
+		return nil, err
+	}
+	if base != nil {
+		tree.Strategy, tree.Warnings, tree.Duplicates = base.Strategy, base.Warnings, base.Duplicates
+	}
+	return tree, nil
+}
+
+// MarshalMarkdown 将树渲染为Markdown嵌套列表，每层缩进两个空格；节点的标签以 [标签] 附在名称之后
+func (t *Tree) MarshalMarkdown() []byte {
+	var buf bytes.Buffer
+	writeMarkdown(&buf, t.Roots, 0)
+	return buf.Bytes()
+}
+
+// markdownLine 将节点名称中的换行替换为空格，避免破坏列表结构
+var markdownLine = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+func writeMarkdown(buf *bytes.Buffer, nodes []*SimplifiedNode, level int) {
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		buf.WriteString(strings.Repeat("  ", level))
+		buf.WriteString("- ")
+		buf.WriteString(markdownLine.Replace(node.Name))
+		for _, tag := range node.Tags {
+			buf.WriteString(" [" + markdownLine.Replace(tag) + "]")
+		}
+		buf.WriteByte('\n')
+		writeMarkdown(buf, node.Children, level+1)
+	}
+}
+
+// mindNode TestCaseMind脑图节点，节点文本同时写入 text 与 richText，与源系统导出的两种写法都兼容
+type mindNode struct {
+	Data     *mindData   `json:"data,omitempty"`
+	Children []*mindNode `json:"children"`
+}
+
+type mindData struct {
+	Text     string         `json:"text"`
+	RichText []mindRichText `json:"richText"`
+	Priority int            `json:"priority,omitempty"` // 优先级标记，1-9
+	Progress int            `json:"progress,omitempty"` // 进度标记，1-9
+	Resource []string       `json:"resource,omitempty"` // 其余节点标签，脑图中显示为资源标签
+}
+
+// newMindData 创建节点的脑图数据：priority:1 至 priority:9 与 progress:1 至 progress:9 标签写入脑图的优先级与进度标记，
+// 与 --tag-from priority,progress 从脑图中读取的标签对应；其余标签写入 resource
+func newMindData(node *SimplifiedNode) *mindData {
+	data := &mindData{Text: node.Name, RichText: []mindRichText{{Text: node.Name, Type: 1}}}
+	for _, tag := range node.Tags {
+		field, value, _ := strings.Cut(tag, TagSeparator)
+		if marker := mindMarker(data, field); marker != nil && *marker == 0 {
+			if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= 9 {
+				*marker = n
+				continue
+			}
+		}
+		data.Resource = append(data.Resource, tag)
+	}
+	return data
+}
+
+// mindMarker 返回标签字段对应的脑图标记，不是标记时返回nil
+func mindMarker(data *mindData, field string) *int {
+	switch field {
+	case "priority":
+		return &data.Priority
+	case "progress":
+		return &data.Progress
+	}
+	return nil
+}
+
+type mindRichText struct {
+	Text string `json:"text"`
+	Type int    `json:"type"`
+}
+
+// MarshalTestCaseMind 将树还原为TestCaseMind格式：脑图的 data/children/richText 结构序列化为JSON字符串，
+// 嵌入 {"data":{"TestCaseMind":"..."}} 中，与源系统的响应结构相同，修改后可以重新上传。
+// 只有一个根节点时以它作为脑图根（TestCaseMind抽取的单根结构同样序列化为单元素数组）；
+// 多个根节点或空树时脑图根没有 data，只包含 children
+func (t *Tree) MarshalTestCaseMind() ([]byte, error) {
+	root := &mindNode{Children: toMindNodes(t.Roots)}
+	if len(root.Children) == 1 {
+		root = root.Children[0]
+	}
+	mind, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	payload := map[string]map[string]string{"data": {"TestCaseMind": string(mind)}}
+	return json.MarshalIndent(payload, "", "  ")
+}
+
+func toMindNodes(nodes []*SimplifiedNode) []*mindNode {
+	mind := make([]*mindNode, 0, len(nodes))
+	for _, node := range nodes {
+		if node == nil {
+			continue
+		}
+		mind = append(mind, &mindNode{
+			Data:     newMindData(node),
+			Children: toMindNodes(node.Children),
+		})
+	}
+	return mind
+}
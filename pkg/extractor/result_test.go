@@ -0,0 +1,191 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTree_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "单根结构输出对象",
+			input: `{"title":"根","children":[{"title":"子","children":[]}]}`,
+			want:  `{"name":"根","children":[{"name":"子","children":[]}]}`,
+		},
+		{
+			name:  "多根结构输出数组",
+			input: `[{"title":"A"},{"title":"B"}]`,
+			want:  `[{"name":"A","children":[]},{"name":"B","children":[]}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := New().Extract(context.Background(), []byte(tt.input))
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			got, err := json.Marshal(tree)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+
+			var parsed Tree
+			if err := json.Unmarshal(got, &parsed); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			again, _ := json.Marshal(&parsed)
+			if string(again) != tt.want {
+				t.Errorf("Marshal(Unmarshal()) = %s, want %s", again, tt.want)
+			}
+		})
+	}
+
+	if got, _ := json.Marshal(&Tree{}); string(got) != "null" {
+		t.Errorf("Marshal(empty) = %s, want null", got)
+	}
+}
+
+func TestTree_StatsAndMarkdown(t *testing.T) {
+	input := `{"title":"根","children":[{"title":"A","children":[{"title":"A1\n换行"}]},{"title":"B"}]}`
+	tree, err := New().Extract(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	if want := (Stats{Nodes: 4, Leaves: 2, Depth: 3}); tree.Stats != want {
+		t.Errorf("Stats = %+v, want %+v", tree.Stats, want)
+	}
+	if len(tree.Warnings) != 0 {
+		t.Errorf("Warnings = %v, want none", tree.Warnings)
+	}
+
+	want := "- 根\n  - A\n    - A1 换行\n  - B\n"
+	if got := string(tree.MarshalMarkdown()); got != want {
+		t.Errorf("MarshalMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestTree_Warnings(t *testing.T) {
+	input := `{"title":"根","children":[{"title":"A","children":[{"title":"A1"}]}]}`
+	tree, err := New(WithMaxDepth(2)).Extract(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(tree.Warnings) != 1 {
+		t.Errorf("Warnings = %v, want depth warning", tree.Warnings)
+	}
+}
+
+func TestTree_DepthLimit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  *DepthLimit
+	}{
+		{
+			"standard",
+			`{"title":"根","children":[{"title":"A","children":[{"title":"A1","children":[{"title":"A11"},{"title":"A12"}]}]},{"title":"B","children":[{"title":"B1"}]}]}`,
+			&DepthLimit{MaxDepth: 1, Hits: 2, DeepestPath: []string{"根", "A"}},
+		},
+		{
+			"testcasemind",
+			`{"data":{"TestCaseMind":"{\"data\":{\"text\":\"客户详情\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[{\"data\":{\"text\":\"输入门店名称\"}}]}]}"}}`,
+			&DepthLimit{MaxDepth: 1, Hits: 1, DeepestPath: []string{"客户详情", "门店搜索"}},
+		},
+		{
+			"within limit",
+			`{"title":"根","children":[{"title":"A"}]}`,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := New(WithMaxDepth(1)).Extract(context.Background(), []byte(tt.input))
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if !reflect.DeepEqual(tree.DepthLimit, tt.want) {
+				t.Errorf("DepthLimit = %+v, want %+v", tree.DepthLimit, tt.want)
+			}
+			if tt.want != nil && (len(tree.Warnings) != 1 || !strings.Contains(tree.Warnings[0], strings.Join(tt.want.DeepestPath, " > "))) {
+				t.Errorf("Warnings = %v, want one depth limit warning naming the deepest path", tree.Warnings)
+			}
+		})
+	}
+}
+
+func TestTree_Strategy(t *testing.T) {
+	tests := map[string]Strategy{
+		`{"data":{"TestCaseMind":"{\"data\":{\"text\":\"根节点\"},\"children\":[]}"}}`: StrategyTestCaseMind,
+		`{"title":"根","children":[{"title":"A"}]}`:                                  StrategyStandard,
+		`["门店搜索功能说明"]`:                                                              StrategyGeneric,
+	}
+	for input, want := range tests {
+		tree, err := New().Extract(context.Background(), []byte(input))
+		if err != nil {
+			t.Fatalf("Extract(%s) error = %v", input, err)
+		}
+		if tree.Strategy != want {
+			t.Errorf("Extract(%s).Strategy = %s, want %s", input, tree.Strategy, want)
+		}
+	}
+}
+
+func TestParseTreeOutput(t *testing.T) {
+	base := &Tree{Strategy: StrategyStandard, Warnings: []string{"警告"}, Duplicates: []Duplicate{{}}}
+	tree, err := ParseTreeOutput([]byte(`[{"name":"根","children":[{"name":"子","children":[]}]}]`), base)
+	if err != nil {
+		t.Fatalf("ParseTreeOutput() error = %v", err)
+	}
+	if tree.Stats.Nodes != 2 || tree.Strategy != StrategyStandard || len(tree.Warnings) != 1 || len(tree.Duplicates) != 1 {
+		t.Errorf("ParseTreeOutput() = %+v", tree)
+	}
+
+	tree, err = ParseTreeOutput([]byte(`{"name":"根","children":[]}`), nil)
+	if err != nil || tree.Strategy != "" || tree.Stats.Nodes != 1 {
+		t.Errorf("ParseTreeOutput(nil base) = %+v, %v", tree, err)
+	}
+	if _, err := ParseTreeOutput([]byte(`{"title":"根"}`), base); err == nil {
+		t.Error("ParseTreeOutput() should reject output that does not match the schema")
+	}
+}
+
+func TestTree_MarshalTestCaseMind(t *testing.T) {
+	tests := []string{
+		`{"name":"客户详情-门店列表","children":[{"name":"门店搜索","children":[{"name":"输入存在的门店名称"}]}]}`,
+		`[{"name":"客户详情-门店列表","children":[{"name":"输入存在的门店名称"}]},{"name":"门店详情页面"}]`,
+	}
+	for _, input := range tests {
+		var tree Tree
+		if err := json.Unmarshal([]byte(input), &tree); err != nil {
+			t.Fatal(err)
+		}
+		exported, err := tree.MarshalTestCaseMind()
+		if err != nil {
+			t.Fatalf("MarshalTestCaseMind() error = %v", err)
+		}
+
+		// 导出的结果重新抽取后应得到相同的树
+		again, err := New().Extract(context.Background(), exported)
+		if err != nil {
+			t.Fatalf("Extract(%s) error = %v", exported, err)
+		}
+		if again.Strategy != StrategyTestCaseMind {
+			t.Errorf("Strategy = %s, want %s", again.Strategy, StrategyTestCaseMind)
+		}
+		if got, want := string(again.MarshalMarkdown()), string(tree.MarshalMarkdown()); got != want {
+			t.Errorf("round trip = %q, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package extractor
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// DefaultScalarTitleTemplate 默认的标量标题模板，直接使用值本身
+const DefaultScalarTitleTemplate = "{{.value}}"
+
+// ScalarTitleFormat 标题字段为数字或布尔值（如编号、版本号、开关）时的格式
+type ScalarTitleFormat struct {
+	Template string // {{.key}} 替换为字段名，{{.value}} 替换为值的文字；为空时使用 DefaultScalarTitleTemplate
+	True     string // 布尔值true的文字，为空时为 true
+	False    string // 布尔值false的文字，为空时为 false
+}
+
+// format 将标量值格式化为标题，value 不是数字或布尔值时返回false。
+// 整数不使用科学计数法；超过2^53的整数在解析JSON时已经丢失精度
+func (f *ScalarTitleFormat) format(key string, value interface{}) (string, bool) {
+	var text string
+	switch v := value.(type) {
+	case float64:
+		text = strconv.FormatFloat(v, 'f', -1, 64)
+	case json.Number:
+		text = v.String()
+	case bool:
+		text = strconv.FormatBool(v)
+		if v && f.True != "" {
+			text = f.True
+		} else if !v && f.False != "" {
+			text = f.False
+		}
+	default:
+		return "", false
+	}
+
+	template := f.Template
+	if template == "" {
+		template = DefaultScalarTitleTemplate
+	}
+	return strings.NewReplacer("{{.key}}", key, "{{.value}}", text).Replace(template), true
+}
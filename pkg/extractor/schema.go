@@ -0,0 +1,197 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// maxSchemaErrors 校验输出时最多报告的问题数
+const maxSchemaErrors = 10
+
+// OutputSchema 树状JSON输出的结构约定：顶层为单个节点、节点数组或null，
+// 每个节点只包含字符串类型的名称字段和数组类型的子节点字段，以及可选的非负整数序号字段与字符串数组标签字段
+type OutputSchema struct {
+	NameKey     string // 节点名称字段，默认为 name
+	ChildrenKey string // 子节点数组字段，默认为 children
+	OrderKey    string // 可选的兄弟节点序号字段，默认为 order；为空时不允许该字段
+	TagsKey     string // 可选的标签字段，默认为 tags；为空时不允许该字段
+}
+
+// DefaultOutputSchema 返回 SimplifiedNode 对应的输出结构
+func DefaultOutputSchema() OutputSchema {
+	return OutputSchema{NameKey: "name", ChildrenKey: "children", OrderKey: "order", TagsKey: "tags"}
+}
+
+// MapKeys 返回各字段名经 convert 转换后的输出结构，用于校验按 --key-style 等改写过键名的输出；
+// 为空的可选字段保持为空
+func (s OutputSchema) MapKeys(convert func(string) string) OutputSchema {
+	mapKey := func(key string) string {
+		if key == "" {
+			return ""
+		}
+		return convert(key)
+	}
+	return OutputSchema{
+		NameKey:     mapKey(s.NameKey),
+		ChildrenKey: mapKey(s.ChildrenKey),
+		OrderKey:    mapKey(s.OrderKey),
+		TagsKey:     mapKey(s.TagsKey),
+	}
+}
+
+// JSONSchema 返回描述输出结构的 JSON Schema（draft 2020-12），可供其他工具校验结果文件
+func (s OutputSchema) JSONSchema() ([]byte, error) {
+	properties := map[string]interface{}{
+		s.NameKey:     map[string]interface{}{"type": "string"},
+		s.ChildrenKey: map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/$defs/node"}},
+	}
+	if s.OrderKey != "" {
+		properties[s.OrderKey] = map[string]interface{}{"type": "integer", "minimum": 0}
+	}
+	if s.TagsKey != "" {
+		properties[s.TagsKey] = map[string]interface{}{"type": "array", "items": map[string]interface{}{"type": "string"}}
+	}
+	node := map[string]interface{}{
+		"type":                 "object",
+		"properties":           properties,
+		"required":             []string{s.NameKey, s.ChildrenKey},
+		"additionalProperties": false,
+	}
+	schema := map[string]interface{}{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title":   "Curl2json tree",
+		"oneOf": []interface{}{
+			map[string]interface{}{"$ref": "#/$defs/node"},
+			map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/$defs/node"}},
+			map[string]interface{}{"type": "null"},
+		},
+		"$defs": map[string]interface{}{"node": node},
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// Validate 校验树状JSON是否符合输出结构，返回的错误列出各问题所在的路径
+func (s OutputSchema) Validate(data []byte) error {
+	var root interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&root); err != nil {
+		return i18n.Errorf("输出不是有效的JSON: %w", err)
+	}
+
+	v := &schemaValidator{schema: s}
+	switch value := root.(type) {
+	case nil:
+	case []interface{}:
+		for i, item := range value {
+			v.node(fmt.Sprintf("$[%d]", i), item)
+		}
+	default:
+		v.node("$", value)
+	}
+	if len(v.problems) == 0 {
+		return nil
+	}
+	if v.omitted > 0 {
+		v.problems = append(v.problems, i18n.Errorf("另有 %d 个问题未列出", v.omitted))
+	}
+	return errors.Join(v.problems...)
+}
+
+// schemaValidator 累积校验过程中发现的问题
+type schemaValidator struct {
+	schema   OutputSchema
+	problems []error
+	omitted  int
+}
+
+func (v *schemaValidator) report(path, format string, args ...interface{}) {
+	if len(v.problems) >= maxSchemaErrors {
+		v.omitted++
+		return
+	}
+	v.problems = append(v.problems, fmt.Errorf("%s: %s", path, i18n.Sprintf(format, args...)))
+}
+
+func (v *schemaValidator) node(path string, value interface{}) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		v.report(path, "节点应为对象，实际为 %s", jsonType(value))
+		return
+	}
+
+	for key := range obj {
+		if key != v.schema.NameKey && key != v.schema.ChildrenKey && (v.schema.OrderKey == "" || key != v.schema.OrderKey) &&
+			(v.schema.TagsKey == "" || key != v.schema.TagsKey) {
+			v.report(path, "存在未定义的字段 %s", key)
+		}
+	}
+
+	if order, ok := obj[v.schema.OrderKey]; v.schema.OrderKey != "" && ok && !isIndex(order) {
+		v.report(path+"."+v.schema.OrderKey, "应为非负整数，实际为 %s", jsonType(order))
+	}
+
+	if tags, ok := obj[v.schema.TagsKey]; v.schema.TagsKey != "" && ok {
+		if items, ok := tags.([]interface{}); !ok {
+			v.report(path+"."+v.schema.TagsKey, "应为数组，实际为 %s", jsonType(tags))
+		} else {
+			for i, item := range items {
+				if _, ok := item.(string); !ok {
+					v.report(fmt.Sprintf("%s.%s[%d]", path, v.schema.TagsKey, i), "应为字符串，实际为 %s", jsonType(item))
+				}
+			}
+		}
+	}
+
+	if name, ok := obj[v.schema.NameKey]; !ok {
+		v.report(path, "缺少字段 %s", v.schema.NameKey)
+	} else if _, ok := name.(string); !ok {
+		v.report(path+"."+v.schema.NameKey, "应为字符串，实际为 %s", jsonType(name))
+	}
+
+	children, ok := obj[v.schema.ChildrenKey]
+	if !ok {
+		v.report(path, "缺少字段 %s", v.schema.ChildrenKey)
+		return
+	}
+	items, ok := children.([]interface{})
+	if !ok {
+		v.report(path+"."+v.schema.ChildrenKey, "应为数组，实际为 %s", jsonType(children))
+		return
+	}
+	for i, item := range items {
+		v.node(fmt.Sprintf("%s.%s[%d]", path, v.schema.ChildrenKey, i), item)
+	}
+}
+
+// isIndex 判断JSON值是否为非负整数
+func isIndex(value interface{}) bool {
+	number, ok := value.(json.Number)
+	if !ok {
+		return false
+	}
+	n, err := number.Int64()
+	return err == nil && n >= 0
+}
+
+// jsonType 返回JSON值的类型名称
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number, float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -0,0 +1,79 @@
+package extractor
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestOutputSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{name: "单根结构", input: `{"name":"根","children":[{"name":"子","children":[]}]}`},
+		{name: "多根数组", input: `[{"name":"A","children":[]},{"name":"B","children":[]}]`},
+		{name: "空树", input: `null`},
+		{name: "缺少children", input: `{"name":"根"}`, wantErr: "$: "},
+		{name: "name类型错误", input: `{"name":1,"children":[]}`, wantErr: "$.name: "},
+		{name: "多余字段", input: `[{"name":"A","children":[],"id":1}]`, wantErr: "$[0]: "},
+		{name: "序号", input: `{"name":"根","order":0,"children":[{"name":"子","order":1,"children":[]}]}`},
+		{name: "序号不是整数", input: `{"name":"根","children":[{"name":"子","order":-1,"children":[]}]}`, wantErr: "$.children[0].order: "},
+		{name: "标签", input: `{"name":"根","tags":["priority:P1"],"children":[]}`},
+		{name: "标签不是字符串", input: `{"name":"根","tags":["priority:P1",1],"children":[]}`, wantErr: "$.tags[1]: "},
+		{name: "子节点不是对象", input: `{"name":"根","children":["x"]}`, wantErr: "$.children[0]: "},
+		{name: "无效JSON", input: `{"name":`, wantErr: "JSON"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := DefaultOutputSchema().Validate([]byte(tt.input))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutputSchema_CustomKeys(t *testing.T) {
+	schema := OutputSchema{NameKey: "title", ChildrenKey: "items"}
+	if err := schema.Validate([]byte(`{"title":"根","items":[]}`)); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+	if err := schema.Validate([]byte(`{"name":"根","children":[]}`)); err == nil {
+		t.Error("Validate() with default keys error = nil, want error")
+	}
+	if err := schema.Validate([]byte(`{"title":"根","items":[],"order":0}`)); err == nil {
+		t.Error("Validate() with order but no OrderKey error = nil, want error")
+	}
+
+	content, err := schema.JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema() error = %v", err)
+	}
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("JSONSchema() invalid JSON: %v", err)
+	}
+	if !strings.Contains(string(content), `"items"`) {
+		t.Errorf("JSONSchema() = %s, want custom children key", content)
+	}
+}
+
+func TestOutputSchema_MapKeys(t *testing.T) {
+	schema := OutputSchema{NameKey: "case_title", ChildrenKey: "sub_items", TagsKey: "tags"}.MapKeys(strings.ToUpper)
+	want := OutputSchema{NameKey: "CASE_TITLE", ChildrenKey: "SUB_ITEMS", TagsKey: "TAGS"}
+	if schema != want {
+		t.Errorf("MapKeys() = %+v, want %+v", schema, want)
+	}
+	if err := schema.Validate([]byte(`{"CASE_TITLE":"根","SUB_ITEMS":[],"TAGS":["a"]}`)); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
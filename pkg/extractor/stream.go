@@ -0,0 +1,111 @@
+package extractor
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteJSON 将树逐个节点写入w，输出与 json.MarshalIndent(t, "", "  ") 完全一致；
+// 不在内存中构建完整的序列化结果，输出超大的树时内存占用只与树本身和树的深度有关
+func (t *Tree) WriteJSON(w io.Writer) error {
+	sw := &streamWriter{w: bufio.NewWriter(w)}
+	switch {
+	case t.multiRoot:
+		if t.Roots == nil {
+			sw.w.WriteString("[]")
+		} else {
+			sw.writeNodes(t.Roots, 0)
+		}
+	case len(t.Roots) == 0:
+		sw.w.WriteString("null")
+	default:
+		sw.writeNode(t.Roots[0], 0)
+	}
+	if sw.err != nil {
+		return sw.err
+	}
+	return sw.w.Flush()
+}
+
+// streamWriter 按 json.MarshalIndent 的缩进规则写入节点；bufio.Writer 出错后的写入均被忽略，
+// 错误在 Flush 时返回
+type streamWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+// indent 写入换行及level层缩进
+func (sw *streamWriter) indent(level int) {
+	sw.w.WriteByte('\n')
+	sw.w.WriteString(strings.Repeat("  ", level))
+}
+
+// writeNodes 写入节点数组，level 为数组所在的缩进层级；nil 写为 null，空数组写为 []
+func (sw *streamWriter) writeNodes(nodes []*SimplifiedNode, level int) {
+	if nodes == nil {
+		sw.w.WriteString("null")
+		return
+	}
+	if len(nodes) == 0 {
+		sw.w.WriteString("[]")
+		return
+	}
+	sw.w.WriteByte('[')
+	for i, node := range nodes {
+		if i > 0 {
+			sw.w.WriteByte(',')
+		}
+		sw.indent(level + 1)
+		sw.writeNode(node, level+1)
+	}
+	sw.indent(level)
+	sw.w.WriteByte(']')
+}
+
+// writeNode 写入单个节点，level 为节点所在的缩进层级
+func (sw *streamWriter) writeNode(node *SimplifiedNode, level int) {
+	if node == nil {
+		sw.w.WriteString("null")
+		return
+	}
+	// 名称按 encoding/json 的规则转义（包括 <、>、& 的HTML转义），保证与 MarshalIndent 一致
+	name, err := json.Marshal(node.Name)
+	if err != nil && sw.err == nil {
+		sw.err = err
+	}
+
+	sw.w.WriteByte('{')
+	sw.indent(level + 1)
+	sw.w.WriteString(`"name": `)
+	sw.w.Write(name)
+	sw.w.WriteByte(',')
+	if node.Order != nil {
+		sw.indent(level + 1)
+		sw.w.WriteString(`"order": ` + strconv.Itoa(*node.Order) + ",")
+	}
+	if len(node.Tags) > 0 {
+		sw.indent(level + 1)
+		sw.w.WriteString(`"tags": [`)
+		for i, tag := range node.Tags {
+			text, err := json.Marshal(tag)
+			if err != nil && sw.err == nil {
+				sw.err = err
+			}
+			if i > 0 {
+				sw.w.WriteByte(',')
+			}
+			sw.indent(level + 2)
+			sw.w.Write(text)
+		}
+		sw.indent(level + 1)
+		sw.w.WriteString("],")
+	}
+	sw.indent(level + 1)
+	sw.w.WriteString(`"children": `)
+	sw.writeNodes(node.Children, level+1)
+	sw.indent(level)
+	sw.w.WriteByte('}')
+}
@@ -0,0 +1,64 @@
+package extractor
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTree_WriteJSON(t *testing.T) {
+	deep := &SimplifiedNode{Name: "叶子"}
+	for i := 0; i < 50; i++ {
+		deep = &SimplifiedNode{Name: strings.Repeat("层", i%3+1), Children: []*SimplifiedNode{deep, {Name: "兄弟", Children: []*SimplifiedNode{}}}}
+	}
+
+	order := 3
+	trees := map[string]*Tree{
+		"单根":    newTree(&SimplifiedNode{Name: "根", Children: []*SimplifiedNode{{Name: `<a href="x">&"引号"\n`, Children: []*SimplifiedNode{}}}}),
+		"多根":    newTree([]*SimplifiedNode{{Name: "A"}, nil, {Name: "B", Children: []*SimplifiedNode{{Name: "B1"}}}}),
+		"空多根":   newTree([]*SimplifiedNode{}),
+		"nil多根": {multiRoot: true},
+		"空树":    newTree(nil),
+		"深层":    newTree(deep),
+		"序号":    newTree(&SimplifiedNode{Name: "根", Order: &order, Children: []*SimplifiedNode{{Name: "子", Order: &order}}}),
+		"标签":    newTree(&SimplifiedNode{Name: "根", Order: &order, Tags: []string{"priority:P1", `owner:<"张三">`}, Children: []*SimplifiedNode{{Name: "子", Tags: []string{"a"}}}}),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			want, err := json.MarshalIndent(tree, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := tree.WriteJSON(&buf); err != nil {
+				t.Fatalf("WriteJSON() error = %v", err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("WriteJSON() = %s, want %s", buf.String(), want)
+			}
+		})
+	}
+}
+
+// failingWriter 写入一定字节数后返回错误
+type failingWriter struct{ limit int }
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.limit {
+		return 0, errors.New("disk full")
+	}
+	w.limit -= len(p)
+	return len(p), nil
+}
+
+func TestTree_WriteJSON_Error(t *testing.T) {
+	nodes := make([]*SimplifiedNode, 1000)
+	for i := range nodes {
+		nodes[i] = &SimplifiedNode{Name: "门店搜索功能说明"}
+	}
+	if err := newTree(nodes).WriteJSON(&failingWriter{limit: 100}); err == nil {
+		t.Error("WriteJSON() error = nil, want write error")
+	}
+}
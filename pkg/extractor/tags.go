@@ -0,0 +1,78 @@
+package extractor
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TagSeparator 标签中字段名与值之间的分隔符
+const TagSeparator = ":"
+
+// WithTagFields 将源对象中的这些字段转换为节点的 tags 数组，每个标签为 "字段名:值"，按字段顺序排列；
+// 字段值为数组时每个元素一个标签，空字符串、null 与对象被忽略。TestCaseMind脑图节点从 data 对象中读取字段
+func WithTagFields(fields ...string) Option {
+	return func(e *TreeExtractor) {
+		e.tagFields = nil
+		for _, field := range fields {
+			if field = strings.TrimSpace(field); field != "" {
+				e.tagFields = append(e.tagFields, field)
+			}
+		}
+	}
+}
+
+// nodeTags 按 WithTagFields 的字段从源对象中生成节点的标签，没有标签时返回nil
+func (e *TreeExtractor) nodeTags(obj map[string]interface{}) []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, field := range e.tagFields {
+		value, ok := obj[field]
+		if !ok {
+			continue
+		}
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		for _, item := range values {
+			text, ok := tagValue(item)
+			if !ok {
+				continue
+			}
+			tag := field + TagSeparator + text
+			if !seen[tag] {
+				seen[tag] = true
+				tags = append(tags, tag)
+			}
+		}
+	}
+	return tags
+}
+
+// tagValue 返回标量值的文字，数字与布尔值的写法与 ScalarTitleFormat 的默认格式一致
+func tagValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		v = strings.TrimSpace(v)
+		return v, v != ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// mergeTags 将incoming中不重复的标签追加到base之后
+func mergeTags(base, incoming []string) []string {
+	for _, tag := range incoming {
+		if !slices.Contains(base, tag) {
+			base = append(base, tag)
+		}
+	}
+	return base
+}
@@ -0,0 +1,64 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTreeExtractor_TagFields(t *testing.T) {
+	const input = `{"title":"门店管理","priority":"P0","children":[
+		{"title":"按名称搜索","priority":1,"owner":["alice","bob","alice"],"smoke":true},
+		{"title":"按编号搜索","priority":"","owner":null}
+	]}`
+
+	tree, err := New(WithTagFields("priority", " owner ", "smoke", "")).Extract(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, _ := json.Marshal(tree)
+	want := `{"name":"门店管理","tags":["priority:P0"],"children":[` +
+		`{"name":"按名称搜索","tags":["priority:1","owner:alice","owner:bob","smoke:true"],"children":[]},` +
+		`{"name":"按编号搜索","children":[]}]}`
+	if string(got) != want {
+		t.Errorf("Extract() = %s, want %s", got, want)
+	}
+	if err := DefaultOutputSchema().Validate(got); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if md := string(tree.MarshalMarkdown()); !strings.Contains(md, "- 按名称搜索 [priority:1] [owner:alice]") {
+		t.Errorf("MarshalMarkdown() = %q, want tags after the name", md)
+	}
+	mind, _ := tree.MarshalTestCaseMind()
+	if !strings.Contains(string(mind), `\"resource\":[\"priority:P0\"]`) || !strings.Contains(string(mind), `\"priority\":1,\"resource\":[\"owner:alice\"`) {
+		t.Errorf("MarshalTestCaseMind() = %s, want priority marker and other tags as resource", mind)
+	}
+}
+
+func TestTreeExtractor_TagFieldsTestCaseMind(t *testing.T) {
+	mind := `{"data":{"text":"登录功能测试"},"children":[{"data":{"text":"密码错误时提示","priority":2,"resource":["回归"]},"children":[]}]}`
+	input, _ := json.Marshal(map[string]interface{}{"data": map[string]string{"TestCaseMind": mind}})
+
+	tree, err := New(WithTagFields("priority", "resource")).Extract(context.Background(), input)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(tree.Roots) != 1 || len(tree.Roots[0].Children) != 1 {
+		t.Fatalf("Extract() roots = %+v", tree.Roots)
+	}
+	got := strings.Join(tree.Roots[0].Children[0].Tags, ",")
+	if want := "priority:2,resource:回归"; got != want {
+		t.Errorf("Tags = %q, want %q", got, want)
+	}
+}
+
+func TestMergeRoots_MergeChildrenTags(t *testing.T) {
+	base := []*SimplifiedNode{{Name: "A", Tags: []string{"owner:alice"}}}
+	incoming := []*SimplifiedNode{{Name: "A", Tags: []string{"owner:bob", "owner:alice"}}}
+	merged, _ := MergeRoots(base, incoming, ConflictMergeChildren)
+	if got := strings.Join(merged[0].Tags, ","); got != "owner:alice,owner:bob" {
+		t.Errorf("Tags = %q, want owner:alice,owner:bob", got)
+	}
+}
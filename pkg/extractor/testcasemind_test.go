@@ -0,0 +1,94 @@
+package extractor
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestTreeExtractor_TestCaseMind(t *testing.T) {
+	extractor := New(WithTitleKeys("case_title", "title", "name"), WithChildrenKeys("children", "items", "nodes"))
+
+	tests := []struct {
+		name          string
+		data          []byte
+		wantErr       bool
+		roots         int
+		expectedNames []string
+	}{
+		{
+			name: "TestCaseMind单根结构",
+			data: []byte(`{
+				"data": {
+					"TestCaseMind": "{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"text\":\"门店搜索\"},\"children\":[{\"data\":{\"richText\":[{\"text\":\"输入存在的门店名称\",\"type\":1}]},\"children\":[]}]}]}"
+				}
+			}`),
+			wantErr:       false,
+			roots:         1,
+			expectedNames: []string{"客户详情-门店列表", "门店搜索", "输入存在的门店名称"},
+		},
+		{
+			name: "TestCaseMind多根结构",
+			data: []byte(`{
+				"data": {
+					"TestCaseMind": "{\"children\":[{\"data\":{\"text\":\"客户详情-门店列表\"},\"children\":[{\"data\":{\"richText\":[{\"text\":\"输入存在的门店名称\",\"type\":1}]},\"children\":[]}]}]}"
+				}
+			}`),
+			wantErr:       false,
+			roots:         1,
+			expectedNames: []string{"客户详情-门店列表", "输入存在的门店名称"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := extractor.Extract(context.Background(), tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Extract() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			got, err := json.Marshal(tree)
+			if err != nil {
+				t.Errorf("Extract() result marshal error = %v", err)
+				return
+			}
+
+			// 解析结果
+			// TestCaseMind 的结果与其他策略一样为节点数组（单根结构时只有一个元素）
+			var roots []map[string]interface{}
+			if err := json.Unmarshal(got, &roots); err != nil {
+				t.Fatalf("Extract() = %s, want a node array: %v", got, err)
+			}
+			if len(roots) != tt.roots {
+				t.Errorf("Extract() roots = %d, want %d", len(roots), tt.roots)
+			}
+
+			var foundNames []string
+			for _, root := range roots {
+				collectNames(root, &foundNames)
+			}
+			if !reflect.DeepEqual(foundNames, tt.expectedNames) {
+				t.Errorf("Extract() names = %v, want %v", foundNames, tt.expectedNames)
+			}
+		})
+	}
+}
+
+// collectNames 递归收集树中所有节点的名称
+func collectNames(node map[string]interface{}, names *[]string) {
+	if name, ok := node["name"].(string); ok {
+		*names = append(*names, name)
+	}
+
+	if children, ok := node["children"].([]interface{}); ok {
+		for _, child := range children {
+			if childNode, ok := child.(map[string]interface{}); ok {
+				collectNames(childNode, names)
+			}
+		}
+	}
+}
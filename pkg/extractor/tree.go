@@ -1,127 +1,379 @@
+// Package extractor 从接口返回的JSON（包括TestCaseMind脑图数据）中识别业务用例树，
+// 并抽取为只包含节点名称和子节点的精简结构。
 package extractor
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"reflect"
+	"io"
+	"log/slog"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/internal/logger"
 )
 
 // TreeExtractor 树抽取器
 type TreeExtractor struct {
-	titleKeys    []string
-	childrenKeys []string
-	verbose      bool
-	maxDepth     int
+	titleKeys      []string
+	levelTitleKeys map[int][]string // 按层数优先使用的内容字段候选键名，根节点为第1层
+	childrenKeys   []string
+	mergeChildren  bool               // 合并所有匹配的子节点数组，而不是只使用第一个
+	scalarTitles   *ScalarTitleFormat // 非nil时数字与布尔值也可以作为标题
+	maxTitleLength int                // 节点名称的最大字符数，0 表示不限制
+	nodeOrder      bool               // 为节点记录在兄弟节点中的序号
+	tagFields      []string           // 转换为节点标签的源字段
+	verbose        bool
+	maxDepth       int
+	logger         *slog.Logger
+	textProfile    *TextProfile     // 指定的业务文本规则，nil 时按响应自动选择
+	conflict       ConflictStrategy // 合并多个JSON文档的根节点时同名节点的处理方式
+
+	// profile 单次抽取使用的业务文本规则，由 extract 设置；为nil时（如直接调用 ExtractTextContent）使用中文规则
+	profile *TextProfile
+
+	// truncated 单次抽取中因达到最大递归深度而丢弃的子节点数，按父节点记录，由 extract 为每次抽取单独创建
+	truncated map[*SimplifiedNode]int
 }
 
 // SimplifiedNode 简化的树节点结构
 type SimplifiedNode struct {
 	Name     string            `json:"name"`
+	Order    *int              `json:"order,omitempty"` // 在兄弟节点中的原始序号，从0开始，仅在 WithNodeOrder 时设置
+	Tags     []string          `json:"tags,omitempty"`  // 由源字段生成的 "字段名:值" 标签，仅在 WithTagFields 时设置
 	Children []*SimplifiedNode `json:"children"`
 }
 
+// DefaultTitleKeys 默认的节点内容字段候选键名，按优先级排序
+func DefaultTitleKeys() []string {
+	return []string{"case_title", "title", "name", "label"}
+}
+
+// DefaultChildrenKeys 默认的子节点数组候选键名，按优先级排序
+func DefaultChildrenKeys() []string {
+	return []string{"children", "nodes", "sub_cases", "items", "data"}
+}
+
+// DefaultMaxDepth 默认的最大递归深度，防止无限递归
+const DefaultMaxDepth = 100
+
+// Option 树抽取器选项
+type Option func(*TreeExtractor)
+
+// WithTitleKeys 设置节点内容字段候选键名，按优先级排序，为空时使用 DefaultTitleKeys
+func WithTitleKeys(keys ...string) Option {
+	return func(e *TreeExtractor) {
+		if len(keys) > 0 {
+			e.titleKeys = keys
+		}
+	}
+}
+
+// WithLevelTitleKeys 为第level层（抽取结果的根节点为第1层）的节点设置优先使用的内容字段候选键名，
+// 用于不同层级使用不同字段名的接口；该层节点找不到这些键时仍按 WithTitleKeys 的候选键名查找
+func WithLevelTitleKeys(level int, keys ...string) Option {
+	return func(e *TreeExtractor) {
+		if level <= 0 || len(keys) == 0 {
+			return
+		}
+		if e.levelTitleKeys == nil {
+			e.levelTitleKeys = make(map[int][]string)
+		}
+		e.levelTitleKeys[level] = keys
+	}
+}
+
+// WithScalarTitles 允许标题字段为数字或布尔值，按format格式化为标题；
+// 默认只使用非空字符串，标题字段为其他类型的节点没有标题
+func WithScalarTitles(format ScalarTitleFormat) Option {
+	return func(e *TreeExtractor) {
+		e.scalarTitles = &format
+	}
+}
+
+// WithChildrenKeys 设置子节点数组候选键名，按优先级排序，为空时使用 DefaultChildrenKeys
+func WithChildrenKeys(keys ...string) Option {
+	return func(e *TreeExtractor) {
+		if len(keys) > 0 {
+			e.childrenKeys = keys
+		}
+	}
+}
+
+// WithMergeChildren 设置是否合并节点上所有匹配候选键名的子节点数组（如同时存在 items 与 sub_cases），
+// 按候选键名的顺序拼接；默认只使用第一个非空的子节点数组
+func WithMergeChildren(merge bool) Option {
+	return func(e *TreeExtractor) {
+		e.mergeChildren = merge
+	}
+}
+
+// WithMaxTitleLength 设置节点名称的最大字符数（按Unicode字符计算），超过时截断并以 … 结尾，
+// 截断后连同省略号不超过n个字符；小于等于0时不限制
+func WithMaxTitleLength(n int) Option {
+	return func(e *TreeExtractor) {
+		if n > 0 {
+			e.maxTitleLength = n
+		}
+	}
+}
+
+// WithNodeOrder 设置是否为每个节点记录它在兄弟节点中的原始序号（order 字段，从0开始），
+// 调用方对子节点重新排序后可以据此恢复响应中的顺序
+func WithNodeOrder(enabled bool) Option {
+	return func(e *TreeExtractor) {
+		e.nodeOrder = enabled
+	}
+}
+
+// WithMaxDepth 设置最大递归深度，小于等于0时使用 DefaultMaxDepth
+func WithMaxDepth(depth int) Option {
+	return func(e *TreeExtractor) {
+		if depth > 0 {
+			e.maxDepth = depth
+		}
+	}
+}
+
+// WithLogger 设置日志器，调试输出是否开启由日志器的级别决定；默认不输出日志
+func WithLogger(l *slog.Logger) Option {
+	return func(e *TreeExtractor) {
+		if l != nil {
+			e.logger = l
+			e.verbose = l.Enabled(context.Background(), slog.LevelDebug)
+		}
+	}
+}
+
 // New 创建新的树抽取器
-func New(titleKeys, childrenKeys []string, verbose bool) *TreeExtractor {
-	if len(titleKeys) == 0 {
-		titleKeys = []string{"case_title", "title", "name", "label"}
+func New(opts ...Option) *TreeExtractor {
+	e := &TreeExtractor{
+		titleKeys:    DefaultTitleKeys(),
+		childrenKeys: DefaultChildrenKeys(),
+		maxDepth:     DefaultMaxDepth,
+		logger:       logger.Discard(),
 	}
-	if len(childrenKeys) == 0 {
-		childrenKeys = []string{"children", "nodes", "sub_cases", "items", "data"}
+	for _, opt := range opts {
+		opt(e)
 	}
+	return e
+}
 
-	return &TreeExtractor{
-		titleKeys:    titleKeys,
-		childrenKeys: childrenKeys,
-		verbose:      verbose,
-		maxDepth:     100, // 防止无限递归
+// debugf 以debug级别输出格式化的调试信息
+func (e *TreeExtractor) debugf(format string, args ...interface{}) {
+	e.logger.Debug(strings.TrimRight(i18n.Sprintf(format, args...), "\n"))
+}
+
+// debugln 以debug级别输出调试信息
+func (e *TreeExtractor) debugln(msg string) {
+	e.logger.Debug(i18n.T(msg))
+}
+
+// Extract 从原始JSON中抽取树状结构，ctx 取消时在各阶段之间尽早返回；
+// data 包含首尾相接的多个JSON文档时分别抽取，每个文档的根节点依次合并为多根结果
+func (e *TreeExtractor) Extract(ctx context.Context, data []byte) (*Tree, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	docs, err := decodeDocuments(bytes.NewReader(data))
+	if err != nil {
+		return nil, decodeError(err)
 	}
+
+	return e.extractDocuments(ctx, docs)
 }
 
-// Extract 从原始JSON中抽取树状结构
-func (e *TreeExtractor) Extract(data []byte) ([]byte, error) {
-	var rawData interface{}
-	if err := json.Unmarshal(data, &rawData); err != nil {
-		return nil, fmt.Errorf("JSON解析失败: %w", err)
+// ExtractStream 从r流式读取JSON并将树状JSON逐个节点写入w（见 Tree.WriteJSON），不在内存中保留原始字节和序列化结果；
+// 输出内容与 Extract 的结果经 json.MarshalIndent 后一致，末尾多一个换行符
+func (e *TreeExtractor) ExtractStream(ctx context.Context, r io.Reader, w io.Writer) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	docs, err := decodeDocuments(r)
+	if err != nil {
+		return decodeError(err)
+	}
+
+	tree, err := e.extractDocuments(ctx, docs)
+	if err != nil {
+		return err
+	}
+
+	if err := tree.WriteJSON(w); err != nil {
+		return i18n.Errorf("结果写入失败: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return i18n.Errorf("结果写入失败: %w", err)
+	}
+	return nil
+}
+
+// decodeError 将JSON解析错误归类为无效或被截断
+func decodeError(err error) error {
+	kind := errs.ErrInvalidJSON
+	if errs.IsTruncated(err) {
+		kind = errs.ErrTruncatedJSON
+	}
+	return errs.Mark(i18n.Errorf("JSON解析失败: %w", err), kind)
+}
+
+// extract 从已解析的JSON中抽取树状结构
+func (e *TreeExtractor) extract(ctx context.Context, rawData interface{}) (*Tree, error) {
+	// 截断记录只属于本次抽取，复制抽取器使同一个抽取器可以并发使用
+	run := *e
+	run.truncated = make(map[*SimplifiedNode]int)
+	run.profile = e.textProfile
+	if run.profile == nil {
+		run.profile = DetectTextProfile(rawData)
 	}
+	e = &run
+	e.logger.Debug(i18n.T("业务文本规则"), "profile", e.profile.Name, "detected", e.textProfile == nil)
 
 	if e.verbose {
-		fmt.Printf("开始抽取树状结构，标题候选键: %v, 子节点候选键: %v\n", e.titleKeys, e.childrenKeys)
+		e.debugf("开始抽取树状结构，标题候选键: %v, 子节点候选键: %v\n", e.titleKeys, e.childrenKeys)
 	}
 
-	var result interface{}
+	// 脑图JSON被截断时其余策略只能抽取到残缺的文本，直接报错
+	if truncatedTestCaseMind(rawData) {
+		return nil, errs.Mark(i18n.Errorf("TestCaseMind JSON被截断，无法抽取完整的树状结构"), errs.ErrTruncatedJSON)
+	}
 
 	// 强制使用业务文本提取，避免技术元数据干扰
 	if e.verbose {
-		fmt.Println("强制使用业务文本提取模式...")
+		e.debugln("强制使用业务文本提取模式...")
+	}
+	result, strategy, duplicates := e.createDefaultStructure(rawData)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	result = e.createDefaultStructure(rawData)
 	if result == nil {
-		return nil, fmt.Errorf("未找到有效的树状结构")
+		return nil, errs.Mark(i18n.Errorf("未找到有效的树状结构"), errs.ErrEmptyTree)
 	}
 
-	// 序列化结果
-	output, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("结果序列化失败: %w", err)
+	tree := newTree(result)
+	tree.Strategy = strategy
+	tree.TextProfile = e.profile.Name
+	tree.Duplicates = duplicates
+	tree.DepthLimit = e.depthLimit(tree.Roots)
+	if tree.DepthLimit == nil && tree.Stats.Depth >= e.maxDepth {
+		tree.warn("树的层数达到最大递归深度 %d，更深的节点可能已被截断", e.maxDepth)
+	}
+	if empty := countEmptyNames(tree.Roots); empty > 0 {
+		tree.warn("%d 个节点名称为空", empty)
+	}
+	if nodes, dropped := countDuplicates(duplicates); nodes > 0 {
+		tree.warn("%d 处重复的内容已合并为 %d 个节点，来源见运行报告的 duplicates", dropped, nodes)
+	}
+	if e.maxTitleLength > 0 {
+		if truncated := truncateNames(tree.Roots, e.maxTitleLength); truncated > 0 {
+			tree.warn("%d 个节点名称超过 %d 个字符，已截断", truncated, e.maxTitleLength)
+		}
+		for _, d := range tree.Duplicates {
+			for i := range d.Path {
+				d.Path[i], _ = truncateName(d.Path[i], e.maxTitleLength)
+			}
+		}
+		if tree.DepthLimit != nil {
+			for i := range tree.DepthLimit.DeepestPath {
+				tree.DepthLimit.DeepestPath[i], _ = truncateName(tree.DepthLimit.DeepestPath[i], e.maxTitleLength)
+			}
+		}
+	}
+	if limit := tree.DepthLimit; limit != nil {
+		tree.warn("%d 个子节点因达到最大递归深度 %d 被丢弃，最深的截断位置: %s，可以用 --max-depth 调大", limit.Hits, limit.MaxDepth, strings.Join(limit.DeepestPath, " > "))
 	}
 
-	if e.verbose {
-		fmt.Println("树状结构抽取完成")
+	if e.nodeOrder {
+		setOrder(tree.Roots)
 	}
 
-	return output, nil
+	if e.verbose {
+		e.debugln("树状结构抽取完成")
+	}
+	return tree, nil
 }
 
-// ExtractTextContent 从复杂的JSON数据中提取所有文本内容
-func (e *TreeExtractor) ExtractTextContent(data interface{}) []string {
-	var texts []string
+// ellipsis 截断节点名称时的后缀
+const ellipsis = "…"
 
-	switch v := data.(type) {
-	case string:
-		if v != "" && e.isBusinessText(v) {
-			texts = append(texts, v)
+// truncateNames 将超过n个字符的节点名称截断为n个字符（含省略号），返回截断的节点数
+func truncateNames(nodes []*SimplifiedNode, n int) int {
+	count := 0
+	for _, node := range nodes {
+		if node == nil {
+			continue
 		}
-	case map[string]interface{}:
-		// 优先查找richText数组中的text字段
-		if richTextArray, exists := v["richText"]; exists {
-			if richTextItems, ok := richTextArray.([]interface{}); ok {
-				for _, item := range richTextItems {
-					if richTextObj, ok := item.(map[string]interface{}); ok {
-						if textVal, textExists := richTextObj["text"]; textExists {
-							if textStr, ok := textVal.(string); ok && textStr != "" && e.isBusinessText(textStr) {
-								texts = append(texts, textStr)
-							}
-						}
-					}
-				}
-			}
+		if name, ok := truncateName(node.Name, n); ok {
+			node.Name = name
+			count++
 		}
+		count += truncateNames(node.Children, n)
+	}
+	return count
+}
 
-		// 查找其他可能的text字段
-		for key, value := range v {
-			// 只关注包含text的字段
-			if key == "text" || strings.Contains(key, "text") {
-				if textVal, ok := value.(string); ok && textVal != "" && e.isBusinessText(textVal) {
-					texts = append(texts, textVal)
-				}
-			} else if key == "title" || key == "name" || key == "label" || key == "message" || key == "description" {
-				if textVal, ok := value.(string); ok && textVal != "" && e.isBusinessText(textVal) {
-					texts = append(texts, textVal)
-				}
-			} else {
-				// 递归处理嵌套结构
-				texts = append(texts, e.ExtractTextContent(value)...)
-			}
+// truncateName 将超过n个字符的名称截断为n个字符（含省略号），未超过时返回false
+func truncateName(name string, n int) (string, bool) {
+	if utf8.RuneCountInString(name) <= n {
+		return name, false
+	}
+	runes := []rune(name)
+	return strings.TrimRightFunc(string(runes[:n-1]), unicode.IsSpace) + ellipsis, true
+}
+
+// setOrder 按节点在兄弟节点中的位置设置 Order
+func setOrder(nodes []*SimplifiedNode) {
+	for i, node := range nodes {
+		if node == nil {
+			continue
 		}
-	case []interface{}:
-		for _, item := range v {
-			texts = append(texts, e.ExtractTextContent(item)...)
+		order := i
+		node.Order = &order
+		setOrder(node.Children)
+	}
+}
+
+// countEmptyNames 统计名称为空的节点数
+func countEmptyNames(nodes []*SimplifiedNode) int {
+	count := 0
+	for _, node := range nodes {
+		if node == nil {
+			continue
 		}
-	default:
-		// 对于其他类型，不处理，避免技术字段混入
+		if strings.TrimSpace(node.Name) == "" {
+			count++
+		}
+		count += countEmptyNames(node.Children)
+	}
+	return count
+}
+
+// truncatedTestCaseMind 判断 data.TestCaseMind 中嵌套的JSON字符串是否被截断
+func truncatedTestCaseMind(data interface{}) bool {
+	root, _ := data.(map[string]interface{})
+	dataMap, _ := root["data"].(map[string]interface{})
+	testCaseMind, ok := dataMap["TestCaseMind"].(string)
+	if !ok {
+		return false
 	}
+	var v interface{}
+	return errs.IsTruncated(json.Unmarshal([]byte(testCaseMind), &v))
+}
 
+// ExtractTextContent 从复杂的JSON数据中提取所有文本内容
+func (e *TreeExtractor) ExtractTextContent(data interface{}) []string {
+	var texts []string
+	for _, source := range e.collectTexts(data, "$") {
+		texts = append(texts, source.text)
+	}
 	return texts
 }
 
@@ -130,6 +382,9 @@ func (e *TreeExtractor) isBusinessText(text string) bool {
 	if text == "" {
 		return false
 	}
+	if e.profile != nil && e.profile.Name != ProfileZh {
+		return e.profile.accepts(text)
+	}
 
 	// 过滤掉明显的技术字段和ID
 	technicalKeywords := []string{
@@ -167,7 +422,7 @@ func (e *TreeExtractor) isBusinessText(text string) bool {
 
 	// 新增：特殊过滤 - 如果文本看起来像是API错误响应的一部分，直接过滤
 	if strings.Contains(text, "Auth ERROR") || strings.Contains(text, "Jwt validate failed") ||
-	   strings.Contains(text, "API Response") || strings.Contains(text, "errCode") {
+		strings.Contains(text, "API Response") || strings.Contains(text, "errCode") {
 		return false
 	}
 
@@ -197,10 +452,10 @@ func (e *TreeExtractor) isBusinessText(text string) bool {
 	// 检查是否为纯技术数据（如时间戳、ID、数字等），但要避免误判业务编号文本
 	// 只有当文本以数字开头且长度很短时才认为是技术数据
 	if (strings.HasPrefix(text, "1.") || strings.HasPrefix(text, "2.") ||
-	    strings.HasPrefix(text, "3.") || strings.HasPrefix(text, "4.") ||
-	    strings.HasPrefix(text, "5.") || strings.HasPrefix(text, "6.") ||
-	    strings.HasPrefix(text, "7.") || strings.HasPrefix(text, "8.") ||
-	    strings.HasPrefix(text, "9.")) && len([]rune(text)) < 10 {
+		strings.HasPrefix(text, "3.") || strings.HasPrefix(text, "4.") ||
+		strings.HasPrefix(text, "5.") || strings.HasPrefix(text, "6.") ||
+		strings.HasPrefix(text, "7.") || strings.HasPrefix(text, "8.") ||
+		strings.HasPrefix(text, "9.")) && len([]rune(text)) < 10 {
 		// 短的数字开头文本可能是业务步骤，检查是否包含业务关键词
 		businessKeywords := []string{"用户", "查询", "指标", "数据", "结果", "展示",
 			"Agent", "多轮", "对话", "携带", "上下文", "筛选", "条件", "切换", "主题", "开始", "新"}
@@ -217,10 +472,10 @@ func (e *TreeExtractor) isBusinessText(text string) bool {
 	}
 
 	if strings.HasPrefix(text, "e+") || strings.HasPrefix(text, "E+") ||
-	   strings.HasPrefix(text, "[]") || strings.HasPrefix(text, "{}") ||
-	   strings.HasPrefix(text, "map[") || strings.Contains(text, ": 0") ||
-	   strings.Contains(text, ": 1") || strings.Contains(text, ": false") ||
-	   strings.Contains(text, ": true") || strings.Contains(text, "read write") {
+		strings.HasPrefix(text, "[]") || strings.HasPrefix(text, "{}") ||
+		strings.HasPrefix(text, "map[") || strings.Contains(text, ": 0") ||
+		strings.Contains(text, ": 1") || strings.Contains(text, ": false") ||
+		strings.Contains(text, ": true") || strings.Contains(text, "read write") {
 		return false
 	}
 
@@ -271,7 +526,7 @@ func isEnglishBusinessText(text string) bool {
 		"logout", "auth", "user", "admin", "system", "feature",
 		"module", "component", "service", "api", "endpoint",
 		"request", "response", "client", "server", "database",
-	"frontend", "backend", "interface", "config", "setting",
+		"frontend", "backend", "interface", "config", "setting",
 	}
 
 	textLower := strings.ToLower(text)
@@ -284,30 +539,31 @@ func isEnglishBusinessText(text string) bool {
 	return false
 }
 
-// createDefaultStructure 为非标准响应创建默认树状结构，只提取业务文本
-func (e *TreeExtractor) createDefaultStructure(data interface{}) interface{} {
+// createDefaultStructure 为非标准响应创建默认树状结构，只提取业务文本；同时返回因内容重复而合并的节点
+func (e *TreeExtractor) createDefaultStructure(data interface{}) (interface{}, Strategy, []Duplicate) {
 	if e.verbose {
-		fmt.Println("创建默认树状结构...")
+		e.debugln("创建默认树状结构...")
 	}
 
 	// 优先尝试解析TestCaseMind结构
 	if testCaseMindNodes := e.parseTestCaseMindStructureDirect(data); testCaseMindNodes != nil {
 		if e.verbose {
-			fmt.Println("成功解析TestCaseMind结构")
+			e.debugln("成功解析TestCaseMind结构")
 		}
-		return testCaseMindNodes
+		return testCaseMindNodes, StrategyTestCaseMind, nil
 	}
 
 	// 然后尝试标准的树结构解析
 	if standardTree := e.tryStandardTreeStructure(data); standardTree != nil {
 		if e.verbose {
-			fmt.Println("成功解析标准树结构")
+			e.debugln("成功解析标准树结构")
 		}
-		return standardTree
+		return standardTree, StrategyStandard, nil
 	}
 
 	// 回退到通用的业务文本提取
-	return e.createGenericBusinessTextStructure(data)
+	node, duplicates := e.createGenericBusinessTextStructure(data)
+	return node, StrategyGeneric, duplicates
 }
 
 // tryStandardTreeStructure 尝试解析标准树结构
@@ -343,14 +599,14 @@ func (e *TreeExtractor) tryStandardTreeStructure(data interface{}) interface{} {
 // parseTestCaseMindStructureDirect 直接解析TestCaseMind结构
 func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) interface{} {
 	if e.verbose {
-		fmt.Println("=== parseTestCaseMindStructureDirect 开始 ===")
+		e.debugln("=== parseTestCaseMindStructureDirect 开始 ===")
 	}
 
 	// 将数据转换为map以便访问
 	dataMap, ok := data.(map[string]interface{})
 	if !ok {
 		if e.verbose {
-			fmt.Printf("数据类型断言失败，期望map[string]interface{}，实际: %T\n", data)
+			e.debugf("数据类型断言失败，期望map[string]interface{}，实际: %T\n", data)
 		}
 		return nil
 	}
@@ -359,7 +615,7 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	dataField, exists := dataMap["data"]
 	if !exists {
 		if e.verbose {
-			fmt.Println("未找到data字段")
+			e.debugln("未找到data字段")
 		}
 		return nil
 	}
@@ -367,7 +623,7 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	dataMap2, ok := dataField.(map[string]interface{})
 	if !ok {
 		if e.verbose {
-			fmt.Printf("data字段类型断言失败，期望map[string]interface{}，实际: %T\n", dataField)
+			e.debugf("data字段类型断言失败，期望map[string]interface{}，实际: %T\n", dataField)
 		}
 		return nil
 	}
@@ -376,7 +632,7 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	testCaseMind, exists := dataMap2["TestCaseMind"]
 	if !exists {
 		if e.verbose {
-			fmt.Println("未找到TestCaseMind字段")
+			e.debugln("未找到TestCaseMind字段")
 		}
 		return nil
 	}
@@ -384,33 +640,33 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	testCaseMindStr, ok := testCaseMind.(string)
 	if !ok {
 		if e.verbose {
-			fmt.Printf("TestCaseMind字段类型断言失败，期望string，实际: %T\n", testCaseMind)
+			e.debugf("TestCaseMind字段类型断言失败，期望string，实际: %T\n", testCaseMind)
 		}
 		return nil
 	}
 
 	if e.verbose {
-		fmt.Printf("TestCaseMind字符串长度: %d\n", len(testCaseMindStr))
-		fmt.Printf("TestCaseMind前100字符: %s\n", testCaseMindStr[:min(100, len(testCaseMindStr))])
-		fmt.Printf("TestCaseMind后100字符: %s\n", testCaseMindStr[max(0, len(testCaseMindStr)-100):])
+		e.debugf("TestCaseMind字符串长度: %d\n", len(testCaseMindStr))
+		e.debugf("TestCaseMind前100字符: %s\n", testCaseMindStr[:min(100, len(testCaseMindStr))])
+		e.debugf("TestCaseMind后100字符: %s\n", testCaseMindStr[max(0, len(testCaseMindStr)-100):])
 
 		// 检查字符串是否平衡
 		openCount := strings.Count(testCaseMindStr, "{")
 		closeCount := strings.Count(testCaseMindStr, "}")
-		fmt.Printf("JSON括号平衡检查: 开括号{%d, 闭括号}%d\n", openCount, closeCount)
+		e.debugf("JSON括号平衡检查: 开括号{%d, 闭括号}%d\n", openCount, closeCount)
 
 		// 检查字符串是否以{开始，以}结束
 		if len(testCaseMindStr) > 0 {
 			startsWithBrace := strings.HasPrefix(strings.TrimSpace(testCaseMindStr), "{")
 			endsWithBrace := strings.HasSuffix(strings.TrimSpace(testCaseMindStr), "}")
-			fmt.Printf("JSON格式检查: 以{开始:%v, 以}结束:%v\n", startsWithBrace, endsWithBrace)
+			e.debugf("JSON格式检查: 以{开始:%v, 以}结束:%v\n", startsWithBrace, endsWithBrace)
 		}
 	}
 
 	// 验证字符串完整性
 	if len(testCaseMindStr) == 0 {
 		if e.verbose {
-			fmt.Println("TestCaseMind字符串为空")
+			e.debugln("TestCaseMind字符串为空")
 		}
 		return nil
 	}
@@ -419,17 +675,17 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	var testCaseMindData map[string]interface{}
 	if err := json.Unmarshal([]byte(testCaseMindStr), &testCaseMindData); err != nil {
 		if e.verbose {
-			fmt.Printf("解析TestCaseMind JSON失败: %v\n", err)
-			fmt.Printf("错误类型: %T\n", err)
+			e.debugf("解析TestCaseMind JSON失败: %v\n", err)
+			e.debugf("错误类型: %T\n", err)
 
 			// 检查是否是unexpected end of JSON input错误
 			if err.Error() == "unexpected end of JSON input" {
-				fmt.Println("检测到'unexpected end of JSON input'错误，JSON可能被截断")
+				e.debugln("检测到'unexpected end of JSON input'错误，JSON可能被截断")
 				// 尝试找到最后一个有效的位置
 				lastValidPos := e.findLastValidJSONPosition(testCaseMindStr)
-				fmt.Printf("最后有效JSON位置: %d\n", lastValidPos)
+				e.debugf("最后有效JSON位置: %d\n", lastValidPos)
 				if lastValidPos > 0 {
-					fmt.Printf("截断的JSON片段: %s\n", testCaseMindStr[:lastValidPos])
+					e.debugf("截断的JSON片段: %s\n", testCaseMindStr[:lastValidPos])
 				}
 			}
 		}
@@ -437,9 +693,9 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 	}
 
 	if e.verbose {
-		fmt.Println("JSON解析成功，TestCaseMind数据结构:")
+		e.debugln("JSON解析成功，TestCaseMind数据结构:")
 		e.printJSONStructure(testCaseMindData, 0)
-		fmt.Println("=== parseTestCaseMindStructureDirect 成功 ===")
+		e.debugln("=== parseTestCaseMindStructureDirect 成功 ===")
 	}
 
 	// 使用结构模式识别
@@ -449,7 +705,7 @@ func (e *TreeExtractor) parseTestCaseMindStructureDirect(data interface{}) inter
 // parseTestCaseMindStructurePattern 基于JSON结构模式识别来解析TestCaseMind
 func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[string]interface{}) interface{} {
 	if e.verbose {
-		fmt.Println("开始结构模式识别...")
+		e.debugln("开始结构模式识别...")
 	}
 
 	// 检查是否有data字段
@@ -460,9 +716,9 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 		// 如果根节点为空（比如text为空），但有children，则解析为多根结构
 		if rootNode == nil {
 			if childrenData, hasChildren := testCaseMindData["children"]; hasChildren {
-				if childrenArray, ok := childrenData.([]interface{}); ok && len(childrenArray) > 0 {
+				if childrenArray, ok := childList(childrenData); ok && len(childrenArray) > 0 {
 					if e.verbose {
-						fmt.Printf("根节点text为空，解析为多根结构，共 %d 个顶级节点\n", len(childrenArray))
+						e.debugf("根节点text为空，解析为多根结构，共 %d 个顶级节点\n", len(childrenArray))
 					}
 
 					var validNodes []*SimplifiedNode
@@ -474,7 +730,7 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 
 						if candidate := e.parseTestCaseMindNode(childMap, 0); candidate != nil {
 							if e.verbose {
-								fmt.Printf("找到第 %d 个有效根节点: %s\n", len(validNodes)+1, candidate.Name)
+								e.debugf("找到第 %d 个有效根节点: %s\n", len(validNodes)+1, candidate.Name)
 							}
 							validNodes = append(validNodes, candidate)
 						}
@@ -482,21 +738,21 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 
 					if len(validNodes) > 0 {
 						if e.verbose {
-							fmt.Printf("返回 %d 个有效根节点的数组\n", len(validNodes))
+							e.debugf("返回 %d 个有效根节点的数组\n", len(validNodes))
 						}
 						// 返回数组格式，与预期结果一致
 						return validNodes
 					}
 
 					if e.verbose {
-						fmt.Println("没有找到有效的根节点")
+						e.debugln("没有找到有效的根节点")
 					}
 				}
 			}
 		} else {
 			// 成功解析出根节点，检查是否需要转换为数组格式
 			if e.verbose {
-				fmt.Printf("检测到标准单根结构，根节点: %s\n", rootNode.Name)
+				e.debugf("检测到标准单根结构，根节点: %s\n", rootNode.Name)
 			}
 
 			// 根据预期结果，将单根节点也包装成数组格式
@@ -507,9 +763,9 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 
 	// 检测是否为只有children数组的多根结构
 	if childrenData, hasChildren := testCaseMindData["children"]; hasChildren {
-		if childrenArray, ok := childrenData.([]interface{}); ok && len(childrenArray) > 0 {
+		if childrenArray, ok := childList(childrenData); ok && len(childrenArray) > 0 {
 			if e.verbose {
-				fmt.Printf("检测到纯多根结构，共 %d 个顶级节点\n", len(childrenArray))
+				e.debugf("检测到纯多根结构，共 %d 个顶级节点\n", len(childrenArray))
 			}
 
 			var validNodes []*SimplifiedNode
@@ -521,7 +777,7 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 
 				if candidate := e.parseTestCaseMindNode(childMap, 0); candidate != nil {
 					if e.verbose {
-						fmt.Printf("找到第 %d 个有效根节点: %s\n", len(validNodes)+1, candidate.Name)
+						e.debugf("找到第 %d 个有效根节点: %s\n", len(validNodes)+1, candidate.Name)
 					}
 					validNodes = append(validNodes, candidate)
 				}
@@ -529,29 +785,29 @@ func (e *TreeExtractor) parseTestCaseMindStructurePattern(testCaseMindData map[s
 
 			if len(validNodes) > 0 {
 				if e.verbose {
-					fmt.Printf("返回 %d 个有效根节点的数组\n", len(validNodes))
+					e.debugf("返回 %d 个有效根节点的数组\n", len(validNodes))
 				}
 				return validNodes
 			}
 
 			if e.verbose {
-				fmt.Println("没有找到有效的根节点")
+				e.debugln("没有找到有效的根节点")
 			}
 		}
 	}
 
 	// 回退到原始解析
 	if e.verbose {
-		fmt.Println("回退到原始解析逻辑")
+		e.debugln("回退到原始解析逻辑")
 	}
 	result := e.parseTestCaseMindNode(testCaseMindData, 0)
 
 	// 如果根节点解析失败但存在children，尝试解析为多根结构
 	if result == nil {
 		if childrenData, hasChildren := testCaseMindData["children"]; hasChildren {
-			if childrenArray, ok := childrenData.([]interface{}); ok && len(childrenArray) > 0 {
+			if childrenArray, ok := childList(childrenData); ok && len(childrenArray) > 0 {
 				if e.verbose {
-					fmt.Printf("根节点解析失败，尝试多根结构解析，子节点数: %d\n", len(childrenArray))
+					e.debugf("根节点解析失败，尝试多根结构解析，子节点数: %d\n", len(childrenArray))
 				}
 				return e.parseMultiRootNode(childrenArray, 0)
 			}
@@ -574,7 +830,7 @@ func (e *TreeExtractor) isGoodRootNode(node *SimplifiedNode) bool {
 	textLength := len([]rune(node.Name))
 	if textLength < 2 || textLength > 50 {
 		if e.verbose {
-			fmt.Printf("节点 '%s' 长度不合适: %d\n", node.Name, textLength)
+			e.debugf("节点 '%s' 长度不合适: %d\n", node.Name, textLength)
 		}
 		return false
 	}
@@ -582,14 +838,14 @@ func (e *TreeExtractor) isGoodRootNode(node *SimplifiedNode) bool {
 	// 检查是否是真正的业务文本
 	if !e.isBusinessText(node.Name) {
 		if e.verbose {
-			fmt.Printf("节点 '%s' 不符合业务文本特征\n", node.Name)
+			e.debugf("节点 '%s' 不符合业务文本特征\n", node.Name)
 		}
 		return false
 	}
 
 	// 检查是否包含过多的技术词汇
 	technicalPatterns := []string{
-		"接口", "系统", "平台", "验证", "测试",  // 移除了可能在业务标题中出现的词汇
+		"接口", "系统", "平台", "验证", "测试", // 移除了可能在业务标题中出现的词汇
 		"API", "HTTP", "JSON", "XML", "SQL", "UI", "UX", "QA", "CI", "CD",
 	}
 
@@ -604,7 +860,7 @@ func (e *TreeExtractor) isGoodRootNode(node *SimplifiedNode) bool {
 	words := strings.Fields(node.Name)
 	if len(words) > 0 && float64(technicalCount)/float64(len(words)) > 0.3 {
 		if e.verbose {
-			fmt.Printf("节点 '%s' 技术词汇过多: %d/%d\n", node.Name, technicalCount, len(words))
+			e.debugf("节点 '%s' 技术词汇过多: %d/%d\n", node.Name, technicalCount, len(words))
 		}
 		return false
 	}
@@ -625,7 +881,7 @@ func (e *TreeExtractor) isGoodRootNode(node *SimplifiedNode) bool {
 
 	if !hasBusinessKeyword {
 		if e.verbose {
-			fmt.Printf("节点 '%s' 缺少业务关键词\n", node.Name)
+			e.debugf("节点 '%s' 缺少业务关键词\n", node.Name)
 		}
 		return false
 	}
@@ -678,7 +934,7 @@ func (e *TreeExtractor) selectBestRootNode(candidates []*SimplifiedNode) *Simpli
 		}
 
 		// 评分标准3: 避免技术词汇
-		technicalWords := []string{"系统", "平台", "接口", "验证", "测试"}  // 移除了业务相关的词汇
+		technicalWords := []string{"系统", "平台", "接口", "验证", "测试"} // 移除了业务相关的词汇
 		technicalCount := 0
 		for _, word := range technicalWords {
 			if strings.Contains(candidate.Name, word) {
@@ -718,20 +974,19 @@ func (e *TreeExtractor) selectBestRootNode(candidates []*SimplifiedNode) *Simpli
 	}
 
 	if e.verbose {
-		fmt.Printf("根节点选择结果:\n")
+		e.debugf("根节点选择结果:\n")
 		for _, scored := range scoredNodes {
 			marker := " "
 			if scored.node.Name == best.node.Name {
 				marker = "✓"
 			}
-			fmt.Printf("  %s '%s': %.1f分 (%s)\n", marker, scored.node.Name, scored.score, scored.reason)
+			e.debugf("  %s '%s': %.1f分 (%s)\n", marker, scored.node.Name, scored.score, scored.reason)
 		}
 	}
 
 	return best.node
 }
 
-
 // extractTestCaseMindStructure 专门解析TestCaseMind的三层嵌套结构
 func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *SimplifiedNode {
 	// 将数据转换为map以便访问
@@ -766,7 +1021,7 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 	var testCaseMindData map[string]interface{}
 	if err := json.Unmarshal([]byte(testCaseMindStr), &testCaseMindData); err != nil {
 		if e.verbose {
-			fmt.Printf("解析TestCaseMind JSON失败: %v\n", err)
+			e.debugf("解析TestCaseMind JSON失败: %v\n", err)
 		}
 		return nil
 	}
@@ -789,8 +1044,8 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 
 	// 创建根节点
 	rootNode := &SimplifiedNode{
-		Name: rootText,
-		Children:  []*SimplifiedNode{},
+		Name:     rootText,
+		Children: []*SimplifiedNode{},
 	}
 
 	// 提取第二层：children数组
@@ -799,7 +1054,7 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 		return rootNode
 	}
 
-	childrenArray, ok := childrenData.([]interface{})
+	childrenArray, ok := childList(childrenData)
 	if !ok || len(childrenArray) == 0 {
 		return rootNode
 	}
@@ -827,8 +1082,8 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 
 	// 创建第二层节点
 	secondLevelNode := &SimplifiedNode{
-		Name: secondLevelText,
-		Children:  []*SimplifiedNode{},
+		Name:     secondLevelText,
+		Children: []*SimplifiedNode{},
 	}
 
 	// 提取第三层： grandchildren数组
@@ -838,7 +1093,7 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 		return rootNode
 	}
 
-	grandchildrenArray, ok := grandchildrenData.([]interface{})
+	grandchildrenArray, ok := childList(grandchildrenData)
 	if !ok {
 		rootNode.Children = append(rootNode.Children, secondLevelNode)
 		return rootNode
@@ -865,8 +1120,8 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 						if textVal, textExists := richTextObj["text"]; textExists {
 							if textStr, ok := textVal.(string); ok && textStr != "" && e.isBusinessText(textStr) && !seen[textStr] {
 								thirdLevelNode := &SimplifiedNode{
-									Name: textStr,
-									Children:  []*SimplifiedNode{},
+									Name:     textStr,
+									Children: []*SimplifiedNode{},
 								}
 								secondLevelNode.Children = append(secondLevelNode.Children, thirdLevelNode)
 								seen[textStr] = true
@@ -880,8 +1135,8 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 		// 如果没有richText，则使用text字段
 		if textVal, ok := grandchildData["text"].(string); ok && textVal != "" && e.isBusinessText(textVal) && !seen[textVal] {
 			thirdLevelNode := &SimplifiedNode{
-				Name: textVal,
-				Children:  []*SimplifiedNode{},
+				Name:     textVal,
+				Children: []*SimplifiedNode{},
 			}
 			secondLevelNode.Children = append(secondLevelNode.Children, thirdLevelNode)
 			seen[textVal] = true
@@ -893,35 +1148,26 @@ func (e *TreeExtractor) extractTestCaseMindStructure(data interface{}) *Simplifi
 
 	if e.verbose && rootNode != nil {
 		maxDepth := e.calculateTreeDepth(rootNode)
-		fmt.Printf("成功解析TestCaseMind %d层嵌套结构，标题: %s，子节点数: %d\n", maxDepth, rootNode.Name, len(rootNode.Children))
+		e.debugf("成功解析TestCaseMind %d层嵌套结构，标题: %s，子节点数: %d\n", maxDepth, rootNode.Name, len(rootNode.Children))
 	}
 
 	return rootNode
 }
 
-// createGenericBusinessTextStructure 创建通用的业务文本结构（回退方案）
-func (e *TreeExtractor) createGenericBusinessTextStructure(data interface{}) *SimplifiedNode {
+// createGenericBusinessTextStructure 创建通用的业务文本结构（回退方案），
+// 多处出现的同一段文本只保留一个节点，合并的节点连同每处出现的位置一起返回
+func (e *TreeExtractor) createGenericBusinessTextStructure(data interface{}) (*SimplifiedNode, []Duplicate) {
 	node := &SimplifiedNode{
 		Children: []*SimplifiedNode{},
 	}
 
-	// 使用ExtractTextContent提取所有业务文本内容
-	texts := e.ExtractTextContent(data)
-
-	// 过滤掉技术字段，只保留真正的业务文本
-	var businessTexts []string
-	seen := make(map[string]bool) // 用于去重
-	for _, text := range texts {
-		if e.isBusinessText(text) && !seen[text] {
-			businessTexts = append(businessTexts, text)
-			seen[text] = true
-		}
-	}
+	// 提取所有业务文本内容，过滤掉技术字段并去重，记录每段文本出现的位置
+	businessTexts, occurrences := e.dedupTexts(e.collectTexts(data, "$"))
 
 	// 如果没有找到业务文本，使用默认标题
 	if len(businessTexts) == 0 {
 		node.Name = "API Response"
-		return node
+		return node, nil
 	}
 
 	// 选择最长的文本作为标题（通常是最详细的业务描述）
@@ -955,25 +1201,80 @@ func (e *TreeExtractor) createGenericBusinessTextStructure(data interface{}) *Si
 	// 创建子节点
 	for _, text := range childTexts {
 		childNode := &SimplifiedNode{
-			Name: text,
-			Children:  []*SimplifiedNode{},
+			Name:     text,
+			Children: []*SimplifiedNode{},
 		}
 		node.Children = append(node.Children, childNode)
 	}
 
 	if e.verbose {
-		fmt.Printf("提取到 %d 个唯一业务文本，标题: %s\n", len(businessTexts), node.Name)
-		fmt.Printf("子节点数量: %d\n", len(node.Children))
+		e.debugf("提取到 %d 个唯一业务文本，标题: %s\n", len(businessTexts), node.Name)
+		e.debugf("子节点数量: %d\n", len(node.Children))
 	}
 
-	return node
+	// 按节点在结果中的顺序记录合并的重复文本
+	var duplicates []Duplicate
+	for _, text := range append([]string{node.Name}, childTexts...) {
+		sources := occurrences[text]
+		if len(sources) < 2 {
+			continue
+		}
+		path := []string{node.Name}
+		if text != node.Name {
+			path = append(path, text)
+		}
+		duplicates = append(duplicates, Duplicate{Path: path, Count: len(sources), Sources: sources})
+	}
+	return node, duplicates
+}
+
+// depthLimitHit 记录parent的一个子节点因达到最大递归深度而被丢弃
+func (e *TreeExtractor) depthLimitHit(parent *SimplifiedNode) {
+	if e.verbose {
+		e.debugf("警告: 达到最大递归深度 %d，停止递归\n", e.maxDepth)
+	}
+	if e.truncated != nil {
+		e.truncated[parent]++
+	}
+}
+
+// depthLimit 汇总抽取结果中被截断的节点，没有节点被截断时返回nil。
+// 只统计最终保留在结果中的节点，候选根节点等被舍弃的中间结果不计入
+func (e *TreeExtractor) depthLimit(roots []*SimplifiedNode) *DepthLimit {
+	limit := &DepthLimit{MaxDepth: e.maxDepth}
+	var walk func(nodes []*SimplifiedNode, path []string)
+	walk = func(nodes []*SimplifiedNode, path []string) {
+		for _, node := range nodes {
+			if node == nil {
+				continue
+			}
+			nodePath := append(path[:len(path):len(path)], node.Name)
+			if hits := e.truncated[node]; hits > 0 {
+				limit.Hits += hits
+				if len(nodePath) > len(limit.DeepestPath) {
+					limit.DeepestPath = nodePath
+				}
+			}
+			walk(node.Children, nodePath)
+		}
+	}
+	walk(roots, nil)
+	if limit.Hits == 0 {
+		return nil
+	}
+	return limit
 }
 
-// extractTree 递归抽取树结构
+// extractTree 递归抽取树结构，obj 为抽取结果的根节点
 func (e *TreeExtractor) extractTree(obj map[string]interface{}, depth int) *SimplifiedNode {
+	return e.extractNode(obj, depth, 1)
+}
+
+// extractNode 递归抽取以obj为根的子树，depth 为递归深度，level 为节点在抽取结果中的层数
+func (e *TreeExtractor) extractNode(obj map[string]interface{}, depth, level int) *SimplifiedNode {
 	if depth > e.maxDepth {
 		if e.verbose {
-			fmt.Printf("警告: 达到最大递归深度 %d，停止递归\n", e.maxDepth)
+			e.debugf("警告: 达到最大递归深度 %d，停止递归\n", e.maxDepth)
 		}
 		return nil
 	}
@@ -983,14 +1284,19 @@ func (e *TreeExtractor) extractTree(obj map[string]interface{}, depth int) *Simp
 	}
 
 	// 1. 查找标题
-	title := e.findTitle(obj)
+	title := e.findTitle(obj, level)
 	node.Name = title
+	node.Tags = e.nodeTags(obj)
 
 	// 2. 查找子节点并递归
 	children := e.findChildren(obj)
 	for _, childData := range children {
 		if childObj, ok := childData.(map[string]interface{}); ok {
-			if childNode := e.extractTree(childObj, depth+1); childNode != nil {
+			if depth+1 > e.maxDepth {
+				e.depthLimitHit(node)
+				continue
+			}
+			if childNode := e.extractNode(childObj, depth+1, level+1); childNode != nil {
 				node.Children = append(node.Children, childNode)
 			}
 		}
@@ -999,29 +1305,29 @@ func (e *TreeExtractor) extractTree(obj map[string]interface{}, depth int) *Simp
 	// 3. 如果没有找到标准子节点，将所有嵌套对象作为子节点
 	if len(node.Children) == 0 {
 		for key, value := range obj {
-			if key == title || value == nil {
-				continue // 跳过标题字段和nil值
+			if key == title || value == nil || slices.Contains(e.tagFields, key) {
+				continue // 跳过标题字段、nil值和已转换为标签的字段
 			}
 
 			switch v := value.(type) {
 			case map[string]interface{}:
 				// 处理嵌套对象
 				nestedNode := &SimplifiedNode{
-					Name: fmt.Sprintf("%s (Object)", key),
-					Children:  []*SimplifiedNode{},
+					Name:     fmt.Sprintf("%s (Object)", key),
+					Children: []*SimplifiedNode{},
 				}
 
 				for nestedKey, nestedValue := range v {
 					if nestedStr, ok := nestedValue.(string); ok && nestedStr != "" {
 						nestedChild := &SimplifiedNode{
-							Name: fmt.Sprintf("%s: %s", nestedKey, nestedStr),
-							Children:  []*SimplifiedNode{},
+							Name:     fmt.Sprintf("%s: %s", nestedKey, nestedStr),
+							Children: []*SimplifiedNode{},
 						}
 						nestedNode.Children = append(nestedNode.Children, nestedChild)
 					} else if nestedValue != nil {
 						nestedChild := &SimplifiedNode{
-							Name: fmt.Sprintf("%s: %v", nestedKey, nestedValue),
-							Children:  []*SimplifiedNode{},
+							Name:     fmt.Sprintf("%s: %v", nestedKey, nestedValue),
+							Children: []*SimplifiedNode{},
 						}
 						nestedNode.Children = append(nestedNode.Children, nestedChild)
 					}
@@ -1034,21 +1340,21 @@ func (e *TreeExtractor) extractTree(obj map[string]interface{}, depth int) *Simp
 			case []interface{}:
 				// 处理数组
 				arrayNode := &SimplifiedNode{
-					Name: fmt.Sprintf("%s (Array - %d items)", key, len(v)),
-					Children:  []*SimplifiedNode{},
+					Name:     fmt.Sprintf("%s (Array - %d items)", key, len(v)),
+					Children: []*SimplifiedNode{},
 				}
 
 				for i, item := range v {
 					if itemStr, ok := item.(string); ok && itemStr != "" {
 						arrayChild := &SimplifiedNode{
-							Name: fmt.Sprintf("[%d]: %s", i, itemStr),
-							Children:  []*SimplifiedNode{},
+							Name:     fmt.Sprintf("[%d]: %s", i, itemStr),
+							Children: []*SimplifiedNode{},
 						}
 						arrayNode.Children = append(arrayNode.Children, arrayChild)
 					} else if item != nil {
 						arrayChild := &SimplifiedNode{
-							Name: fmt.Sprintf("[%d]: %v", i, item),
-							Children:  []*SimplifiedNode{},
+							Name:     fmt.Sprintf("[%d]: %v", i, item),
+							Children: []*SimplifiedNode{},
 						}
 						arrayNode.Children = append(arrayNode.Children, arrayChild)
 					}
@@ -1069,31 +1375,41 @@ func (e *TreeExtractor) extractTree(obj map[string]interface{}, depth int) *Simp
 	return nil
 }
 
-// findTitle 查找节点标题
-func (e *TreeExtractor) findTitle(obj map[string]interface{}) string {
-	for _, key := range e.titleKeys {
-		if value, exists := obj[key]; exists {
-			if title, ok := value.(string); ok && title != "" {
-				return title
+// findTitle 查找第level层节点的标题，先使用该层的候选键名，再使用全局的候选键名；
+// 启用标量标题时，按候选键名的顺序第一个非空字符串、数字或布尔值作为标题
+func (e *TreeExtractor) findTitle(obj map[string]interface{}, level int) string {
+	for _, keys := range [][]string{e.levelTitleKeys[level], e.titleKeys} {
+		for _, key := range keys {
+			if value, exists := obj[key]; exists {
+				if title, ok := value.(string); ok && title != "" {
+					return title
+				}
+				if e.scalarTitles != nil {
+					if title, ok := e.scalarTitles.format(key, value); ok && title != "" {
+						return title
+					}
+				}
 			}
 		}
 	}
 	return ""
 }
 
-// findChildren 查找子节点数组
+// findChildren 查找子节点数组，合并模式下按候选键名的顺序拼接所有非空的子节点数组
 func (e *TreeExtractor) findChildren(obj map[string]interface{}) []interface{} {
+	var merged []interface{}
 	for _, key := range e.childrenKeys {
 		if value, exists := obj[key]; exists {
-			// 检查是否为数组
-			if reflect.TypeOf(value).Kind() == reflect.Slice {
-				if children, ok := value.([]interface{}); ok && len(children) > 0 {
+			// 数组，或键为数字的对象
+			if children, ok := childList(value); ok && len(children) > 0 {
+				if !e.mergeChildren {
 					return children
 				}
+				merged = append(merged, children...)
 			}
 		}
 	}
-	return nil
+	return merged
 }
 
 // deepSearchInObject 深度搜索对象中的树结构
@@ -1211,16 +1527,11 @@ func (e *TreeExtractor) searchInObject(obj map[string]interface{}) interface{} {
 	return nil
 }
 
-// SetMaxDepth 设置最大递归深度
-func (e *TreeExtractor) SetMaxDepth(depth int) {
-	e.maxDepth = depth
-}
-
 // GetStats 获取抽取统计信息
 func (e *TreeExtractor) GetStats(data []byte) (map[string]interface{}, error) {
 	var rawData interface{}
 	if err := json.Unmarshal(data, &rawData); err != nil {
-		return nil, fmt.Errorf("JSON解析失败: %w", err)
+		return nil, i18n.Errorf("JSON解析失败: %w", err)
 	}
 
 	stats := make(map[string]interface{})
@@ -1229,7 +1540,7 @@ func (e *TreeExtractor) GetStats(data []byte) (map[string]interface{}, error) {
 	case map[string]interface{}:
 		stats["root_type"] = "object"
 		stats["root_keys"] = e.getObjectKeys(v)
-		e.collectStats(v, stats, "root")
+		e.collectStats(v, stats, "root", 1)
 	case []interface{}:
 		stats["root_type"] = "array"
 		stats["array_length"] = len(v)
@@ -1252,9 +1563,9 @@ func (e *TreeExtractor) getObjectKeys(obj map[string]interface{}) []string {
 	return keys
 }
 
-// collectStats 递归收集统计信息
-func (e *TreeExtractor) collectStats(obj map[string]interface{}, stats map[string]interface{}, path string) {
-	title := e.findTitle(obj)
+// collectStats 递归收集统计信息，level 为obj所在的层数
+func (e *TreeExtractor) collectStats(obj map[string]interface{}, stats map[string]interface{}, path string, level int) {
+	title := e.findTitle(obj, level)
 	children := e.findChildren(obj)
 
 	if title != "" {
@@ -1270,7 +1581,7 @@ func (e *TreeExtractor) collectStats(obj map[string]interface{}, stats map[strin
 		for i := 0; i < maxCheck; i++ {
 			if childObj, ok := children[i].(map[string]interface{}); ok {
 				childPath := fmt.Sprintf("%s.child_%d", path, i)
-				e.collectStats(childObj, stats, childPath)
+				e.collectStats(childObj, stats, childPath, level+1)
 			}
 		}
 	}
@@ -1287,13 +1598,13 @@ func min(a, b int) int {
 // parseTestCaseMindNode 递归解析TestCaseMind节点，支持任意层级
 func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, depth int) *SimplifiedNode {
 	if e.verbose {
-		fmt.Printf("%sparseTestCaseMindNode 开始，深度: %d\n", strings.Repeat("  ", depth), depth)
+		e.debugf("%sparseTestCaseMindNode 开始，深度: %d\n", strings.Repeat("  ", depth), depth)
 	}
 
 	// 防止无限递归
 	if depth > e.maxDepth {
 		if e.verbose {
-			fmt.Printf("警告: 达到最大递归深度 %d，停止递归\n", e.maxDepth)
+			e.debugf("警告: 达到最大递归深度 %d，停止递归\n", e.maxDepth)
 		}
 		return nil
 	}
@@ -1302,7 +1613,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 	currentData, ok := nodeData["data"].(map[string]interface{})
 	if !ok {
 		if e.verbose {
-			fmt.Printf("%s未找到data字段或类型错误\n", strings.Repeat("  ", depth))
+			e.debugf("%s未找到data字段或类型错误\n", strings.Repeat("  ", depth))
 		}
 		return nil
 	}
@@ -1314,7 +1625,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 	if richTextArray, exists := currentData["richText"]; exists {
 		if richTextItems, ok := richTextArray.([]interface{}); ok {
 			if e.verbose {
-				fmt.Printf("%s找到richText数组，长度: %d\n", strings.Repeat("  ", depth), len(richTextItems))
+				e.debugf("%s找到richText数组，长度: %d\n", strings.Repeat("  ", depth), len(richTextItems))
 			}
 			// 收集所有有效的业务文本
 			var validTexts []string
@@ -1323,7 +1634,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 					if textVal, textExists := richTextObj["text"]; textExists {
 						if textStr, ok := textVal.(string); ok && textStr != "" {
 							if e.verbose {
-								fmt.Printf("%srichText文本: '%s', 是否业务文本: %v\n", strings.Repeat("  ", depth), textStr, e.isBusinessText(textStr))
+								e.debugf("%srichText文本: '%s', 是否业务文本: %v\n", strings.Repeat("  ", depth), textStr, e.isBusinessText(textStr))
 							}
 							if e.isBusinessText(textStr) {
 								validTexts = append(validTexts, textStr)
@@ -1336,7 +1647,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 			if len(validTexts) > 0 {
 				titleText = validTexts[0]
 				if e.verbose {
-					fmt.Printf("%s使用richText作为标题: '%s'\n", strings.Repeat("  ", depth), titleText)
+					e.debugf("%s使用richText作为标题: '%s'\n", strings.Repeat("  ", depth), titleText)
 				}
 			}
 		}
@@ -1346,7 +1657,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 	if titleText == "" {
 		if textVal, ok := currentData["text"].(string); ok {
 			if e.verbose {
-				fmt.Printf("%s发现text字段: '%s', 长度: %d\n", strings.Repeat("  ", depth), textVal, len(textVal))
+				e.debugf("%s发现text字段: '%s', 长度: %d\n", strings.Repeat("  ", depth), textVal, len(textVal))
 			}
 			// 对于根节点，如果text为空但有children，不直接返回nil
 			if textVal != "" {
@@ -1354,10 +1665,10 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 				if e.isBusinessText(textVal) || e.isUIBusinessText(textVal, depth) {
 					titleText = textVal
 					if e.verbose {
-						fmt.Printf("%s使用text字段作为标题: '%s'\n", strings.Repeat("  ", depth), titleText)
+						e.debugf("%s使用text字段作为标题: '%s'\n", strings.Repeat("  ", depth), titleText)
 					}
 				} else if e.verbose {
-					fmt.Printf("%stext字段不是业务文本，跳过: '%s'\n", strings.Repeat("  ", depth), textVal)
+					e.debugf("%stext字段不是业务文本，跳过: '%s'\n", strings.Repeat("  ", depth), textVal)
 				}
 			}
 		}
@@ -1367,11 +1678,11 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 	if titleText == "" {
 		childrenData, hasChildren := nodeData["children"]
 		if hasChildren {
-			if childrenArray, ok := childrenData.([]interface{}); ok && len(childrenArray) > 0 {
+			if childrenArray, ok := childList(childrenData); ok && len(childrenArray) > 0 {
 				if depth == 0 {
 					// 这是根节点且有子节点，为多根结构创建数组而不是单个节点
 					if e.verbose {
-						fmt.Printf("%s根节点无标题但有子节点，解析为多根结构\n", strings.Repeat("  ", depth))
+						e.debugf("%s根节点无标题但有子节点，解析为多根结构\n", strings.Repeat("  ", depth))
 					}
 					// 继续解析子节点，让调用者处理多根结构，但不直接返回nil
 					// 先尝试解析所有子节点，看看能否找到有效的根节点候选
@@ -1389,7 +1700,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 						bestNode := e.selectBestBusinessRootNode(validNodes)
 						if bestNode != nil {
 							if e.verbose {
-								fmt.Printf("%s从子节点中选择最佳根节点: '%s'\n", strings.Repeat("  ", depth), bestNode.Name)
+								e.debugf("%s从子节点中选择最佳根节点: '%s'\n", strings.Repeat("  ", depth), bestNode.Name)
 							}
 							return bestNode
 						}
@@ -1403,12 +1714,12 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 					if inferredTitle != "" {
 						titleText = inferredTitle
 						if e.verbose {
-							fmt.Printf("%s从子节点推断标题: '%s'\n", strings.Repeat("  ", depth), titleText)
+							e.debugf("%s从子节点推断标题: '%s'\n", strings.Repeat("  ", depth), titleText)
 						}
 					} else {
 						titleText = "未命名节点"
 						if e.verbose {
-							fmt.Printf("%s��法推断标题，使用默认标题: '%s'\n", strings.Repeat("  ", depth), titleText)
+							e.debugf("%s无法推断标题，使用默认标题: '%s'\n", strings.Repeat("  ", depth), titleText)
 						}
 					}
 				}
@@ -1419,36 +1730,37 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 	// 如果仍然没有找到标题，跳过这个节点
 	if titleText == "" {
 		if e.verbose {
-			fmt.Printf("%s未找到有效标题，跳过节点\n", strings.Repeat("  ", depth))
+			e.debugf("%s未找到有效标题，跳过节点\n", strings.Repeat("  ", depth))
 		}
 		return nil
 	}
 
 	// 创建当前节点
 	simpleNode := &SimplifiedNode{
-		Name: titleText,
-		Children:  []*SimplifiedNode{},
+		Name:     titleText,
+		Tags:     e.nodeTags(currentData),
+		Children: []*SimplifiedNode{},
 	}
 
 	// 递归处理子节点
 	childrenData, exists := nodeData["children"]
 	if !exists {
 		if e.verbose {
-			fmt.Printf("%s无children字段，返回节点: '%s'\n", strings.Repeat("  ", depth), titleText)
+			e.debugf("%s无children字段，返回节点: '%s'\n", strings.Repeat("  ", depth), titleText)
 		}
 		return simpleNode
 	}
 
-	childrenArray, ok := childrenData.([]interface{})
+	childrenArray, ok := childList(childrenData)
 	if !ok || len(childrenArray) == 0 {
 		if e.verbose {
-			fmt.Printf("%schildren为空或格式错误，返回节点: '%s'\n", strings.Repeat("  ", depth), titleText)
+			e.debugf("%schildren为空或格式错误，返回节点: '%s'\n", strings.Repeat("  ", depth), titleText)
 		}
 		return simpleNode
 	}
 
 	if e.verbose {
-		fmt.Printf("%s处理 %d 个子节点\n", strings.Repeat("  ", depth), len(childrenArray))
+		e.debugf("%s处理 %d 个子节点\n", strings.Repeat("  ", depth), len(childrenArray))
 	}
 
 	// 处理每个子节点
@@ -1456,22 +1768,26 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 		childMap, ok := child.(map[string]interface{})
 		if !ok {
 			if e.verbose {
-				fmt.Printf("%s子节点 %d 格式错误\n", strings.Repeat("  ", depth), i)
+				e.debugf("%s子节点 %d 格式错误\n", strings.Repeat("  ", depth), i)
 			}
 			continue
 		}
 
+		if depth+1 > e.maxDepth {
+			e.depthLimitHit(simpleNode)
+			continue
+		}
 		childNode := e.parseTestCaseMindNode(childMap, depth+1)
 		if childNode != nil {
 			if e.verbose {
-				fmt.Printf("%s添加子节点: '%s'\n", strings.Repeat("  ", depth), childNode.Name)
+				e.debugf("%s添加子节点: '%s'\n", strings.Repeat("  ", depth), childNode.Name)
 			}
 			simpleNode.Children = append(simpleNode.Children, childNode)
 		}
 	}
 
 	if e.verbose {
-		fmt.Printf("%s完成节点解析: '%s', 子节点数: %d\n", strings.Repeat("  ", depth), titleText, len(simpleNode.Children))
+		e.debugf("%s完成节点解析: '%s', 子节点数: %d\n", strings.Repeat("  ", depth), titleText, len(simpleNode.Children))
 	}
 
 	return simpleNode
@@ -1480,7 +1796,7 @@ func (e *TreeExtractor) parseTestCaseMindNode(nodeData map[string]interface{}, d
 // parseMultiRootNode 解析多根节点结构
 func (e *TreeExtractor) parseMultiRootNode(childrenArray []interface{}, depth int) interface{} {
 	if e.verbose {
-		fmt.Printf("%s=== parseMultiRootNode 开始，子节点数: %d ===\n", strings.Repeat("  ", depth), len(childrenArray))
+		e.debugf("%s=== parseMultiRootNode 开始，子节点数: %d ===\n", strings.Repeat("  ", depth), len(childrenArray))
 	}
 
 	var validNodes []*SimplifiedNode
@@ -1488,7 +1804,7 @@ func (e *TreeExtractor) parseMultiRootNode(childrenArray []interface{}, depth in
 		childMap, ok := child.(map[string]interface{})
 		if !ok {
 			if e.verbose {
-				fmt.Printf("%s子节点 %d 格式错误\n", strings.Repeat("  ", depth), i)
+				e.debugf("%s子节点 %d 格式错误\n", strings.Repeat("  ", depth), i)
 			}
 			continue
 		}
@@ -1496,14 +1812,14 @@ func (e *TreeExtractor) parseMultiRootNode(childrenArray []interface{}, depth in
 		childNode := e.parseTestCaseMindNode(childMap, depth+1)
 		if childNode != nil {
 			if e.verbose {
-				fmt.Printf("%s找到有效根节点 %d: '%s'\n", strings.Repeat("  ", depth), len(validNodes)+1, childNode.Name)
+				e.debugf("%s找到有效根节点 %d: '%s'\n", strings.Repeat("  ", depth), len(validNodes)+1, childNode.Name)
 			}
 			validNodes = append(validNodes, childNode)
 		}
 	}
 
 	if e.verbose {
-		fmt.Printf("%s=== parseMultiRootNode 完成，有效节点数: %d ===\n", strings.Repeat("  ", depth), len(validNodes))
+		e.debugf("%s=== parseMultiRootNode 完成，有效节点数: %d ===\n", strings.Repeat("  ", depth), len(validNodes))
 	}
 
 	if len(validNodes) > 0 {
@@ -1523,7 +1839,7 @@ func (e *TreeExtractor) selectBestBusinessRootNode(nodes []*SimplifiedNode) *Sim
 	}
 
 	if e.verbose {
-		fmt.Println("开始智能选择最佳业务根节点...")
+		e.debugln("开始智能选择最佳业务根节点...")
 	}
 
 	// 评分系统：为每个节点打分
@@ -1551,7 +1867,7 @@ func (e *TreeExtractor) selectBestBusinessRootNode(nodes []*SimplifiedNode) *Sim
 		}
 
 		// 评分标准2: 避免选择包含"接口"、"系统"等技术性描述的节点
-		avoidKeywords := []string{"接口", "系统", "平台", "验证", "测试"}  // 移除了业务相关的词汇
+		avoidKeywords := []string{"接口", "系统", "平台", "验证", "测试"} // 移除了业务相关的词汇
 		for _, keyword := range avoidKeywords {
 			if strings.Contains(nodeName, keyword) {
 				score -= 50
@@ -1579,7 +1895,7 @@ func (e *TreeExtractor) selectBestBusinessRootNode(nodes []*SimplifiedNode) *Sim
 		})
 
 		if e.verbose {
-			fmt.Printf("节点 '%s': %d分 (%s)\n", node.Name, score, strings.Join(reasons, ", "))
+			e.debugf("节点 '%s': %d分 (%s)\n", node.Name, score, strings.Join(reasons, ", "))
 		}
 	}
 
@@ -1592,7 +1908,7 @@ func (e *TreeExtractor) selectBestBusinessRootNode(nodes []*SimplifiedNode) *Sim
 	}
 
 	if e.verbose {
-		fmt.Printf("最终选择: '%s' (%d分)\n", best.node.Name, best.score)
+		e.debugf("最终选择: '%s' (%d分)\n", best.node.Name, best.score)
 	}
 
 	return best.node
@@ -1666,25 +1982,25 @@ func (e *TreeExtractor) printJSONStructure(data interface{}, indent int) {
 		for key, value := range v {
 			switch value.(type) {
 			case map[string]interface{}, []interface{}:
-				fmt.Printf("%s%s: (complex type)\n", prefix, key)
+				e.debugf("%s%s: (complex type)\n", prefix, key)
 				if indent < 2 {
 					e.printJSONStructure(value, indent+1)
 				}
 			default:
 				if str, ok := value.(string); ok && len(str) > 50 {
-					fmt.Printf("%s%s: \"%s...\" (length:%d)\n", prefix, key, str[:47], len(str))
+					e.debugf("%s%s: \"%s...\" (length:%d)\n", prefix, key, str[:47], len(str))
 				} else {
-					fmt.Printf("%s%s: %v\n", prefix, key, value)
+					e.debugf("%s%s: %v\n", prefix, key, value)
 				}
 			}
 		}
 	case []interface{}:
-		fmt.Printf("%s(array with %d items)\n", prefix, len(v))
+		e.debugf("%s(array with %d items)\n", prefix, len(v))
 		if len(v) > 0 && indent < 2 {
 			e.printJSONStructure(v[0], indent+1)
 		}
 	default:
-		fmt.Printf("%s%v\n", prefix, v)
+		e.debugf("%s%v\n", prefix, v)
 	}
 }
 
@@ -1725,7 +2041,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 	for _, action := range businessActions {
 		if strings.Contains(text, action) {
 			if e.verbose {
-				fmt.Printf("识别业务动作文本: '%s' (包含关键词: '%s')\n", text, action)
+				e.debugf("识别业务动作文本: '%s' (包含关键词: '%s')\n", text, action)
 			}
 			return true
 		}
@@ -1738,7 +2054,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 		for _, keyword := range timeBusinessKeywords {
 			if strings.Contains(text, keyword) {
 				if e.verbose {
-					fmt.Printf("识别时间相关业务文本: '%s' (包含关键词: '%s')\n", text, keyword)
+					e.debugf("识别时间相关业务文本: '%s' (包含关键词: '%s')\n", text, keyword)
 				}
 				return true
 			}
@@ -1748,7 +2064,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 	// 检查埋点和数据统计相关的业务文本
 	if strings.Contains(text, "埋点") || strings.Contains(text, "上报") || strings.Contains(text, "统计") || strings.Contains(text, "快捷筛选") {
 		if e.verbose {
-			fmt.Printf("识别埋点统计业务文本: '%s'\n", text)
+			e.debugf("识别埋点统计业务文本: '%s'\n", text)
 		}
 		return true
 	}
@@ -1756,7 +2072,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 	// 检查配置和开关相关的业务文本
 	if strings.Contains(text, "配置") || strings.Contains(text, "开关") || strings.Contains(text, "tcc") || strings.Contains(text, "手动设置") {
 		if e.verbose {
-			fmt.Printf("识别配置开关业务文本: '%s'\n", text)
+			e.debugf("识别配置开关业务文本: '%s'\n", text)
 		}
 		return true
 	}
@@ -1767,7 +2083,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 		for _, action := range bdActions {
 			if strings.Contains(text, action) {
 				if e.verbose {
-					fmt.Printf("识别BD操作业务文本: '%s' (包含关键词: '%s')\n", text, action)
+					e.debugf("识别BD操作业务文本: '%s' (包含关键词: '%s')\n", text, action)
 				}
 				return true
 			}
@@ -1784,7 +2100,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 	for _, interaction := range uiInteractions {
 		if strings.Contains(text, interaction) {
 			if e.verbose {
-				fmt.Printf("识别UI交互文本: '%s' (匹配模式: '%s')\n", text, interaction)
+				e.debugf("识别UI交互文本: '%s' (匹配模式: '%s')\n", text, interaction)
 			}
 			return true
 		}
@@ -1792,19 +2108,19 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 
 	// 检查是否为描述开关状态或配置相关的文本
 	if (strings.Contains(text, "为准") && strings.Contains(text, "不影响")) ||
-	   (strings.Contains(text, "手动") && strings.Contains(text, "状态")) ||
-	   (strings.Contains(text, "配置") && strings.Contains(text, "tcc")) ||
-	   (strings.Contains(text, "当前") && strings.Contains(text, "开关")) {
+		(strings.Contains(text, "手动") && strings.Contains(text, "状态")) ||
+		(strings.Contains(text, "配置") && strings.Contains(text, "tcc")) ||
+		(strings.Contains(text, "当前") && strings.Contains(text, "开关")) {
 		if e.verbose {
-			fmt.Printf("识别状态配置文本: '%s'\n", text)
+			e.debugf("识别状态配置文本: '%s'\n", text)
 		}
 		return true
 	}
 
 	// 专门检查编号格式的业务文本
 	if strings.HasPrefix(text, "1.") || strings.HasPrefix(text, "2.") || strings.HasPrefix(text, "3.") ||
-	   strings.HasPrefix(text, "4.") || strings.HasPrefix(text, "5.") || strings.HasPrefix(text, "6.") ||
-	   strings.HasPrefix(text, "7.") || strings.HasPrefix(text, "8.") || strings.HasPrefix(text, "9.") {
+		strings.HasPrefix(text, "4.") || strings.HasPrefix(text, "5.") || strings.HasPrefix(text, "6.") ||
+		strings.HasPrefix(text, "7.") || strings.HasPrefix(text, "8.") || strings.HasPrefix(text, "9.") {
 		// 检查是否包含业务关键词
 		stepBusinessKeywords := []string{"用户", "查询", "指标", "数据", "结果", "展示",
 			"Agent", "多轮", "对话", "携带", "上下文", "筛选", "条件", "切换", "主题", "开始", "新",
@@ -1812,7 +2128,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 		for _, keyword := range stepBusinessKeywords {
 			if strings.Contains(text, keyword) {
 				if e.verbose {
-					fmt.Printf("识别编号格式业务文本: '%s' (包含关键词: '%s')\n", text, keyword)
+					e.debugf("识别编号格式业务文本: '%s' (包含关键词: '%s')\n", text, keyword)
 				}
 				return true
 			}
@@ -1825,7 +2141,7 @@ func (e *TreeExtractor) isUIBusinessText(text string, depth int) bool {
 // inferTitleFromChildren 从子节点推断合适的标题
 func (e *TreeExtractor) inferTitleFromChildren(childrenArray []interface{}, depth int) string {
 	if e.verbose {
-		fmt.Printf("%s开始从子节点推断标题，子节点数: %d\n", strings.Repeat("  ", depth), len(childrenArray))
+		e.debugf("%s开始从子节点推断标题，子节点数: %d\n", strings.Repeat("  ", depth), len(childrenArray))
 	}
 
 	// 收集所有子节点的名称
@@ -1839,7 +2155,7 @@ func (e *TreeExtractor) inferTitleFromChildren(childrenArray []interface{}, dept
 						if textStr, ok := textVal.(string); ok && textStr != "" && e.isBusinessText(textStr) {
 							childNames = append(childNames, textStr)
 							if e.verbose {
-								fmt.Printf("%s找到子节点文本: '%s'\n", strings.Repeat("  ", depth), textStr)
+								e.debugf("%s找到子节点文本: '%s'\n", strings.Repeat("  ", depth), textStr)
 							}
 						}
 					}
@@ -1852,7 +2168,7 @@ func (e *TreeExtractor) inferTitleFromChildren(childrenArray []interface{}, dept
 										if textStr, ok := textVal.(string); ok && textStr != "" && e.isBusinessText(textStr) {
 											childNames = append(childNames, textStr)
 											if e.verbose {
-												fmt.Printf("%s找到子节点richText: '%s'\n", strings.Repeat("  ", depth), textStr)
+												e.debugf("%s找到子节点richText: '%s'\n", strings.Repeat("  ", depth), textStr)
 											}
 										}
 									}
@@ -1867,14 +2183,14 @@ func (e *TreeExtractor) inferTitleFromChildren(childrenArray []interface{}, dept
 
 	if len(childNames) == 0 {
 		if e.verbose {
-			fmt.Printf("%s未找到有效的子节点文本\n", strings.Repeat("  ", depth))
+			e.debugf("%s未找到有效的子节点文本\n", strings.Repeat("  ", depth))
 		}
 		return ""
 	}
 
 	// 分析子节点名称的模式来推断父节点标题
 	if e.verbose {
-		fmt.Printf("%s子节点名称: %v\n", strings.Repeat("  ", depth), childNames)
+		e.debugf("%s子节点名称: %v\n", strings.Repeat("  ", depth), childNames)
 	}
 
 	// 模式1: 如果子节点都包含时间相关的词汇（如"3秒后"、"5秒后"），推断为时间相关的自动操作
@@ -1937,7 +2253,7 @@ func (e *TreeExtractor) inferTitleFromChildren(childrenArray []interface{}, dept
 
 	// 模式6: 如果所有模式都不匹配，返回第一个子节点的核心概念
 	if len(childNames) > 0 {
-	 firstName := childNames[0]
+		firstName := childNames[0]
 		// 提取前几个字符作为简化标题
 		if len([]rune(firstName)) > 10 {
 			return string([]rune(firstName)[:8]) + "..."
@@ -1954,4 +2270,4 @@ func max(a, b int) int {
 		return a
 	}
 	return b
-}
\ No newline at end of file
+}
@@ -0,0 +1,418 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/errs"
+)
+
+func TestTreeExtractor_Extract(t *testing.T) {
+	extractor := New(WithTitleKeys("case_title", "title", "name"), WithChildrenKeys("children", "items", "nodes"))
+
+	tests := []struct {
+		name    string
+		data    []byte
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "简单树结构",
+			data: []byte(`{
+				"case_title": "根节点",
+				"children": [
+					{"case_title": "子节点1", "children": []},
+					{"case_title": "子节点2", "children": []}
+				]
+			}`),
+			want: `{
+  "name": "根节点",
+  "children": [
+    {
+      "name": "子节点1",
+      "children": []
+    },
+    {
+      "name": "子节点2",
+      "children": []
+    }
+  ]
+}`,
+			wantErr: false,
+		},
+		{
+			name: "使用不同字段名",
+			data: []byte(`{
+				"title": "项目A",
+				"items": [
+					{"name": "功能1", "nodes": []}
+				]
+			}`),
+			want: `{
+  "name": "项目A",
+  "children": [
+    {
+      "name": "功能1",
+      "children": []
+    }
+  ]
+}`,
+			wantErr: false,
+		},
+		{
+			name: "嵌套结构",
+			data: []byte(`{
+				"case_title": "根",
+				"children": [
+					{
+						"case_title": "子1",
+						"children": [
+							{"case_title": "孙子1", "children": []}
+						]
+					}
+				]
+			}`),
+			want: `{
+  "name": "根",
+  "children": [
+    {
+      "name": "子1",
+      "children": [
+        {
+          "name": "孙子1",
+          "children": []
+        }
+      ]
+    }
+  ]
+}`,
+			wantErr: false,
+		},
+		{
+			name:    "无效JSON",
+			data:    []byte(`{invalid json}`),
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := extractor.Extract(context.Background(), tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Extract() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			got, err := json.Marshal(tree)
+			if err != nil {
+				t.Errorf("Extract() result marshal error = %v", err)
+				return
+			}
+
+			// 比较JSON结构（忽略空格）
+			var gotJSON, wantJSON interface{}
+			if err := json.Unmarshal(got, &gotJSON); err != nil {
+				t.Errorf("Extract() got invalid JSON: %v", err)
+				return
+			}
+			if err := json.Unmarshal([]byte(tt.want), &wantJSON); err != nil {
+				t.Errorf("Extract() want invalid JSON: %v", err)
+				return
+			}
+
+			gotBytes, _ := json.Marshal(gotJSON)
+			wantBytes, _ := json.Marshal(wantJSON)
+
+			if string(gotBytes) != string(wantBytes) {
+				t.Errorf("Extract() = %v, want %v", string(gotBytes), string(wantBytes))
+			}
+		})
+	}
+}
+
+func TestTreeExtractor_findTitle(t *testing.T) {
+	extractor := New(WithTitleKeys("case_title", "title", "name", "label"), WithChildrenKeys("children"))
+
+	tests := []struct {
+		name     string
+		obj      map[string]interface{}
+		expected string
+	}{
+		{
+			name: "找到case_title",
+			obj: map[string]interface{}{
+				"case_title": "测试标题",
+				"title":      "其他标题",
+			},
+			expected: "测试标题",
+		},
+		{
+			name: "找到title",
+			obj: map[string]interface{}{
+				"name":  "名称",
+				"title": "标题",
+			},
+			expected: "标题",
+		},
+		{
+			name: "找到name",
+			obj: map[string]interface{}{
+				"name": "名称",
+			},
+			expected: "名称",
+		},
+		{
+			name: "未找到标题",
+			obj: map[string]interface{}{
+				"other": "其他字段",
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractor.findTitle(tt.obj, 1)
+			if result != tt.expected {
+				t.Errorf("findTitle() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTreeExtractor_LevelTitleKeys(t *testing.T) {
+	extractor := New(
+		WithTitleKeys("name"),
+		WithLevelTitleKeys(1, "module_name"),
+		WithLevelTitleKeys(2, "case_title"),
+	)
+	data := []byte(`{
+		"module_name": "门店管理", "name": "module-1",
+		"children": [
+			{"case_title": "门店搜索", "name": "case-1", "children": [{"name": "按名称搜索", "case_title": ""}]},
+			{"name": "门店详情"}
+		]
+	}`)
+
+	tree, err := extractor.Extract(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, _ := json.Marshal(tree)
+	want := `{"name":"门店管理","children":[{"name":"门店搜索","children":[{"name":"按名称搜索","children":[]}]},{"name":"门店详情","children":[]}]}`
+	if string(got) != want {
+		t.Errorf("Extract() = %s, want %s", got, want)
+	}
+}
+
+func TestTreeExtractor_ScalarTitles(t *testing.T) {
+	data := []byte(`{"id": 1024, "children": [{"id": 1.5}, {"id": true}, {"id": 12345678901}, {"id": null}]}`)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "默认丢弃非字符串标题的节点",
+			want: `{"name":"","children":[{"name":"children (Array - 4 items)","children":[{"name":"[0]: map[id:1.5]","children":[]},{"name":"[1]: map[id:true]","children":[]},{"name":"[2]: map[id:1.2345678901e+10]","children":[]},{"name":"[3]: map[id:\u003cnil\u003e]","children":[]}]}]}`,
+		},
+		{
+			name: "直接使用值",
+			opts: []Option{WithScalarTitles(ScalarTitleFormat{})},
+			want: `{"name":"1024","children":[{"name":"1.5","children":[]},{"name":"true","children":[]},{"name":"12345678901","children":[]}]}`,
+		},
+		{
+			name: "模板与布尔值文字",
+			opts: []Option{WithScalarTitles(ScalarTitleFormat{Template: "{{.key}}-{{.value}}", True: "是", False: "否"})},
+			want: `{"name":"id-1024","children":[{"name":"id-1.5","children":[]},{"name":"id-是","children":[]},{"name":"id-12345678901","children":[]}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor := New(append([]Option{WithTitleKeys("id")}, tt.opts...)...)
+			var obj map[string]interface{}
+			if err := json.Unmarshal(data, &obj); err != nil {
+				t.Fatal(err)
+			}
+			got, _ := json.Marshal(extractor.extractTree(obj, 0))
+			if string(got) != tt.want {
+				t.Errorf("extractTree() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTreeExtractor_MaxTitleLength(t *testing.T) {
+	extractor := New(WithMaxTitleLength(5))
+	data := []byte(`{"name": "门店管理模块", "children": [{"name": "搜索"}, {"name": "abcd efgh"}, {"name": "12345"}]}`)
+
+	tree, err := extractor.Extract(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, _ := json.Marshal(tree)
+	want := `{"name":"门店管理…","children":[{"name":"搜索","children":[]},{"name":"abcd…","children":[]},{"name":"12345","children":[]}]}`
+	if string(got) != want {
+		t.Errorf("Extract() = %s, want %s", got, want)
+	}
+	if len(tree.Warnings) != 1 || !strings.Contains(tree.Warnings[0], "2") {
+		t.Errorf("Warnings = %v, want one warning for 2 truncated names", tree.Warnings)
+	}
+}
+
+func TestTreeExtractor_NodeOrder(t *testing.T) {
+	extractor := New(WithNodeOrder(true))
+	data := []byte(`{"name": "门店管理", "children": [{"name": "搜索", "children": [{"name": "按名称"}]}, {"name": "详情"}]}`)
+
+	tree, err := extractor.Extract(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	got, _ := json.Marshal(tree)
+	want := `{"name":"门店管理","order":0,"children":[{"name":"搜索","order":0,"children":[{"name":"按名称","order":0,"children":[]}]},{"name":"详情","order":1,"children":[]}]}`
+	if string(got) != want {
+		t.Errorf("Extract() = %s, want %s", got, want)
+	}
+	if err := DefaultOutputSchema().Validate(got); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestTreeExtractor_Duplicates(t *testing.T) {
+	extractor := New()
+	data := []byte(`["门店搜索功能测试用例说明", ["按名称搜索门店", ["按名称搜索门店"]], "按名称搜索门店", "查看门店详情"]`)
+
+	tree, err := extractor.Extract(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if tree.Strategy != StrategyGeneric || tree.Stats.Nodes != 3 {
+		t.Fatalf("Extract() = %s tree with %d nodes, want a generic tree with 3 nodes", tree.Strategy, tree.Stats.Nodes)
+	}
+	got, _ := json.Marshal(tree.Duplicates)
+	want := `[{"path":["门店搜索功能测试用例说明","按名称搜索门店"],"count":3,"sources":["$[1][0]","$[1][1][0]","$[2]"]}]`
+	if string(got) != want {
+		t.Errorf("Duplicates = %s, want %s", got, want)
+	}
+	if len(tree.Warnings) != 1 || !strings.Contains(tree.Warnings[0], "2") {
+		t.Errorf("Warnings = %v, want one warning for 2 dropped duplicates", tree.Warnings)
+	}
+}
+
+func TestTreeExtractor_findChildren(t *testing.T) {
+	extractor := New(WithTitleKeys("title"), WithChildrenKeys("children", "items", "nodes"))
+
+	tests := []struct {
+		name     string
+		obj      map[string]interface{}
+		merge    bool
+		expected []interface{}
+	}{
+		{
+			name: "找到children",
+			obj: map[string]interface{}{
+				"children": []interface{}{"child1", "child2"},
+				"items":    []interface{}{"item1"},
+			},
+			expected: []interface{}{"child1", "child2"},
+		},
+		{
+			name: "找到items",
+			obj: map[string]interface{}{
+				"items": []interface{}{"item1", "item2"},
+			},
+			expected: []interface{}{"item1", "item2"},
+		},
+		{
+			name: "找到nodes",
+			obj: map[string]interface{}{
+				"nodes": []interface{}{"node1"},
+			},
+			expected: []interface{}{"node1"},
+		},
+		{
+			name: "键为数字的对象",
+			obj: map[string]interface{}{
+				"children": map[string]interface{}{"1": "child2", "0": "child1"},
+			},
+			expected: []interface{}{"child1", "child2"},
+		},
+		{
+			name: "未找到子节点",
+			obj: map[string]interface{}{
+				"other": "其他字段",
+			},
+			expected: nil,
+		},
+		{
+			name: "合并所有子节点数组",
+			obj: map[string]interface{}{
+				"nodes":    []interface{}{"node1"},
+				"children": []interface{}{"child1"},
+				"items":    []interface{}{},
+			},
+			merge:    true,
+			expected: []interface{}{"child1", "node1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractor.mergeChildren = tt.merge
+			result := extractor.findChildren(tt.obj)
+			if len(result) != len(tt.expected) {
+				t.Errorf("findChildren() length = %v, want %v", len(result), len(tt.expected))
+				return
+			}
+			for i, v := range result {
+				if v != tt.expected[i] {
+					t.Errorf("findChildren()[%d] = %v, want %v", i, v, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+func TestTreeExtractor_ExtractCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := New().Extract(ctx, []byte(`{"title":"根节点"}`)); !errors.Is(err, context.Canceled) {
+		t.Errorf("Extract() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestTreeExtractor_ExtractStream(t *testing.T) {
+	input := `{"title":"根节点","children":[{"title":"子节点","children":[]}]}`
+	e := New()
+
+	tree, err := e.Extract(context.Background(), []byte(input))
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	want, err := json.MarshalIndent(tree, "", "  ")
+	if err != nil {
+		t.Fatalf("MarshalIndent() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := e.ExtractStream(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("ExtractStream() error = %v", err)
+	}
+	if got := out.String(); got != string(want)+"\n" {
+		t.Errorf("ExtractStream() = %s, want %s", got, want)
+	}
+
+	err = e.ExtractStream(context.Background(), strings.NewReader(input[:20]), &out)
+	if !errors.Is(err, errs.ErrTruncatedJSON) {
+		t.Errorf("ExtractStream() truncated error = %v, want ErrTruncatedJSON", err)
+	}
+}
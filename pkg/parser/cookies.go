@@ -0,0 +1,39 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+// applyCookies 处理 -b/--cookie 的参数：与cURL一样，含 = 的参数为 'key1=value1; key2=value2' 形式的cookie字符串，
+// 写入 Cookies 并追加到 Cookie 请求头；不含 = 的参数是cookie文件名，不影响请求
+func applyCookies(values []string, info *config.RequestInfo) {
+	// 浏览器复制的请求头名称可能是小写的 cookie，沿用已有的名称
+	name := "Cookie"
+	var header []string
+	for key, existing := range info.Headers {
+		if strings.EqualFold(key, name) {
+			name = key
+			if existing != "" {
+				header = append(header, existing)
+			}
+		}
+	}
+	for _, value := range values {
+		if !strings.Contains(value, "=") {
+			continue
+		}
+		header = append(header, strings.TrimSpace(value))
+
+		for _, cookie := range strings.Split(value, ";") {
+			key, val, ok := strings.Cut(strings.TrimSpace(cookie), "=")
+			if key = strings.TrimSpace(key); ok && key != "" {
+				info.Cookies[key] = strings.TrimSpace(val)
+			}
+		}
+	}
+	if len(header) > 0 {
+		info.Headers[name] = strings.Join(header, "; ")
+	}
+}
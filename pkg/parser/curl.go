@@ -0,0 +1,364 @@
+// Package parser 解析浏览器开发者工具"Copy as cURL"等格式的cURL命令，
+// 提取URL、请求方法、请求头、Cookie和请求体。
+package parser
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/errs"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// Request 解析得到的HTTP请求信息
+type Request = config.RequestInfo
+
+// CurlParser cURL解析器
+type CurlParser struct {
+	files fileAccess
+}
+
+// Option 解析器选项
+type Option func(*CurlParser)
+
+// WithBaseDir 设置cURL命令所在文件的目录：-d @file 等引用的相对路径在当前目录下不存在时，相对于该目录查找
+func WithBaseDir(dir string) Option {
+	return func(p *CurlParser) {
+		p.files.baseDir = dir
+	}
+}
+
+// WithoutFileAccess 禁止cURL命令引用本地文件：-d @file、--data-urlencode name@file、-F name=@file、
+// --cacert、--cert 与 --key 均解析失败。用于解析来自网络或代理等不受信任来源的cURL命令
+func WithoutFileAccess() Option {
+	return func(p *CurlParser) {
+		p.files.disabled = true
+	}
+}
+
+// New 创建新的cURL解析器
+func New(opts ...Option) *CurlParser {
+	p := &CurlParser{}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// 需要参数的cURL选项，未列出的选项视为不带参数的开关（如 --compressed、-k、-s）
+var argOptions = map[string]string{
+	"-X": "--request", "--request": "--request",
+	"-H": "--header", "--header": "--header",
+	"-b": "--cookie", "--cookie": "--cookie",
+	"-d": "--data", "--data": "--data",
+	"--data-raw": "--data-raw", "--data-binary": "--data-binary",
+	"--data-ascii": "--data-ascii", "--data-urlencode": "--data-urlencode",
+	"-A": "--user-agent", "--user-agent": "--user-agent",
+	"-e": "--referer", "--referer": "--referer",
+	"--url": "--url",
+
+	"-F": "--form", "--form": "--form", "--form-string": "--form-string",
+	"-u": "--user", "--user": "--user",
+	"--max-redirs": "--max-redirs",
+
+	// 代理
+	"-x": "--proxy", "--proxy": "--proxy", "--socks5": "--socks5", "--socks5-hostname": "--socks5-hostname",
+	"-U": "--proxy-user", "--proxy-user": "--proxy-user", "--noproxy": "--noproxy",
+
+	// TLS
+	"--cacert": "--cacert", "-E": "--cert", "--cert": "--cert", "--key": "--key",
+
+	// 以下选项暂不影响解析结果，只需跳过其参数
+	"-o": "--output", "--output": "--output", "-w": "--write-out", "--write-out": "--write-out",
+	"-m": "--max-time", "--max-time": "--max-time", "--connect-timeout": "--connect-timeout",
+	"--retry": "--retry", "--retry-delay": "--retry-delay",
+	"--capath": "--capath", "--cert-type": "--cert-type", "--key-type": "--key-type", "--pass": "--pass",
+	"-c": "--cookie-jar", "--cookie-jar": "--cookie-jar", "-T": "--upload-file", "--upload-file": "--upload-file",
+	"--resolve": "--resolve", "--connect-to": "--connect-to", "--interface": "--interface",
+	"-r": "--range", "--range": "--range", "-z": "--time-cond", "--time-cond": "--time-cond",
+	"-K": "--config", "--config": "--config", "--limit-rate": "--limit-rate",
+}
+
+// 不带参数的短选项对应的规范名称，可以与其他短选项合写（如 -sIk、-sG）；未列出的短选项（如 -s、-v）被忽略
+var flagOptions = map[string]string{
+	"-I": "--head", "-G": "--get", "-L": "--location", "-k": "--insecure",
+}
+
+// Parse 解析cURL命令：按shell规则切分单词（见 splitWords），再逐个识别cURL选项
+func (p *CurlParser) Parse(curlCmd string) (*Request, error) {
+	if strings.TrimSpace(curlCmd) == "" {
+		return nil, errs.Mark(i18n.Errorf("cURL命令为空"), errs.ErrCurlParse)
+	}
+
+	words, err := splitWords(curlCmd)
+	if err != nil {
+		return nil, errs.Mark(i18n.Errorf("解析cURL参数失败: %w", err), errs.ErrCurlParse)
+	}
+	if len(words) > 0 && isCurlKeyword(words[0]) {
+		words = words[1:]
+	}
+
+	info := &Request{
+		Method:  "GET",
+		Headers: make(map[string]string),
+		Cookies: make(map[string]string),
+	}
+	if err := parseWords(words, info, p.files); err != nil {
+		return nil, errs.Mark(i18n.Errorf("解析cURL参数失败: %w", err), errs.ErrCurlParse)
+	}
+
+	if info.URL == "" {
+		return nil, errs.Mark(i18n.Errorf("未在cURL命令中找到URL"), errs.ErrCurlParse)
+	}
+
+	return info, nil
+}
+
+// isCurlKeyword 判断单词是否为curl命令本身，如 curl、CURL、/usr/bin/curl、curl.exe
+func isCurlKeyword(word string) bool {
+	name := strings.ToLower(path.Base(strings.ReplaceAll(word, `\`, "/")))
+	return name == "curl" || name == "curl.exe"
+}
+
+// parseWords 识别cURL选项并写入info，引用的本地文件按files查找
+func parseWords(words []string, info *Request, files fileAccess) error {
+	var data []string
+	var cookies []string
+	methodSet := false
+	get := false
+
+	apply := func(option, value string) error {
+		switch option {
+		case "--request":
+			info.Method = strings.ToUpper(joinLines(value, ""))
+			methodSet = true
+		case "--head":
+			if !methodSet {
+				info.Method = "HEAD"
+			}
+		case "--get":
+			get = true
+		case "--compressed":
+			info.Compressed = true
+		case "--location":
+			info.Location = true
+		case "--location-trusted":
+			info.Location, info.LocationTrusted = true, true
+		case "--max-redirs":
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < -1 {
+				return i18n.Errorf("--max-redirs 的值 %q 无效，应为非负整数或 -1（不限制）", value)
+			}
+			info.MaxRedirects = &n
+		case "--proxy", "--socks5", "--socks5-hostname":
+			scheme := "http"
+			if option != "--proxy" {
+				scheme = "socks5"
+			}
+			proxy, err := proxyURL(value, scheme)
+			if err != nil {
+				return err
+			}
+			info.Proxy = proxy
+		case "--insecure":
+			info.Insecure = true
+		case "--cacert", "--cert", "--key":
+			path, err := resolvePath(value, files)
+			if err != nil {
+				return err
+			}
+			switch option {
+			case "--cacert":
+				info.CACert = path
+			case "--cert":
+				info.Cert = path
+			default:
+				info.Key = path
+			}
+		case "--proxy-user":
+			info.ProxyUser = value
+		case "--noproxy":
+			info.NoProxy = strings.TrimSpace(value)
+		case "--header":
+			parseHeader(joinLines(value, " "), info.Headers)
+		case "--cookie":
+			cookies = append(cookies, joinLines(value, " "))
+		case "--data-raw":
+			data = append(data, value)
+		case "--data", "--data-binary", "--data-ascii":
+			if strings.HasPrefix(value, "@") {
+				content, err := readDataFile(value[1:], files)
+				if err != nil {
+					return err
+				}
+				// 与cURL一样，-d 读取的文件去掉换行，--data-binary 原样使用
+				if value = content; option != "--data-binary" {
+					value = strings.NewReplacer("\r", "", "\n", "").Replace(value)
+				}
+			}
+			data = append(data, value)
+		case "--data-urlencode":
+			encoded, err := urlencodeData(value, files)
+			if err != nil {
+				return err
+			}
+			data = append(data, encoded)
+		case "--form", "--form-string":
+			field, err := parseFormField(value, option == "--form-string", files)
+			if err != nil {
+				return err
+			}
+			info.Form = append(info.Form, field)
+		case "--user":
+			// 与cURL一样只在第一个冒号处分隔，密码中可以包含冒号
+			info.User, info.Password, _ = strings.Cut(value, ":")
+		case "--basic":
+			info.AuthType = "basic"
+		case "--digest":
+			info.AuthType = "digest"
+		case "--user-agent":
+			info.Headers["User-Agent"] = joinLines(value, " ")
+		case "--referer":
+			info.Headers["Referer"] = joinLines(value, "")
+		case "--url":
+			if info.URL == "" {
+				info.URL = joinLines(value, "")
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < len(words); i++ {
+		word := words[i]
+		if !strings.HasPrefix(word, "-") || word == "-" {
+			// 第一个非选项参数为URL，其余忽略
+			if info.URL == "" {
+				info.URL = joinLines(word, "")
+			}
+			continue
+		}
+
+		flags, option, value, hasArg := splitOption(word)
+		for _, flag := range flags {
+			if err := apply(flag, ""); err != nil {
+				return err
+			}
+		}
+		if !hasArg {
+			continue
+		}
+		if value == "" {
+			if i+1 >= len(words) {
+				return i18n.Errorf("选项 %s 缺少参数", word)
+			}
+			i++
+			value = words[i]
+		}
+		if err := apply(option, value); err != nil {
+			return err
+		}
+	}
+
+	// 多个data参数与cURL一样以 & 连接；-G 时追加到URL的查询参数，请求不带请求体
+	joined := strings.Join(data, "&")
+	if get {
+		if joined != "" && info.URL != "" {
+			sep := "?"
+			if strings.Contains(info.URL, "?") {
+				sep = "&"
+			}
+			info.URL += sep + joined
+		}
+	} else {
+		info.Body = joined
+	}
+	if (info.Body != "" || len(info.Form) > 0) && !methodSet {
+		info.Method = "POST"
+	}
+	applyCookies(cookies, info)
+	return nil
+}
+
+// urlencodeData 按cURL --data-urlencode 的规则编码参数：content 与 =content 编码整个内容，
+// name=content 只编码 = 之后的内容；@file 与 name@file 编码文件的内容，文件按files查找
+func urlencodeData(value string, files fileAccess) (string, error) {
+	i := strings.IndexAny(value, "=@")
+	switch {
+	case i < 0:
+		return escapeData(value), nil
+	case value[i] == '@':
+		content, err := readDataFile(value[i+1:], files)
+		if err != nil {
+			return "", err
+		}
+		if i == 0 {
+			return escapeData(content), nil
+		}
+		return value[:i] + "=" + escapeData(content), nil
+	case i == 0:
+		return escapeData(value[1:]), nil
+	default:
+		return value[:i+1] + escapeData(value[i+1:]), nil
+	}
+}
+
+// escapeData 与cURL一样对除字母、数字与 -._~ 之外的字节进行百分号编码，空格编码为 %20
+func escapeData(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// splitOption 拆分选项：flags 为不带参数的选项（短选项换成 flagOptions 中的规范名称），option 为需要参数的选项的规范名称，hasArg 为是否有这样的选项。
+// 短选项的参数可以直接跟在后面（如 -XPOST），不带参数的短选项可以合并（如 -sSL），其中最后一个可以是带参数的选项（如 -sX POST）
+func splitOption(word string) (flags []string, option, value string, hasArg bool) {
+	if strings.HasPrefix(word, "--") {
+		name, ok := argOptions[word]
+		if ok {
+			return nil, name, "", true
+		}
+		return []string{word}, "", "", false
+	}
+
+	for j := 1; j < len(word); j++ {
+		short := "-" + word[j:j+1]
+		if name, ok := argOptions[short]; ok {
+			return flags, name, word[j+1:], true
+		}
+		if name, ok := flagOptions[short]; ok {
+			short = name
+		}
+		flags = append(flags, short)
+	}
+	return flags, "", "", false
+}
+
+// joinLines 将引号内被换行折断的单行参数（URL、请求头等）重新拼接：每行去掉首尾空白与行尾的续行反斜杠后以sep连接，
+// 空行忽略；请求体中的换行有意义，不经过此处理
+func joinLines(value, sep string) string {
+	if !strings.Contains(value, "\n") {
+		return value
+	}
+	var parts []string
+	for _, line := range strings.Split(value, "\n") {
+		line = strings.TrimSpace(strings.TrimSuffix(strings.TrimRight(line, " \t"), `\`))
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// parseHeader 解析 'Name: value' 形式的请求头，'Name;' 表示值为空的请求头
+func parseHeader(header string, headers map[string]string) {
+	name, value, ok := strings.Cut(header, ":")
+	if !ok {
+		if name, ok = strings.CutSuffix(strings.TrimSpace(header), ";"); !ok {
+			return
+		}
+	}
+	if name = strings.TrimSpace(name); name != "" {
+		headers[name] = strings.TrimSpace(value)
+	}
+}
@@ -0,0 +1,346 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+func TestCurlParser_Parse(t *testing.T) {
+	parser := New()
+
+	tests := []struct {
+		name    string
+		curl    string
+		want    *config.RequestInfo
+		wantErr bool
+	}{
+		{
+			name: "简单GET请求",
+			curl: "curl http://example.com",
+			want: &config.RequestInfo{
+				Method:  "GET",
+				URL:     "http://example.com",
+				Headers: make(map[string]string),
+				Body:    "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "带引号的URL",
+			curl: `curl "http://example.com/api"`,
+			want: &config.RequestInfo{
+				Method:  "GET",
+				URL:     "http://example.com/api",
+				Headers: make(map[string]string),
+				Body:    "",
+			},
+			wantErr: false,
+		},
+		{
+			name: "POST请求",
+			curl: `curl -X POST http://example.com/api -H "Content-Type: application/json" --data '{"key": "value"}'`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "http://example.com/api",
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+				Body: `{"key": "value"}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "F12风格的data-binary请求（无引号）",
+			curl: `curl -X POST http://example.com/api -H "Content-Type: application/json" --data-binary {"productId":123,"testCaseId":456}`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "http://example.com/api",
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+				Body: `{"productId":123,"testCaseId":456}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "F12风格的data-binary请求（单引号）",
+			curl: `curl -X POST http://example.com/api -H "Content-Type: application/json" --data-binary '{"productId":123,"testCaseId":456}'`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "http://example.com/api",
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+				Body: `{"productId":123,"testCaseId":456}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "F12风格的data-binary请求（混合引号和复杂JSON）",
+			curl: `curl -X POST http://example.com/api -H "Content-Type: application/json" --data-binary {"productId":123,"data":{"nested":{"key":"value"}}}`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "http://example.com/api",
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+				Body: `{"productId":123,"data":{"nested":{"key":"value"}}}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Charles风格的data-binary请求（转义双引号）",
+			curl: `curl -X POST http://example.com/api -H "Content-Type: application/json" --data-binary "{\"productId\":123,\"testCaseId\":456}"`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "http://example.com/api",
+				Headers: map[string]string{
+					"Content-Type": "application/json",
+				},
+				Body: `{"productId":123,"testCaseId":456}`,
+			},
+			wantErr: false,
+		},
+		{
+			name: "DevTools复制的bash格式（$'...' 与嵌套引号）",
+			curl: `curl 'https://example.com/api?a=1&b=2' \
+  -H 'accept: application/json' \
+  -H 'content-type: application/json' \
+  -b 'sid=abc; theme=dark' \
+  --data-raw $'{"title":"it\'s \\"quoted\\"","path":"C:\\\\tmp"}' \
+  --compressed`,
+			want: &config.RequestInfo{
+				Method: "POST",
+				URL:    "https://example.com/api?a=1&b=2",
+				Headers: map[string]string{
+					"accept":       "application/json",
+					"content-type": "application/json",
+					"Cookie":       "sid=abc; theme=dark",
+				},
+				Body: `{"title":"it's \"quoted\"","path":"C:\\tmp"}`,
+			},
+		},
+		{
+			name: "合并的短选项与紧跟的参数",
+			curl: `curl -sSL -XPUT -H'X-Token: a:b' "http://example.com/a b" -d 'x=1' -d "y=2"`,
+			want: &config.RequestInfo{
+				Method:  "PUT",
+				URL:     "http://example.com/a b",
+				Headers: map[string]string{"X-Token": "a:b"},
+				Body:    "x=1&y=2",
+			},
+		},
+		{
+			name: "选项在URL之前且请求头含引号",
+			curl: `curl -H "X-Filter: {\"name\": \"a'b\"}" -A agent --url http://example.com`,
+			want: &config.RequestInfo{
+				Method: "GET",
+				URL:    "http://example.com",
+				Headers: map[string]string{
+					"X-Filter":   `{"name": "a'b"}`,
+					"User-Agent": "agent",
+				},
+			},
+		},
+		{
+			name: "文档中的多行命令（CRLF、续行后的空白与引号内的换行）",
+			curl: "curl -X POST \\ \r\n  'https://example.com/api/v1/\r\n    search?q=1' \\\r\n  -H 'Content-Type:\n    application/json' \\\r\n  -d '{\n  \"a\": 1\n}'\r\n",
+			want: &config.RequestInfo{
+				Method:  "POST",
+				URL:     "https://example.com/api/v1/search?q=1",
+				Headers: map[string]string{"Content-Type": "application/json"},
+				Body:    "{\n  \"a\": 1\n}",
+			},
+		},
+		{
+			name: "-G 与 --data-urlencode 构造查询参数",
+			curl: `curl -sG 'https://example.com/search?page=1' --data-urlencode 'q=门店 列表&x' --data-urlencode '=a+b' -d size=20`,
+			want: &config.RequestInfo{
+				Method:  "GET",
+				URL:     "https://example.com/search?page=1&q=%E9%97%A8%E5%BA%97%20%E5%88%97%E8%A1%A8%26x&a%2Bb&size=20",
+				Headers: map[string]string{},
+			},
+		},
+		{
+			name: "--data-urlencode 作为请求体",
+			curl: `curl https://example.com/api --data-urlencode 'name=a b' --data-urlencode 'c/d'`,
+			want: &config.RequestInfo{
+				Method:  "POST",
+				URL:     "https://example.com/api",
+				Headers: map[string]string{},
+				Body:    "name=a%20b&c%2Fd",
+			},
+		},
+		{
+			name:    "引号未闭合",
+			curl:    `curl 'http://example.com`,
+			wantErr: true,
+		},
+		{
+			name:    "空cURL命令",
+			curl:    "",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parser.Parse(tt.curl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Method != tt.want.Method {
+				t.Errorf("Parse() Method = %v, want %v", got.Method, tt.want.Method)
+			}
+			if got.URL != tt.want.URL {
+				t.Errorf("Parse() URL = %v, want %v", got.URL, tt.want.URL)
+			}
+			if got.Body != tt.want.Body {
+				t.Errorf("Parse() Body = %v, want %v", got.Body, tt.want.Body)
+			}
+
+			// 比较Headers
+			if len(got.Headers) != len(tt.want.Headers) {
+				t.Errorf("Parse() Headers length = %v, want %v", len(got.Headers), len(tt.want.Headers))
+			}
+			for k, v := range tt.want.Headers {
+				if got.Headers[k] != v {
+					t.Errorf("Parse() Headers[%s] = %v, want %v", k, got.Headers[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestCurlParser_ParseUser(t *testing.T) {
+	tests := []struct {
+		curl                     string
+		user, password, authType string
+	}{
+		{`curl -u admin:p@ss:word http://example.com`, "admin", "p@ss:word", ""},
+		{`curl --user 'admin' --digest http://example.com`, "admin", "", "digest"},
+		{`curl -uadmin:secret --basic http://example.com`, "admin", "secret", "basic"},
+	}
+	for _, tt := range tests {
+		info, err := New().Parse(tt.curl)
+		if err != nil {
+			t.Fatalf("Parse(%q) error = %v", tt.curl, err)
+		}
+		if info.User != tt.user || info.Password != tt.password || info.AuthType != tt.authType {
+			t.Errorf("Parse(%q) = %q, %q, %q, want %q, %q, %q", tt.curl, info.User, info.Password, info.AuthType, tt.user, tt.password, tt.authType)
+		}
+	}
+}
+
+func TestCurlParser_ParseRedirects(t *testing.T) {
+	info, err := New().Parse(`curl -sL --max-redirs 5 https://example.com/a`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !info.Location || info.LocationTrusted || info.MaxRedirects == nil || *info.MaxRedirects != 5 {
+		t.Errorf("Parse() = %v, %v, %v", info.Location, info.LocationTrusted, info.MaxRedirects)
+	}
+
+	info, err = New().Parse(`curl --location-trusted https://example.com/a`)
+	if err != nil || !info.Location || !info.LocationTrusted || info.MaxRedirects != nil {
+		t.Errorf("Parse(--location-trusted) = %+v, %v", info, err)
+	}
+	if _, err := New().Parse(`curl --max-redirs many https://example.com/a`); err == nil {
+		t.Error("Parse() with an invalid --max-redirs should fail")
+	}
+}
+
+func TestCurlParser_ParseShortFlags(t *testing.T) {
+	tests := []struct {
+		curl     string
+		method   string
+		url      string
+		insecure bool
+		location bool
+	}{
+		{curl: `curl -sI https://example.com/a`, method: "HEAD", url: "https://example.com/a"},
+		{curl: `curl -sk https://example.com/a`, method: "GET", url: "https://example.com/a", insecure: true},
+		{curl: `curl -sG https://example.com/a -d q=1`, method: "GET", url: "https://example.com/a?q=1"},
+		{curl: `curl -sSLk https://example.com/a`, method: "GET", url: "https://example.com/a", insecure: true, location: true},
+		{curl: `curl -Isk https://example.com/a`, method: "HEAD", url: "https://example.com/a", insecure: true},
+		{curl: `curl -sGd q=1 https://example.com/a`, method: "GET", url: "https://example.com/a?q=1"},
+		{curl: `curl -skXPUT https://example.com/a`, method: "PUT", url: "https://example.com/a", insecure: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.curl, func(t *testing.T) {
+			info, err := New().Parse(tt.curl)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if info.Method != tt.method || info.URL != tt.url || info.Insecure != tt.insecure || info.Location != tt.location || info.Body != "" {
+				t.Errorf("Parse() = %s %s insecure=%v location=%v body=%q", info.Method, info.URL, info.Insecure, info.Location, info.Body)
+			}
+		})
+	}
+}
+
+func TestCurlParser_ParseProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		curl    string
+		want    string
+		wantErr bool
+	}{
+		{name: "无协议与端口", curl: `curl -x proxy.local https://example.com/a`, want: "http://proxy.local:1080"},
+		{name: "带协议与用户信息", curl: `curl --proxy https://u:p@proxy.local:8443/ https://example.com/a`, want: "https://u:p@proxy.local:8443"},
+		{name: "socks5", curl: `curl --socks5 127.0.0.1:9050 https://example.com/a`, want: "socks5://127.0.0.1:9050"},
+		{name: "socks5h", curl: `curl -x socks5h://proxy.local https://example.com/a`, want: "socks5://proxy.local:1080"},
+		{name: "不支持的协议", curl: `curl -x socks4://proxy.local https://example.com/a`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := New().Parse(tt.curl)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse() proxy = %q, want error", info.Proxy)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if info.Proxy != tt.want {
+				t.Errorf("Parse() proxy = %q, want %q", info.Proxy, tt.want)
+			}
+		})
+	}
+
+	info, err := New().Parse(`curl -U alice:s3:cret --noproxy 'localhost, .internal' https://example.com/a`)
+	if err != nil || info.ProxyUser != "alice:s3:cret" || info.NoProxy != "localhost, .internal" || info.URL != "https://example.com/a" {
+		t.Errorf("Parse(-U, --noproxy) = %+v, %v", info, err)
+	}
+}
+
+func TestCurlParser_ParseTLS(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"ca.pem", "client.pem"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("pem"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	info, err := New(WithBaseDir(dir)).Parse(`curl -sk --cacert ca.pem -E client.pem --key /etc/client.key https://example.com/a`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !info.Insecure || info.CACert != filepath.Join(dir, "ca.pem") || info.Cert != filepath.Join(dir, "client.pem") || info.Key != "/etc/client.key" {
+		t.Errorf("Parse() = %v, %q, %q, %q", info.Insecure, info.CACert, info.Cert, info.Key)
+	}
+	if info.URL != "https://example.com/a" {
+		t.Errorf("Parse() URL = %q", info.URL)
+	}
+}
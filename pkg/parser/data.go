@@ -0,0 +1,52 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// fileAccess 解析器引用本地文件的方式：baseDir 见 WithBaseDir，disabled 见 WithoutFileAccess
+type fileAccess struct {
+	baseDir  string
+	disabled bool
+}
+
+// readDataFile 读取 -d @file 等引用的文件内容，路径按 resolvePath 查找；
+// 不支持cURL从标准输入读取的 @- 写法，cURL命令本身可能就来自标准输入
+func readDataFile(name string, files fileAccess) (string, error) {
+	if name == "-" {
+		return "", i18n.Errorf("不支持从标准输入读取请求体（@-），请改为引用文件")
+	}
+	path, err := resolvePath(name, files)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", i18n.Errorf("读取请求体文件失败: %w", err)
+	}
+	return string(content), nil
+}
+
+// resolvePath 返回引用文件的路径：相对路径优先相对于当前目录，不存在时相对于baseDir（cURL命令所在文件的目录）；
+// 禁止引用本地文件时返回错误
+func resolvePath(name string, files fileAccess) (string, error) {
+	if files.disabled {
+		return "", i18n.Errorf("不允许引用本地文件 %q", name)
+	}
+	if name == "" || files.baseDir == "" || filepath.IsAbs(name) || fileExists(name) {
+		return name, nil
+	}
+	if candidate := filepath.Join(files.baseDir, name); fileExists(candidate) {
+		return candidate, nil
+	}
+	return name, nil
+}
+
+// fileExists 判断路径是否存在
+func fileExists(name string) bool {
+	_, err := os.Stat(name)
+	return err == nil
+}
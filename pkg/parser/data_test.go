@@ -0,0 +1,90 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCurlParser_ParseDataFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "payload.json"), []byte("{\"name\": \"门店\",\r\n \"size\": 20}\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "query.txt"), []byte("a b&c"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		curl string
+		want string
+	}{
+		{"-d 去掉换行", `curl https://example.com/api -d @payload.json`, `{"name": "门店", "size": 20}`},
+		{"--data-binary 原样使用", `curl https://example.com/api --data-binary @payload.json`, "{\"name\": \"门店\",\r\n \"size\": 20}\n"},
+		{"--data-raw 不读取文件", `curl https://example.com/api --data-raw @payload.json`, "@payload.json"},
+		{"--data-urlencode 编码文件内容", `curl https://example.com/api --data-urlencode q@query.txt --data-urlencode @query.txt`, "q=a%20b%26c&a%20b%26c"},
+		{"绝对路径", `curl https://example.com/api -d @` + filepath.Join(dir, "query.txt"), "a b&c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := New(WithBaseDir(dir)).Parse(tt.curl)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if info.Body != tt.want || info.Method != "POST" {
+				t.Errorf("Parse() body = %q, method = %s, want %q, POST", info.Body, info.Method, tt.want)
+			}
+		})
+	}
+
+	if _, err := New().Parse(`curl https://example.com/api -d @payload.json`); err == nil || !strings.Contains(err.Error(), "payload.json") {
+		t.Errorf("Parse() without base dir error = %v, want missing file", err)
+	}
+	if _, err := New().Parse(`curl https://example.com/api -d @-`); err == nil {
+		t.Error("Parse() with @- should fail")
+	}
+}
+
+func TestResolvePath(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "case.xlsx"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	files := fileAccess{baseDir: dir}
+	if got, _ := resolvePath("case.xlsx", files); got != filepath.Join(dir, "case.xlsx") {
+		t.Errorf("resolvePath() = %s, want file in base dir", got)
+	}
+	if got, _ := resolvePath("missing.xlsx", files); got != "missing.xlsx" {
+		t.Errorf("resolvePath() = %s, want the original path", got)
+	}
+	if got, _ := resolvePath("data_test.go", files); got != "data_test.go" {
+		t.Errorf("resolvePath() = %s, want the file in the current directory", got)
+	}
+}
+
+func TestCurlParser_WithoutFileAccess(t *testing.T) {
+	for _, curl := range []string{
+		`curl https://example.com/api -d @data_test.go`,
+		`curl https://example.com/api --data-binary @/etc/hostname`,
+		`curl https://example.com/api --data-urlencode q@data_test.go`,
+		`curl https://example.com/api -F file=@data_test.go`,
+		`curl https://example.com/api -F text=<data_test.go`,
+		`curl https://example.com/api --cacert ca.pem`,
+		`curl https://example.com/api --cert client.pem --key client.key`,
+	} {
+		if _, err := New(WithoutFileAccess()).Parse(curl); err == nil || !strings.Contains(err.Error(), "本地文件") {
+			t.Errorf("Parse(%q) error = %v, want file access error", curl, err)
+		}
+	}
+
+	// 不引用文件的写法不受影响
+	info, err := New(WithoutFileAccess()).Parse(`curl https://example.com/api --data-raw @payload.json -F name=value --form-string f=@x`)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if info.Body != "@payload.json" || len(info.Form) != 2 || info.Form[1].Value != "@x" {
+		t.Errorf("Parse() = %+v, want literal values", info)
+	}
+}
@@ -0,0 +1,60 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// parseFormField 解析 -F/--form 的参数，与cURL的写法一致：
+//
+//   - name=value 为文本字段
+//   - name=@path 上传文件，name=<path 读取文件内容作为文本字段的值
+//   - 值之后可以用 ;type=...、;filename=... 指定字段的Content-Type与上传的文件名
+//
+// literal 为true时（--form-string）值原样使用，不识别 @、< 与 ; 之后的属性；文件路径按 resolvePath 查找
+func parseFormField(arg string, literal bool, files fileAccess) (config.FormField, error) {
+	name, value, ok := strings.Cut(arg, "=")
+	if name = strings.TrimSpace(name); !ok || name == "" {
+		return config.FormField{}, i18n.Errorf("无效的表单参数 %q，应为 name=value 形式", arg)
+	}
+	field := config.FormField{Name: name}
+	if literal {
+		field.Value = value
+		return field, nil
+	}
+
+	value = parseFormAttrs(value, &field)
+	var err error
+	switch {
+	case strings.HasPrefix(value, "@"):
+		field.File, err = resolvePath(value[1:], files)
+	case strings.HasPrefix(value, "<"):
+		field.ValueFile, err = resolvePath(value[1:], files)
+	default:
+		field.Value = value
+	}
+	return field, err
+}
+
+// parseFormAttrs 从值的末尾依次取出 ;type= 与 ;filename= 属性写入field，返回去掉属性后的值；
+// 其他以 ; 分隔的内容属于值本身
+func parseFormAttrs(value string, field *config.FormField) string {
+	for {
+		i := strings.LastIndexByte(value, ';')
+		if i < 0 {
+			return value
+		}
+		key, attr, _ := strings.Cut(strings.TrimSpace(value[i+1:]), "=")
+		switch strings.ToLower(key) {
+		case "type":
+			field.ContentType = strings.Trim(attr, `"`)
+		case "filename":
+			field.Filename = strings.Trim(attr, `"`)
+		default:
+			return value
+		}
+		value = value[:i]
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/internal/config"
+)
+
+func TestParseFormField(t *testing.T) {
+	tests := []struct {
+		arg     string
+		literal bool
+		want    config.FormField
+	}{
+		{"name=张三", false, config.FormField{Name: "name", Value: "张三"}},
+		{"file=@./case.xlsx", false, config.FormField{Name: "file", File: "./case.xlsx"}},
+		{"file=@a.bin;type=image/png;filename=b.png", false, config.FormField{Name: "file", File: "a.bin", ContentType: "image/png", Filename: "b.png"}},
+		{"desc=<notes.txt", false, config.FormField{Name: "desc", ValueFile: "notes.txt"}},
+		{"meta={\"a\":1};type=application/json", false, config.FormField{Name: "meta", Value: `{"a":1}`, ContentType: "application/json"}},
+		{"q=a;b", false, config.FormField{Name: "q", Value: "a;b"}},
+		{"raw=@not-a-file;type=x", true, config.FormField{Name: "raw", Value: "@not-a-file;type=x"}},
+	}
+	for _, tt := range tests {
+		got, err := parseFormField(tt.arg, tt.literal, fileAccess{})
+		if err != nil {
+			t.Fatalf("parseFormField(%q) error = %v", tt.arg, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFormField(%q) = %+v, want %+v", tt.arg, got, tt.want)
+		}
+	}
+
+	if _, err := parseFormField("novalue", false, fileAccess{}); err == nil {
+		t.Error("parseFormField(novalue) error = nil, want error")
+	}
+}
+
+func TestCurlParser_ParseForm(t *testing.T) {
+	info, err := New().Parse(`curl http://example.com/upload -F 'file=@case.xlsx' --form-string 'note=@x'`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Method != "POST" {
+		t.Errorf("Method = %s, want POST", info.Method)
+	}
+	want := []config.FormField{{Name: "file", File: "case.xlsx"}, {Name: "note", Value: "@x"}}
+	if !reflect.DeepEqual(info.Form, want) {
+		t.Errorf("Form = %+v, want %+v", info.Form, want)
+	}
+}
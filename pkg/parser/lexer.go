@@ -0,0 +1,268 @@
+package parser
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// splitWords 按POSIX shell（bash）的规则将命令行切分为单词：
+//
+//   - 空白（空格、制表符、换行）分隔单词，引号内的空白属于单词本身
+//   - '...' 内的内容原样保留，不处理任何转义
+//   - "..." 内的反斜杠只转义 $ ` " \ 与换行，其他反斜杠原样保留
+//   - $'...'（浏览器复制含特殊字符的请求体时使用）按ANSI-C规则处理 \n、\t、\xHH、\uHHHH 等转义
+//   - 引号外的反斜杠转义下一个字符，反斜杠加换行为续行，两者都被删除
+//   - 相邻的引号与非引号片段拼接为同一个单词，如 'a'"b"c 为 abc
+//   - 切分前先统一换行符（见 normalizeLines），Windows换行的文件同样可以续行
+//
+// 为兼容直接粘贴未加引号JSON的写法（如 --data-binary {"a":1}），以 { 或 [ 开头的未加引号单词原样读取到括号闭合后的空白为止，
+// 其中的引号与反斜杠保持不变
+func splitWords(input string) ([]string, error) {
+	l := &lexer{input: normalizeLines(input)}
+	var words []string
+	for {
+		word, ok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return words, nil
+		}
+		words = append(words, word)
+	}
+}
+
+// 续行反斜杠之后误带的空白，复制文档中的命令时常见
+var trailingContinuation = regexp.MustCompile(`\\[ \t]+\n`)
+
+// normalizeLines 统一换行符以便按行续接：去掉UTF-8 BOM，将Windows的 CRLF 与单独的 CR 转换为 LF，
+// 并删除续行反斜杠与换行之间的空白
+func normalizeLines(input string) string {
+	input = strings.TrimPrefix(input, "\uFEFF")
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	input = strings.ReplaceAll(input, "\r", "\n")
+	return trailingContinuation.ReplaceAllString(input, "\\\n")
+}
+
+// lexer 逐个读取单词
+type lexer struct {
+	input string
+	pos   int
+}
+
+// isSpace 判断c是否为单词分隔符
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+// next 读取下一个单词，没有更多单词时ok为false
+func (l *lexer) next() (word string, ok bool, err error) {
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		if isSpace(c) {
+			l.pos++
+			continue
+		}
+		// 单词之间的续行
+		if c == '\\' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '\n' {
+			l.pos += 2
+			continue
+		}
+		break
+	}
+	if l.pos >= len(l.input) {
+		return "", false, nil
+	}
+	if c := l.input[l.pos]; c == '{' || c == '[' {
+		return l.readJSONWord(), true, nil
+	}
+
+	var b strings.Builder
+	for l.pos < len(l.input) {
+		c := l.input[l.pos]
+		switch {
+		case isSpace(c):
+			return b.String(), true, nil
+		case c == '\'':
+			if err := l.readSingleQuoted(&b); err != nil {
+				return "", false, err
+			}
+		case c == '"':
+			if err := l.readDoubleQuoted(&b); err != nil {
+				return "", false, err
+			}
+		case c == '$' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '\'':
+			l.pos++
+			if err := l.readANSIQuoted(&b); err != nil {
+				return "", false, err
+			}
+		case c == '\\':
+			l.pos++
+			if l.pos < len(l.input) {
+				if l.input[l.pos] != '\n' {
+					b.WriteByte(l.input[l.pos])
+				}
+				l.pos++
+			}
+		default:
+			b.WriteByte(c)
+			l.pos++
+		}
+	}
+	return b.String(), true, nil
+}
+
+// readSingleQuoted 读取 '...'，pos 指向开头的引号
+func (l *lexer) readSingleQuoted(b *strings.Builder) error {
+	start := l.pos
+	end := strings.IndexByte(l.input[start+1:], '\'')
+	if end < 0 {
+		return i18n.Errorf("第 %d 个字符开始的单引号未闭合", start+1)
+	}
+	b.WriteString(l.input[start+1 : start+1+end])
+	l.pos = start + end + 2
+	return nil
+}
+
+// readDoubleQuoted 读取 "..."，pos 指向开头的引号
+func (l *lexer) readDoubleQuoted(b *strings.Builder) error {
+	start := l.pos
+	for l.pos++; l.pos < len(l.input); l.pos++ {
+		c := l.input[l.pos]
+		switch c {
+		case '"':
+			l.pos++
+			return nil
+		case '\\':
+			if l.pos+1 < len(l.input) {
+				switch next := l.input[l.pos+1]; next {
+				case '$', '`', '"', '\\':
+					b.WriteByte(next)
+					l.pos++
+					continue
+				case '\n':
+					l.pos++
+					continue
+				}
+			}
+			b.WriteByte(c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return i18n.Errorf("第 %d 个字符开始的双引号未闭合", start+1)
+}
+
+// readANSIQuoted 读取 $'...' 中的内容，pos 指向 $ 之后的引号
+func (l *lexer) readANSIQuoted(b *strings.Builder) error {
+	start := l.pos - 1
+	for l.pos++; l.pos < len(l.input); l.pos++ {
+		c := l.input[l.pos]
+		if c == '\'' {
+			l.pos++
+			return nil
+		}
+		if c != '\\' || l.pos+1 >= len(l.input) {
+			b.WriteByte(c)
+			continue
+		}
+		l.pos++
+		l.pos += writeANSIEscape(b, l.input[l.pos:]) - 1
+	}
+	return i18n.Errorf("第 %d 个字符开始的 $'...' 未闭合", start+1)
+}
+
+// ansiEscapes $'...' 中的单字符转义
+var ansiEscapes = map[byte]byte{
+	'a': '\a', 'b': '\b', 'e': 0x1b, 'E': 0x1b, 'f': '\f', 'n': '\n', 'r': '\r', 't': '\t', 'v': '\v',
+	'\\': '\\', '\'': '\'', '"': '"', '?': '?',
+}
+
+// writeANSIEscape 写入s开头的转义序列（不含反斜杠）对应的内容，返回消耗的字节数；无法识别的转义原样保留反斜杠
+func writeANSIEscape(b *strings.Builder, s string) int {
+	c := s[0]
+	if r, ok := ansiEscapes[c]; ok {
+		b.WriteByte(r)
+		return 1
+	}
+	switch c {
+	case 'x':
+		if n := hexDigits(s[1:], 2); n > 0 {
+			v, _ := strconv.ParseUint(s[1:1+n], 16, 8)
+			b.WriteByte(byte(v))
+			return 1 + n
+		}
+	case 'u', 'U':
+		max := 4
+		if c == 'U' {
+			max = 8
+		}
+		if n := hexDigits(s[1:], max); n > 0 {
+			v, _ := strconv.ParseUint(s[1:1+n], 16, 32)
+			if r := rune(v); utf8.ValidRune(r) {
+				b.WriteRune(r)
+				return 1 + n
+			}
+		}
+	case '0', '1', '2', '3', '4', '5', '6', '7':
+		n := 1
+		for n < 3 && n < len(s) && s[n] >= '0' && s[n] <= '7' {
+			n++
+		}
+		v, _ := strconv.ParseUint(s[:n], 8, 16)
+		b.WriteByte(byte(v))
+		return n
+	}
+	b.WriteByte('\\')
+	b.WriteByte(c)
+	return 1
+}
+
+// hexDigits 返回s开头最多max个十六进制数字的个数
+func hexDigits(s string, max int) int {
+	n := 0
+	for n < max && n < len(s) && strings.IndexByte("0123456789abcdefABCDEF", s[n]) >= 0 {
+		n++
+	}
+	return n
+}
+
+// readJSONWord 原样读取以 { 或 [ 开头的未加引号JSON，到括号全部闭合后的第一个空白为止；
+// 双引号字符串内的空白与括号不计入
+func (l *lexer) readJSONWord() string {
+	start := l.pos
+	depth := 0
+	inString := false
+	for ; l.pos < len(l.input); l.pos++ {
+		c := l.input[l.pos]
+		if inString {
+			switch c {
+			case '\\':
+				l.pos++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		default:
+			if isSpace(c) && depth <= 0 {
+				return l.input[start:l.pos]
+			}
+		}
+	}
+	if l.pos > len(l.input) {
+		l.pos = len(l.input)
+	}
+	return l.input[start:l.pos]
+}
@@ -0,0 +1,45 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitWords(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"空白分隔", "curl  -s\t'http://a'\n", []string{"curl", "-s", "http://a"}},
+		{"单引号原样保留", `'a\"b $x'`, []string{`a\"b $x`}},
+		{"双引号转义", `"a\"b\\c\$d\e"`, []string{`a"b\c$d\e`}},
+		{"拼接片段", `'a'"b"c\ d`, []string{"abc d"}},
+		{"单引号内的单引号", `'it'\''s'`, []string{"it's"}},
+		{"ANSI-C引号", `$'{"a":"it\'s\\n\u4e2d\x41"}'`, []string{"{\"a\":\"it's\\n中A\"}"}},
+		{"ANSI-C换行", `$'a\nb\tc\101'`, []string{"a\nb\tcA"}},
+		{"续行", "curl \\\n  -H 'a: b' \\\n  url", []string{"curl", "-H", "a: b", "url"}},
+		{"Windows换行与续行后的空白", "\uFEFFcurl \\\r\n  -s \\  \n  url\r\n", []string{"curl", "-s", "url"}},
+		{"空字符串参数", `-d '' url`, []string{"-d", "", "url"}},
+		{"未加引号的JSON", `--data-binary {"a": "b c", "d": [1, 2]} url`, []string{"--data-binary", `{"a": "b c", "d": [1, 2]}`, "url"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := splitWords(tt.input)
+			if err != nil {
+				t.Fatalf("splitWords() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitWords() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitWords_Unterminated(t *testing.T) {
+	for _, input := range []string{`'abc`, `"abc`, `$'abc`, `"a\"`} {
+		if _, err := splitWords(input); err == nil {
+			t.Errorf("splitWords(%q) error = nil, want error", input)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package parser
+
+import (
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+)
+
+// defaultProxyPort 代理地址没有端口时使用的端口，与cURL一致
+const defaultProxyPort = "1080"
+
+// proxyURL 按cURL的规则补全 -x/--proxy 与 --socks5 的代理地址：没有协议时使用scheme，没有端口时使用1080。
+// 支持 http、https、socks5 与 socks5h 协议，socks5h 与 socks5 相同（主机名总是由代理解析）
+func proxyURL(value, scheme string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if !strings.Contains(value, "://") {
+		value = scheme + "://" + value
+	}
+	u, err := url.Parse(value)
+	if err != nil || u.Hostname() == "" {
+		return "", i18n.Errorf("无效的代理地址 %q", value)
+	}
+	switch u.Scheme = strings.ToLower(u.Scheme); u.Scheme {
+	case "http", "https", "socks5":
+	case "socks5h":
+		u.Scheme = "socks5"
+	default:
+		return "", i18n.Errorf("不支持的代理协议 %s，可选 http、https、socks5、socks5h", u.Scheme)
+	}
+	if u.Port() == "" {
+		u.Host = net.JoinHostPort(u.Hostname(), defaultProxyPort)
+	}
+	u.Path, u.RawPath = "", ""
+	return u.String(), nil
+}
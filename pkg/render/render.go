@@ -0,0 +1,135 @@
+// Package render 将抽取得到的树渲染为导出格式。格式按名称注册，内置 json、markdown 与 testcasemind，
+// 新的格式（包括 renderer 插件）通过 Register 添加，命令行的 --format 即按名称查找：
+//
+//	render.Register(render.Format{Name: "csv", Ext: ".csv", ContentType: "text/csv", Renderer: render.RendererFunc(writeCSV)})
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/wellkilo/Curl2json/internal/i18n"
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+// 内置格式名称
+const (
+	JSON         = "json"
+	Markdown     = "markdown"
+	TestCaseMind = "testcasemind"
+)
+
+// Options 渲染选项
+type Options struct {
+	Indent  string          // JSON类格式每层的缩进，为空时为两个空格
+	Context context.Context // 渲染所属的上下文，取消时 renderer 插件随之终止；为nil时为 context.Background()
+}
+
+// indent 返回生效的缩进
+func (o Options) indent() string {
+	if o.Indent == "" {
+		return "  "
+	}
+	return o.Indent
+}
+
+// Renderer 将树写入w
+type Renderer interface {
+	Render(tree *extractor.Tree, w io.Writer, opts Options) error
+}
+
+// RendererFunc 将函数适配为 Renderer
+type RendererFunc func(tree *extractor.Tree, w io.Writer, opts Options) error
+
+// Render 调用 f
+func (f RendererFunc) Render(tree *extractor.Tree, w io.Writer, opts Options) error {
+	return f(tree, w, opts)
+}
+
+// Format 注册的导出格式
+type Format struct {
+	Name        string
+	Ext         string // 默认输出文件的扩展名，如 .md
+	ContentType string // 上传到对象存储时的Content-Type，为空时为 application/octet-stream
+	Renderer    Renderer
+}
+
+// formats 已注册的格式，由 formatsMu 保护
+var (
+	formatsMu sync.RWMutex
+	formats   = map[string]Format{}
+)
+
+func init() {
+	Register(Format{Name: JSON, Ext: ".json", ContentType: "application/json", Renderer: RendererFunc(renderJSON)})
+	Register(Format{Name: Markdown, Ext: ".md", ContentType: "text/markdown; charset=utf-8", Renderer: RendererFunc(renderMarkdown)})
+	Register(Format{Name: TestCaseMind, Ext: ".json", ContentType: "application/json", Renderer: RendererFunc(renderTestCaseMind)})
+}
+
+// Register 注册导出格式，同名的格式被替换；可与渲染并发调用
+func Register(format Format) {
+	if format.ContentType == "" {
+		format.ContentType = "application/octet-stream"
+	}
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[format.Name] = format
+}
+
+// Lookup 按名称查找导出格式
+func Lookup(name string) (Format, bool) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	format, ok := formats[name]
+	return format, ok
+}
+
+// Names 返回已注册的格式名称，按名称排序
+func Names() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render 按名称查找格式并将树写入w
+func Render(name string, tree *extractor.Tree, w io.Writer, opts Options) error {
+	format, ok := Lookup(name)
+	if !ok {
+		return i18n.Errorf("不支持的输出格式 %q", name)
+	}
+	return format.Renderer.Render(tree, w, opts)
+}
+
+// renderJSON 输出树状JSON，与流水线 render 阶段的输出一致
+func renderJSON(tree *extractor.Tree, w io.Writer, opts Options) error {
+	output, err := json.MarshalIndent(tree, "", opts.indent())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
+
+// renderMarkdown 输出Markdown嵌套列表
+func renderMarkdown(tree *extractor.Tree, w io.Writer, opts Options) error {
+	_, err := w.Write(tree.MarshalMarkdown())
+	return err
+}
+
+// renderTestCaseMind 输出可重新上传的TestCaseMind响应结构
+func renderTestCaseMind(tree *extractor.Tree, w io.Writer, opts Options) error {
+	output, err := tree.MarshalTestCaseMind()
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(output)
+	return err
+}
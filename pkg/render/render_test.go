@@ -0,0 +1,105 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/wellkilo/Curl2json/pkg/extractor"
+)
+
+func TestRender_Builtin(t *testing.T) {
+	var tree extractor.Tree
+	if err := json.Unmarshal([]byte(`{"name":"根","children":[{"name":"用例","children":[]}]}`), &tree); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		JSON:         "{\n  \"name\": \"根\",\n  \"children\": [\n    {\n      \"name\": \"用例\",\n      \"children\": []\n    }\n  ]\n}",
+		Markdown:     "- 根\n  - 用例\n",
+		TestCaseMind: "{\n  \"data\": {\n    \"TestCaseMind\":",
+	}
+	for name, want := range tests {
+		var buf bytes.Buffer
+		if err := Render(name, &tree, &buf, Options{}); err != nil {
+			t.Fatalf("Render(%s) error = %v", name, err)
+		}
+		if !strings.HasPrefix(buf.String(), want) {
+			t.Errorf("Render(%s) = %q, want prefix %q", name, buf.String(), want)
+		}
+	}
+	if err := Render("xmind", &tree, io.Discard, Options{}); err == nil {
+		t.Error("Render() of an unknown format should fail")
+	}
+}
+
+func TestRender_Tags(t *testing.T) {
+	var tree extractor.Tree
+	if err := json.Unmarshal([]byte(`{"name":"根","children":[{"name":"用例","tags":["priority:2","owner:alice","progress:5"],"children":[]}]}`), &tree); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := Render(Markdown, &tree, &buf, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	if want := "- 根\n  - 用例 [priority:2] [owner:alice] [progress:5]\n"; buf.String() != want {
+		t.Errorf("Render(markdown) = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := Render(TestCaseMind, &tree, &buf, Options{}); err != nil {
+		t.Fatal(err)
+	}
+	var payload struct {
+		Data struct {
+			TestCaseMind string `json:"TestCaseMind"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &payload); err != nil {
+		t.Fatal(err)
+	}
+	var mind struct {
+		Children []struct {
+			Data struct {
+				Priority int      `json:"priority"`
+				Progress int      `json:"progress"`
+				Resource []string `json:"resource"`
+			} `json:"data"`
+		} `json:"children"`
+	}
+	if err := json.Unmarshal([]byte(payload.Data.TestCaseMind), &mind); err != nil || len(mind.Children) != 1 {
+		t.Fatalf("TestCaseMind = %s, %v", payload.Data.TestCaseMind, err)
+	}
+	if data := mind.Children[0].Data; data.Priority != 2 || data.Progress != 5 || len(data.Resource) != 1 || data.Resource[0] != "owner:alice" {
+		t.Errorf("TestCaseMind node data = %+v, want priority 2, progress 5 and resource owner:alice", data)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(Format{Name: "test-lines", Ext: ".txt", Renderer: RendererFunc(func(tree *extractor.Tree, w io.Writer, opts Options) error {
+		for _, root := range tree.Roots {
+			io.WriteString(w, root.Name+"\n")
+		}
+		return nil
+	})})
+
+	format, ok := Lookup("test-lines")
+	if !ok || format.Ext != ".txt" || format.ContentType != "application/octet-stream" {
+		t.Fatalf("Lookup() = %+v, %v", format, ok)
+	}
+	found := false
+	for _, name := range Names() {
+		found = found || name == "test-lines"
+	}
+	if !found {
+		t.Errorf("Names() = %q, want test-lines", Names())
+	}
+
+	var buf bytes.Buffer
+	tree := &extractor.Tree{Roots: []*extractor.SimplifiedNode{{Name: "a"}, {Name: "b"}}}
+	if err := Render("test-lines", tree, &buf, Options{}); err != nil || buf.String() != "a\nb\n" {
+		t.Errorf("Render() = %q, %v", buf.String(), err)
+	}
+}